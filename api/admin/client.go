@@ -6,9 +6,12 @@ package admin
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/MetalBlockchain/metalgo/api"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/rpc"
 )
@@ -24,11 +27,23 @@ type Client interface {
 	Alias(ctx context.Context, endpoint string, alias string, options ...rpc.Option) error
 	AliasChain(ctx context.Context, chainID string, alias string, options ...rpc.Option) error
 	GetChainAliases(ctx context.Context, chainID string, options ...rpc.Option) ([]string, error)
+	InspectChain(ctx context.Context, chain string, graphviz bool, options ...rpc.Option) (interface{}, error)
+	SetConsensusParameters(ctx context.Context, subnetID ids.ID, params avalanche.Parameters, options ...rpc.Option) error
 	Stacktrace(context.Context, ...rpc.Option) error
 	LoadVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error)
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) error
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	ConnectPeer(ctx context.Context, nodeID ids.NodeID, ip string, options ...rpc.Option) error
+	DisconnectPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error
+	BanPeer(ctx context.Context, nodeID ids.NodeID, ip string, duration time.Duration, options ...rpc.Option) error
+	UnbanPeer(ctx context.Context, nodeID ids.NodeID, ip string, options ...rpc.Option) error
+	ListBans(ctx context.Context, options ...rpc.Option) ([]Ban, error)
+	SetAccessList(ctx context.Context, config network.AccessListConfig, options ...rpc.Option) error
+	GetAccessList(ctx context.Context, options ...rpc.Option) (network.AccessListConfig, error)
+	Bench(ctx context.Context, chain string, nodeID ids.NodeID, duration time.Duration, options ...rpc.Option) error
+	Unbench(ctx context.Context, chain string, nodeID ids.NodeID, options ...rpc.Option) error
+	GetBenchlist(ctx context.Context, chain string, options ...rpc.Option) ([]BenchedNode, error)
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -81,6 +96,22 @@ func (c *client) GetChainAliases(ctx context.Context, chain string, options ...r
 	return res.Aliases, err
 }
 
+func (c *client) InspectChain(ctx context.Context, chain string, graphviz bool, options ...rpc.Option) (interface{}, error) {
+	res := &InspectChainReply{}
+	err := c.requester.SendRequest(ctx, "admin.inspectChain", &InspectChainArgs{
+		Chain:    chain,
+		Graphviz: graphviz,
+	}, res, options...)
+	return res.Inspection, err
+}
+
+func (c *client) SetConsensusParameters(ctx context.Context, subnetID ids.ID, params avalanche.Parameters, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.setConsensusParameters", &SetConsensusParametersArgs{
+		SubnetID:   subnetID,
+		Parameters: params,
+	}, &api.EmptyReply{}, options...)
+}
+
 func (c *client) Stacktrace(ctx context.Context, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "admin.stacktrace", struct{}{}, &api.EmptyReply{}, options...)
 }
@@ -139,3 +170,82 @@ func (c *client) GetConfig(ctx context.Context, options ...rpc.Option) (interfac
 	err := c.requester.SendRequest(ctx, "admin.getConfig", struct{}{}, &res, options...)
 	return res, err
 }
+
+func (c *client) ConnectPeer(ctx context.Context, nodeID ids.NodeID, ip string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.connectPeer", &ConnectPeerArgs{
+		NodeID: nodeID,
+		IP:     ip,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) DisconnectPeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.disconnectPeer", &DisconnectPeerArgs{
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) BanPeer(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	ip string,
+	duration time.Duration,
+	options ...rpc.Option,
+) error {
+	return c.requester.SendRequest(ctx, "admin.banPeer", &BanPeerArgs{
+		NodeID:   nodeID,
+		IP:       ip,
+		Duration: duration.String(),
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) UnbanPeer(ctx context.Context, nodeID ids.NodeID, ip string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.unbanPeer", &UnbanPeerArgs{
+		NodeID: nodeID,
+		IP:     ip,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) ListBans(ctx context.Context, options ...rpc.Option) ([]Ban, error) {
+	res := &ListBansReply{}
+	err := c.requester.SendRequest(ctx, "admin.listBans", struct{}{}, res, options...)
+	return res.Bans, err
+}
+
+func (c *client) SetAccessList(ctx context.Context, config network.AccessListConfig, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.setAccessList", &config, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetAccessList(ctx context.Context, options ...rpc.Option) (network.AccessListConfig, error) {
+	res := network.AccessListConfig{}
+	err := c.requester.SendRequest(ctx, "admin.getAccessList", struct{}{}, &res, options...)
+	return res, err
+}
+
+func (c *client) Bench(
+	ctx context.Context,
+	chain string,
+	nodeID ids.NodeID,
+	duration time.Duration,
+	options ...rpc.Option,
+) error {
+	return c.requester.SendRequest(ctx, "admin.bench", &BenchArgs{
+		Chain:    chain,
+		NodeID:   nodeID,
+		Duration: duration.String(),
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) Unbench(ctx context.Context, chain string, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.unbench", &UnbenchArgs{
+		Chain:  chain,
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetBenchlist(ctx context.Context, chain string, options ...rpc.Option) ([]BenchedNode, error) {
+	res := &GetBenchlistReply{}
+	err := c.requester.SendRequest(ctx, "admin.getBenchlist", &GetBenchlistArgs{
+		Chain: chain,
+	}, res, options...)
+	return res.Benched, err
+}
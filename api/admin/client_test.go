@@ -7,11 +7,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/api"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/rpc"
 )
@@ -60,12 +63,24 @@ func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, re
 	case *GetChainAliasesReply:
 		response := mc.response.(*GetChainAliasesReply)
 		*p = *response
+	case *InspectChainReply:
+		response := mc.response.(*InspectChainReply)
+		*p = *response
 	case *LoadVMsReply:
 		response := mc.response.(*LoadVMsReply)
 		*p = *response
 	case *GetLoggerLevelReply:
 		response := mc.response.(*GetLoggerLevelReply)
 		*p = *response
+	case *ListBansReply:
+		response := mc.response.(*ListBansReply)
+		*p = *response
+	case *GetBenchlistReply:
+		response := mc.response.(*GetBenchlistReply)
+		*p = *response
+	case *network.AccessListConfig:
+		response := mc.response.(*network.AccessListConfig)
+		*p = *response
 	case *interface{}:
 		response := mc.response.(*interface{})
 		*p = *response
@@ -192,6 +207,43 @@ func TestGetChainAliases(t *testing.T) {
 	})
 }
 
+func TestClientInspectChain(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := map[string]interface{}{"graphviz": "digraph snowman {}"}
+		mockClient := client{requester: NewMockClient(&InspectChainReply{
+			Inspection: expectedReply,
+		}, nil)}
+
+		reply, err := mockClient.InspectChain(context.Background(), "chain", true)
+		require.NoError(t, err)
+		require.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&InspectChainReply{}, errTest)}
+
+		_, err := mockClient.InspectChain(context.Background(), "chain", false)
+
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestClientSetConsensusParameters(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.SetConsensusParameters(context.Background(), ids.GenerateTestID(), avalanche.Parameters{})
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+}
+
 func TestStacktrace(t *testing.T) {
 	tests := GetSuccessResponseTests()
 
@@ -387,3 +439,104 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestClientBanPeer(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.BanPeer(context.Background(), ids.GenerateTestNodeID(), "", time.Hour)
+		require.ErrorIs(t, err, test.Err)
+	}
+}
+
+func TestClientListBans(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		nodeID := ids.GenerateTestNodeID()
+		expectedReply := []Ban{
+			{NodeID: &nodeID, Expiry: time.Unix(1, 0)},
+			{IP: "10.0.0.1", Expiry: time.Unix(2, 0)},
+		}
+		mockClient := client{requester: NewMockClient(&ListBansReply{
+			Bans: expectedReply,
+		}, nil)}
+
+		reply, err := mockClient.ListBans(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ListBansReply{}, errTest)}
+
+		_, err := mockClient.ListBans(context.Background())
+
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestClientGetAccessList(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := network.AccessListConfig{
+			AllowedCIDRs:  []string{"10.0.0.0/8"},
+			DeniedNodeIDs: []ids.NodeID{ids.GenerateTestNodeID()},
+		}
+		mockClient := client{requester: NewMockClient(&expectedReply, nil)}
+
+		reply, err := mockClient.GetAccessList(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&network.AccessListConfig{}, errTest)}
+
+		_, err := mockClient.GetAccessList(context.Background())
+
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestClientBench(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.Bench(context.Background(), "C", ids.GenerateTestNodeID(), time.Hour)
+		require.ErrorIs(t, err, test.Err)
+	}
+}
+
+func TestClientUnbench(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.Unbench(context.Background(), "C", ids.GenerateTestNodeID())
+		require.ErrorIs(t, err, test.Err)
+	}
+}
+
+func TestClientGetBenchlist(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := []BenchedNode{
+			{NodeID: ids.GenerateTestNodeID(), BenchedUntil: time.Unix(1, 0)},
+			{NodeID: ids.GenerateTestNodeID(), BenchedUntil: time.Unix(2, 0)},
+		}
+		mockClient := client{requester: NewMockClient(&GetBenchlistReply{
+			Benched: expectedReply,
+		}, nil)}
+
+		reply, err := mockClient.GetBenchlist(context.Background(), "C")
+		require.NoError(t, err)
+		require.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetBenchlistReply{}, errTest)}
+
+		_, err := mockClient.GetBenchlist(context.Background(), "C")
+
+		require.ErrorIs(t, err, errTest)
+	})
+}
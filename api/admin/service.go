@@ -5,8 +5,14 @@ package admin
 
 import (
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"path"
+	"sort"
+	"time"
+
+	stdjson "encoding/json"
 
 	"github.com/gorilla/rpc/v2"
 
@@ -16,9 +22,14 @@ import (
 	"github.com/MetalBlockchain/metalgo/api/server"
 	"github.com/MetalBlockchain/metalgo/chains"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
+	"github.com/MetalBlockchain/metalgo/subnets"
 	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/utils/ips"
 	"github.com/MetalBlockchain/metalgo/utils/json"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/perms"
@@ -35,8 +46,13 @@ const (
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
-	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errAliasTooLong       = errors.New("alias length is too long")
+	errNoLogLevel         = errors.New("need to specify either displayLevel or logLevel")
+	errNoNodeID           = errors.New("need to specify nodeID")
+	errPeerBanned         = errors.New("peer is banned")
+	errNoBanTarget        = errors.New("need to specify either nodeID or ip")
+	errMultipleBanTargets = errors.New("can't specify both nodeID and ip")
+	errInvalidIP          = errors.New("invalid IP")
 )
 
 type Config struct {
@@ -48,6 +64,8 @@ type Config struct {
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	Network      network.Network
+	Benchlist    benchlist.Manager
 }
 
 // Admin is the API service for node admin management
@@ -193,6 +211,76 @@ func (a *Admin) GetChainAliases(_ *http.Request, args *GetChainAliasesArgs, repl
 	return err
 }
 
+// InspectChainArgs are the arguments for calling InspectChain
+type InspectChainArgs struct {
+	Chain    string `json:"chain"`
+	Graphviz bool   `json:"graphviz"`
+}
+
+// InspectChainReply is the state of the consensus engine of a chain
+type InspectChainReply struct {
+	Inspection interface{} `json:"inspection"`
+}
+
+// InspectChain returns the state of the consensus engine that is running the
+// chain. The processing containers are also described in the Graphviz DOT
+// language if [Graphviz] is true.
+func (a *Admin) InspectChain(r *http.Request, args *InspectChainArgs, reply *InspectChainReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "inspectChain"),
+		logging.UserString("chain", args.Chain),
+		zap.Bool("graphviz", args.Graphviz),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	reply.Inspection, err = a.ChainManager.Inspect(r.Context(), chainID, args.Graphviz)
+	return err
+}
+
+// SetConsensusParametersArgs are the arguments for calling
+// SetConsensusParameters
+type SetConsensusParametersArgs struct {
+	SubnetID   ids.ID               `json:"subnetID"`
+	Parameters avalanche.Parameters `json:"parameters"`
+}
+
+// UnmarshalJSON accepts the same consensus parameters as a subnet config,
+// including the legacy [alpha] field.
+func (a *SetConsensusParametersArgs) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		SubnetID   ids.ID             `json:"subnetID"`
+		Parameters stdjson.RawMessage `json:"parameters"`
+	}
+	if err := stdjson.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	a.SubnetID = raw.SubnetID
+	if raw.Parameters == nil {
+		return nil
+	}
+	return subnets.UnmarshalConsensusParameters(raw.Parameters, &a.Parameters)
+}
+
+// SetConsensusParameters replaces the consensus parameters of a subnet without
+// restarting its chains. Each running chain applies the new parameters once
+// its outstanding polls have finished.
+func (a *Admin) SetConsensusParameters(r *http.Request, args *SetConsensusParametersArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "setConsensusParameters"),
+		zap.Stringer("subnetID", args.SubnetID),
+		zap.Reflect("parameters", args.Parameters),
+	)
+
+	return a.ChainManager.SetConsensusParameters(r.Context(), args.SubnetID, args.Parameters)
+}
+
 // Stacktrace returns the current global stacktrace
 func (a *Admin) Stacktrace(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
 	a.Log.Debug("API called",
@@ -345,3 +433,300 @@ func (a *Admin) LoadVMs(r *http.Request, _ *struct{}, reply *LoadVMsReply) error
 	reply.NewVMs, err = ids.GetRelevantAliases(a.VMManager, loadedVMs)
 	return err
 }
+
+// ConnectPeerArgs are the arguments for calling ConnectPeer
+type ConnectPeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// IP and port of the peer, e.g. "127.0.0.1:9651"
+	IP string `json:"ip"`
+}
+
+// ConnectPeer starts attempting to connect to a peer. Attempts continue until
+// DisconnectPeer is called.
+func (a *Admin) ConnectPeer(_ *http.Request, args *ConnectPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "connectPeer"),
+		logging.UserString("ip", args.IP),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	if args.NodeID == ids.EmptyNodeID {
+		return errNoNodeID
+	}
+	ip, err := ips.ToIPPort(args.IP)
+	if err != nil {
+		return fmt.Errorf("couldn't parse IP %q: %w", args.IP, err)
+	}
+	for _, ban := range a.Network.Bans() {
+		if ban.NodeID == args.NodeID || ban.IP.Equal(ip.IP) {
+			return errPeerBanned
+		}
+	}
+
+	a.Network.ManuallyTrack(args.NodeID, ip)
+	return nil
+}
+
+// DisconnectPeerArgs are the arguments for calling DisconnectPeer
+type DisconnectPeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// DisconnectPeer stops attempting to connect to a peer and closes the current
+// connection to it, if any. The peer is able to reconnect unless it is banned.
+func (a *Admin) DisconnectPeer(_ *http.Request, args *DisconnectPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "disconnectPeer"),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	if args.NodeID == ids.EmptyNodeID {
+		return errNoNodeID
+	}
+	a.Network.Disconnect(args.NodeID)
+	return nil
+}
+
+// BanPeerArgs are the arguments for calling BanPeer. Exactly one of [NodeID]
+// and [IP] must be provided.
+type BanPeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	IP     string     `json:"ip"`
+	// Duration of the ban, e.g. "1h30m"
+	Duration string `json:"duration"`
+}
+
+// BanPeer disconnects from a node or IP and refuses all connections with it
+// for the provided duration. Bans are kept across restarts.
+func (a *Admin) BanPeer(_ *http.Request, args *BanPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "banPeer"),
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("ip", args.IP),
+		logging.UserString("duration", args.Duration),
+	)
+
+	duration, err := time.ParseDuration(args.Duration)
+	if err != nil {
+		return fmt.Errorf("couldn't parse duration %q: %w", args.Duration, err)
+	}
+
+	nodeID, ip, err := parseBanTarget(args.NodeID, args.IP)
+	if err != nil {
+		return err
+	}
+	if ip != nil {
+		return a.Network.BanIP(ip, duration)
+	}
+	return a.Network.BanNodeID(nodeID, duration)
+}
+
+// UnbanPeerArgs are the arguments for calling UnbanPeer. Exactly one of
+// [NodeID] and [IP] must be provided.
+type UnbanPeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	IP     string     `json:"ip"`
+}
+
+// UnbanPeer removes a ban created by BanPeer.
+func (a *Admin) UnbanPeer(_ *http.Request, args *UnbanPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "unbanPeer"),
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("ip", args.IP),
+	)
+
+	nodeID, ip, err := parseBanTarget(args.NodeID, args.IP)
+	if err != nil {
+		return err
+	}
+	if ip != nil {
+		return a.Network.UnbanIP(ip)
+	}
+	return a.Network.UnbanNodeID(nodeID)
+}
+
+// Ban describes a node or an IP that is banned until [Expiry]
+type Ban struct {
+	NodeID *ids.NodeID `json:"nodeID,omitempty"`
+	IP     string      `json:"ip,omitempty"`
+	Expiry time.Time   `json:"expiry"`
+}
+
+// ListBansReply are the results from calling ListBans
+type ListBansReply struct {
+	Bans []Ban `json:"bans"`
+}
+
+// ListBans returns the bans that are currently in effect.
+func (a *Admin) ListBans(_ *http.Request, _ *struct{}, reply *ListBansReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "listBans"),
+	)
+
+	bans := a.Network.Bans()
+	reply.Bans = make([]Ban, len(bans))
+	for i, ban := range bans {
+		reply.Bans[i].Expiry = ban.Expiry
+		if ban.IP != nil {
+			reply.Bans[i].IP = ban.IP.String()
+			continue
+		}
+		nodeID := ban.NodeID
+		reply.Bans[i].NodeID = &nodeID
+	}
+	return nil
+}
+
+// SetAccessList replaces the lists of CIDRs and node IDs that peers are allowed
+// or denied to connect from, and disconnects from all peers that are denied.
+func (a *Admin) SetAccessList(_ *http.Request, args *network.AccessListConfig, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "setAccessList"),
+	)
+
+	return a.Network.SetAccessList(*args)
+}
+
+// GetAccessList returns the access list that is currently in effect.
+func (a *Admin) GetAccessList(_ *http.Request, _ *struct{}, reply *network.AccessListConfig) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getAccessList"),
+	)
+
+	*reply = a.Network.AccessList()
+	return nil
+}
+
+// BenchArgs are the arguments for calling Bench
+type BenchArgs struct {
+	Chain  string     `json:"chain"`
+	NodeID ids.NodeID `json:"nodeID"`
+	// Duration of the bench, e.g. "1h30m"
+	Duration string `json:"duration"`
+}
+
+// Bench stops querying a node on a chain for the provided duration, as if it
+// had repeatedly failed to respond. Benched nodes are kept across restarts.
+func (a *Admin) Bench(_ *http.Request, args *BenchArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "bench"),
+		logging.UserString("chain", args.Chain),
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("duration", args.Duration),
+	)
+
+	if args.NodeID == ids.EmptyNodeID {
+		return errNoNodeID
+	}
+	duration, err := time.ParseDuration(args.Duration)
+	if err != nil {
+		return fmt.Errorf("couldn't parse duration %q: %w", args.Duration, err)
+	}
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.Benchlist.Bench(chainID, args.NodeID, duration)
+}
+
+// UnbenchArgs are the arguments for calling Unbench
+type UnbenchArgs struct {
+	Chain  string     `json:"chain"`
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// Unbench resumes querying a node on a chain, whether it was benched by
+// Bench or because it failed to respond.
+func (a *Admin) Unbench(_ *http.Request, args *UnbenchArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "unbench"),
+		logging.UserString("chain", args.Chain),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	if args.NodeID == ids.EmptyNodeID {
+		return errNoNodeID
+	}
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.Benchlist.Unbench(chainID, args.NodeID)
+}
+
+// GetBenchlistArgs are the arguments for calling GetBenchlist
+type GetBenchlistArgs struct {
+	Chain string `json:"chain"`
+}
+
+// BenchedNode describes a node that isn't queried on a chain until
+// [BenchedUntil]
+type BenchedNode struct {
+	NodeID       ids.NodeID `json:"nodeID"`
+	BenchedUntil time.Time  `json:"benchedUntil"`
+}
+
+// GetBenchlistReply are the results from calling GetBenchlist
+type GetBenchlistReply struct {
+	Benched []BenchedNode `json:"benched"`
+}
+
+// GetBenchlist returns the nodes that are currently benched on a chain, in the
+// order that they will leave the bench.
+func (a *Admin) GetBenchlist(_ *http.Request, args *GetBenchlistArgs, reply *GetBenchlistReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getBenchlist"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	benched, err := a.Benchlist.Benched(chainID)
+	if err != nil {
+		return err
+	}
+
+	reply.Benched = make([]BenchedNode, 0, len(benched))
+	for nodeID, benchedUntil := range benched {
+		reply.Benched = append(reply.Benched, BenchedNode{
+			NodeID:       nodeID,
+			BenchedUntil: benchedUntil,
+		})
+	}
+	sort.Slice(reply.Benched, func(i, j int) bool {
+		return reply.Benched[i].BenchedUntil.Before(reply.Benched[j].BenchedUntil)
+	})
+	return nil
+}
+
+// parseBanTarget returns the IP to ban if [ipStr] is set, or [nodeID]
+// otherwise. An error is returned unless exactly one of them is provided.
+func parseBanTarget(nodeID ids.NodeID, ipStr string) (ids.NodeID, net.IP, error) {
+	switch {
+	case nodeID == ids.EmptyNodeID && ipStr == "":
+		return ids.EmptyNodeID, nil, errNoBanTarget
+	case nodeID != ids.EmptyNodeID && ipStr != "":
+		return ids.EmptyNodeID, nil, errMultipleBanTargets
+	case ipStr != "":
+		ip := net.ParseIP(ipStr)
+		if ip == nil {
+			return ids.EmptyNodeID, nil, fmt.Errorf("%w: %q", errInvalidIP, ipStr)
+		}
+		return ids.EmptyNodeID, ip, nil
+	default:
+		return nodeID, nil, nil
+	}
+}
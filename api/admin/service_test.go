@@ -4,14 +4,29 @@
 package admin
 
 import (
+	"context"
+	"net"
 	"net/http"
 	"testing"
+	"time"
+
+	stdjson "encoding/json"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/MetalBlockchain/metalgo/api"
+	"github.com/MetalBlockchain/metalgo/chains"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network"
+	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
+	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
+	"github.com/MetalBlockchain/metalgo/snow/validators"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/utils/ips"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/vms"
 	"github.com/MetalBlockchain/metalgo/vms/registry"
@@ -118,3 +133,343 @@ func TestLoadVMsGetAliasesFails(t *testing.T) {
 
 	require.Equal(t, err, errTest)
 }
+
+func TestConnectPeer(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nodeID := ids.GenerateTestNodeID()
+	bannedNodeID := ids.GenerateTestNodeID()
+	mockNetwork := network.NewMockNetwork(ctrl)
+	admin := &Admin{Config: Config{
+		Log:     logging.NoLog{},
+		Network: mockNetwork,
+	}}
+
+	err := admin.ConnectPeer(&http.Request{}, &ConnectPeerArgs{IP: "127.0.0.1:9651"}, nil)
+	require.ErrorIs(err, errNoNodeID)
+
+	err = admin.ConnectPeer(&http.Request{}, &ConnectPeerArgs{NodeID: nodeID, IP: "not an ip"}, nil)
+	require.Error(err)
+
+	mockNetwork.EXPECT().Bans().Return([]network.Ban{
+		{NodeID: bannedNodeID, Expiry: time.Now().Add(time.Hour)},
+		{IP: net.IPv4(10, 0, 0, 1), Expiry: time.Now().Add(time.Hour)},
+	}).Times(3)
+
+	err = admin.ConnectPeer(&http.Request{}, &ConnectPeerArgs{NodeID: bannedNodeID, IP: "127.0.0.1:9651"}, nil)
+	require.ErrorIs(err, errPeerBanned)
+
+	err = admin.ConnectPeer(&http.Request{}, &ConnectPeerArgs{NodeID: nodeID, IP: "10.0.0.1:9651"}, nil)
+	require.ErrorIs(err, errPeerBanned)
+
+	mockNetwork.EXPECT().ManuallyTrack(nodeID, ips.IPPort{
+		IP:   net.IPv4(127, 0, 0, 1),
+		Port: 9651,
+	}).Times(1)
+	err = admin.ConnectPeer(&http.Request{}, &ConnectPeerArgs{NodeID: nodeID, IP: "127.0.0.1:9651"}, nil)
+	require.NoError(err)
+}
+
+func TestBanPeer(t *testing.T) {
+	nodeID := ids.GenerateTestNodeID()
+	tests := []struct {
+		name        string
+		args        BanPeerArgs
+		setup       func(*network.MockNetwork)
+		expectedErr error
+	}{
+		{
+			name: "ban nodeID",
+			args: BanPeerArgs{
+				NodeID:   nodeID,
+				Duration: "1h30m",
+			},
+			setup: func(n *network.MockNetwork) {
+				n.EXPECT().BanNodeID(nodeID, 90*time.Minute).Return(nil)
+			},
+		},
+		{
+			name: "ban IP",
+			args: BanPeerArgs{
+				IP:       "2001:db8::1",
+				Duration: "24h",
+			},
+			setup: func(n *network.MockNetwork) {
+				n.EXPECT().BanIP(net.ParseIP("2001:db8::1"), 24*time.Hour).Return(nil)
+			},
+		},
+		{
+			name: "network error",
+			args: BanPeerArgs{
+				NodeID:   nodeID,
+				Duration: "1s",
+			},
+			setup: func(n *network.MockNetwork) {
+				n.EXPECT().BanNodeID(nodeID, time.Second).Return(errTest)
+			},
+			expectedErr: errTest,
+		},
+		{
+			name: "no target",
+			args: BanPeerArgs{
+				Duration: "1h",
+			},
+			expectedErr: errNoBanTarget,
+		},
+		{
+			name: "multiple targets",
+			args: BanPeerArgs{
+				NodeID:   nodeID,
+				IP:       "10.0.0.1",
+				Duration: "1h",
+			},
+			expectedErr: errMultipleBanTargets,
+		},
+		{
+			name: "invalid IP",
+			args: BanPeerArgs{
+				IP:       "10.0.0.1:9651",
+				Duration: "1h",
+			},
+			expectedErr: errInvalidIP,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockNetwork := network.NewMockNetwork(ctrl)
+			if test.setup != nil {
+				test.setup(mockNetwork)
+			}
+			admin := &Admin{Config: Config{
+				Log:     logging.NoLog{},
+				Network: mockNetwork,
+			}}
+
+			err := admin.BanPeer(&http.Request{}, &test.args, nil)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestUnbanPeer(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nodeID := ids.GenerateTestNodeID()
+	mockNetwork := network.NewMockNetwork(ctrl)
+	admin := &Admin{Config: Config{
+		Log:     logging.NoLog{},
+		Network: mockNetwork,
+	}}
+
+	mockNetwork.EXPECT().UnbanNodeID(nodeID).Return(nil)
+	require.NoError(admin.UnbanPeer(&http.Request{}, &UnbanPeerArgs{NodeID: nodeID}, nil))
+
+	mockNetwork.EXPECT().UnbanIP(net.IPv4(10, 0, 0, 1)).Return(nil)
+	require.NoError(admin.UnbanPeer(&http.Request{}, &UnbanPeerArgs{IP: "10.0.0.1"}, nil))
+
+	err := admin.UnbanPeer(&http.Request{}, &UnbanPeerArgs{}, nil)
+	require.ErrorIs(err, errNoBanTarget)
+}
+
+func TestListBans(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nodeID := ids.GenerateTestNodeID()
+	expiry := time.Unix(1_000_000, 0)
+	mockNetwork := network.NewMockNetwork(ctrl)
+	mockNetwork.EXPECT().Bans().Return([]network.Ban{
+		{NodeID: nodeID, Expiry: expiry},
+		{IP: net.IPv4(10, 0, 0, 1), Expiry: expiry},
+	})
+	admin := &Admin{Config: Config{
+		Log:     logging.NoLog{},
+		Network: mockNetwork,
+	}}
+
+	reply := ListBansReply{}
+	require.NoError(admin.ListBans(&http.Request{}, nil, &reply))
+	require.Equal(
+		[]Ban{
+			{NodeID: &nodeID, Expiry: expiry},
+			{IP: "10.0.0.1", Expiry: expiry},
+		},
+		reply.Bans,
+	)
+}
+
+type inspectChainManager struct {
+	chains.Manager
+	graphviz bool
+}
+
+func (m *inspectChainManager) Inspect(_ context.Context, chainID ids.ID, graphviz bool) (interface{}, error) {
+	m.graphviz = graphviz
+	return chainID, nil
+}
+
+func TestInspectChain(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	chainManager := &inspectChainManager{
+		Manager: chains.TestManager,
+	}
+	admin := &Admin{Config: Config{
+		Log:          logging.NoLog{},
+		ChainManager: chainManager,
+	}}
+
+	reply := InspectChainReply{}
+	require.NoError(admin.InspectChain(&http.Request{}, &InspectChainArgs{
+		Chain:    chainID.String(),
+		Graphviz: true,
+	}, &reply))
+	require.Equal(chainID, reply.Inspection)
+	require.True(chainManager.graphviz)
+
+	err := admin.InspectChain(&http.Request{}, &InspectChainArgs{
+		Chain: "not a chain",
+	}, &reply)
+	require.Error(err)
+}
+
+type setConsensusParametersChainManager struct {
+	chains.Manager
+	subnetID ids.ID
+	params   avalanche.Parameters
+}
+
+func (m *setConsensusParametersChainManager) SetConsensusParameters(_ context.Context, subnetID ids.ID, params avalanche.Parameters) error {
+	m.subnetID = subnetID
+	m.params = params
+	return nil
+}
+
+func TestSetConsensusParameters(t *testing.T) {
+	require := require.New(t)
+
+	chainManager := &setConsensusParametersChainManager{
+		Manager: chains.TestManager,
+	}
+	admin := &Admin{Config: Config{
+		Log:          logging.NoLog{},
+		ChainManager: chainManager,
+	}}
+
+	args := &SetConsensusParametersArgs{
+		SubnetID: ids.GenerateTestID(),
+		Parameters: avalanche.Parameters{
+			Parents:   2,
+			BatchSize: 1,
+		},
+	}
+	require.NoError(admin.SetConsensusParameters(&http.Request{}, args, &api.EmptyReply{}))
+	require.Equal(args.SubnetID, chainManager.subnetID)
+	require.Equal(args.Parameters, chainManager.params)
+}
+
+func TestSetConsensusParametersArgsLegacyAlpha(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	argsJSON := `{"subnetID":"` + subnetID.String() + `","parameters":{"k":20,"alpha":15,"parents":5}}`
+
+	args := &SetConsensusParametersArgs{}
+	require.NoError(stdjson.Unmarshal([]byte(argsJSON), args))
+	require.Equal(subnetID, args.SubnetID)
+	require.Equal(20, args.Parameters.K)
+	require.Equal(15, args.Parameters.AlphaPreference)
+	require.Equal(15, args.Parameters.AlphaConfidence)
+	require.Equal(5, args.Parameters.Parents)
+}
+
+func TestBenchlist(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := validators.NewManager()
+	require.True(vdrs.Add(constants.PrimaryNetworkID, validators.NewSet()))
+	benchlistManager := benchlist.NewManager(&benchlist.Config{
+		Benchable:  &benchlist.TestBenchable{T: t},
+		Validators: vdrs,
+		DB:         memdb.New(),
+		Threshold:  3,
+		Duration:   time.Minute,
+		MaxPortion: 0.5,
+	})
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+	require.NoError(benchlistManager.RegisterChain(ctx))
+
+	admin := &Admin{Config: Config{
+		Log:          logging.NoLog{},
+		ChainManager: chains.TestManager,
+		Benchlist:    benchlistManager,
+	}}
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+	chain := ctx.ChainID.String()
+
+	err := admin.Bench(&http.Request{}, &BenchArgs{
+		Chain:    chain,
+		Duration: "1h",
+	}, &api.EmptyReply{})
+	require.ErrorIs(err, errNoNodeID)
+
+	err = admin.Bench(&http.Request{}, &BenchArgs{
+		Chain:    chain,
+		NodeID:   nodeID0,
+		Duration: "forever",
+	}, &api.EmptyReply{})
+	require.Error(err)
+
+	err = admin.Bench(&http.Request{}, &BenchArgs{
+		Chain:    ids.GenerateTestID().String(),
+		NodeID:   nodeID0,
+		Duration: "1h",
+	}, &api.EmptyReply{})
+	require.Error(err)
+
+	require.NoError(admin.Bench(&http.Request{}, &BenchArgs{
+		Chain:    chain,
+		NodeID:   nodeID0,
+		Duration: "2h",
+	}, &api.EmptyReply{}))
+	require.NoError(admin.Bench(&http.Request{}, &BenchArgs{
+		Chain:    chain,
+		NodeID:   nodeID1,
+		Duration: "1h",
+	}, &api.EmptyReply{}))
+	require.True(benchlistManager.IsBenched(nodeID0, ctx.ChainID))
+
+	reply := GetBenchlistReply{}
+	require.NoError(admin.GetBenchlist(&http.Request{}, &GetBenchlistArgs{
+		Chain: chain,
+	}, &reply))
+	require.Len(reply.Benched, 2)
+	require.Equal(nodeID1, reply.Benched[0].NodeID)
+	require.Equal(nodeID0, reply.Benched[1].NodeID)
+
+	require.NoError(admin.Unbench(&http.Request{}, &UnbenchArgs{
+		Chain:  chain,
+		NodeID: nodeID1,
+	}, &api.EmptyReply{}))
+	require.False(benchlistManager.IsBenched(nodeID1, ctx.ChainID))
+
+	reply = GetBenchlistReply{}
+	require.NoError(admin.GetBenchlist(&http.Request{}, &GetBenchlistArgs{
+		Chain: chain,
+	}, &reply))
+	require.Len(reply.Benched, 1)
+	require.Equal(nodeID0, reply.Benched[0].NodeID)
+}
@@ -37,7 +37,7 @@ func TestClient(t *testing.T) {
 	require := require.New(t)
 
 	mc := &mockClient{
-		reply:  IsBootstrappedResponse{true},
+		reply:  IsBootstrappedResponse{IsBootstrapped: true},
 		err:    nil,
 		onCall: func() {},
 	}
@@ -212,6 +212,9 @@ func (i *Info) GetBlockchainID(_ *http.Request, args *GetBlockchainIDArgs, reply
 // PeersArgs are the arguments for calling Peers
 type PeersArgs struct {
 	NodeIDs []ids.NodeID `json:"nodeIDs"`
+	// IncludeTraffic adds the traffic exchanged with each peer, by chain and
+	// op, to the reply.
+	IncludeTraffic bool `json:"includeTraffic"`
 }
 
 type Peer struct {
@@ -238,6 +241,9 @@ func (i *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error
 	peers := i.networking.PeerInfo(args.NodeIDs)
 	peerInfo := make([]Peer, len(peers))
 	for index, peer := range peers {
+		if !args.IncludeTraffic {
+			peer.Traffic = nil
+		}
 		peerInfo[index] = Peer{
 			Info:    peer,
 			Benched: i.benchlist.GetBenched(peer.ID),
@@ -260,6 +266,25 @@ type IsBootstrappedArgs struct {
 type IsBootstrappedResponse struct {
 	// True iff the chain exists and is done bootstrapping
 	IsBootstrapped bool `json:"isBootstrapped"`
+	// How far the chain has progressed in bootstrapping
+	Progress BootstrapProgress `json:"progress"`
+}
+
+// BootstrapProgress reports how far a chain has progressed in bootstrapping
+type BootstrapProgress struct {
+	// One of notStarted, stateSyncing, fetching, executing or done
+	Phase string `json:"phase"`
+	// Number of containers fetched and waiting to be executed
+	Fetched json.Uint64 `json:"fetched"`
+	// Number of containers executed in the current execution phase
+	Executed json.Uint64 `json:"executed"`
+	// Height of the accepted frontier being bootstrapped to. Only reported by
+	// linear chains.
+	TargetHeight json.Uint64 `json:"targetHeight"`
+	// Number of containers processed per second in the current phase
+	Rate json.Float64 `json:"rate"`
+	// Estimated nanoseconds until the current phase finishes. Zero if unknown.
+	ETA json.Uint64 `json:"eta"`
 }
 
 // IsBootstrapped returns nil and sets [reply.IsBootstrapped] == true iff [args.Chain] exists and is done bootstrapping
@@ -279,6 +304,18 @@ func (i *Info) IsBootstrapped(_ *http.Request, args *IsBootstrappedArgs, reply *
 		return fmt.Errorf("there is no chain with alias/ID '%s'", args.Chain)
 	}
 	reply.IsBootstrapped = i.chainManager.IsBootstrapped(chainID)
+
+	// Chains that haven't been created yet report that they haven't started
+	// bootstrapping.
+	progress, _ := i.chainManager.BootstrapProgress(chainID)
+	reply.Progress = BootstrapProgress{
+		Phase:        progress.Phase.String(),
+		Fetched:      json.Uint64(progress.Fetched),
+		Executed:     json.Uint64(progress.Executed),
+		TargetHeight: json.Uint64(progress.TargetHeight),
+		Rate:         json.Float64(progress.Rate),
+		ETA:          json.Uint64(progress.ETA),
+	}
 	return nil
 }
 
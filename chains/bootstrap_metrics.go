@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const bootstrapProgressNamespace = "bs_progress"
+
+// registerBootstrapProgressMetrics registers metrics that report the
+// bootstrapping progress of the chain of [ctx] when they are gathered.
+func registerBootstrapProgressMetrics(ctx *snow.ConsensusContext) error {
+	newGauge := func(name, help string, value func(snow.BootstrapProgress) float64) prometheus.GaugeFunc {
+		return prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Namespace: bootstrapProgressNamespace,
+				Name:      name,
+				Help:      help,
+			},
+			func() float64 {
+				return value(ctx.BootstrapProgress.Get())
+			},
+		)
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		ctx.Registerer.Register(newGauge(
+			"phase",
+			"Phase of bootstrapping: 0 if not started, 1 if state syncing, 2 if fetching, 3 if executing and 4 if done",
+			func(p snow.BootstrapProgress) float64 { return float64(p.Phase) },
+		)),
+		ctx.Registerer.Register(newGauge(
+			"fetched",
+			"Number of containers fetched and waiting to be executed",
+			func(p snow.BootstrapProgress) float64 { return float64(p.Fetched) },
+		)),
+		ctx.Registerer.Register(newGauge(
+			"executed",
+			"Number of containers executed in the current execution phase",
+			func(p snow.BootstrapProgress) float64 { return float64(p.Executed) },
+		)),
+		ctx.Registerer.Register(newGauge(
+			"target_height",
+			"Height of the accepted frontier being bootstrapped to",
+			func(p snow.BootstrapProgress) float64 { return float64(p.TargetHeight) },
+		)),
+		ctx.Registerer.Register(newGauge(
+			"rate",
+			"Number of containers processed per second in the current phase",
+			func(p snow.BootstrapProgress) float64 { return p.Rate },
+		)),
+		ctx.Registerer.Register(newGauge(
+			"eta",
+			"ETA in nanoseconds until the current phase of bootstrapping finishes",
+			func(p snow.BootstrapProgress) float64 { return float64(p.ETA) },
+		)),
+	)
+	return errs.Err
+}
@@ -17,8 +17,9 @@ import (
 )
 
 var (
-	_ vertex.LinearizableVM = (*initializeOnLinearizeVM)(nil)
-	_ block.ChainVM         = (*linearizeOnInitializeVM)(nil)
+	_ vertex.LinearizableVM        = (*initializeOnLinearizeVM)(nil)
+	_ vertex.ConcurrentVerifyDAGVM = (*initializeOnLinearizeVM)(nil)
+	_ block.ChainVM                = (*linearizeOnInitializeVM)(nil)
 )
 
 // initializeOnLinearizeVM transforms the consensus engine's call to Linearize
@@ -57,6 +58,14 @@ func (vm *initializeOnLinearizeVM) Linearize(ctx context.Context, stopVertexID i
 	)
 }
 
+func (vm *initializeOnLinearizeVM) ConcurrentTxVerifyEnabled(ctx context.Context) (bool, error) {
+	cvVM, ok := vm.DAGVM.(vertex.ConcurrentVerifyDAGVM)
+	if !ok {
+		return false, nil
+	}
+	return cvVM.ConcurrentTxVerifyEnabled(ctx)
+}
+
 // linearizeOnInitializeVM transforms the proposervm's call to Initialize into a
 // call to Linearize. This enables the proposervm to provide its toEngine
 // channel to the VM that is being linearized.
@@ -27,6 +27,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
 	"github.com/MetalBlockchain/metalgo/network"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/engine/avalanche/state"
@@ -36,6 +37,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/engine/common/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/syncer"
+	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
 	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 	"github.com/MetalBlockchain/metalgo/snow/networking/sender"
@@ -43,12 +45,15 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/subnets"
 	"github.com/MetalBlockchain/metalgo/trace"
+	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/buffer"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/perms"
 	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
 	"github.com/MetalBlockchain/metalgo/version"
 	"github.com/MetalBlockchain/metalgo/vms"
 	"github.com/MetalBlockchain/metalgo/vms/metervm"
@@ -92,6 +97,8 @@ var (
 	errCreatePlatformVM       = errors.New("attempted to create a chain running the PlatformVM")
 	errNotBootstrapped        = errors.New("subnets not bootstrapped")
 	errNoPlatformSubnetConfig = errors.New("subnet config for platform chain not found")
+	errUnknownChain           = errors.New("unknown chain")
+	errUnknownSubnet          = errors.New("unknown subnet")
 
 	_ Manager = (*manager)(nil)
 )
@@ -127,6 +134,19 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// BootstrapProgress returns how far the chain with the given ID has
+	// progressed in bootstrapping
+	BootstrapProgress(ids.ID) (snow.BootstrapProgress, error)
+
+	// Inspect returns a snapshot of the consensus engine state of the chain
+	// with the given ID
+	Inspect(ctx context.Context, chainID ids.ID, graphviz bool) (interface{}, error)
+
+	// SetConsensusParameters replaces the consensus parameters of the subnet
+	// with the given ID. The running chains of the subnet apply the new
+	// parameters once their outstanding polls have finished.
+	SetConsensusParameters(ctx context.Context, subnetID ids.ID, params avcon.Parameters) error
+
 	// Starts the chain creator with the initial platform chain parameters, must
 	// be called once.
 	StartChainCreator(platformChain ChainParameters) error
@@ -220,10 +240,21 @@ type ManagerConfig struct {
 
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker timetracker.ResourceTracker
+	// Limits the CPU/disk usage caused by the messages of each subnet.
+	SubnetResourceThrottler throttling.SubnetSystemThrottler
+	// Benchlist of every chain. Its max portion is derived from the primary
+	// network's alpha and is refreshed when those parameters change.
+	Benchlist benchlist.Manager
+	// Minimum percentage of connected stake of each subnet for the node to be
+	// healthy. Refreshed when a subnet's consensus parameters change.
+	MinPercentConnectedStakeHealthy *utils.Atomic[map[ids.ID]float64]
 
 	StateSyncBeacons []ids.NodeID
 
 	ChainDataDir string
+
+	// Clock that the chains' engine and VM timers run on
+	Clock mockable.Clock
 }
 
 type manager struct {
@@ -246,6 +277,9 @@ type manager struct {
 	// Value: Subnet description
 	subnets map[ids.ID]subnets.Subnet
 
+	// Serializes updates of the consensus parameters of subnets
+	reconfigureLock sync.Mutex
+
 	chainsLock sync.Mutex
 	// Key: Chain's ID
 	// Value: The chain
@@ -473,6 +507,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 			SharedMemory: m.AtomicMemory.NewSharedMemory(chainParams.ID),
 			BCLookup:     m,
 			Metrics:      vmMetrics,
+			Clock:        m.Clock,
 
 			WarpSigner: warp.NewSigner(m.StakingBLSKey, chainParams.ID),
 
@@ -485,6 +520,9 @@ func (m *manager) buildChain(chainParams ChainParameters, sb subnets.Subnet) (*c
 		Registerer:          consensusMetrics,
 		AvalancheRegisterer: avalancheConsensusMetrics,
 	}
+	if err := registerBootstrapProgressMetrics(ctx); err != nil {
+		return nil, fmt.Errorf("error while registering bootstrap progress metrics %w", err)
+	}
 
 	// Get a factory for the vm we want to use on our chain
 	vmFactory, err := m.VMManager.GetFactory(chainParams.VMID)
@@ -818,6 +856,7 @@ func (m *manager) createAvalancheChain(
 		msgChan,
 		m.ConsensusGossipFrequency,
 		m.ResourceTracker,
+		m.SubnetResourceThrottler,
 		validators.UnhandledSubnetConnector, // avalanche chains don't use subnet connector
 		sb,
 	)
@@ -877,10 +916,11 @@ func (m *manager) createAvalancheChain(
 
 	// create bootstrap gear
 	bootstrapCfg := smbootstrap.Config{
-		Config:        snowmanCommonCfg,
-		AllGetsServer: snowGetHandler,
-		Blocked:       blockBlocker,
-		VM:            vmWrappingProposerVM,
+		Config:           snowmanCommonCfg,
+		AllGetsServer:    snowGetHandler,
+		Blocked:          blockBlocker,
+		VM:               vmWrappingProposerVM,
+		PeerCapabilities: m.Net.PeerCapabilities,
 	}
 	snowmanBootstrapper, err := smbootstrap.New(
 		context.TODO(),
@@ -1173,6 +1213,7 @@ func (m *manager) createSnowmanChain(
 		msgChan,
 		m.ConsensusGossipFrequency,
 		m.ResourceTracker,
+		m.SubnetResourceThrottler,
 		subnetConnector,
 		sb,
 	)
@@ -1233,11 +1274,12 @@ func (m *manager) createSnowmanChain(
 
 	// create bootstrap gear
 	bootstrapCfg := smbootstrap.Config{
-		Config:        commonCfg,
-		AllGetsServer: snowGetHandler,
-		Blocked:       blocked,
-		VM:            vm,
-		Bootstrapped:  bootstrapFunc,
+		Config:           commonCfg,
+		AllGetsServer:    snowGetHandler,
+		Blocked:          blocked,
+		VM:               vm,
+		PeerCapabilities: m.Net.PeerCapabilities,
+		Bootstrapped:     bootstrapFunc,
 	}
 	bootstrapper, err := smbootstrap.New(
 		context.TODO(),
@@ -1304,6 +1346,105 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Context().State.Get().State == snow.NormalOp
 }
 
+func (m *manager) BootstrapProgress(chainID ids.ID) (snow.BootstrapProgress, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return snow.BootstrapProgress{}, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+
+	return chain.Context().BootstrapProgress.Get(), nil
+}
+
+func (m *manager) Inspect(ctx context.Context, chainID ids.ID, graphviz bool) (interface{}, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+
+	return chain.Inspect(ctx, graphviz)
+}
+
+func (m *manager) SetConsensusParameters(ctx context.Context, subnetID ids.ID, params avcon.Parameters) error {
+	if err := params.Valid(); err != nil {
+		return err
+	}
+
+	m.subnetsLock.Lock()
+	sb, exists := m.subnets[subnetID]
+	m.subnetsLock.Unlock()
+	if !exists {
+		return fmt.Errorf("%w: %s", errUnknownSubnet, subnetID)
+	}
+
+	m.reconfigureLock.Lock()
+	defer m.reconfigureLock.Unlock()
+
+	// Chains that are created later in this subnet use the new parameters.
+	sb.SetConsensusParameters(params)
+
+	m.chainsLock.Lock()
+	subnetChains := make([]handler.Handler, 0, len(m.chains))
+	for _, chain := range m.chains {
+		if chain.Context().SubnetID == subnetID {
+			subnetChains = append(subnetChains, chain)
+		}
+	}
+	m.chainsLock.Unlock()
+
+	// A failure to reconfigure one chain must not leave the rest of the subnet
+	// running with the old parameters, so every chain is reconfigured and the
+	// failures are reported together.
+	var (
+		errs           wrappers.Errs
+		failedChainIDs []ids.ID
+	)
+	for _, chain := range subnetChains {
+		chainID := chain.Context().ChainID
+		if err := chain.Reconfigure(ctx, params); err != nil {
+			m.Log.Warn("couldn't reconfigure chain",
+				zap.Stringer("subnetID", subnetID),
+				zap.Stringer("chainID", chainID),
+				zap.Error(err),
+			)
+			errs.Add(err)
+			failedChainIDs = append(failedChainIDs, chainID)
+		}
+	}
+
+	// Refresh the values derived from alpha.
+	if m.MinPercentConnectedStakeHealthy != nil {
+		oldMinPercents := m.MinPercentConnectedStakeHealthy.Get()
+		minPercents := make(map[ids.ID]float64, len(oldMinPercents)+1)
+		for id, minPercent := range oldMinPercents {
+			minPercents[id] = minPercent
+		}
+		minPercents[subnetID] = subnets.MinPercentConnectedStakeHealthy(params.Parameters)
+		m.MinPercentConnectedStakeHealthy.Set(minPercents)
+	}
+	if subnetID == constants.PrimaryNetworkID && m.Benchlist != nil {
+		if err := m.Benchlist.SetMaxPortion(subnets.BenchlistMaxPortion(params.Parameters)); err != nil {
+			errs.Add(fmt.Errorf("couldn't update benchlist: %w", err))
+		}
+	}
+
+	if len(failedChainIDs) > 0 {
+		return fmt.Errorf("couldn't reconfigure chains %v: %w", failedChainIDs, errs.Err)
+	}
+	if errs.Errored() {
+		return errs.Err
+	}
+
+	m.Log.Info("updated consensus parameters",
+		zap.Stringer("subnetID", subnetID),
+		zap.Reflect("parameters", params),
+	)
+	return nil
+}
+
 func (m *manager) subnetsNotBootstrapped() []ids.ID {
 	m.subnetsLock.Lock()
 	defer m.subnetsLock.Unlock()
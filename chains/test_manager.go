@@ -4,7 +4,11 @@
 package chains
 
 import (
+	"context"
+
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 )
 
@@ -56,6 +60,18 @@ func (testManager) IsBootstrapped(ids.ID) bool {
 	return false
 }
 
+func (testManager) BootstrapProgress(ids.ID) (snow.BootstrapProgress, error) {
+	return snow.BootstrapProgress{}, nil
+}
+
+func (testManager) Inspect(context.Context, ids.ID, bool) (interface{}, error) {
+	return nil, nil
+}
+
+func (testManager) SetConsensusParameters(context.Context, ids.ID, avalanche.Parameters) error {
+	return nil
+}
+
 func (testManager) Lookup(s string) (ids.ID, error) {
 	return ids.FromString(s)
 }
@@ -33,11 +33,13 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
 	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
+	"github.com/MetalBlockchain/metalgo/snow/networking/capture"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/staking"
 	"github.com/MetalBlockchain/metalgo/subnets"
 	"github.com/MetalBlockchain/metalgo/trace"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
 	"github.com/MetalBlockchain/metalgo/utils/dynamicip"
@@ -61,7 +63,9 @@ const (
 )
 
 var (
-	deprecatedKeys = map[string]string{}
+	deprecatedKeys = map[string]string{
+		SnowQuorumSizeKey: fmt.Sprintf("use %s and %s instead", SnowPreferenceQuorumSizeKey, SnowConfidenceQuorumSizeKey),
+	}
 
 	errInvalidStakerWeights          = errors.New("staking weights must be positive")
 	errStakingDisableOnPublicNetwork = errors.New("staking disabled on public network")
@@ -83,10 +87,11 @@ var (
 )
 
 func getConsensusConfig(v *viper.Viper) avalanche.Parameters {
-	return avalanche.Parameters{
+	p := avalanche.Parameters{
 		Parameters: snowball.Parameters{
 			K:                       v.GetInt(SnowSampleSizeKey),
-			Alpha:                   v.GetInt(SnowQuorumSizeKey),
+			AlphaPreference:         v.GetInt(SnowPreferenceQuorumSizeKey),
+			AlphaConfidence:         v.GetInt(SnowConfidenceQuorumSizeKey),
 			BetaVirtuous:            v.GetInt(SnowVirtuousCommitThresholdKey),
 			BetaRogue:               v.GetInt(SnowRogueCommitThresholdKey),
 			ConcurrentRepolls:       v.GetInt(SnowConcurrentRepollsKey),
@@ -99,6 +104,11 @@ func getConsensusConfig(v *viper.Viper) avalanche.Parameters {
 		BatchSize: v.GetInt(SnowAvalancheBatchSizeKey),
 		Parents:   v.GetInt(SnowAvalancheNumParentsKey),
 	}
+	if v.IsSet(SnowQuorumSizeKey) {
+		p.AlphaPreference = v.GetInt(SnowQuorumSizeKey)
+		p.AlphaConfidence = p.AlphaPreference
+	}
+	return p
 }
 
 func getLoggingConfig(v *viper.Viper) (logging.Config, error) {
@@ -335,6 +345,19 @@ func getNetworkConfig(v *viper.Viper, stakingEnabled bool, halflife time.Duratio
 				VdrAllocSize:        v.GetUint64(OutboundThrottlerVdrAllocSizeKey),
 				NodeMaxAtLargeBytes: v.GetUint64(OutboundThrottlerNodeMaxAtLargeBytesKey),
 			},
+
+			OutboundBandwidthThrottlerConfig: throttling.OutboundBandwidthThrottlerConfig{
+				RefillRate:       v.GetUint64(OutboundThrottlerBandwidthRefillRateKey),
+				MaxBurstSize:     v.GetUint64(OutboundThrottlerBandwidthMaxBurstSizeKey),
+				NodeRefillRate:   v.GetUint64(OutboundThrottlerNodeBandwidthRefillRateKey),
+				NodeMaxBurstSize: v.GetUint64(OutboundThrottlerNodeBandwidthMaxBurstSizeKey),
+			},
+
+			PeerDiversityThrottlerConfig: throttling.PeerDiversityThrottlerConfig{
+				MaxPeersPerIPv4Prefix: v.GetInt(PeerDiversityMaxPeersPerIPv4PrefixKey),
+				MaxPeersPerIPv6Prefix: v.GetInt(PeerDiversityMaxPeersPerIPv6PrefixKey),
+				MaxPeersPerASN:        v.GetInt(PeerDiversityMaxPeersPerASNKey),
+			},
 		},
 
 		HealthConfig: network.HealthConfig{
@@ -350,10 +373,7 @@ func getNetworkConfig(v *viper.Viper, stakingEnabled bool, halflife time.Duratio
 		ProxyEnabled:           v.GetBool(NetworkTCPProxyEnabledKey),
 		ProxyReadHeaderTimeout: v.GetDuration(NetworkTCPProxyReadTimeoutKey),
 
-		DialerConfig: dialer.Config{
-			ThrottleRps:       v.GetUint32(OutboundConnectionThrottlingRpsKey),
-			ConnectionTimeout: v.GetDuration(OutboundConnectionTimeoutKey),
-		},
+		DialerConfig: getDialerConfig(v),
 
 		TLSKeyLogFile: v.GetString(NetworkTLSKeyLogFileKey),
 
@@ -376,7 +396,7 @@ func getNetworkConfig(v *viper.Viper, stakingEnabled bool, halflife time.Duratio
 		},
 
 		MaxClockDifference:           v.GetDuration(NetworkMaxClockDifferenceKey),
-		CompressionEnabled:           v.GetBool(NetworkCompressionEnabledKey),
+		CompressionType:              compression.TypeNone,
 		PingFrequency:                v.GetDuration(NetworkPingFrequencyKey),
 		AllowPrivateIPs:              v.GetBool(NetworkAllowPrivateIPsKey),
 		UptimeMetricFreq:             v.GetDuration(UptimeMetricFreqKey),
@@ -385,6 +405,34 @@ func getNetworkConfig(v *viper.Viper, stakingEnabled bool, halflife time.Duratio
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 		PeerReadBufferSize:        int(v.GetUint(NetworkPeerReadBufferSizeKey)),
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
+		PeerTrafficMetricsEnabled: v.GetBool(NetworkPeerTrafficMetricsEnabledKey),
+	}
+
+	if v.GetBool(NetworkCompressionEnabledKey) {
+		compressionType, err := compression.TypeFromString(v.GetString(NetworkCompressionTypeKey))
+		if err != nil {
+			return network.Config{}, fmt.Errorf("couldn't parse %s: %w", NetworkCompressionTypeKey, err)
+		}
+		if compressionType == compression.TypeNone {
+			return network.Config{}, fmt.Errorf("%s must not be %q, use %s instead", NetworkCompressionTypeKey, compressionType, NetworkCompressionEnabledKey)
+		}
+		config.CompressionType = compressionType
+	}
+
+	accessListConfig, err := getAccessListConfig(v)
+	if err != nil {
+		return network.Config{}, err
+	}
+	config.AccessListConfig = accessListConfig
+
+	if v.IsSet(PeerDiversityASNsContentKey) {
+		asnsContent, err := base64.StdEncoding.DecodeString(v.GetString(PeerDiversityASNsContentKey))
+		if err != nil {
+			return network.Config{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+		if err := json.Unmarshal(asnsContent, &config.ThrottlerConfig.PeerDiversityThrottlerConfig.ASNs); err != nil {
+			return network.Config{}, fmt.Errorf("couldn't parse %s: %w", PeerDiversityASNsContentKey, err)
+		}
 	}
 
 	switch {
@@ -416,18 +464,75 @@ func getNetworkConfig(v *viper.Viper, stakingEnabled bool, halflife time.Duratio
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReadHandshakeTimeoutKey)
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
+	case config.ThrottlerConfig.PeerDiversityThrottlerConfig.MaxPeersPerIPv4Prefix < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", PeerDiversityMaxPeersPerIPv4PrefixKey)
+	case config.ThrottlerConfig.PeerDiversityThrottlerConfig.MaxPeersPerIPv6Prefix < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", PeerDiversityMaxPeersPerIPv6PrefixKey)
+	case config.ThrottlerConfig.PeerDiversityThrottlerConfig.MaxPeersPerASN < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", PeerDiversityMaxPeersPerASNKey)
 	}
 	return config, nil
 }
 
+func getDialerConfig(v *viper.Viper) dialer.Config {
+	return dialer.Config{
+		ThrottleRps:         v.GetUint32(OutboundConnectionThrottlingRpsKey),
+		ConnectionTimeout:   v.GetDuration(OutboundConnectionTimeoutKey),
+		SOCKS5Proxy:         v.GetString(OutboundConnectionSOCKS5ProxyKey),
+		SOCKS5ProxyUsername: v.GetString(OutboundConnectionSOCKS5ProxyUsernameKey),
+		SOCKS5ProxyPassword: v.GetString(OutboundConnectionSOCKS5ProxyPasswordKey),
+	}
+}
+
+func getAccessListConfig(v *viper.Viper) (network.AccessListConfig, error) {
+	config := network.AccessListConfig{
+		AllowedCIDRs: splitList(v.GetString(NetworkAllowedCIDRsKey)),
+		DeniedCIDRs:  splitList(v.GetString(NetworkDeniedCIDRsKey)),
+	}
+	for _, cidr := range append(config.AllowedCIDRs, config.DeniedCIDRs...) {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return network.AccessListConfig{}, fmt.Errorf("couldn't parse CIDR %q: %w", cidr, err)
+		}
+	}
+
+	var err error
+	config.AllowedNodeIDs, err = parseNodeIDs(splitList(v.GetString(NetworkAllowedNodeIDsKey)))
+	if err != nil {
+		return network.AccessListConfig{}, err
+	}
+	config.DeniedNodeIDs, err = parseNodeIDs(splitList(v.GetString(NetworkDeniedNodeIDsKey)))
+	return config, err
+}
+
+// splitList returns the non-empty elements of the comma separated list [s].
+func splitList(s string) []string {
+	var elts []string
+	for _, elt := range strings.Split(s, ",") {
+		if elt = strings.TrimSpace(elt); elt != "" {
+			elts = append(elts, elt)
+		}
+	}
+	return elts
+}
+
+func parseNodeIDs(nodeIDStrs []string) ([]ids.NodeID, error) {
+	nodeIDs := make([]ids.NodeID, len(nodeIDStrs))
+	for i, nodeIDStr := range nodeIDStrs {
+		nodeID, err := ids.NodeIDFromString(nodeIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse nodeID %q: %w", nodeIDStr, err)
+		}
+		nodeIDs[i] = nodeID
+	}
+	return nodeIDs, nil
+}
+
 func getBenchlistConfig(v *viper.Viper, consensusParameters avalanche.Parameters) (benchlist.Config, error) {
-	alpha := consensusParameters.Alpha
-	k := consensusParameters.K
 	config := benchlist.Config{
 		Threshold:              v.GetInt(BenchlistFailThresholdKey),
 		Duration:               v.GetDuration(BenchlistDurationKey),
 		MinimumFailingDuration: v.GetDuration(BenchlistMinFailingDurationKey),
-		MaxPortion:             (1.0 - (float64(alpha) / float64(k))) / 3.0,
+		MaxPortion:             subnets.BenchlistMaxPortion(consensusParameters.Parameters),
 	}
 	switch {
 	case config.Duration < 0:
@@ -561,7 +666,7 @@ func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 	}
 	if ipResolutionService != "" {
 		// User specified to use dynamic IP resolution.
-		resolver, err := dynamicip.NewResolver(ipResolutionService)
+		resolver, err := getIPResolver(v, ipResolutionService)
 		if err != nil {
 			return node.IPConfig{}, fmt.Errorf("couldn't create IP resolver: %w", err)
 		}
@@ -603,6 +708,24 @@ func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 	}, nil
 }
 
+// getIPResolver returns a resolver that uses [ipResolutionService], through the
+// SOCKS5 proxy if requested.
+func getIPResolver(v *viper.Viper, ipResolutionService string) (dynamicip.Resolver, error) {
+	if !v.GetBool(PublicIPResolutionUseSOCKS5ProxyKey) {
+		return dynamicip.NewResolver(ipResolutionService)
+	}
+
+	dialerConfig := getDialerConfig(v)
+	if dialerConfig.SOCKS5Proxy == "" {
+		return nil, fmt.Errorf("%s requires %s to be set", PublicIPResolutionUseSOCKS5ProxyKey, OutboundConnectionSOCKS5ProxyKey)
+	}
+	contextDialer, err := dialer.NewContextDialer(dialerConfig)
+	if err != nil {
+		return nil, err
+	}
+	return dynamicip.NewProxiedResolver(ipResolutionService, contextDialer)
+}
+
 func getProfilerConfig(v *viper.Viper) (profiler.Config, error) {
 	config := profiler.Config{
 		Dir:         GetExpandedArg(v, ProfileDirKey),
@@ -616,6 +739,44 @@ func getProfilerConfig(v *viper.Viper) (profiler.Config, error) {
 	return config, nil
 }
 
+func getCaptureConfig(v *viper.Viper) (capture.Config, error) {
+	config := capture.Config{
+		RotatingWriterConfig: logging.RotatingWriterConfig{
+			MaxSize:   int(v.GetUint(NetworkCaptureMaxSizeKey)),
+			MaxFiles:  int(v.GetUint(NetworkCaptureMaxFilesKey)),
+			MaxAge:    int(v.GetUint(NetworkCaptureMaxAgeKey)),
+			Directory: GetExpandedArg(v, NetworkCaptureDirKey),
+			Compress:  v.GetBool(NetworkCaptureCompressEnabledKey),
+		},
+		Enabled: v.GetBool(NetworkCaptureEnabledKey),
+	}
+	if config.MaxSize == 0 {
+		return capture.Config{}, fmt.Errorf("%s must be > 0", NetworkCaptureMaxSizeKey)
+	}
+
+	for _, chainIDStr := range strings.Split(v.GetString(NetworkCaptureChainIDsKey), ",") {
+		if chainIDStr == "" {
+			continue
+		}
+		chainID, err := ids.FromString(chainIDStr)
+		if err != nil {
+			return capture.Config{}, fmt.Errorf("couldn't parse chainID %q: %w", chainIDStr, err)
+		}
+		config.ChainIDs.Add(chainID)
+	}
+	for _, nodeIDStr := range strings.Split(v.GetString(NetworkCaptureNodeIDsKey), ",") {
+		if nodeIDStr == "" {
+			continue
+		}
+		nodeID, err := ids.NodeIDFromString(nodeIDStr)
+		if err != nil {
+			return capture.Config{}, fmt.Errorf("couldn't parse nodeID %q: %w", nodeIDStr, err)
+		}
+		config.NodeIDs.Add(nodeID)
+	}
+	return config, nil
+}
+
 func getStakingTLSCertFromFlag(v *viper.Viper) (tls.Certificate, error) {
 	stakingKeyRawContent := v.GetString(StakingTLSKeyContentKey)
 	stakingKeyContent, err := base64.StdEncoding.DecodeString(stakingKeyRawContent)
@@ -1067,8 +1228,8 @@ func getSubnetConfigsFromFlags(v *viper.Viper, subnetIDs []ids.ID) (map[ids.ID]s
 	res := make(map[ids.ID]subnets.Config)
 	for _, subnetID := range subnetIDs {
 		if rawSubnetConfigBytes, ok := subnetConfigs[subnetID]; ok {
-			config := getDefaultSubnetConfig(v)
-			if err := json.Unmarshal(rawSubnetConfigBytes, &config); err != nil {
+			config, err := parseSubnetConfig(v, rawSubnetConfigBytes)
+			if err != nil {
 				return nil, err
 			}
 
@@ -1115,8 +1276,8 @@ func getSubnetConfigsFromDir(v *viper.Viper, subnetIDs []ids.ID) (map[ids.ID]sub
 			return nil, err
 		}
 
-		config := getDefaultSubnetConfig(v)
-		if err := json.Unmarshal(file, &config); err != nil {
+		config, err := parseSubnetConfig(v, file)
+		if err != nil {
 			return nil, err
 		}
 
@@ -1130,6 +1291,15 @@ func getSubnetConfigsFromDir(v *viper.Viper, subnetIDs []ids.ID) (map[ids.ID]sub
 	return subnetConfigs, nil
 }
 
+// parseSubnetConfig parses [configBytes] on top of the default subnet config.
+func parseSubnetConfig(v *viper.Viper, configBytes []byte) (subnets.Config, error) {
+	config := getDefaultSubnetConfig(v)
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return subnets.Config{}, err
+	}
+	return config, nil
+}
+
 func getDefaultSubnetConfig(v *viper.Viper) subnets.Config {
 	return subnets.Config{
 		ConsensusParameters:   getConsensusConfig(v),
@@ -1350,10 +1520,15 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 	}
 
 	primaryNetworkConfig := getDefaultSubnetConfig(v)
+	primaryNetworkConfig.CPUAllocation.Allocation = v.GetFloat64(PrimaryNetworkCPUAllocKey)
+	primaryNetworkConfig.DiskAllocation.Allocation = v.GetFloat64(PrimaryNetworkDiskAllocKey)
 	if err := primaryNetworkConfig.Valid(); err != nil {
-		return node.Config{}, fmt.Errorf("invalid consensus parameters: %w", err)
+		return node.Config{}, fmt.Errorf("invalid primary network config: %w", err)
 	}
 	subnetConfigs[constants.PrimaryNetworkID] = primaryNetworkConfig
+	if err := subnets.VerifyAllocations(subnetConfigs); err != nil {
+		return node.Config{}, fmt.Errorf("invalid subnet configs: %w", err)
+	}
 
 	nodeConfig.SubnetConfigs = subnetConfigs
 
@@ -1390,11 +1565,11 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 
 	// Node health
 	nodeConfig.MinPercentConnectedStakeHealthy = map[ids.ID]float64{
-		constants.PrimaryNetworkID: calcMinConnectedStake(primaryNetworkConfig.ConsensusParameters.Parameters),
+		constants.PrimaryNetworkID: subnets.MinPercentConnectedStakeHealthy(primaryNetworkConfig.ConsensusParameters.Parameters),
 	}
 
 	for subnetID, config := range subnetConfigs {
-		nodeConfig.MinPercentConnectedStakeHealthy[subnetID] = calcMinConnectedStake(config.ConsensusParameters.Parameters)
+		nodeConfig.MinPercentConnectedStakeHealthy[subnetID] = subnets.MinPercentConnectedStakeHealthy(config.ConsensusParameters.Parameters)
 	}
 
 	// Chain Configs
@@ -1409,6 +1584,12 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	// Capture
+	nodeConfig.CaptureConfig, err = getCaptureConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	// VM Aliases
 	nodeConfig.VMAliaser, err = getVMAliaser(v)
 	if err != nil {
@@ -1451,15 +1632,6 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 	return nodeConfig, nil
 }
 
-// calcMinConnectedStake takes [consensusParams] as input and calculates the
-// expected min connected stake percentage according to alpha and k.
-func calcMinConnectedStake(consensusParams snowball.Parameters) float64 {
-	alpha := consensusParams.Alpha
-	k := consensusParams.K
-	r := float64(alpha) / float64(k)
-	return r*(1-constants.MinConnectedStakeBuffer) + constants.MinConnectedStakeBuffer
-}
-
 func providedFlags(v *viper.Viper) map[string]interface{} {
 	settings := v.AllSettings()
 	customSettings := make(map[string]interface{}, len(settings))
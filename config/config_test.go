@@ -428,7 +428,7 @@ func TestGetSubnetConfigsFromFile(t *testing.T) {
 			testF: func(require *require.Assertions, given map[ids.ID]subnets.Config) {
 				require.Nil(given)
 			},
-			errMessage: "fails the condition that: alpha <= k",
+			errMessage: "fails the condition that: alphaConfidence <= k",
 		},
 		"correct config": {
 			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.json",
@@ -440,12 +440,33 @@ func TestGetSubnetConfigsFromFile(t *testing.T) {
 
 				require.Equal(true, config.ValidatorOnly)
 				require.Equal(111, config.ConsensusParameters.Parents)
-				require.Equal(16, config.ConsensusParameters.Alpha)
+				require.Equal(16, config.ConsensusParameters.AlphaPreference)
+				require.Equal(16, config.ConsensusParameters.AlphaConfidence)
 				// must still respect defaults
 				require.Equal(20, config.ConsensusParameters.K)
 			},
 			errMessage: "",
 		},
+		"split quorum sizes": {
+			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.json",
+			givenJSON: `{"consensusParameters":{"k": 30, "alphaPreference": 16, "alphaConfidence": 25} }`,
+			testF: func(require *require.Assertions, given map[ids.ID]subnets.Config) {
+				id, _ := ids.FromString("2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i")
+				config, ok := given[id]
+				require.True(ok)
+				require.Equal(16, config.ConsensusParameters.AlphaPreference)
+				require.Equal(25, config.ConsensusParameters.AlphaConfidence)
+			},
+			errMessage: "",
+		},
+		"preference quorum size above confidence quorum size": {
+			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.json",
+			givenJSON: `{"consensusParameters":{"alphaPreference": 18, "alphaConfidence": 16} }`,
+			testF: func(require *require.Assertions, given map[ids.ID]subnets.Config) {
+				require.Nil(given)
+			},
+			errMessage: "fails the condition that: alphaPreference <= alphaConfidence",
+		},
 		"gossip config": {
 			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.json",
 			givenJSON: `{"appGossipNonValidatorSize": 100 }`,
@@ -527,7 +548,7 @@ func TestGetSubnetConfigsFromFlags(t *testing.T) {
 			testF: func(require *require.Assertions, given map[ids.ID]subnets.Config) {
 				require.Empty(given)
 			},
-			errMessage: "fails the condition that: alpha <= k",
+			errMessage: "fails the condition that: alphaConfidence <= k",
 		},
 		"correct config": {
 			givenJSON: `{
@@ -546,7 +567,8 @@ func TestGetSubnetConfigsFromFlags(t *testing.T) {
 				require.True(ok)
 				require.Equal(true, config.ValidatorOnly)
 				require.Equal(111, config.ConsensusParameters.Parents)
-				require.Equal(20, config.ConsensusParameters.Alpha)
+				require.Equal(20, config.ConsensusParameters.AlphaPreference)
+				require.Equal(20, config.ConsensusParameters.AlphaConfidence)
 				require.Equal(30, config.ConsensusParameters.K)
 				// must still respect defaults
 				require.Equal(uint(10), config.GossipConfig.AppGossipValidatorSize)
@@ -579,11 +601,28 @@ func TestGetSubnetConfigsFromFlags(t *testing.T) {
 	}
 }
 
+func TestGetConsensusConfigQuorumSize(t *testing.T) {
+	require := require.New(t)
+
+	v := setupViperFlags()
+	v.Set(SnowPreferenceQuorumSizeKey, 12)
+	v.Set(SnowConfidenceQuorumSizeKey, 16)
+	params := getConsensusConfig(v)
+	require.Equal(12, params.AlphaPreference)
+	require.Equal(16, params.AlphaConfidence)
+
+	// The deprecated quorum size overrides both quorum sizes
+	v.Set(SnowQuorumSizeKey, 14)
+	params = getConsensusConfig(v)
+	require.Equal(14, params.AlphaPreference)
+	require.Equal(14, params.AlphaConfidence)
+}
+
 func TestCalcMinConnectedStake(t *testing.T) {
 	v := setupViperFlags()
 	defaultParams := getConsensusConfig(v)
 	defaultExpectedMinStake := 0.8
-	minStake := calcMinConnectedStake(defaultParams.Parameters)
+	minStake := subnets.MinPercentConnectedStakeHealthy(defaultParams.Parameters)
 	require.Equal(t, defaultExpectedMinStake, minStake)
 }
 
@@ -17,6 +17,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/genesis"
 	"github.com/MetalBlockchain/metalgo/trace"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/ulimit"
 	"github.com/MetalBlockchain/metalgo/utils/units"
@@ -36,6 +37,7 @@ var (
 	defaultDBDir                = filepath.Join(defaultUnexpandedDataDir, "db")
 	defaultLogDir               = filepath.Join(defaultUnexpandedDataDir, "logs")
 	defaultProfileDir           = filepath.Join(defaultUnexpandedDataDir, "profiles")
+	defaultNetworkCaptureDir    = filepath.Join(defaultUnexpandedDataDir, "capture")
 	defaultStakingPath          = filepath.Join(defaultUnexpandedDataDir, "staking")
 	defaultStakingTLSKeyPath    = filepath.Join(defaultStakingPath, "staker.key")
 	defaultStakingCertPath      = filepath.Join(defaultStakingPath, "staker.crt")
@@ -123,14 +125,23 @@ func addNodeFlags(fs *flag.FlagSet) {
 	// Public IP Resolution
 	fs.String(PublicIPKey, "", "Public IP of this node for P2P communication. If empty, try to discover with NAT")
 	fs.Duration(PublicIPResolutionFreqKey, 5*time.Minute, "Frequency at which this node resolves/updates its public IP and renew NAT mappings, if applicable")
-	fs.String(PublicIPResolutionServiceKey, "", fmt.Sprintf("Only acceptable values are 'ifconfigco', 'opendns' or 'ifconfigme'. When provided, the node will use that service to periodically resolve/update its public IP. Ignored if %s is set", PublicIPKey))
+	fs.String(PublicIPResolutionServiceKey, "", fmt.Sprintf("Only acceptable values are 'ifconfigco', 'opendns', 'ifconfigme', 'stun' or 'stun:<host:port>'. When provided, the node will use that service to periodically resolve/update its public IP. If a comma separated list is provided, the public IP is only updated when a majority of the services agree on it. Ignored if %s is set", PublicIPKey))
+	fs.Bool(PublicIPResolutionUseSOCKS5ProxyKey, false, fmt.Sprintf("If true, public IP resolution services are reached through the proxy given by %s. Only 'ifconfigco' and 'ifconfigme' support this", OutboundConnectionSOCKS5ProxyKey))
 
 	// Inbound Connection Throttling
 	fs.Duration(InboundConnUpgradeThrottlerCooldownKey, constants.DefaultInboundConnUpgradeThrottlerCooldown, "Upgrade an inbound connection from a given IP at most once per this duration. If 0, don't rate-limit inbound connection upgrades")
 	fs.Float64(InboundThrottlerMaxConnsPerSecKey, constants.DefaultInboundThrottlerMaxConnsPerSec, "Max number of inbound connections to accept (from all peers) per second")
+	// Peer Diversity
+	fs.Int(PeerDiversityMaxPeersPerIPv4PrefixKey, constants.DefaultPeerDiversityMaxPeersPerIPv4Prefix, "Max number of non-validator peers with an IPv4 address in the same /24. Also defers dialing validators in saturated ranges. If 0, don't limit")
+	fs.Int(PeerDiversityMaxPeersPerIPv6PrefixKey, constants.DefaultPeerDiversityMaxPeersPerIPv6Prefix, "Max number of non-validator peers with an IPv6 address in the same /48. Also defers dialing validators in saturated ranges. If 0, don't limit")
+	fs.Int(PeerDiversityMaxPeersPerASNKey, constants.DefaultPeerDiversityMaxPeersPerASN, fmt.Sprintf("Max number of non-validator peers with an address announced by the same autonomous system. See %s. If 0, don't limit", PeerDiversityASNsContentKey))
+	fs.String(PeerDiversityASNsContentKey, "", "Specifies base64 encoded JSON mapping autonomous system numbers to the CIDR prefixes they announce. e.g. {\"16509\":[\"3.0.0.0/9\",\"2600:1f00::/24\"]}")
 	// Outbound Connection Throttling
 	fs.Uint(OutboundConnectionThrottlingRpsKey, constants.DefaultOutboundConnectionThrottlingRps, "Make at most this number of outgoing peer connection attempts per second")
 	fs.Duration(OutboundConnectionTimeoutKey, constants.DefaultOutboundConnectionTimeout, "Timeout when dialing a peer")
+	fs.String(OutboundConnectionSOCKS5ProxyKey, "", "Host:port of a SOCKS5 proxy to dial peers through. If empty, peers are dialed directly")
+	fs.String(OutboundConnectionSOCKS5ProxyUsernameKey, "", fmt.Sprintf("Username to authenticate with the proxy given by %s", OutboundConnectionSOCKS5ProxyKey))
+	fs.String(OutboundConnectionSOCKS5ProxyPasswordKey, "", fmt.Sprintf("Password to authenticate with the proxy given by %s", OutboundConnectionSOCKS5ProxyKey))
 	// Timeouts
 	fs.Duration(NetworkInitialTimeoutKey, constants.DefaultNetworkInitialTimeout, "Initial timeout value of the adaptive timeout manager")
 	fs.Duration(NetworkMinimumTimeoutKey, constants.DefaultNetworkMinimumTimeout, "Minimum timeout value of the adaptive timeout manager")
@@ -143,11 +154,17 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Duration(NetworkPingFrequencyKey, constants.DefaultPingFrequency, "Frequency of pinging other peers")
 
 	fs.Bool(NetworkCompressionEnabledKey, constants.DefaultNetworkCompressionEnabled, "If true, compress certain outbound messages. This node will be able to parse compressed inbound messages regardless of this flag's value")
+	fs.String(NetworkCompressionTypeKey, constants.DefaultNetworkCompressionType.String(), fmt.Sprintf("Compression type to use for outbound messages when compression is enabled. Must be one of [%s, %s]. Messages sent to peers that don't support the selected type are compressed with %s instead", compression.TypeGzip, compression.TypeZstd, compression.TypeGzip))
 	fs.Duration(NetworkMaxClockDifferenceKey, constants.DefaultNetworkMaxClockDifference, "Max allowed clock difference value between this node and peers")
 	fs.Bool(NetworkAllowPrivateIPsKey, constants.DefaultNetworkAllowPrivateIPs, "Allows the node to initiate outbound connection attempts to peers with private IPs")
+	fs.String(NetworkAllowedCIDRsKey, "", "Comma separated list of CIDRs that peers must connect from. If empty, peers may connect from any IP that isn't denied")
+	fs.String(NetworkDeniedCIDRsKey, "", "Comma separated list of CIDRs that peers must not connect from")
+	fs.String(NetworkAllowedNodeIDsKey, "", "Comma separated list of node IDs that are allowed to connect. If empty, any node that isn't denied may connect")
+	fs.String(NetworkDeniedNodeIDsKey, "", "Comma separated list of node IDs that are not allowed to connect")
 	fs.Bool(NetworkRequireValidatorToConnectKey, constants.DefaultNetworkRequireValidatorToConnect, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
 	fs.Uint(NetworkPeerReadBufferSizeKey, constants.DefaultNetworkPeerReadBufferSize, "Size, in bytes, of the buffer that we read peer messages into (there is one buffer per peer)")
 	fs.Uint(NetworkPeerWriteBufferSizeKey, constants.DefaultNetworkPeerWriteBufferSize, "Size, in bytes, of the buffer that we write peer messages into (there is one buffer per peer)")
+	fs.Bool(NetworkPeerTrafficMetricsEnabledKey, constants.DefaultNetworkPeerTrafficMetricsEnabled, "If true, report the messages and bytes exchanged with each peer, by chain and op, as metrics. Adds metrics for every connected peer")
 
 	fs.Bool(NetworkTCPProxyEnabledKey, constants.DefaultNetworkTCPProxyEnabled, "Require all P2P connections to be initiated with a TCP proxy header")
 	// The PROXY protocol specification recommends setting this value to be at
@@ -158,6 +175,14 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Duration(NetworkTCPProxyReadTimeoutKey, constants.DefaultNetworkTCPProxyReadTimeout, "Maximum duration to wait for a TCP proxy header")
 
 	fs.String(NetworkTLSKeyLogFileKey, "", "TLS key log file path. Should only be specified for debugging")
+	fs.Bool(NetworkCaptureEnabledKey, false, "If true, the messages handled by each chain, including inbound consensus and application messages, request timeouts and connection events, are written to a capture file that can be replayed for debugging")
+	fs.String(NetworkCaptureDirKey, defaultNetworkCaptureDir, "Directory to write captured inbound messages to")
+	fs.String(NetworkCaptureChainIDsKey, "", "Comma separated list of chain IDs to capture messages for. If empty, messages for all chains are captured")
+	fs.String(NetworkCaptureNodeIDsKey, "", "Comma separated list of node IDs to capture messages from. If empty, messages from all peers are captured")
+	fs.Uint(NetworkCaptureMaxSizeKey, 64, "The maximum file size in megabytes of the capture file before it gets rotated")
+	fs.Uint(NetworkCaptureMaxFilesKey, 4, "The maximum number of old capture files to retain. 0 means retain all old capture files")
+	fs.Uint(NetworkCaptureMaxAgeKey, 0, "The maximum number of days to retain old capture files based on the timestamp encoded in their filename. 0 means retain all old capture files")
+	fs.Bool(NetworkCaptureCompressEnabledKey, false, "Enables the compression of rotated capture files through gzip")
 
 	// Benchlist
 	fs.Int(BenchlistFailThresholdKey, constants.DefaultBenchlistFailThreshold, "Number of consecutive failed queries before benchlisting a node")
@@ -191,6 +216,10 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint64(OutboundThrottlerAtLargeAllocSizeKey, constants.DefaultOutboundThrottlerAtLargeAllocSize, "Size, in bytes, of at-large byte allocation in outbound message throttler")
 	fs.Uint64(OutboundThrottlerVdrAllocSizeKey, constants.DefaultOutboundThrottlerVdrAllocSize, "Size, in bytes, of validator byte allocation in outbound message throttler")
 	fs.Uint64(OutboundThrottlerNodeMaxAtLargeBytesKey, constants.DefaultOutboundThrottlerNodeMaxAtLargeBytes, "Max number of bytes a node can take from the outbound message throttler's at-large allocation. Must be at least the max message size")
+	fs.Uint64(OutboundThrottlerBandwidthRefillRateKey, constants.DefaultOutboundThrottlerBandwidthRefillRate, "Max average outbound bandwidth usage to all peers, in bytes per second. If 0, the outbound bandwidth to all peers isn't limited. See OutboundBandwidthThrottler")
+	fs.Uint64(OutboundThrottlerBandwidthMaxBurstSizeKey, constants.DefaultOutboundThrottlerBandwidthMaxBurstSize, "Max outbound bandwidth that can be used to all peers at once. Must be at least the max message size. See OutboundBandwidthThrottler")
+	fs.Uint64(OutboundThrottlerNodeBandwidthRefillRateKey, constants.DefaultOutboundThrottlerNodeBandwidthRefillRate, "Max average outbound bandwidth usage to a peer, in bytes per second. If 0, the outbound bandwidth to each peer isn't limited. See OutboundBandwidthThrottler")
+	fs.Uint64(OutboundThrottlerNodeBandwidthMaxBurstSizeKey, constants.DefaultOutboundThrottlerNodeBandwidthMaxBurstSize, "Max outbound bandwidth that can be used to a peer at once. Must be at least the max message size. See OutboundBandwidthThrottler")
 
 	// HTTP APIs
 	fs.String(HTTPHostKey, "127.0.0.1", "Address of the HTTP server")
@@ -285,7 +314,9 @@ func addNodeFlags(fs *flag.FlagSet) {
 
 	// Consensus
 	fs.Int(SnowSampleSizeKey, 20, "Number of nodes to query for each network poll")
-	fs.Int(SnowQuorumSizeKey, 15, "Alpha value to use for required number positive results")
+	fs.Int(SnowQuorumSizeKey, 15, "Alpha value to use for required number positive results. If set, overrides both the preference and the confidence quorum sizes")
+	fs.Int(SnowPreferenceQuorumSizeKey, 15, fmt.Sprintf("Number of positive results required in a poll to change this node's preference. Must be <= %s", SnowConfidenceQuorumSizeKey))
+	fs.Int(SnowConfidenceQuorumSizeKey, 15, fmt.Sprintf("Number of positive results required in a poll to increase this node's confidence. Must be <= %s", SnowSampleSizeKey))
 	fs.Int(SnowVirtuousCommitThresholdKey, 15, "Beta value to use for virtuous transactions")
 	fs.Int(SnowRogueCommitThresholdKey, 20, "Beta value to use for rogue transactions")
 	fs.Int(SnowAvalancheNumParentsKey, 5, "Number of vertexes for reference from each new vertex")
@@ -349,11 +380,13 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Float64(CPUVdrAllocKey, float64(runtime.NumCPU()), "Maximum number of CPUs to allocate for use by validators. Value should be in range [0, total core count]")
 	fs.Float64(CPUMaxNonVdrUsageKey, .8*float64(runtime.NumCPU()), "Number of CPUs that if fully utilized, will rate limit all non-validators. Value should be in range [0, total core count]")
 	fs.Float64(CPUMaxNonVdrNodeUsageKey, float64(runtime.NumCPU())/8, "Maximum number of CPUs that a non-validator can utilize. Value should be in range [0, total core count]")
+	fs.Float64(PrimaryNetworkCPUAllocKey, .5, fmt.Sprintf("Portion of [%s] reserved for processing primary network messages. Subnets can reserve a portion with their cpuAllocation config. Value should be in range [0, 1]", CPUVdrAllocKey))
 
 	// Disk management
 	fs.Float64(DiskVdrAllocKey, 1000*units.GiB, "Maximum number of disk reads/writes per second to allocate for use by validators. Must be > 0")
 	fs.Float64(DiskMaxNonVdrUsageKey, 1000*units.GiB, "Number of disk reads/writes per second that, if fully utilized, will rate limit all non-validators. Must be >= 0")
 	fs.Float64(DiskMaxNonVdrNodeUsageKey, 1000*units.GiB, "Maximum number of disk reads/writes per second that a non-validator can utilize. Must be >= 0")
+	fs.Float64(PrimaryNetworkDiskAllocKey, .5, fmt.Sprintf("Portion of [%s] reserved for processing primary network messages. Subnets can reserve a portion with their diskAllocation config. Value should be in range [0, 1]", DiskVdrAllocKey))
 
 	// Opentelemetry tracing
 	fs.Bool(TracingEnabledKey, false, "If true, enable opentelemetry tracing")
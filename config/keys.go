@@ -40,10 +40,18 @@ const (
 	PublicIPKey                                        = "public-ip"
 	PublicIPResolutionFreqKey                          = "public-ip-resolution-frequency"
 	PublicIPResolutionServiceKey                       = "public-ip-resolution-service"
+	PublicIPResolutionUseSOCKS5ProxyKey                = "public-ip-resolution-use-socks5-proxy"
 	InboundConnUpgradeThrottlerCooldownKey             = "inbound-connection-throttling-cooldown"
 	InboundThrottlerMaxConnsPerSecKey                  = "inbound-connection-throttling-max-conns-per-sec"
+	PeerDiversityMaxPeersPerIPv4PrefixKey              = "peer-diversity-max-peers-per-ipv4-prefix"
+	PeerDiversityMaxPeersPerIPv6PrefixKey              = "peer-diversity-max-peers-per-ipv6-prefix"
+	PeerDiversityMaxPeersPerASNKey                     = "peer-diversity-max-peers-per-asn"
+	PeerDiversityASNsContentKey                        = "peer-diversity-asns-content"
 	OutboundConnectionThrottlingRpsKey                 = "outbound-connection-throttling-rps"
 	OutboundConnectionTimeoutKey                       = "outbound-connection-timeout"
+	OutboundConnectionSOCKS5ProxyKey                   = "outbound-connection-socks5-proxy"
+	OutboundConnectionSOCKS5ProxyUsernameKey           = "outbound-connection-socks5-proxy-username"
+	OutboundConnectionSOCKS5ProxyPasswordKey           = "outbound-connection-socks5-proxy-password"
 	HTTPHostKey                                        = "http-host"
 	HTTPPortKey                                        = "http-port"
 	HTTPSEnabledKey                                    = "http-tls-enabled"
@@ -99,14 +107,28 @@ const (
 	NetworkPingFrequencyKey                            = "network-ping-frequency"
 	NetworkMaxReconnectDelayKey                        = "network-max-reconnect-delay"
 	NetworkCompressionEnabledKey                       = "network-compression-enabled"
+	NetworkCompressionTypeKey                          = "network-compression-type"
 	NetworkMaxClockDifferenceKey                       = "network-max-clock-difference"
 	NetworkAllowPrivateIPsKey                          = "network-allow-private-ips"
+	NetworkAllowedCIDRsKey                             = "network-allowed-cidrs"
+	NetworkDeniedCIDRsKey                              = "network-denied-cidrs"
+	NetworkAllowedNodeIDsKey                           = "network-allowed-node-ids"
+	NetworkDeniedNodeIDsKey                            = "network-denied-node-ids"
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
 	NetworkPeerReadBufferSizeKey                       = "network-peer-read-buffer-size"
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
+	NetworkPeerTrafficMetricsEnabledKey                = "network-peer-traffic-metrics-enabled"
 	NetworkTCPProxyEnabledKey                          = "network-tcp-proxy-enabled"
 	NetworkTCPProxyReadTimeoutKey                      = "network-tcp-proxy-read-timeout"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
+	NetworkCaptureEnabledKey                           = "network-capture-enabled"
+	NetworkCaptureDirKey                               = "network-capture-dir"
+	NetworkCaptureChainIDsKey                          = "network-capture-chain-ids"
+	NetworkCaptureNodeIDsKey                           = "network-capture-node-ids"
+	NetworkCaptureMaxSizeKey                           = "network-capture-max-size"
+	NetworkCaptureMaxFilesKey                          = "network-capture-max-files"
+	NetworkCaptureMaxAgeKey                            = "network-capture-max-age"
+	NetworkCaptureCompressEnabledKey                   = "network-capture-compress-enabled"
 	BenchlistFailThresholdKey                          = "benchlist-fail-threshold"
 	BenchlistDurationKey                               = "benchlist-duration"
 	BenchlistMinFailingDurationKey                     = "benchlist-min-failing-duration"
@@ -121,6 +143,8 @@ const (
 	LogDisableDisplayPluginLogsKey                     = "log-disable-display-plugin-logs"
 	SnowSampleSizeKey                                  = "snow-sample-size"
 	SnowQuorumSizeKey                                  = "snow-quorum-size"
+	SnowPreferenceQuorumSizeKey                        = "snow-preference-quorum-size"
+	SnowConfidenceQuorumSizeKey                        = "snow-confidence-quorum-size"
 	SnowVirtuousCommitThresholdKey                     = "snow-virtuous-commit-threshold"
 	SnowRogueCommitThresholdKey                        = "snow-rogue-commit-threshold"
 	SnowAvalancheNumParentsKey                         = "snow-avalanche-num-parents"
@@ -187,6 +211,7 @@ const (
 	CPUVdrAllocKey                                     = "throttler-inbound-cpu-validator-alloc"
 	CPUMaxNonVdrUsageKey                               = "throttler-inbound-cpu-max-non-validator-usage"
 	CPUMaxNonVdrNodeUsageKey                           = "throttler-inbound-cpu-max-non-validator-node-usage"
+	PrimaryNetworkCPUAllocKey                          = "throttler-inbound-cpu-primary-network-alloc"
 	SystemTrackerFrequencyKey                          = "system-tracker-frequency"
 	SystemTrackerProcessingHalflifeKey                 = "system-tracker-processing-halflife"
 	SystemTrackerCPUHalflifeKey                        = "system-tracker-cpu-halflife"
@@ -196,9 +221,14 @@ const (
 	DiskVdrAllocKey                                    = "throttler-inbound-disk-validator-alloc"
 	DiskMaxNonVdrUsageKey                              = "throttler-inbound-disk-max-non-validator-usage"
 	DiskMaxNonVdrNodeUsageKey                          = "throttler-inbound-disk-max-non-validator-node-usage"
+	PrimaryNetworkDiskAllocKey                         = "throttler-inbound-disk-primary-network-alloc"
 	OutboundThrottlerAtLargeAllocSizeKey               = "throttler-outbound-at-large-alloc-size"
 	OutboundThrottlerVdrAllocSizeKey                   = "throttler-outbound-validator-alloc-size"
 	OutboundThrottlerNodeMaxAtLargeBytesKey            = "throttler-outbound-node-max-at-large-bytes"
+	OutboundThrottlerBandwidthRefillRateKey            = "throttler-outbound-bandwidth-refill-rate"
+	OutboundThrottlerBandwidthMaxBurstSizeKey          = "throttler-outbound-bandwidth-max-burst-size"
+	OutboundThrottlerNodeBandwidthRefillRateKey        = "throttler-outbound-node-bandwidth-refill-rate"
+	OutboundThrottlerNodeBandwidthMaxBurstSizeKey      = "throttler-outbound-node-bandwidth-max-burst-size"
 	UptimeMetricFreqKey                                = "uptime-metric-freq"
 	VMAliasesFileKey                                   = "vm-aliases-file"
 	VMAliasesContentKey                                = "vm-aliases-file-content"
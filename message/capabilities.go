@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+// Capabilities are named optional features of the p2p protocol. They are
+// advertised in the Version message and a capability is only used with a peer
+// if both sides advertised it.
+const (
+	CapabilityCompressionGzip = "compression/gzip"
+	CapabilityCompressionZstd = "compression/zstd"
+	// CapabilityAncestorsAtHeight indicates that the node answers
+	// GetAncestorsAtHeight requests.
+	CapabilityAncestorsAtHeight = "bootstrap/ancestorsAtHeight"
+)
+
+// SupportedCapabilities returns the capabilities that this node supports.
+func SupportedCapabilities() set.Set[string] {
+	capabilities := set.NewSet[string](3)
+	capabilities.Add(
+		CapabilityCompressionGzip,
+		CapabilityCompressionZstd,
+		CapabilityAncestorsAtHeight,
+	)
+	return capabilities
+}
+
+// Capabilities returns the capabilities claimed by the sender of [msg].
+//
+// Compression types advertised by peers that predate capability negotiation
+// are reported as their equivalent capabilities.
+func Capabilities(msg *p2p.Version) set.Set[string] {
+	capabilities := set.NewSet[string](len(msg.GetCapabilities()))
+	capabilities.Add(msg.GetCapabilities()...)
+	for compressionType := range SupportedCompressionTypes(msg) {
+		if capability, ok := CompressionCapability(compressionType); ok {
+			capabilities.Add(capability)
+		}
+	}
+	return capabilities
+}
+
+// CompressionCapability returns the capability that indicates support for
+// decompressing messages with [compressionType]. Returns false if every peer
+// supports [compressionType].
+func CompressionCapability(compressionType compression.Type) (string, bool) {
+	switch compressionType {
+	case compression.TypeGzip:
+		return CapabilityCompressionGzip, true
+	case compression.TypeZstd:
+		return CapabilityCompressionZstd, true
+	default:
+		return "", false
+	}
+}
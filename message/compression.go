@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+// compressionTypes returns the compression types that are advertised to peers
+// that predate capability negotiation by a node with [capabilities].
+func compressionTypes(capabilities []string) []p2p.CompressionType {
+	var compressionTypes []p2p.CompressionType
+	for _, capability := range capabilities {
+		switch capability {
+		case CapabilityCompressionGzip:
+			compressionTypes = append(compressionTypes, p2p.CompressionType_COMPRESSION_TYPE_GZIP)
+		case CapabilityCompressionZstd:
+			compressionTypes = append(compressionTypes, p2p.CompressionType_COMPRESSION_TYPE_ZSTD)
+		}
+	}
+	return compressionTypes
+}
+
+// SupportedCompressionTypes returns the compression types that the sender of
+// [msg] is able to decompress.
+//
+// Peers that predate compression negotiation don't populate the field, so
+// uncompressed and gzip compressed messages are always assumed to be
+// supported.
+func SupportedCompressionTypes(msg *p2p.Version) set.Set[compression.Type] {
+	supported := set.NewSet[compression.Type](3)
+	supported.Add(compression.TypeNone, compression.TypeGzip)
+	for _, compressionType := range msg.GetSupportedCompressionTypes() {
+		switch compressionType {
+		case p2p.CompressionType_COMPRESSION_TYPE_GZIP:
+			supported.Add(compression.TypeGzip)
+		case p2p.CompressionType_COMPRESSION_TYPE_ZSTD:
+			supported.Add(compression.TypeZstd)
+		}
+	}
+	return supported
+}
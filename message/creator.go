@@ -8,6 +8,9 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MetalBlockchain/metalgo/utils/compression"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 )
 
 var _ Creator = (*creator)(nil)
@@ -25,8 +28,26 @@ type creator struct {
 func NewCreator(
 	metrics prometheus.Registerer,
 	parentNamespace string,
-	compressionEnabled bool,
+	compressionType compression.Type,
+	maxMessageTimeout time.Duration,
+) (Creator, error) {
+	return NewCreatorWithClock(
+		metrics,
+		parentNamespace,
+		compressionType,
+		maxMessageTimeout,
+		mockable.Clock{},
+	)
+}
+
+// NewCreatorWithClock returns a Creator whose parsed messages expire according
+// to [clock].
+func NewCreatorWithClock(
+	metrics prometheus.Registerer,
+	parentNamespace string,
+	compressionType compression.Type,
 	maxMessageTimeout time.Duration,
+	clock mockable.Clock,
 ) (Creator, error) {
 	namespace := fmt.Sprintf("%s_codec", parentNamespace)
 	builder, err := newMsgBuilder(
@@ -37,9 +58,10 @@ func NewCreator(
 	if err != nil {
 		return nil, err
 	}
+	builder.clock = clock
 
 	return &creator{
-		OutboundMsgBuilder: newOutboundBuilder(compressionEnabled, builder),
+		OutboundMsgBuilder: newOutboundBuilder(compressionType, builder),
 		InboundMsgBuilder:  newInboundBuilder(builder),
 	}, nil
 }
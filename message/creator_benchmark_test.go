@@ -0,0 +1,122 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/genesis"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+
+	pgenesis "github.com/MetalBlockchain/metalgo/vms/platformvm/genesis"
+)
+
+var benchmarkCompressionTypes = []compression.Type{
+	compression.TypeNone,
+	compression.TypeGzip,
+	compression.TypeZstd,
+}
+
+// mainnetContainers returns the mainnet P-chain genesis along with the signed
+// transactions it contains. These are used as realistic container payloads.
+func mainnetContainers(b *testing.B) ([]byte, [][]byte) {
+	require := require.New(b)
+
+	genesisBytes, _, err := genesis.FromConfig(genesis.GetConfig(constants.MainnetID))
+	require.NoError(err)
+
+	gen, err := pgenesis.Parse(genesisBytes)
+	require.NoError(err)
+
+	containers := make([][]byte, 0, len(gen.Validators)+len(gen.Chains))
+	for _, tx := range gen.Validators {
+		containers = append(containers, tx.Bytes())
+	}
+	for _, tx := range gen.Chains {
+		containers = append(containers, tx.Bytes())
+	}
+	return genesisBytes, containers
+}
+
+func newBenchmarkCreator(b *testing.B, compressionType compression.Type) message.Creator {
+	mc, err := message.NewCreator(
+		prometheus.NewRegistry(),
+		"",
+		compressionType,
+		10*time.Second,
+	)
+	require.NoError(b, err)
+	return mc
+}
+
+// Benchmarks building and parsing "Ancestors" messages with each compression
+// type.
+//
+// e.g.,
+//
+//	$ go test -run=NONE -bench=BenchmarkAncestors -benchmem
+func BenchmarkAncestors(b *testing.B) {
+	_, containers := mainnetContainers(b)
+	chainID := ids.GenerateTestID()
+
+	for _, compressionType := range benchmarkCompressionTypes {
+		mc := newBenchmarkCreator(b, compressionType)
+		b.Run(compressionType.String(), func(b *testing.B) {
+			benchmarkCreateAndParse(b, mc, func() (message.OutboundMessage, error) {
+				return mc.Ancestors(chainID, 1, containers)
+			})
+		})
+	}
+}
+
+// Benchmarks building and parsing "Put" messages with each compression type.
+//
+// e.g.,
+//
+//	$ go test -run=NONE -bench=BenchmarkPut -benchmem
+func BenchmarkPut(b *testing.B) {
+	container, _ := mainnetContainers(b)
+	chainID := ids.GenerateTestID()
+
+	for _, compressionType := range benchmarkCompressionTypes {
+		mc := newBenchmarkCreator(b, compressionType)
+		b.Run(compressionType.String(), func(b *testing.B) {
+			benchmarkCreateAndParse(b, mc, func() (message.OutboundMessage, error) {
+				return mc.Put(chainID, 1, container, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+			})
+		})
+	}
+}
+
+func benchmarkCreateAndParse(
+	b *testing.B,
+	mc message.Creator,
+	create func() (message.OutboundMessage, error),
+) {
+	require := require.New(b)
+
+	var (
+		msg message.OutboundMessage
+		err error
+	)
+	for i := 0; i < b.N; i++ {
+		msg, err = create()
+		require.NoError(err)
+
+		_, err = mc.Parse(msg.Bytes(), ids.EmptyNodeID, func() {})
+		require.NoError(err)
+	}
+
+	b.ReportMetric(float64(len(msg.Bytes())), "bytes/msg")
+	b.ReportMetric(float64(msg.BytesSavedCompression()), "saved-bytes/msg")
+}
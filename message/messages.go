@@ -4,6 +4,7 @@
 package message
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -24,6 +25,8 @@ import (
 var (
 	_ InboundMessage  = (*inboundMessage)(nil)
 	_ OutboundMessage = (*outboundMessage)(nil)
+
+	errUnknownCompressionType = errors.New("unknown compression type")
 )
 
 // InboundMessage represents a set of fields for an inbound message
@@ -93,6 +96,12 @@ type OutboundMessage interface {
 	// BytesSavedCompression returns the number of bytes that this message saved
 	// due to being compressed
 	BytesSavedCompression() int
+	// CompressionType returns the compression algorithm that was used to
+	// encode this message
+	CompressionType() compression.Type
+	// ChainID returns the chain this message is sent on behalf of. Returns
+	// false if this message isn't specific to a chain.
+	ChainID() (ids.ID, bool)
 }
 
 type outboundMessage struct {
@@ -100,6 +109,9 @@ type outboundMessage struct {
 	op                    Op
 	bytes                 []byte
 	bytesSavedCompression int
+	compressionType       compression.Type
+	chainID               ids.ID
+	hasChainID            bool
 }
 
 func (m *outboundMessage) BypassThrottling() bool {
@@ -118,14 +130,25 @@ func (m *outboundMessage) BytesSavedCompression() int {
 	return m.bytesSavedCompression
 }
 
-// TODO: add other compression algorithms with extended interface
+func (m *outboundMessage) CompressionType() compression.Type {
+	return m.compressionType
+}
+
+func (m *outboundMessage) ChainID() (ids.ID, bool) {
+	return m.chainID, m.hasChainID
+}
+
 type msgBuilder struct {
 	gzipCompressor compression.Compressor
+	zstdCompressor compression.Compressor
 
-	compressTimeMetrics   map[Op]metric.Averager
-	decompressTimeMetrics map[Op]metric.Averager
+	compressTimeMetrics   map[compression.Type]map[Op]metric.Averager
+	decompressTimeMetrics map[compression.Type]map[Op]metric.Averager
 
 	maxMessageTimeout time.Duration
+
+	// Clock that the expiration of parsed messages is measured with
+	clock mockable.Clock
 }
 
 func newMsgBuilder(
@@ -133,70 +156,104 @@ func newMsgBuilder(
 	metrics prometheus.Registerer,
 	maxMessageTimeout time.Duration,
 ) (*msgBuilder, error) {
-	cpr, err := compression.NewGzipCompressor(constants.DefaultMaxMessageSize)
+	gzipCompressor, err := compression.NewGzipCompressor(constants.DefaultMaxMessageSize)
+	if err != nil {
+		return nil, err
+	}
+	zstdCompressor, err := compression.NewZstdCompressor(constants.DefaultMaxMessageSize)
 	if err != nil {
 		return nil, err
 	}
 
 	mb := &msgBuilder{
-		gzipCompressor: cpr,
+		gzipCompressor: gzipCompressor,
+		zstdCompressor: zstdCompressor,
 
-		compressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
-		decompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
+		compressTimeMetrics:   make(map[compression.Type]map[Op]metric.Averager, 2),
+		decompressTimeMetrics: make(map[compression.Type]map[Op]metric.Averager, 2),
 
 		maxMessageTimeout: maxMessageTimeout,
 	}
 
 	errs := wrappers.Errs{}
-	for _, op := range ExternalOps {
-		mb.compressTimeMetrics[op] = metric.NewAveragerWithErrs(
-			namespace,
-			fmt.Sprintf("%s_compress_time", op),
-			fmt.Sprintf("time (in ns) to compress %s messages", op),
-			metrics,
-			&errs,
-		)
-		mb.decompressTimeMetrics[op] = metric.NewAveragerWithErrs(
-			namespace,
-			fmt.Sprintf("%s_decompress_time", op),
-			fmt.Sprintf("time (in ns) to decompress %s messages", op),
-			metrics,
-			&errs,
-		)
+	for _, compressionType := range []compression.Type{compression.TypeGzip, compression.TypeZstd} {
+		// Gzip metrics keep their original names so that existing dashboards
+		// continue to work.
+		metricPrefix := ""
+		if compressionType != compression.TypeGzip {
+			metricPrefix = fmt.Sprintf("%s_", compressionType)
+		}
+
+		compressTimeMetrics := make(map[Op]metric.Averager, len(ExternalOps))
+		decompressTimeMetrics := make(map[Op]metric.Averager, len(ExternalOps))
+		for _, op := range ExternalOps {
+			compressTimeMetrics[op] = metric.NewAveragerWithErrs(
+				namespace,
+				fmt.Sprintf("%s%s_compress_time", metricPrefix, op),
+				fmt.Sprintf("time (in ns) to compress %s messages with %s", op, compressionType),
+				metrics,
+				&errs,
+			)
+			decompressTimeMetrics[op] = metric.NewAveragerWithErrs(
+				namespace,
+				fmt.Sprintf("%s%s_decompress_time", metricPrefix, op),
+				fmt.Sprintf("time (in ns) to decompress %s messages with %s", op, compressionType),
+				metrics,
+				&errs,
+			)
+		}
+		mb.compressTimeMetrics[compressionType] = compressTimeMetrics
+		mb.decompressTimeMetrics[compressionType] = decompressTimeMetrics
 	}
 	return mb, errs.Err
 }
 
 func (mb *msgBuilder) marshal(
 	uncompressedMsg *p2p.Message,
-	gzipCompress bool,
+	compressionType compression.Type,
 ) ([]byte, int, time.Duration, error) {
 	uncompressedMsgBytes, err := proto.Marshal(uncompressedMsg)
 	if err != nil {
 		return nil, 0, 0, err
 	}
 
-	if !gzipCompress {
-		return uncompressedMsgBytes, 0, 0, nil
-	}
-
 	// If compression is enabled, we marshal twice:
 	// 1. the original message
 	// 2. the message with compressed bytes
 	//
 	// This recursive packing allows us to avoid an extra compression on/off
 	// field in the message.
-	startTime := time.Now()
-	compressedBytes, err := mb.gzipCompressor.Compress(uncompressedMsgBytes)
-	if err != nil {
-		return nil, 0, 0, err
+	var (
+		startTime     = time.Now()
+		compressedMsg p2p.Message
+	)
+	switch compressionType {
+	case compression.TypeNone:
+		return uncompressedMsgBytes, 0, 0, nil
+	case compression.TypeGzip:
+		compressedBytes, err := mb.gzipCompressor.Compress(uncompressedMsgBytes)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		compressedMsg = p2p.Message{
+			Message: &p2p.Message_CompressedGzip{
+				CompressedGzip: compressedBytes,
+			},
+		}
+	case compression.TypeZstd:
+		compressedBytes, err := mb.zstdCompressor.Compress(uncompressedMsgBytes)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		compressedMsg = p2p.Message{
+			Message: &p2p.Message_CompressedZstd{
+				CompressedZstd: compressedBytes,
+			},
+		}
+	default:
+		return nil, 0, 0, fmt.Errorf("%w: %s", errUnknownCompressionType, compressionType)
 	}
 
-	compressedMsg := p2p.Message{
-		Message: &p2p.Message_CompressedGzip{
-			CompressedGzip: compressedBytes,
-		},
-	}
 	compressedMsgBytes, err := proto.Marshal(&compressedMsg)
 	if err != nil {
 		return nil, 0, 0, err
@@ -207,35 +264,49 @@ func (mb *msgBuilder) marshal(
 	return compressedMsgBytes, bytesSaved, compressTook, nil
 }
 
-func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, bool, int, time.Duration, error) {
+func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, compression.Type, int, time.Duration, error) {
 	m := new(p2p.Message)
 	if err := proto.Unmarshal(b, m); err != nil {
-		return nil, false, 0, 0, err
+		return nil, compression.TypeNone, 0, 0, err
 	}
 
-	compressed := m.GetCompressedGzip()
+	var (
+		compressionType compression.Type
+		compressor      compression.Compressor
+		compressed      []byte
+	)
+	switch msg := m.GetMessage().(type) {
+	case *p2p.Message_CompressedGzip:
+		compressionType = compression.TypeGzip
+		compressor = mb.gzipCompressor
+		compressed = msg.CompressedGzip
+	case *p2p.Message_CompressedZstd:
+		compressionType = compression.TypeZstd
+		compressor = mb.zstdCompressor
+		compressed = msg.CompressedZstd
+	}
 	if len(compressed) == 0 {
 		// The message wasn't compressed
-		return m, false, 0, 0, nil
+		return m, compression.TypeNone, 0, 0, nil
 	}
 
 	startTime := time.Now()
-	decompressed, err := mb.gzipCompressor.Decompress(compressed)
+	decompressed, err := compressor.Decompress(compressed)
 	if err != nil {
-		return nil, true, 0, 0, err
+		return nil, compressionType, 0, 0, err
 	}
 
 	if err := proto.Unmarshal(decompressed, m); err != nil {
-		return nil, true, 0, 0, err
+		return nil, compressionType, 0, 0, err
 	}
 	decompressTook := time.Since(startTime)
 
 	bytesSavedCompression := len(decompressed) - len(compressed)
-	return m, true, bytesSavedCompression, decompressTook, nil
+	return m, compressionType, bytesSavedCompression, decompressTook, nil
 }
 
-func (mb *msgBuilder) createOutbound(m *p2p.Message, gzipCompress bool, bypassThrottling bool) (*outboundMessage, error) {
-	b, saved, compressTook, err := mb.marshal(m, gzipCompress)
+func (mb *msgBuilder) createOutbound(m *p2p.Message, compressionType compression.Type, bypassThrottling bool) (*outboundMessage, error) {
+	b, saved, compressTook, err := mb.marshal(m, compressionType)
 	if err != nil {
 		return nil, err
 	}
@@ -245,24 +316,46 @@ func (mb *msgBuilder) createOutbound(m *p2p.Message, gzipCompress bool, bypassTh
 		return nil, err
 	}
 
-	if gzipCompress {
-		mb.compressTimeMetrics[op].Observe(float64(compressTook))
+	if compressionType != compression.TypeNone {
+		mb.compressTimeMetrics[compressionType][op].Observe(float64(compressTook))
+	}
+
+	msg, err := Unwrap(m)
+	if err != nil {
+		return nil, err
 	}
+	chainID, err := GetChainID(msg)
 
 	return &outboundMessage{
 		bypassThrottling:      bypassThrottling,
 		op:                    op,
 		bytes:                 b,
 		bytesSavedCompression: saved,
+		compressionType:       compressionType,
+		chainID:               chainID,
+		hasChainID:            err == nil,
 	}, nil
 }
 
+// recompress re-encodes [msg] using [compressionType].
+func (mb *msgBuilder) recompress(msg OutboundMessage, compressionType compression.Type) (OutboundMessage, error) {
+	if msg.CompressionType() == compressionType {
+		return msg, nil
+	}
+
+	m, _, _, _, err := mb.unmarshal(msg.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return mb.createOutbound(m, compressionType, msg.BypassThrottling())
+}
+
 func (mb *msgBuilder) parseInbound(
 	bytes []byte,
 	nodeID ids.NodeID,
 	onFinishedHandling func(),
 ) (*inboundMessage, error) {
-	m, wasCompressed, bytesSavedCompression, decompressTook, err := mb.unmarshal(bytes)
+	m, compressionType, bytesSavedCompression, decompressTook, err := mb.unmarshal(bytes)
 	if err != nil {
 		return nil, err
 	}
@@ -277,14 +370,14 @@ func (mb *msgBuilder) parseInbound(
 		return nil, err
 	}
 
-	if wasCompressed {
-		mb.decompressTimeMetrics[op].Observe(float64(decompressTook))
+	if compressionType != compression.TypeNone {
+		mb.decompressTimeMetrics[compressionType][op].Observe(float64(decompressTook))
 	}
 
 	expiration := mockable.MaxTime
 	if deadline, ok := GetDeadline(msg); ok {
 		deadline = math.Min(deadline, mb.maxMessageTimeout)
-		expiration = time.Now().Add(deadline)
+		expiration = mb.clock.Time().Add(deadline)
 	}
 
 	return &inboundMessage{
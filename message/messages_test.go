@@ -18,6 +18,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/staking"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 )
 
 func TestMessage(t *testing.T) {
@@ -51,7 +52,7 @@ func TestMessage(t *testing.T) {
 		desc             string
 		op               Op
 		msg              *p2p.Message
-		gzipCompress     bool
+		compressionType  compression.Type
 		bypassThrottling bool
 		bytesSaved       bool // if true, outbound message saved bytes must be non-zero
 	}{
@@ -63,7 +64,7 @@ func TestMessage(t *testing.T) {
 					Ping: &p2p.Ping{},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -77,7 +78,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -97,7 +98,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -118,7 +119,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -140,12 +141,12 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
 		{
-			desc: "peer_list message with compression",
+			desc: "peer_list message with gzip compression",
 			op:   PeerListOp,
 			msg: &p2p.Message{
 				Message: &p2p.Message_PeerList{
@@ -162,7 +163,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -181,7 +182,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: false,
 			bytesSaved:       false,
 		},
@@ -197,7 +198,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -213,12 +214,12 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
 		{
-			desc: "state_summary_frontier message with compression",
+			desc: "state_summary_frontier message with gzip compression",
 			op:   StateSummaryFrontierOp,
 			msg: &p2p.Message{
 				Message: &p2p.Message_StateSummaryFrontier_{
@@ -229,7 +230,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -246,12 +247,12 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
 		{
-			desc: "get_accepted_state_summary message with compression",
+			desc: "get_accepted_state_summary message with gzip compression",
 			op:   GetAcceptedStateSummaryOp,
 			msg: &p2p.Message{
 				Message: &p2p.Message_GetAcceptedStateSummary{
@@ -263,7 +264,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -279,12 +280,12 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
 		{
-			desc: "accepted_state_summary message with compression",
+			desc: "accepted_state_summary message with gzip compression",
 			op:   AcceptedStateSummaryOp,
 			msg: &p2p.Message{
 				Message: &p2p.Message_AcceptedStateSummary_{
@@ -295,7 +296,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -312,7 +313,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -328,7 +329,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -346,7 +347,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -362,7 +363,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -380,7 +381,25 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
+			bypassThrottling: true,
+			bytesSaved:       false,
+		},
+		{
+			desc: "get_ancestors message at height with no compression",
+			op:   GetAncestorsOp,
+			msg: &p2p.Message{
+				Message: &p2p.Message_GetAncestors{
+					GetAncestors: &p2p.GetAncestors{
+						ChainId:    testID[:],
+						RequestId:  1,
+						Deadline:   1,
+						EngineType: p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+						Height:     1337,
+					},
+				},
+			},
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -396,12 +415,28 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
 		{
-			desc: "ancestors message with compression",
+			desc: "ancestors message with gzip compression",
+			op:   AncestorsOp,
+			msg: &p2p.Message{
+				Message: &p2p.Message_Ancestors_{
+					Ancestors_: &p2p.Ancestors{
+						ChainId:    testID[:],
+						RequestId:  12345,
+						Containers: compressibleContainers,
+					},
+				},
+			},
+			compressionType:  compression.TypeGzip,
+			bypassThrottling: true,
+			bytesSaved:       true,
+		},
+		{
+			desc: "ancestors message with zstd compression",
 			op:   AncestorsOp,
 			msg: &p2p.Message{
 				Message: &p2p.Message_Ancestors_{
@@ -412,7 +447,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeZstd,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -430,7 +465,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -447,12 +482,29 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
 		{
-			desc: "put message with compression",
+			desc: "put message with gzip compression",
+			op:   PutOp,
+			msg: &p2p.Message{
+				Message: &p2p.Message_Put{
+					Put: &p2p.Put{
+						ChainId:    testID[:],
+						RequestId:  1,
+						Container:  compressibleContainers[0],
+						EngineType: p2p.EngineType_ENGINE_TYPE_AVALANCHE,
+					},
+				},
+			},
+			compressionType:  compression.TypeGzip,
+			bypassThrottling: true,
+			bytesSaved:       true,
+		},
+		{
+			desc: "put message with zstd compression",
 			op:   PutOp,
 			msg: &p2p.Message{
 				Message: &p2p.Message_Put{
@@ -464,7 +516,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeZstd,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -482,12 +534,12 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
 		{
-			desc: "push_query message with compression",
+			desc: "push_query message with gzip compression",
 			op:   PushQueryOp,
 			msg: &p2p.Message{
 				Message: &p2p.Message_PushQuery{
@@ -500,7 +552,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -518,7 +570,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -534,7 +586,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
@@ -551,12 +603,12 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
 		{
-			desc: "app_request message with compression",
+			desc: "app_request message with gzip compression",
 			op:   AppRequestOp,
 			msg: &p2p.Message{
 				Message: &p2p.Message_AppRequest{
@@ -568,7 +620,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -584,12 +636,12 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
 		{
-			desc: "app_response message with compression",
+			desc: "app_response message with gzip compression",
 			op:   AppResponseOp,
 			msg: &p2p.Message{
 				Message: &p2p.Message_AppResponse{
@@ -600,7 +652,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeGzip,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -615,12 +667,27 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     false,
+			compressionType:  compression.TypeNone,
 			bypassThrottling: true,
 			bytesSaved:       false,
 		},
 		{
-			desc: "app_gossip message with compression",
+			desc: "app_gossip message with gzip compression",
+			op:   AppGossipOp,
+			msg: &p2p.Message{
+				Message: &p2p.Message_AppGossip{
+					AppGossip: &p2p.AppGossip{
+						ChainId:  testID[:],
+						AppBytes: compressibleContainers[0],
+					},
+				},
+			},
+			compressionType:  compression.TypeGzip,
+			bypassThrottling: true,
+			bytesSaved:       true,
+		},
+		{
+			desc: "app_gossip message with zstd compression",
 			op:   AppGossipOp,
 			msg: &p2p.Message{
 				Message: &p2p.Message_AppGossip{
@@ -630,7 +697,7 @@ func TestMessage(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:     true,
+			compressionType:  compression.TypeZstd,
 			bypassThrottling: true,
 			bytesSaved:       true,
 		},
@@ -638,7 +705,7 @@ func TestMessage(t *testing.T) {
 
 	for _, tv := range tests {
 		require.True(t.Run(tv.desc, func(t2 *testing.T) {
-			encodedMsg, err := mb.createOutbound(tv.msg, tv.gzipCompress, tv.bypassThrottling)
+			encodedMsg, err := mb.createOutbound(tv.msg, tv.compressionType, tv.bypassThrottling)
 			require.NoError(err)
 
 			require.Equal(tv.bypassThrottling, encodedMsg.BypassThrottling())
@@ -654,6 +721,100 @@ func TestMessage(t *testing.T) {
 	}
 }
 
+func TestRecompress(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+
+	mb, err := newMsgBuilder(
+		"test",
+		prometheus.NewRegistry(),
+		5*time.Second,
+	)
+	require.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	container := bytes.Repeat([]byte{0}, 1024)
+	msg := &p2p.Message{
+		Message: &p2p.Message_Put{
+			Put: &p2p.Put{
+				ChainId:   chainID[:],
+				RequestId: 1,
+				Container: container,
+			},
+		},
+	}
+
+	zstdMsg, err := mb.createOutbound(msg, compression.TypeZstd, true)
+	require.NoError(err)
+	require.Equal(compression.TypeZstd, zstdMsg.CompressionType())
+
+	sameMsg, err := mb.recompress(zstdMsg, compression.TypeZstd)
+	require.NoError(err)
+	require.Equal(zstdMsg, sameMsg)
+
+	gzipMsg, err := mb.recompress(zstdMsg, compression.TypeGzip)
+	require.NoError(err)
+	require.Equal(compression.TypeGzip, gzipMsg.CompressionType())
+	require.Equal(PutOp, gzipMsg.Op())
+	gotChainID, ok := gzipMsg.ChainID()
+	require.True(ok)
+	require.Equal(chainID, gotChainID)
+	require.True(gzipMsg.BypassThrottling())
+	require.Positive(gzipMsg.BytesSavedCompression())
+
+	parsedMsg, err := mb.parseInbound(gzipMsg.Bytes(), ids.EmptyNodeID, func() {})
+	require.NoError(err)
+	require.Equal(PutOp, parsedMsg.Op())
+
+	put, ok := parsedMsg.Message().(*p2p.Put)
+	require.True(ok)
+	require.Equal(container, put.Container)
+}
+
+func TestSupportedCompressionTypes(t *testing.T) {
+	require := require.New(t)
+
+	// Peers that don't advertise anything only support gzip
+	supported := SupportedCompressionTypes(&p2p.Version{})
+	require.True(supported.Contains(compression.TypeNone))
+	require.True(supported.Contains(compression.TypeGzip))
+	require.False(supported.Contains(compression.TypeZstd))
+
+	supported = SupportedCompressionTypes(&p2p.Version{
+		SupportedCompressionTypes: compressionTypes(SupportedCapabilities().List()),
+	})
+	require.True(supported.Contains(compression.TypeNone))
+	require.True(supported.Contains(compression.TypeGzip))
+	require.True(supported.Contains(compression.TypeZstd))
+}
+
+func TestCapabilities(t *testing.T) {
+	require := require.New(t)
+
+	// Peers that don't advertise anything only support gzip
+	capabilities := Capabilities(&p2p.Version{})
+	require.Equal(1, capabilities.Len())
+	require.True(capabilities.Contains(CapabilityCompressionGzip))
+
+	// Compression types advertised by older peers are reported as
+	// capabilities
+	capabilities = Capabilities(&p2p.Version{
+		SupportedCompressionTypes: []p2p.CompressionType{
+			p2p.CompressionType_COMPRESSION_TYPE_ZSTD,
+		},
+	})
+	require.True(capabilities.Contains(CapabilityCompressionGzip))
+	require.True(capabilities.Contains(CapabilityCompressionZstd))
+
+	capabilities = Capabilities(&p2p.Version{
+		Capabilities: []string{CapabilityCompressionZstd, "test"},
+	})
+	require.True(capabilities.Contains(CapabilityCompressionGzip))
+	require.True(capabilities.Contains(CapabilityCompressionZstd))
+	require.True(capabilities.Contains("test"))
+}
+
 func TestEmptyInboundMessage(t *testing.T) {
 	t.Parallel()
 
@@ -701,3 +862,44 @@ func TestNilInboundMessage(t *testing.T) {
 	require.True(ok)
 	require.NotNil(pingMsg)
 }
+
+func TestWrap(t *testing.T) {
+	require := require.New(t)
+
+	msgs := []interface{}{
+		&p2p.Ping{},
+		&p2p.Pong{},
+		&p2p.Version{},
+		&p2p.PeerList{},
+		&p2p.PeerListAck{},
+		&p2p.GetStateSummaryFrontier{},
+		&p2p.StateSummaryFrontier{},
+		&p2p.GetAcceptedStateSummary{},
+		&p2p.AcceptedStateSummary{},
+		&p2p.GetAcceptedFrontier{},
+		&p2p.AcceptedFrontier{},
+		&p2p.GetAccepted{},
+		&p2p.Accepted{},
+		&p2p.GetAncestors{},
+		&p2p.Ancestors{},
+		&p2p.Get{},
+		&p2p.Put{},
+		&p2p.PushQuery{},
+		&p2p.PullQuery{},
+		&p2p.Chits{},
+		&p2p.AppRequest{},
+		&p2p.AppResponse{},
+		&p2p.AppGossip{},
+	}
+	for _, msg := range msgs {
+		wrapped, err := Wrap(msg)
+		require.NoError(err)
+
+		unwrapped, err := Unwrap(wrapped)
+		require.NoError(err)
+		require.Same(msg, unwrapped)
+	}
+
+	_, err := Wrap(&p2p.Message{})
+	require.ErrorIs(err, errUnknownMessageType)
+}
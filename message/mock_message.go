@@ -10,6 +10,8 @@ package message
 import (
 	reflect "reflect"
 
+	ids "github.com/MetalBlockchain/metalgo/ids"
+	compression "github.com/MetalBlockchain/metalgo/utils/compression"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -78,6 +80,35 @@ func (mr *MockOutboundMessageMockRecorder) BytesSavedCompression() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BytesSavedCompression", reflect.TypeOf((*MockOutboundMessage)(nil).BytesSavedCompression))
 }
 
+// ChainID mocks base method.
+func (m *MockOutboundMessage) ChainID() (ids.ID, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainID")
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// ChainID indicates an expected call of ChainID.
+func (mr *MockOutboundMessageMockRecorder) ChainID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainID", reflect.TypeOf((*MockOutboundMessage)(nil).ChainID))
+}
+
+// CompressionType mocks base method.
+func (m *MockOutboundMessage) CompressionType() compression.Type {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompressionType")
+	ret0, _ := ret[0].(compression.Type)
+	return ret0
+}
+
+// CompressionType indicates an expected call of CompressionType.
+func (mr *MockOutboundMessageMockRecorder) CompressionType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompressionType", reflect.TypeOf((*MockOutboundMessage)(nil).CompressionType))
+}
+
 // Op mocks base method.
 func (m *MockOutboundMessage) Op() Op {
 	m.ctrl.T.Helper()
@@ -4,10 +4,12 @@
 package message
 
 import (
+	"context"
 	"time"
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/ips"
 )
 
@@ -25,6 +27,7 @@ type OutboundMsgBuilder interface {
 		myVersionTime uint64,
 		sig []byte,
 		trackedSubnets []ids.ID,
+		capabilities []string,
 	) (OutboundMessage, error)
 
 	PeerList(
@@ -96,6 +99,7 @@ type OutboundMsgBuilder interface {
 	) (OutboundMessage, error)
 
 	GetAncestors(
+		ctx context.Context,
 		chainID ids.ID,
 		requestID uint32,
 		deadline time.Duration,
@@ -103,6 +107,15 @@ type OutboundMsgBuilder interface {
 		engineType p2p.EngineType,
 	) (OutboundMessage, error)
 
+	GetAncestorsAtHeight(
+		ctx context.Context,
+		chainID ids.ID,
+		requestID uint32,
+		deadline time.Duration,
+		height uint64,
+		engineType p2p.EngineType,
+	) (OutboundMessage, error)
+
 	Ancestors(
 		chainID ids.ID,
 		requestID uint32,
@@ -125,6 +138,7 @@ type OutboundMsgBuilder interface {
 	) (OutboundMessage, error)
 
 	PushQuery(
+		ctx context.Context,
 		chainID ids.ID,
 		requestID uint32,
 		deadline time.Duration,
@@ -133,6 +147,7 @@ type OutboundMsgBuilder interface {
 	) (OutboundMessage, error)
 
 	PullQuery(
+		ctx context.Context,
 		chainID ids.ID,
 		requestID uint32,
 		deadline time.Duration,
@@ -148,6 +163,7 @@ type OutboundMsgBuilder interface {
 	) (OutboundMessage, error)
 
 	AppRequest(
+		ctx context.Context,
 		chainID ids.ID,
 		requestID uint32,
 		deadline time.Duration,
@@ -164,23 +180,38 @@ type OutboundMsgBuilder interface {
 		chainID ids.ID,
 		msg []byte,
 	) (OutboundMessage, error)
+
+	// Recompress returns [msg] re-encoded with [compressionType]. This is used
+	// to send a message to a peer that doesn't support the compression type
+	// the message was originally built with.
+	Recompress(
+		msg OutboundMessage,
+		compressionType compression.Type,
+	) (OutboundMessage, error)
 }
 
 type outMsgBuilder struct {
-	compress bool // set to "true" if compression is enabled
+	compressionType compression.Type
 
 	builder *msgBuilder
 }
 
 // Use "message.NewCreator" to import this function
 // since we do not expose "msgBuilder" yet
-func newOutboundBuilder(enableCompression bool, builder *msgBuilder) OutboundMsgBuilder {
+func newOutboundBuilder(compressionType compression.Type, builder *msgBuilder) OutboundMsgBuilder {
 	return &outMsgBuilder{
-		compress: enableCompression,
-		builder:  builder,
+		compressionType: compressionType,
+		builder:         builder,
 	}
 }
 
+func (b *outMsgBuilder) Recompress(
+	msg OutboundMessage,
+	compressionType compression.Type,
+) (OutboundMessage, error) {
+	return b.builder.recompress(msg, compressionType)
+}
+
 func (b *outMsgBuilder) Ping() (OutboundMessage, error) {
 	return b.builder.createOutbound(
 		&p2p.Message{
@@ -188,7 +219,7 @@ func (b *outMsgBuilder) Ping() (OutboundMessage, error) {
 				Ping: &p2p.Ping{},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -206,7 +237,7 @@ func (b *outMsgBuilder) Pong(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -219,6 +250,7 @@ func (b *outMsgBuilder) Version(
 	myVersionTime uint64,
 	sig []byte,
 	trackedSubnets []ids.ID,
+	capabilities []string,
 ) (OutboundMessage, error) {
 	subnetIDBytes := make([][]byte, len(trackedSubnets))
 	encodeIDs(trackedSubnets, subnetIDBytes)
@@ -234,10 +266,13 @@ func (b *outMsgBuilder) Version(
 					MyVersionTime:  myVersionTime,
 					Sig:            sig,
 					TrackedSubnets: subnetIDBytes,
+					Capabilities:   capabilities,
+
+					SupportedCompressionTypes: compressionTypes(capabilities),
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		true,
 	)
 }
@@ -262,7 +297,7 @@ func (b *outMsgBuilder) PeerList(peers []ips.ClaimedIPPort, bypassThrottling boo
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		bypassThrottling,
 	)
 }
@@ -276,7 +311,7 @@ func (b *outMsgBuilder) PeerListAck(peerAcks []*p2p.PeerAck) (OutboundMessage, e
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -296,7 +331,7 @@ func (b *outMsgBuilder) GetStateSummaryFrontier(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -316,7 +351,7 @@ func (b *outMsgBuilder) StateSummaryFrontier(
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
@@ -338,7 +373,7 @@ func (b *outMsgBuilder) GetAcceptedStateSummary(
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
@@ -360,7 +395,7 @@ func (b *outMsgBuilder) AcceptedStateSummary(
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
@@ -382,7 +417,7 @@ func (b *outMsgBuilder) GetAcceptedFrontier(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -404,7 +439,7 @@ func (b *outMsgBuilder) AcceptedFrontier(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -430,7 +465,7 @@ func (b *outMsgBuilder) GetAccepted(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -452,12 +487,13 @@ func (b *outMsgBuilder) Accepted(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
 
 func (b *outMsgBuilder) GetAncestors(
+	ctx context.Context,
 	chainID ids.ID,
 	requestID uint32,
 	deadline time.Duration,
@@ -468,15 +504,44 @@ func (b *outMsgBuilder) GetAncestors(
 		&p2p.Message{
 			Message: &p2p.Message_GetAncestors{
 				GetAncestors: &p2p.GetAncestors{
-					ChainId:     chainID[:],
-					RequestId:   requestID,
-					Deadline:    uint64(deadline),
-					ContainerId: containerID[:],
-					EngineType:  engineType,
+					ChainId:      chainID[:],
+					RequestId:    requestID,
+					Deadline:     uint64(deadline),
+					ContainerId:  containerID[:],
+					EngineType:   engineType,
+					TraceContext: newTraceContext(ctx),
 				},
 			},
 		},
+		compression.TypeNone,
 		false,
+	)
+}
+
+// GetAncestorsAtHeight requests the ancestors of the container accepted at
+// [height]. The container ID is left empty to signal the height lookup.
+func (b *outMsgBuilder) GetAncestorsAtHeight(
+	ctx context.Context,
+	chainID ids.ID,
+	requestID uint32,
+	deadline time.Duration,
+	height uint64,
+	engineType p2p.EngineType,
+) (OutboundMessage, error) {
+	return b.builder.createOutbound(
+		&p2p.Message{
+			Message: &p2p.Message_GetAncestors{
+				GetAncestors: &p2p.GetAncestors{
+					ChainId:      chainID[:],
+					RequestId:    requestID,
+					Deadline:     uint64(deadline),
+					EngineType:   engineType,
+					TraceContext: newTraceContext(ctx),
+					Height:       height,
+				},
+			},
+		},
+		compression.TypeNone,
 		false,
 	)
 }
@@ -496,7 +561,7 @@ func (b *outMsgBuilder) Ancestors(
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
@@ -520,7 +585,7 @@ func (b *outMsgBuilder) Get(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -542,12 +607,13 @@ func (b *outMsgBuilder) Put(
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
 
 func (b *outMsgBuilder) PushQuery(
+	ctx context.Context,
 	chainID ids.ID,
 	requestID uint32,
 	deadline time.Duration,
@@ -558,20 +624,22 @@ func (b *outMsgBuilder) PushQuery(
 		&p2p.Message{
 			Message: &p2p.Message_PushQuery{
 				PushQuery: &p2p.PushQuery{
-					ChainId:    chainID[:],
-					RequestId:  requestID,
-					Deadline:   uint64(deadline),
-					Container:  container,
-					EngineType: engineType,
+					ChainId:      chainID[:],
+					RequestId:    requestID,
+					Deadline:     uint64(deadline),
+					Container:    container,
+					EngineType:   engineType,
+					TraceContext: newTraceContext(ctx),
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
 
 func (b *outMsgBuilder) PullQuery(
+	ctx context.Context,
 	chainID ids.ID,
 	requestID uint32,
 	deadline time.Duration,
@@ -582,15 +650,16 @@ func (b *outMsgBuilder) PullQuery(
 		&p2p.Message{
 			Message: &p2p.Message_PullQuery{
 				PullQuery: &p2p.PullQuery{
-					ChainId:     chainID[:],
-					RequestId:   requestID,
-					Deadline:    uint64(deadline),
-					ContainerId: containerID[:],
-					EngineType:  engineType,
+					ChainId:      chainID[:],
+					RequestId:    requestID,
+					Deadline:     uint64(deadline),
+					ContainerId:  containerID[:],
+					EngineType:   engineType,
+					TraceContext: newTraceContext(ctx),
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
@@ -616,12 +685,13 @@ func (b *outMsgBuilder) Chits(
 				},
 			},
 		},
-		false,
+		compression.TypeNone,
 		false,
 	)
 }
 
 func (b *outMsgBuilder) AppRequest(
+	ctx context.Context,
 	chainID ids.ID,
 	requestID uint32,
 	deadline time.Duration,
@@ -631,14 +701,15 @@ func (b *outMsgBuilder) AppRequest(
 		&p2p.Message{
 			Message: &p2p.Message_AppRequest{
 				AppRequest: &p2p.AppRequest{
-					ChainId:   chainID[:],
-					RequestId: requestID,
-					Deadline:  uint64(deadline),
-					AppBytes:  msg,
+					ChainId:      chainID[:],
+					RequestId:    requestID,
+					Deadline:     uint64(deadline),
+					AppBytes:     msg,
+					TraceContext: newTraceContext(ctx),
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
@@ -654,7 +725,7 @@ func (b *outMsgBuilder) AppResponse(chainID ids.ID, requestID uint32, msg []byte
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
@@ -669,7 +740,7 @@ func (b *outMsgBuilder) AppGossip(chainID ids.ID, msg []byte) (OutboundMessage,
 				},
 			},
 		},
-		b.compress,
+		b.compressionType,
 		false,
 	)
 }
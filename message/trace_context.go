@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+)
+
+var (
+	_ traceContextGetter = (*p2p.GetAncestors)(nil)
+	_ traceContextGetter = (*p2p.PushQuery)(nil)
+	_ traceContextGetter = (*p2p.PullQuery)(nil)
+	_ traceContextGetter = (*p2p.AppRequest)(nil)
+)
+
+type traceContextGetter interface {
+	GetTraceContext() *p2p.TraceContext
+}
+
+// newTraceContext returns the trace context of the span in [ctx], or nil if
+// [ctx] doesn't contain a valid span.
+func newTraceContext(ctx context.Context) *p2p.TraceContext {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+
+	traceID := spanContext.TraceID()
+	spanID := spanContext.SpanID()
+	return &p2p.TraceContext{
+		TraceId:    traceID[:],
+		SpanId:     spanID[:],
+		TraceFlags: uint32(spanContext.TraceFlags()),
+	}
+}
+
+// GetSpanContext returns the context of the remote span that [m] was sent
+// from. If [m] doesn't carry a valid trace context, the returned span context
+// is invalid.
+func GetSpanContext(m any) trace.SpanContext {
+	msg, ok := m.(traceContextGetter)
+	if !ok {
+		return trace.SpanContext{}
+	}
+	traceContext := msg.GetTraceContext()
+	if traceContext == nil {
+		return trace.SpanContext{}
+	}
+
+	var (
+		traceID trace.TraceID
+		spanID  trace.SpanID
+	)
+	if len(traceContext.TraceId) != len(traceID) || len(traceContext.SpanId) != len(spanID) {
+		return trace.SpanContext{}
+	}
+	copy(traceID[:], traceContext.TraceId)
+	copy(spanID[:], traceContext.SpanId)
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.TraceFlags(traceContext.TraceFlags),
+		Remote:     true,
+	})
+}
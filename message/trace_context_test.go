@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
+)
+
+func TestTraceContextPropagation(t *testing.T) {
+	require := require.New(t)
+
+	mb, err := newMsgBuilder(
+		"test",
+		prometheus.NewRegistry(),
+		10*time.Second,
+	)
+	require.NoError(err)
+	builder := newOutboundBuilder(compression.TypeNone, mb)
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3},
+		SpanID:     trace.SpanID{4, 5, 6},
+		TraceFlags: trace.FlagsSampled,
+	})
+	tracedCtx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	chainID := ids.GenerateTestID()
+	containerID := ids.GenerateTestID()
+	builders := map[Op]func(context.Context) (OutboundMessage, error){
+		GetAncestorsOp: func(ctx context.Context) (OutboundMessage, error) {
+			return builder.GetAncestors(ctx, chainID, 1, time.Second, containerID, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+		},
+		PushQueryOp: func(ctx context.Context) (OutboundMessage, error) {
+			return builder.PushQuery(ctx, chainID, 1, time.Second, []byte{1}, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+		},
+		PullQueryOp: func(ctx context.Context) (OutboundMessage, error) {
+			return builder.PullQuery(ctx, chainID, 1, time.Second, containerID, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+		},
+		AppRequestOp: func(ctx context.Context) (OutboundMessage, error) {
+			return builder.AppRequest(ctx, chainID, 1, time.Second, []byte{1})
+		},
+	}
+	for op, build := range builders {
+		outMsg, err := build(tracedCtx)
+		require.NoError(err)
+
+		inMsg, err := mb.parseInbound(outMsg.Bytes(), ids.EmptyNodeID, func() {})
+		require.NoError(err)
+		require.Equal(op, inMsg.Op())
+
+		remoteSpanContext := GetSpanContext(inMsg.Message())
+		require.True(remoteSpanContext.IsRemote())
+		require.True(remoteSpanContext.Equal(spanContext.WithRemote(true)))
+
+		// Requests sent outside of a trace don't carry a trace context.
+		outMsg, err = build(context.Background())
+		require.NoError(err)
+
+		inMsg, err = mb.parseInbound(outMsg.Bytes(), ids.EmptyNodeID, func() {})
+		require.NoError(err)
+		require.False(GetSpanContext(inMsg.Message()).IsValid())
+	}
+}
+
+func TestGetSpanContextInvalid(t *testing.T) {
+	require := require.New(t)
+
+	// Messages that can't carry a trace context
+	require.False(GetSpanContext(&p2p.Put{}).IsValid())
+
+	// Malformed trace contexts are ignored
+	require.False(GetSpanContext(&p2p.PullQuery{
+		TraceContext: &p2p.TraceContext{
+			TraceId: []byte{1},
+			SpanId:  []byte{2},
+		},
+	}).IsValid())
+}
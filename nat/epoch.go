@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import "time"
+
+// epoch tracks the epoch time reported by a NAT-PMP or PCP server. The server
+// resets its epoch time when it loses its port mappings, such as when it
+// reboots or when its external IP changes.
+//
+// See: https://www.rfc-editor.org/rfc/rfc6887#section-8.5
+type epoch struct {
+	initialized bool
+	serverTime  uint32
+	clientTime  time.Time
+}
+
+// update records the epoch time [serverTime] that was received at
+// [clientTime]. Returns false if the server lost its mappings since the
+// previous update.
+func (e *epoch) update(serverTime uint32, clientTime time.Time) bool {
+	if !e.initialized {
+		e.initialized = true
+		e.serverTime = serverTime
+		e.clientTime = clientTime
+		return true
+	}
+
+	prevServerTime := int64(e.serverTime)
+	currServerTime := int64(serverTime)
+	serverDelta := currServerTime - prevServerTime
+	clientDelta := int64(clientTime.Sub(e.clientTime) / time.Second)
+
+	e.serverTime = serverTime
+	e.clientTime = clientTime
+
+	// The server's clock is allowed to drift from ours by 1/16th of the
+	// elapsed time, plus 2 seconds to account for rounding and the
+	// transmission delay.
+	return currServerTime >= prevServerTime-1 &&
+		clientDelta+2 >= serverDelta-serverDelta/16 &&
+		serverDelta+2 >= clientDelta-clientDelta/16
+}
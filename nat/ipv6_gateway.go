@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ipv6RouteFile lists the IPv6 routing table on Linux.
+	ipv6RouteFile = "/proc/net/ipv6_route"
+
+	// rtfGateway is set on routes whose next hop is a router.
+	// See: include/uapi/linux/route.h
+	rtfGateway = 0x0002
+
+	ipv6RouteNumFields = 10
+)
+
+var errInvalidIPv6Route = errors.New("invalid IPv6 route")
+
+type ipv6Route struct {
+	gateway *net.UDPAddr
+	metric  uint32
+}
+
+// discoverIPv6Gateways returns the PCP server address of every IPv6 default
+// router, in order of preference.
+//
+// gateway.DiscoverGateway only reports IPv4 gateways, so the IPv6 routing table
+// is read directly.
+func discoverIPv6Gateways() ([]*net.UDPAddr, error) {
+	f, err := os.Open(ipv6RouteFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parseIPv6DefaultRoutes(f)
+}
+
+// parseIPv6DefaultRoutes parses a routing table in the format of
+// /proc/net/ipv6_route and returns the PCP server address of the next hop of
+// every default route, ordered by increasing metric.
+//
+// Each line describes a route as:
+//
+//	destination prefix_length source source_prefix_length next_hop metric
+//	reference_count use flags interface
+func parseIPv6DefaultRoutes(r io.Reader) ([]*net.UDPAddr, error) {
+	var routes []ipv6Route
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != ipv6RouteNumFields {
+			return nil, fmt.Errorf("%w: expected %d fields but got %d", errInvalidIPv6Route, ipv6RouteNumFields, len(fields))
+		}
+
+		destination, err := parseRouteIP(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		prefixLength, err := strconv.ParseUint(fields[1], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidIPv6Route, err)
+		}
+		nextHop, err := parseRouteIP(fields[4])
+		if err != nil {
+			return nil, err
+		}
+		metric, err := strconv.ParseUint(fields[5], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidIPv6Route, err)
+		}
+		flags, err := strconv.ParseUint(fields[8], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidIPv6Route, err)
+		}
+
+		isDefault := prefixLength == 0 && destination.IsUnspecified()
+		if !isDefault || flags&rtfGateway == 0 || nextHop.IsUnspecified() {
+			continue
+		}
+
+		gateway := &net.UDPAddr{
+			IP:   nextHop,
+			Port: pcpPort,
+		}
+		// Routers are usually advertised by their link-local address, which
+		// is only reachable through the interface of the route.
+		if nextHop.IsLinkLocalUnicast() {
+			gateway.Zone = fields[9]
+		}
+		routes = append(routes, ipv6Route{
+			gateway: gateway,
+			metric:  uint32(metric),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].metric < routes[j].metric
+	})
+	gateways := make([]*net.UDPAddr, len(routes))
+	for i, route := range routes {
+		gateways[i] = route.gateway
+	}
+	return gateways, nil
+}
+
+// parseRouteIP parses an IPv6 address that is encoded as 32 hex characters.
+func parseRouteIP(s string) (net.IP, error) {
+	ip, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidIPv6Route, err)
+	}
+	if len(ip) != net.IPv6len {
+		return nil, fmt.Errorf("%w: invalid address %q", errInvalidIPv6Route, s)
+	}
+	return ip, nil
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIPv6DefaultRoutes(t *testing.T) {
+	require := require.New(t)
+
+	// The routes, in order, are: a default route through a link-local router,
+	// a prefix route, the unreachable default route of the loopback
+	// interface, a preferred default route through a global router and the
+	// local address of the loopback interface.
+	table := strings.Join([]string{
+		"00000000000000000000000000000000 00 00000000000000000000000000000000 00 fe800000000000000000000000000001 00000400 00000001 00000000 00450003     eth0",
+		"20010db8000000000000000000000000 40 00000000000000000000000000000000 00 00000000000000000000000000000000 00000100 00000001 00000000 00000001     eth0",
+		"00000000000000000000000000000000 00 00000000000000000000000000000000 00 00000000000000000000000000000000 ffffffff 00000001 00000000 00200200       lo",
+		"00000000000000000000000000000000 00 00000000000000000000000000000000 00 20010db8000000000000000000000001 00000100 00000001 00000000 00000003     eth1",
+		"00000000000000000000000000000001 80 00000000000000000000000000000000 00 00000000000000000000000000000000 00000000 00000002 00000000 80200001       lo",
+		"",
+	}, "\n")
+
+	gateways, err := parseIPv6DefaultRoutes(strings.NewReader(table))
+	require.NoError(err)
+	require.Equal(
+		[]*net.UDPAddr{
+			{
+				IP:   net.ParseIP("2001:db8::1"),
+				Port: pcpPort,
+			},
+			{
+				IP:   net.ParseIP("fe80::1"),
+				Port: pcpPort,
+				Zone: "eth0",
+			},
+		},
+		gateways,
+	)
+}
+
+func TestParseIPv6DefaultRoutesErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		table string
+	}{
+		{
+			name:  "missing fields",
+			table: "00000000000000000000000000000000 00 00000000000000000000000000000000 00 fe800000000000000000000000000001 00000400 00000001 00000000 00450003",
+		},
+		{
+			name:  "invalid next hop",
+			table: "00000000000000000000000000000000 00 00000000000000000000000000000000 00 fe80 00000400 00000001 00000000 00450003 eth0",
+		},
+		{
+			name:  "invalid metric",
+			table: "00000000000000000000000000000000 00 00000000000000000000000000000000 00 fe800000000000000000000000000001 metric 00000001 00000000 00450003 eth0",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseIPv6DefaultRoutes(strings.NewReader(test.table))
+			require.ErrorIs(t, err, errInvalidIPv6Route)
+		})
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/jackpal/gateway"
+
 	"go.uber.org/zap"
 
 	"github.com/MetalBlockchain/metalgo/utils/ips"
@@ -17,6 +19,9 @@ import (
 const (
 	mapTimeout        = 30 * time.Minute
 	maxRefreshRetries = 3
+	// mappingCheckFrequency is how often the router is checked for lost
+	// mappings and external IP changes
+	mappingCheckFrequency = time.Minute
 )
 
 // Router describes the functionality that a network device must support to be
@@ -28,17 +33,59 @@ type Router interface {
 	MapPort(intPort, extPort uint16, desc string, duration time.Duration) error
 	// Undo a port mapping
 	UnmapPort(intPort, extPort uint16) error
+	// Returns false if the router no longer maps [extPort] to [intPort], such
+	// as after the router restarted
+	MappingExists(intPort, extPort uint16) (bool, error)
 	// Return our external IP
 	ExternalIP() (net.IP, error)
 }
 
 // GetRouter returns a router on the current network.
 func GetRouter() Router {
-	if r := getUPnPRouter(); r != nil {
+	return getRouter(
+		func() Router {
+			if r := getUPnPRouter(); r != nil {
+				return r
+			}
+			return nil
+		},
+		gateway.DiscoverGateway,
+		discoverIPv6Gateways,
+	)
+}
+
+// getRouter returns the first router that is found by:
+//
+//  1. [discoverUPnP]
+//  2. PCP or NAT-PMP on the IPv4 gateway returned by [discoverIPv4Gateway]
+//  3. PCP on the IPv6 routers returned by [discoverIPv6Gateways], which only
+//     open the firewall since IPv6 addresses aren't translated
+func getRouter(
+	discoverUPnP func() Router,
+	discoverIPv4Gateway func() (net.IP, error),
+	discoverIPv6Gateways func() ([]*net.UDPAddr, error),
+) Router {
+	if r := discoverUPnP(); r != nil {
 		return r
 	}
-	if r := getPMPRouter(); r != nil {
-		return r
+	if gatewayIP, err := discoverIPv4Gateway(); err == nil {
+		pcpGateway := &net.UDPAddr{
+			IP:   gatewayIP,
+			Port: pcpPort,
+		}
+		if r := getPCPRouter(pcpGateway); r != nil {
+			return r
+		}
+		if r := getPMPRouter(gatewayIP); r != nil {
+			return r
+		}
+	}
+	if gateways, err := discoverIPv6Gateways(); err == nil {
+		for _, pcpGateway := range gateways {
+			if r := getPCPRouter(pcpGateway); r != nil {
+				return r
+			}
+		}
 	}
 
 	return NewNoRouter()
@@ -46,18 +93,20 @@ func GetRouter() Router {
 
 // Mapper attempts to open a set of ports on a router
 type Mapper struct {
-	log    logging.Logger
-	r      Router
-	closer chan struct{}
-	wg     sync.WaitGroup
+	log            logging.Logger
+	r              Router
+	checkFrequency time.Duration
+	closer         chan struct{}
+	wg             sync.WaitGroup
 }
 
 // NewPortMapper returns an initialized mapper
 func NewPortMapper(log logging.Logger, r Router) Mapper {
 	return Mapper{
-		log:    log,
-		r:      r,
-		closer: make(chan struct{}),
+		log:            log,
+		r:              r,
+		checkFrequency: mappingCheckFrequency,
+		closer:         make(chan struct{}),
 	}
 }
 
@@ -110,11 +159,19 @@ func (m *Mapper) retryMapPort(intPort, extPort uint16, desc string, timeout time
 
 // keepPortMapping runs in the background to keep a port mapped. It renews the mapping from [extPort]
 // to [intPort]] every [updateTime]. Updates [ip] every [updateTime].
+//
+// The mapping and the external IP are also checked every [m.checkFrequency],
+// so that the port is re-mapped promptly if the router dropped the mapping or
+// changed its external IP.
 func (m *Mapper) keepPortMapping(intPort, extPort uint16, desc string, ip ips.DynamicIPPort, updateTime time.Duration) {
 	updateTimer := time.NewTimer(updateTime)
+	checkTicker := time.NewTicker(m.checkFrequency)
+	// externalIP is the last external IP that the router reported
+	var externalIP net.IP
 
 	defer func(extPort uint16) {
 		updateTimer.Stop()
+		checkTicker.Stop()
 
 		m.log.Debug("unmapping port",
 			zap.Uint16("externalPort", extPort),
@@ -144,12 +201,72 @@ func (m *Mapper) keepPortMapping(intPort, extPort uint16, desc string, ip ips.Dy
 			}
 			m.updateIP(ip)
 			updateTimer.Reset(updateTime)
+		case <-checkTicker.C:
+			var lost bool
+			lost, externalIP = m.mappingLost(intPort, extPort, externalIP)
+			if !lost {
+				continue
+			}
+			err := m.retryMapPort(intPort, extPort, desc, mapTimeout)
+			if err != nil {
+				m.log.Warn("re-mapping lost port failed",
+					zap.Uint16("externalPort", extPort),
+					zap.Uint16("internalPort", intPort),
+					zap.Error(err),
+				)
+			} else {
+				m.log.Info("re-mapped lost port",
+					zap.Uint16("externalPort", extPort),
+					zap.Uint16("internalPort", intPort),
+				)
+			}
+			m.updateIP(ip)
+			// The external IP may have changed again while re-mapping
+			externalIP = nil
 		case <-m.closer:
 			return
 		}
 	}
 }
 
+// mappingLost returns true if the router no longer maps [extPort] to [intPort]
+// or if the external IP changed from [externalIP]. The current external IP is
+// returned.
+func (m *Mapper) mappingLost(intPort, extPort uint16, externalIP net.IP) (bool, net.IP) {
+	exists, err := m.r.MappingExists(intPort, extPort)
+	if err != nil {
+		m.log.Debug("failed to check port mapping",
+			zap.Uint16("externalPort", extPort),
+			zap.Uint16("internalPort", intPort),
+			zap.Error(err),
+		)
+		return false, externalIP
+	}
+	if !exists {
+		m.log.Warn("router dropped port mapping",
+			zap.Uint16("externalPort", extPort),
+			zap.Uint16("internalPort", intPort),
+		)
+		return true, externalIP
+	}
+
+	newIP, err := m.r.ExternalIP()
+	if err != nil {
+		m.log.Debug("failed to get external IP",
+			zap.Error(err),
+		)
+		return false, externalIP
+	}
+	if externalIP != nil && !externalIP.Equal(newIP) {
+		m.log.Warn("router changed external IP",
+			zap.Stringer("oldIP", externalIP),
+			zap.Stringer("newIP", newIP),
+		)
+		return true, newIP
+	}
+	return false, newIP
+}
+
 func (m *Mapper) updateIP(ip ips.DynamicIPPort) {
 	if ip == nil {
 		return
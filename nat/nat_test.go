@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/utils/ips"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+var errNoGateway = errors.New("no gateway")
+
+func TestMapperRemapsLostMapping(t *testing.T) {
+	require := require.New(t)
+
+	g := newFakeGateway(t, "127.0.0.1:0")
+	r := newPCPRouter(g.addr(), time.Second)
+
+	m := NewPortMapper(logging.NoLog{}, r)
+	m.checkFrequency = 10 * time.Millisecond
+
+	ip := ips.NewDynamicIPPort(net.IPv4(203, 0, 113, 1), 9651)
+	m.Map(9651, 9651, "", ip, time.Hour)
+	_, ok := g.mapping(9651)
+	require.True(ok)
+
+	// The gateway reboots with a new external IP, so the port must be
+	// re-mapped and the new IP must be advertised.
+	newIP := net.IPv4(203, 0, 113, 2)
+	g.restart(newIP)
+	require.Eventually(func() bool {
+		_, ok := g.mapping(9651)
+		return ok && ip.IPPort().IP.Equal(newIP)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	m.UnmapAllPorts()
+	_, ok = g.mapping(9651)
+	require.False(ok)
+}
+
+func TestGetRouterIPv6Gateway(t *testing.T) {
+	require := require.New(t)
+
+	g := newFakeGateway(t, "[::1]:0")
+
+	r := getRouter(
+		func() Router {
+			return nil
+		},
+		func() (net.IP, error) {
+			return nil, errNoGateway
+		},
+		func() ([]*net.UDPAddr, error) {
+			return []*net.UDPAddr{g.addr()}, nil
+		},
+	)
+	pcp, ok := r.(*pcpRouter)
+	require.True(ok)
+	require.Equal(g.addr(), pcp.gateway)
+
+	// The IPv6 address isn't translated
+	externalIP, err := r.ExternalIP()
+	require.NoError(err)
+	require.Equal(net.IPv6loopback, externalIP)
+}
+
+func TestGetRouterNoGateway(t *testing.T) {
+	r := getRouter(
+		func() Router {
+			return nil
+		},
+		func() (net.IP, error) {
+			return nil, errNoGateway
+		},
+		func() ([]*net.UDPAddr, error) {
+			return nil, errNoGateway
+		},
+	)
+	require.IsType(t, &noRouter{}, r)
+}
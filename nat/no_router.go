@@ -35,6 +35,10 @@ func (noRouter) UnmapPort(uint16, uint16) error {
 	return nil
 }
 
+func (noRouter) MappingExists(uint16, uint16) (bool, error) {
+	return false, errNoRouterCantMapPorts
+}
+
 func (r noRouter) ExternalIP() (net.IP, error) {
 	return r.ip, r.ipErr
 }
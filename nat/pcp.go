@@ -0,0 +1,380 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+)
+
+// See: https://www.rfc-editor.org/rfc/rfc6887
+const (
+	pcpPort          = 5351
+	pcpVersion       = 2
+	pcpOpAnnounce    = 0
+	pcpOpMap         = 1
+	pcpResponseBit   = 0x80
+	pcpProtocolTCP   = 6
+	pcpHeaderLen     = 24
+	pcpMapLen        = pcpHeaderLen + 36
+	pcpNonceLen      = 12
+	pcpMaxMessageLen = 1100
+
+	// NAT-PMP is version 0 of the protocol and is used to query the external
+	// IP, which PCP doesn't support without mapping a port.
+	// See: https://www.rfc-editor.org/rfc/rfc6886#section-3.2
+	pmpVersion            = 0
+	pmpOpExternalAddress  = 0
+	pmpExternalAddressLen = 12
+
+	pcpClientTimeout = 500 * time.Millisecond
+	pcpMaxAttempts   = 3
+
+	pcpResultSuccess = 0
+)
+
+var (
+	_ Router = (*pcpRouter)(nil)
+
+	errPCPUnsupportedVersion = errors.New("unsupported PCP version")
+	errPCPResult             = errors.New("PCP request failed")
+	errPCPNoResponse         = errors.New("no PCP response")
+	errPCPPortUnavailable    = errors.New("requested external port is unavailable")
+
+	pcpResults = map[byte]string{
+		1:  "UNSUPP_VERSION",
+		2:  "NOT_AUTHORIZED",
+		3:  "MALFORMED_REQUEST",
+		4:  "UNSUPP_OPCODE",
+		5:  "UNSUPP_OPTION",
+		6:  "MALFORMED_OPTION",
+		7:  "NETWORK_FAILURE",
+		8:  "NO_RESOURCES",
+		9:  "UNSUPP_PROTOCOL",
+		10: "USER_EX_QUOTA",
+		11: "CANNOT_PROVIDE_EXTERNAL",
+		12: "ADDRESS_MISMATCH",
+		13: "EXCESSIVE_REMOTE_PEERS",
+	}
+)
+
+type pcpMapping struct {
+	// nonce identifies the mapping to the server. It must be reused to renew
+	// or delete the mapping.
+	nonce        [pcpNonceLen]byte
+	externalPort uint16
+}
+
+// pcpRouter implements the Port Control Protocol, which supports both IPv4
+// and IPv6 gateways.
+type pcpRouter struct {
+	gateway *net.UDPAddr
+	timeout time.Duration
+	clock   mockable.Clock
+
+	// lock serializes the requests to the gateway
+	lock  sync.Mutex
+	epoch epoch
+	// externalIP is the address assigned to the most recent mapping
+	externalIP net.IP
+	// internal port -> mapping
+	mappings map[uint16]*pcpMapping
+}
+
+func newPCPRouter(gateway *net.UDPAddr, timeout time.Duration) *pcpRouter {
+	return &pcpRouter{
+		gateway:  gateway,
+		timeout:  timeout,
+		mappings: make(map[uint16]*pcpMapping),
+	}
+}
+
+func (*pcpRouter) SupportsNAT() bool {
+	return true
+}
+
+func (r *pcpRouter) MapPort(
+	intPort,
+	extPort uint16,
+	_ string,
+	duration time.Duration,
+) error {
+	lifetime := duration.Seconds()
+	if lifetime < 0 || lifetime > math.MaxUint32 {
+		return errInvalidLifetime
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	mapping, ok := r.mappings[intPort]
+	if !ok {
+		mapping = &pcpMapping{}
+		if _, err := rand.Read(mapping.nonce[:]); err != nil {
+			return err
+		}
+	}
+
+	response, err := r.requestMap(mapping.nonce, intPort, extPort, r.externalIP, uint32(lifetime))
+	if err != nil {
+		return err
+	}
+
+	assignedPort := binary.BigEndian.Uint16(response[42:44])
+	if assignedPort != extPort {
+		// The server mapped a different port, which our peers wouldn't know
+		// to connect to.
+		_, _ = r.requestMap(mapping.nonce, intPort, 0, nil, 0)
+		delete(r.mappings, intPort)
+		return fmt.Errorf("%w: %d was assigned rather than %d",
+			errPCPPortUnavailable,
+			assignedPort,
+			extPort,
+		)
+	}
+
+	mapping.externalPort = extPort
+	r.mappings[intPort] = mapping
+	r.externalIP = toIP(response[44:60])
+	return nil
+}
+
+func (r *pcpRouter) UnmapPort(intPort, _ uint16) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	mapping, ok := r.mappings[intPort]
+	if !ok {
+		return nil
+	}
+	delete(r.mappings, intPort)
+
+	_, err := r.requestMap(mapping.nonce, intPort, 0, nil, 0)
+	return err
+}
+
+// MappingExists sends an announcement to the gateway to check whether it lost
+// its mappings since the previous request.
+func (r *pcpRouter) MappingExists(intPort, extPort uint16) (bool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err := r.announce(); err != nil {
+		return false, err
+	}
+	mapping, ok := r.mappings[intPort]
+	return ok && mapping.externalPort == extPort, nil
+}
+
+// ExternalIP returns the external IP that was assigned to the most recent
+// mapping. If nothing was mapped yet, the NAT-PMP external address is used for
+// IPv4 gateways. IPv6 gateways typically don't translate addresses, so our
+// own address is used.
+func (r *pcpRouter) ExternalIP() (net.IP, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.externalIP != nil {
+		return r.externalIP, nil
+	}
+
+	conn, err := net.DialUDP("udp", nil, r.gateway)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	localIP := conn.LocalAddr().(*net.UDPAddr).IP
+	if localIP.To4() == nil {
+		return localIP, nil
+	}
+
+	response, err := r.roundTrip(conn, []byte{pmpVersion, pmpOpExternalAddress}, func(response []byte) bool {
+		return len(response) >= 2 &&
+			response[0] == pmpVersion &&
+			response[1] == pmpOpExternalAddress|pcpResponseBit
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(response) < pmpExternalAddressLen {
+		return nil, fmt.Errorf("%w: invalid NAT-PMP response length %d", errPCPResult, len(response))
+	}
+	if result := binary.BigEndian.Uint16(response[2:4]); result != pcpResultSuccess {
+		return nil, fmt.Errorf("%w: NAT-PMP result code %d", errPCPResult, result)
+	}
+	return net.IPv4(response[8], response[9], response[10], response[11]), nil
+}
+
+// announce verifies that the gateway supports PCP and updates the epoch.
+//
+// Assumes [r.lock] is held.
+func (r *pcpRouter) announce() error {
+	_, err := r.request(pcpOpAnnounce, 0, pcpHeaderLen, nil, nil)
+	return err
+}
+
+// requestMap maps [extPort] to [intPort] for [lifetime] seconds. If [lifetime]
+// is 0, the mapping is deleted.
+//
+// Assumes [r.lock] is held.
+func (r *pcpRouter) requestMap(
+	nonce [pcpNonceLen]byte,
+	intPort uint16,
+	extPort uint16,
+	extIP net.IP,
+	lifetime uint32,
+) ([]byte, error) {
+	return r.request(
+		pcpOpMap,
+		lifetime,
+		pcpMapLen,
+		func(clientIP net.IP) []byte {
+			payload := make([]byte, pcpMapLen-pcpHeaderLen)
+			copy(payload, nonce[:])
+			payload[12] = pcpProtocolTCP
+			binary.BigEndian.PutUint16(payload[16:18], intPort)
+			binary.BigEndian.PutUint16(payload[18:20], extPort)
+			switch {
+			case extIP != nil:
+				copy(payload[20:36], extIP.To16())
+			case clientIP.To4() != nil:
+				copy(payload[20:36], net.IPv4zero.To16())
+			}
+			return payload
+		},
+		func(response []byte) bool {
+			return bytes.Equal(response[pcpHeaderLen:pcpHeaderLen+pcpNonceLen], nonce[:])
+		},
+	)
+}
+
+// request sends a PCP request with the payload built by [payload] and returns
+// the response that is accepted by [matches].
+//
+// Assumes [r.lock] is held.
+func (r *pcpRouter) request(
+	opcode byte,
+	lifetime uint32,
+	responseLen int,
+	payload func(clientIP net.IP) []byte,
+	matches func(response []byte) bool,
+) ([]byte, error) {
+	conn, err := net.DialUDP("udp", nil, r.gateway)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	clientIP := conn.LocalAddr().(*net.UDPAddr).IP
+	request := make([]byte, pcpHeaderLen)
+	request[0] = pcpVersion
+	request[1] = opcode
+	binary.BigEndian.PutUint32(request[4:8], lifetime)
+	copy(request[8:24], clientIP.To16())
+	if payload != nil {
+		request = append(request, payload(clientIP)...)
+	}
+
+	response, err := r.roundTrip(conn, request, func(response []byte) bool {
+		if len(response) < 2 || response[1] != opcode|pcpResponseBit {
+			return false
+		}
+		if response[0] != pcpVersion {
+			// The version is checked by the caller
+			return true
+		}
+		if len(response) < responseLen || response[3] != pcpResultSuccess {
+			return true
+		}
+		return matches == nil || matches(response)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response[0] != pcpVersion {
+		return nil, fmt.Errorf("%w: gateway responded with version %d", errPCPUnsupportedVersion, response[0])
+	}
+	if len(response) < pcpHeaderLen {
+		return nil, fmt.Errorf("%w: invalid response length %d", errPCPResult, len(response))
+	}
+	if result := response[3]; result != pcpResultSuccess {
+		return nil, fmt.Errorf("%w: %s (%d)", errPCPResult, pcpResults[result], result)
+	}
+	if len(response) < responseLen {
+		return nil, fmt.Errorf("%w: invalid response length %d", errPCPResult, len(response))
+	}
+
+	if !r.epoch.update(binary.BigEndian.Uint32(response[8:12]), r.clock.Time()) {
+		// The gateway lost its state, so none of our mappings exist anymore.
+		r.mappings = make(map[uint16]*pcpMapping)
+		r.externalIP = nil
+	}
+	return response, nil
+}
+
+// roundTrip sends [request] over [conn] until a response that is accepted by
+// [matches] is received.
+func (r *pcpRouter) roundTrip(conn *net.UDPConn, request []byte, matches func(response []byte) bool) ([]byte, error) {
+	buf := make([]byte, pcpMaxMessageLen)
+	for attempt := 0; attempt < pcpMaxAttempts; attempt++ {
+		if _, err := conn.Write(request); err != nil {
+			return nil, err
+		}
+		if err := conn.SetReadDeadline(time.Now().Add(r.timeout)); err != nil {
+			return nil, err
+		}
+		for {
+			n, err := conn.Read(buf)
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if response := buf[:n]; matches(response) {
+				return response, nil
+			}
+		}
+	}
+	return nil, errPCPNoResponse
+}
+
+// toIP returns the IP encoded in 16 bytes, which may be an IPv4-mapped
+// address.
+func toIP(b []byte) net.IP {
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, b)
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+	return ip
+}
+
+func getPCPRouter(gateway *net.UDPAddr) *pcpRouter {
+	pcp := newPCPRouter(gateway, pcpClientTimeout)
+
+	pcp.lock.Lock()
+	err := pcp.announce()
+	pcp.lock.Unlock()
+	if err != nil {
+		return nil
+	}
+
+	if _, err := pcp.ExternalIP(); err != nil {
+		return nil
+	}
+	return pcp
+}
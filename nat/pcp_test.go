@@ -0,0 +1,334 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"encoding/binary"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	pcpResultNotAuthorized   = 2
+	pcpResultAddressMismatch = 12
+)
+
+// fakeGateway is a PCP server that also answers NAT-PMP external address
+// requests.
+type fakeGateway struct {
+	conn *net.UDPConn
+
+	lock       sync.Mutex
+	externalIP net.IP
+	epoch      uint32
+	// pmpOnly rejects PCP requests as a NAT-PMP server would
+	pmpOnly bool
+	// result is returned for MAP requests
+	result byte
+	// assignedPort, if non-zero, is assigned rather than the suggested port
+	assignedPort uint16
+	// internal port -> external port
+	mappings map[uint16]uint16
+}
+
+func newFakeGateway(t *testing.T, address string) *fakeGateway {
+	addr, err := net.ResolveUDPAddr("udp", address)
+	require.NoError(t, err)
+	conn, err := net.ListenUDP("udp", addr)
+	if err != nil {
+		t.Skipf("failed to listen on %s: %s", address, err)
+	}
+
+	g := &fakeGateway{
+		conn:       conn,
+		externalIP: net.IPv4(203, 0, 113, 1),
+		epoch:      1000,
+		mappings:   make(map[uint16]uint16),
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.serve()
+	}()
+	t.Cleanup(func() {
+		_ = conn.Close()
+		<-done
+	})
+	return g
+}
+
+func (g *fakeGateway) addr() *net.UDPAddr {
+	return g.conn.LocalAddr().(*net.UDPAddr)
+}
+
+func (g *fakeGateway) serve() {
+	buf := make([]byte, pcpMaxMessageLen)
+	for {
+		n, from, err := g.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		if response := g.handle(buf[:n], from); response != nil {
+			_, _ = g.conn.WriteToUDP(response, from)
+		}
+	}
+}
+
+func (g *fakeGateway) handle(request []byte, from *net.UDPAddr) []byte {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if len(request) < 2 {
+		return nil
+	}
+	if request[0] == pmpVersion {
+		response := make([]byte, pmpExternalAddressLen)
+		response[1] = request[1] | pcpResponseBit
+		binary.BigEndian.PutUint32(response[4:8], g.epoch)
+		copy(response[8:12], g.externalIP.To4())
+		return response
+	}
+	if g.pmpOnly {
+		response := make([]byte, 8)
+		response[1] = request[1] | pcpResponseBit
+		binary.BigEndian.PutUint16(response[2:4], 1)
+		return response
+	}
+	if len(request) < pcpHeaderLen {
+		return nil
+	}
+
+	response := make([]byte, len(request))
+	copy(response, request)
+	response[1] |= pcpResponseBit
+	binary.BigEndian.PutUint32(response[8:12], g.epoch)
+	copy(response[12:24], make([]byte, 12))
+	if !net.IP(request[8:24]).Equal(from.IP) {
+		response[3] = pcpResultAddressMismatch
+		return response
+	}
+	if request[1] != pcpOpMap {
+		return response
+	}
+	if len(request) < pcpMapLen {
+		return nil
+	}
+	if g.result != pcpResultSuccess {
+		response[3] = g.result
+		return response
+	}
+
+	lifetime := binary.BigEndian.Uint32(request[4:8])
+	intPort := binary.BigEndian.Uint16(request[40:42])
+	extPort := binary.BigEndian.Uint16(request[42:44])
+	if lifetime == 0 {
+		delete(g.mappings, intPort)
+		return response
+	}
+	if g.assignedPort != 0 {
+		extPort = g.assignedPort
+	}
+	g.mappings[intPort] = extPort
+	binary.BigEndian.PutUint16(response[42:44], extPort)
+	copy(response[44:60], g.externalIP.To16())
+	return response
+}
+
+// restart drops the mappings and resets the epoch, as if the gateway rebooted
+// and was assigned [externalIP].
+func (g *fakeGateway) restart(externalIP net.IP) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.externalIP = externalIP
+	g.epoch = 0
+	g.mappings = make(map[uint16]uint16)
+}
+
+func (g *fakeGateway) mapping(intPort uint16) (uint16, bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	extPort, ok := g.mappings[intPort]
+	return extPort, ok
+}
+
+func TestPCPRouter(t *testing.T) {
+	tests := []struct {
+		name        string
+		address     string
+		unmappedIP  net.IP
+		restartedIP net.IP
+	}{
+		{
+			name:        "ipv4",
+			address:     "127.0.0.1:0",
+			unmappedIP:  net.IPv4(203, 0, 113, 1),
+			restartedIP: net.IPv4(203, 0, 113, 2),
+		},
+		{
+			name:        "ipv6",
+			address:     "[::1]:0",
+			unmappedIP:  net.IPv6loopback,
+			restartedIP: net.ParseIP("2001:db8::2"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			g := newFakeGateway(t, test.address)
+			r := newPCPRouter(g.addr(), time.Second)
+			require.NoError(r.announce())
+
+			ip, err := r.ExternalIP()
+			require.NoError(err)
+			require.True(test.unmappedIP.Equal(ip))
+
+			require.NoError(r.MapPort(9651, 9651, "", time.Hour))
+			extPort, ok := g.mapping(9651)
+			require.True(ok)
+			require.Equal(uint16(9651), extPort)
+
+			ip, err = r.ExternalIP()
+			require.NoError(err)
+			require.True(net.IPv4(203, 0, 113, 1).Equal(ip))
+
+			exists, err := r.MappingExists(9651, 9651)
+			require.NoError(err)
+			require.True(exists)
+
+			// The gateway lost its state, which is detected by the epoch
+			g.restart(test.restartedIP)
+			exists, err = r.MappingExists(9651, 9651)
+			require.NoError(err)
+			require.False(exists)
+
+			require.NoError(r.MapPort(9651, 9651, "", time.Hour))
+			ip, err = r.ExternalIP()
+			require.NoError(err)
+			require.True(test.restartedIP.Equal(ip))
+
+			require.NoError(r.UnmapPort(9651, 9651))
+			_, ok = g.mapping(9651)
+			require.False(ok)
+		})
+	}
+}
+
+func TestPCPRouterPortUnavailable(t *testing.T) {
+	require := require.New(t)
+
+	g := newFakeGateway(t, "127.0.0.1:0")
+	g.lock.Lock()
+	g.assignedPort = 1234
+	g.lock.Unlock()
+	r := newPCPRouter(g.addr(), time.Second)
+
+	err := r.MapPort(9651, 9651, "", time.Hour)
+	require.ErrorIs(err, errPCPPortUnavailable)
+
+	// The unusable mapping is deleted
+	_, ok := g.mapping(9651)
+	require.False(ok)
+}
+
+func TestPCPRouterErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		pmpOnly bool
+		result  byte
+		err     error
+	}{
+		{
+			name:    "unsupported version",
+			pmpOnly: true,
+			err:     errPCPUnsupportedVersion,
+		},
+		{
+			name:   "not authorized",
+			result: pcpResultNotAuthorized,
+			err:    errPCPResult,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := newFakeGateway(t, "127.0.0.1:0")
+			g.lock.Lock()
+			g.pmpOnly = test.pmpOnly
+			g.result = test.result
+			g.lock.Unlock()
+			r := newPCPRouter(g.addr(), time.Second)
+
+			err := r.MapPort(9651, 9651, "", time.Hour)
+			require.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestPCPRouterNoResponse(t *testing.T) {
+	require := require.New(t)
+
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(err)
+	defer conn.Close()
+
+	r := newPCPRouter(conn.LocalAddr().(*net.UDPAddr), 10*time.Millisecond)
+	err = r.announce()
+	require.ErrorIs(err, errPCPNoResponse)
+}
+
+func TestEpoch(t *testing.T) {
+	start := time.Unix(1_000_000, 0)
+	tests := []struct {
+		name       string
+		serverTime uint32
+		clientTime time.Time
+		expected   bool
+	}{
+		{
+			name:       "consistent",
+			serverTime: 1060,
+			clientTime: start.Add(time.Minute),
+			expected:   true,
+		},
+		{
+			name:       "small drift",
+			serverTime: 1062,
+			clientTime: start.Add(time.Minute),
+			expected:   true,
+		},
+		{
+			name:       "reset",
+			serverTime: 5,
+			clientTime: start.Add(time.Minute),
+			expected:   false,
+		},
+		{
+			name:       "server too slow",
+			serverTime: 1010,
+			clientTime: start.Add(time.Minute),
+			expected:   false,
+		},
+		{
+			name:       "server too fast",
+			serverTime: 1200,
+			clientTime: start.Add(time.Minute),
+			expected:   false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			e := epoch{}
+			require.True(e.update(1000, start))
+			require.Equal(test.expected, e.update(test.serverTime, test.clientTime))
+		})
+	}
+}
@@ -7,11 +7,13 @@ import (
 	"errors"
 	"math"
 	"net"
+	"sync"
 	"time"
 
-	"github.com/jackpal/gateway"
-
 	natpmp "github.com/jackpal/go-nat-pmp"
+
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 )
 
 const (
@@ -33,6 +35,12 @@ var (
 // common interface.
 type pmpRouter struct {
 	client *natpmp.Client
+	clock  mockable.Clock
+
+	lock  sync.Mutex
+	epoch epoch
+	// internal ports that are mapped
+	mappings set.Set[uint16]
 }
 
 func (*pmpRouter) SupportsNAT() bool {
@@ -55,31 +63,65 @@ func (r *pmpRouter) MapPort(
 		return errInvalidLifetime
 	}
 
-	_, err := r.client.AddPortMapping(pmpProtocol, internalPort, externalPort, int(lifetime))
-	return err
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	response, err := r.client.AddPortMapping(pmpProtocol, internalPort, externalPort, int(lifetime))
+	if err != nil {
+		return err
+	}
+	r.updateEpoch(response.SecondsSinceStartOfEpoc)
+	r.mappings.Add(newInternalPort)
+	return nil
 }
 
 func (r *pmpRouter) UnmapPort(internalPort uint16, _ uint16) error {
 	internalPortInt := int(internalPort)
 
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.mappings.Remove(internalPort)
 	_, err := r.client.AddPortMapping(pmpProtocol, internalPortInt, 0, 0)
 	return err
 }
 
+// MappingExists queries the gateway's epoch to check whether it lost its
+// mappings since the previous request.
+func (r *pmpRouter) MappingExists(internalPort uint16, _ uint16) (bool, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	response, err := r.client.GetExternalAddress()
+	if err != nil {
+		return false, err
+	}
+	r.updateEpoch(response.SecondsSinceStartOfEpoc)
+	return r.mappings.Contains(internalPort), nil
+}
+
 func (r *pmpRouter) ExternalIP() (net.IP, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
 	response, err := r.client.GetExternalAddress()
 	if err != nil {
 		return nil, err
 	}
+	r.updateEpoch(response.SecondsSinceStartOfEpoc)
 	return response.ExternalIPAddress[:], nil
 }
 
-func getPMPRouter() *pmpRouter {
-	gatewayIP, err := gateway.DiscoverGateway()
-	if err != nil {
-		return nil
+// updateEpoch forgets the mappings if the gateway lost its state.
+//
+// Assumes [r.lock] is held.
+func (r *pmpRouter) updateEpoch(serverTime uint32) {
+	if !r.epoch.update(serverTime, r.clock.Time()) {
+		r.mappings.Clear()
 	}
+}
 
+func getPMPRouter(gatewayIP net.IP) *pmpRouter {
 	pmp := &pmpRouter{
 		client: natpmp.NewClientWithTimeout(gatewayIP, pmpClientTimeout),
 	}
@@ -4,6 +4,7 @@
 package nat
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"net"
@@ -12,6 +13,7 @@ import (
 	"github.com/huin/goupnp"
 	"github.com/huin/goupnp/dcps/internetgateway1"
 	"github.com/huin/goupnp/dcps/internetgateway2"
+	"github.com/huin/goupnp/soap"
 )
 
 const (
@@ -147,6 +149,19 @@ func (r *upnpRouter) UnmapPort(_, extPort uint16) error {
 	return r.client.DeletePortMapping("", extPort, upnpProtocol)
 }
 
+func (r *upnpRouter) MappingExists(intPort, extPort uint16) (bool, error) {
+	mappedPort, _, enabled, _, _, err := r.client.GetSpecificPortMappingEntry("", extPort, upnpProtocol)
+	var soapErr *soap.SOAPFaultError
+	if errors.As(err, &soapErr) {
+		// The router reports a fault if the mapping doesn't exist
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return enabled && mappedPort == intPort, nil
+}
+
 // create UPnP SOAP service client with URN
 func getUPnPClient(client goupnp.ServiceClient) upnpClient {
 	switch client.Service.ServiceType {
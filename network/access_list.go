@@ -0,0 +1,123 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+// AccessListConfig restricts the peers that the network connects to.
+//
+// A peer is denied if its IP is in [DeniedCIDRs] or its node ID is in
+// [DeniedNodeIDs]. Otherwise, if [AllowedCIDRs] is non-empty, the peer's IP
+// must be in it, and if [AllowedNodeIDs] is non-empty, the peer's node ID must
+// be in it.
+type AccessListConfig struct {
+	AllowedCIDRs   []string     `json:"allowedCIDRs"`
+	DeniedCIDRs    []string     `json:"deniedCIDRs"`
+	AllowedNodeIDs []ids.NodeID `json:"allowedNodeIDs"`
+	DeniedNodeIDs  []ids.NodeID `json:"deniedNodeIDs"`
+}
+
+// accessList enforces an AccessListConfig that can be replaced at runtime.
+type accessList struct {
+	lock           sync.RWMutex
+	config         AccessListConfig
+	allowedNets    []*net.IPNet
+	deniedNets     []*net.IPNet
+	allowedNodeIDs set.Set[ids.NodeID]
+	deniedNodeIDs  set.Set[ids.NodeID]
+}
+
+func newAccessList(config AccessListConfig) (*accessList, error) {
+	a := &accessList{}
+	return a, a.set(config)
+}
+
+// set replaces the enforced config with [config]. If [config] is invalid, the
+// previous config is kept.
+func (a *accessList) set(config AccessListConfig) error {
+	allowedNets, err := parseCIDRs(config.AllowedCIDRs)
+	if err != nil {
+		return err
+	}
+	deniedNets, err := parseCIDRs(config.DeniedCIDRs)
+	if err != nil {
+		return err
+	}
+
+	allowedNodeIDs := set.NewSet[ids.NodeID](len(config.AllowedNodeIDs))
+	allowedNodeIDs.Add(config.AllowedNodeIDs...)
+	deniedNodeIDs := set.NewSet[ids.NodeID](len(config.DeniedNodeIDs))
+	deniedNodeIDs.Add(config.DeniedNodeIDs...)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.config = config
+	a.allowedNets = allowedNets
+	a.deniedNets = deniedNets
+	a.allowedNodeIDs = allowedNodeIDs
+	a.deniedNodeIDs = deniedNodeIDs
+	return nil
+}
+
+func (a *accessList) get() AccessListConfig {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return a.config
+}
+
+// isIPAllowed returns true if connections with [ip] are allowed. If [ip] is
+// nil, it is only allowed if no CIDRs are allowlisted.
+func (a *accessList) isIPAllowed(ip net.IP) bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	if ip == nil {
+		return len(a.allowedNets) == 0
+	}
+	if containsIP(a.deniedNets, ip) {
+		return false
+	}
+	return len(a.allowedNets) == 0 || containsIP(a.allowedNets, ip)
+}
+
+// isNodeIDAllowed returns true if connections with [nodeID] are allowed.
+func (a *accessList) isNodeIDAllowed(nodeID ids.NodeID) bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	if a.deniedNodeIDs.Contains(nodeID) {
+		return false
+	}
+	return a.allowedNodeIDs.Len() == 0 || a.allowedNodeIDs.Contains(nodeID)
+}
+
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse CIDR %q: %w", cidr, err)
+		}
+		nets[i] = ipNet
+	}
+	return nets, nil
+}
+
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
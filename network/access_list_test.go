@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+func TestAccessList(t *testing.T) {
+	require := require.New(t)
+
+	a, err := newAccessList(AccessListConfig{})
+	require.NoError(err)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+
+	// Everything is allowed by default
+	require.True(a.isNodeIDAllowed(nodeID0))
+	require.True(a.isIPAllowed(net.IPv4(1, 2, 3, 4)))
+	require.True(a.isIPAllowed(nil))
+
+	config := AccessListConfig{
+		AllowedCIDRs:   []string{"10.0.0.0/8", "2001:db8::/32"},
+		DeniedCIDRs:    []string{"10.1.0.0/16"},
+		AllowedNodeIDs: []ids.NodeID{nodeID0, nodeID1},
+		DeniedNodeIDs:  []ids.NodeID{nodeID1},
+	}
+	require.NoError(a.set(config))
+	require.Equal(config, a.get())
+
+	require.True(a.isNodeIDAllowed(nodeID0))
+	// Denying takes precedence over allowing
+	require.False(a.isNodeIDAllowed(nodeID1))
+	require.False(a.isNodeIDAllowed(ids.GenerateTestNodeID()))
+
+	require.True(a.isIPAllowed(net.IPv4(10, 0, 0, 1)))
+	require.True(a.isIPAllowed(net.IPv4(10, 0, 0, 1).To4()))
+	require.True(a.isIPAllowed(net.ParseIP("2001:db8::1")))
+	require.False(a.isIPAllowed(net.IPv4(10, 1, 0, 1)))
+	require.False(a.isIPAllowed(net.IPv4(1, 2, 3, 4)))
+	require.False(a.isIPAllowed(net.ParseIP("2001:db9::1")))
+	require.False(a.isIPAllowed(nil))
+
+	// An invalid config doesn't replace the current one
+	require.Error(a.set(AccessListConfig{
+		DeniedCIDRs: []string{"10.0.0.1"},
+	}))
+	require.Equal(config, a.get())
+}
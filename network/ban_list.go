@@ -0,0 +1,216 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/prefixdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+var (
+	nodeIDBanPrefix = []byte("nodeID")
+	ipBanPrefix     = []byte("ip")
+
+	errInvalidBanIP = errors.New("invalid IP")
+)
+
+// Ban describes a node or an IP that the network refuses to connect to until
+// [Expiry].
+//
+// Exactly one of [NodeID] and [IP] is set.
+type Ban struct {
+	NodeID ids.NodeID
+	IP     net.IP
+	Expiry time.Time
+}
+
+// banList tracks the nodes and IPs that are banned. Bans are written to the
+// provided database so that they are kept across restarts.
+type banList struct {
+	nodeIDDB database.Database
+	ipDB     database.Database
+
+	lock    sync.RWMutex
+	nodeIDs map[ids.NodeID]time.Time
+	// IP in the 16-byte format --> expiry
+	ips map[string]time.Time
+}
+
+// newBanList loads the bans persisted in [db]. Bans that expired before [now]
+// are removed.
+func newBanList(db database.Database, now time.Time) (*banList, error) {
+	b := &banList{
+		nodeIDDB: prefixdb.New(nodeIDBanPrefix, db),
+		ipDB:     prefixdb.New(ipBanPrefix, db),
+		nodeIDs:  make(map[ids.NodeID]time.Time),
+		ips:      make(map[string]time.Time),
+	}
+
+	nodeIDBans, err := loadBans(b.nodeIDDB, now)
+	if err != nil {
+		return nil, err
+	}
+	for key, expiry := range nodeIDBans {
+		nodeID, err := ids.ToNodeID([]byte(key))
+		if err != nil {
+			return nil, err
+		}
+		b.nodeIDs[nodeID] = expiry
+	}
+
+	b.ips, err = loadBans(b.ipDB, now)
+	return b, err
+}
+
+// loadBans returns the unexpired bans in [db] and deletes the expired ones.
+func loadBans(db database.Database, now time.Time) (map[string]time.Time, error) {
+	var (
+		bans    = make(map[string]time.Time)
+		expired [][]byte
+		it      = db.NewIterator()
+	)
+	defer it.Release()
+
+	for it.Next() {
+		expiry, err := database.ParseTimestamp(it.Value())
+		if err != nil {
+			return nil, err
+		}
+		if !expiry.After(now) {
+			expired = append(expired, it.Key())
+			continue
+		}
+		bans[string(it.Key())] = expiry
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	for _, key := range expired {
+		if err := db.Delete(key); err != nil {
+			return nil, err
+		}
+	}
+	return bans, nil
+}
+
+func (b *banList) banNodeID(nodeID ids.NodeID, expiry time.Time) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if err := database.PutTimestamp(b.nodeIDDB, nodeID[:], expiry); err != nil {
+		return err
+	}
+	b.nodeIDs[nodeID] = expiry
+	return nil
+}
+
+func (b *banList) unbanNodeID(nodeID ids.NodeID) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if err := b.nodeIDDB.Delete(nodeID[:]); err != nil {
+		return err
+	}
+	delete(b.nodeIDs, nodeID)
+	return nil
+}
+
+func (b *banList) banIP(ip net.IP, expiry time.Time) error {
+	key, err := ipKey(ip)
+	if err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if err := database.PutTimestamp(b.ipDB, []byte(key), expiry); err != nil {
+		return err
+	}
+	b.ips[key] = expiry
+	return nil
+}
+
+func (b *banList) unbanIP(ip net.IP) error {
+	key, err := ipKey(ip)
+	if err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if err := b.ipDB.Delete([]byte(key)); err != nil {
+		return err
+	}
+	delete(b.ips, key)
+	return nil
+}
+
+func (b *banList) isNodeIDBanned(nodeID ids.NodeID, now time.Time) bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	expiry, ok := b.nodeIDs[nodeID]
+	return ok && expiry.After(now)
+}
+
+func (b *banList) isIPBanned(ip net.IP, now time.Time) bool {
+	key, err := ipKey(ip)
+	if err != nil {
+		return false
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	expiry, ok := b.ips[key]
+	return ok && expiry.After(now)
+}
+
+// list returns the bans that haven't expired by [now], sorted by expiry.
+func (b *banList) list(now time.Time) []Ban {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	bans := make([]Ban, 0, len(b.nodeIDs)+len(b.ips))
+	for nodeID, expiry := range b.nodeIDs {
+		if expiry.After(now) {
+			bans = append(bans, Ban{
+				NodeID: nodeID,
+				Expiry: expiry,
+			})
+		}
+	}
+	for ip, expiry := range b.ips {
+		if expiry.After(now) {
+			bans = append(bans, Ban{
+				IP:     net.IP(ip),
+				Expiry: expiry,
+			})
+		}
+	}
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].Expiry.Before(bans[j].Expiry)
+	})
+	return bans
+}
+
+// ipKey returns the 16-byte representation of [ip] so that an IPv4 address
+// and its IPv4-mapped IPv6 form are treated as the same IP.
+func ipKey(ip net.IP) (string, error) {
+	ip16 := ip.To16()
+	if ip16 == nil {
+		return "", errInvalidBanIP
+	}
+	return string(ip16), nil
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+func TestBanList(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1_000_000, 0)
+	db := memdb.New()
+	b, err := newBanList(db, now)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	ipv4 := net.IPv4(1, 2, 3, 4)
+	ipv6 := net.ParseIP("2001:db8::1")
+
+	require.False(b.isNodeIDBanned(nodeID, now))
+	require.False(b.isIPBanned(ipv4, now))
+	require.Empty(b.list(now))
+
+	require.NoError(b.banNodeID(nodeID, now.Add(time.Hour)))
+	require.NoError(b.banIP(ipv4.To4(), now.Add(time.Minute)))
+	require.NoError(b.banIP(ipv6, now.Add(2*time.Hour)))
+
+	require.True(b.isNodeIDBanned(nodeID, now))
+	// IPv4 addresses must match regardless of their representation
+	require.True(b.isIPBanned(ipv4, now))
+	require.True(b.isIPBanned(ipv4.To4(), now))
+	require.True(b.isIPBanned(ipv6, now))
+	require.False(b.isIPBanned(net.IPv4(1, 2, 3, 5), now))
+	require.False(b.isIPBanned(nil, now))
+
+	require.Equal(
+		[]Ban{
+			{IP: ipv4.To16(), Expiry: now.Add(time.Minute)},
+			{NodeID: nodeID, Expiry: now.Add(time.Hour)},
+			{IP: ipv6.To16(), Expiry: now.Add(2 * time.Hour)},
+		},
+		b.list(now),
+	)
+
+	// Bans expire
+	later := now.Add(time.Minute)
+	require.False(b.isIPBanned(ipv4, later))
+	require.True(b.isNodeIDBanned(nodeID, later))
+	require.Len(b.list(later), 2)
+
+	// Bans are persisted and expired bans are dropped on load
+	b, err = newBanList(db, later)
+	require.NoError(err)
+	require.False(b.isIPBanned(ipv4, later))
+	require.True(b.isNodeIDBanned(nodeID, later))
+	require.True(b.isIPBanned(ipv6, later))
+	require.Len(b.list(later), 2)
+
+	// Unbanning removes the persisted ban
+	require.NoError(b.unbanNodeID(nodeID))
+	require.NoError(b.unbanIP(ipv6))
+	require.False(b.isNodeIDBanned(nodeID, later))
+	require.False(b.isIPBanned(ipv6, later))
+
+	b, err = newBanList(db, later)
+	require.NoError(err)
+	require.Empty(b.list(later))
+
+	// Unbanning an unknown entry is a no-op
+	require.NoError(b.unbanNodeID(nodeID))
+	require.NoError(b.unbanIP(ipv4))
+
+	require.ErrorIs(b.banIP(net.IP{1, 2, 3}, later), errInvalidBanIP)
+}
@@ -8,6 +8,7 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/network/dialer"
 	"github.com/MetalBlockchain/metalgo/network/peer"
@@ -15,8 +16,10 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/uptime"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/ips"
 	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 )
 
 // HealthConfig describes parameters for network layer health checks.
@@ -99,6 +102,8 @@ type ThrottlerConfig struct {
 	InboundConnUpgradeThrottlerConfig throttling.InboundConnUpgradeThrottlerConfig `json:"inboundConnUpgradeThrottlerConfig"`
 	InboundMsgThrottlerConfig         throttling.InboundMsgThrottlerConfig         `json:"inboundMsgThrottlerConfig"`
 	OutboundMsgThrottlerConfig        throttling.MsgByteThrottlerConfig            `json:"outboundMsgThrottlerConfig"`
+	OutboundBandwidthThrottlerConfig  throttling.OutboundBandwidthThrottlerConfig  `json:"outboundBandwidthThrottlerConfig"`
+	PeerDiversityThrottlerConfig      throttling.PeerDiversityThrottlerConfig      `json:"peerDiversityThrottlerConfig"`
 	MaxInboundConnsPerSec             float64                                      `json:"maxInboundConnsPerSec"`
 }
 
@@ -125,9 +130,10 @@ type Config struct {
 	PingFrequency      time.Duration     `json:"pingFrequency"`
 	AllowPrivateIPs    bool              `json:"allowPrivateIPs"`
 
-	// CompressionEnabled will compress available outbound messages when set to
-	// true.
-	CompressionEnabled bool `json:"compressionEnabled"`
+	// CompressionType is the compression algorithm used for available
+	// outbound messages. Messages sent to peers that don't support this
+	// algorithm are compressed with gzip instead.
+	CompressionType compression.Type `json:"compressionType"`
 
 	// TLSKey is this node's TLS key that is used to sign IPs.
 	TLSKey crypto.Signer `json:"-"`
@@ -168,6 +174,10 @@ type Config struct {
 	// (there is one buffer per peer)
 	PeerWriteBufferSize int `json:"peerWriteBufferSize"`
 
+	// PeerTrafficMetricsEnabled reports the traffic of each peer, by chain
+	// and op, to Prometheus.
+	PeerTrafficMetricsEnabled bool `json:"peerTrafficMetricsEnabled"`
+
 	// Tracks the CPU/disk usage caused by processing messages of each peer.
 	ResourceTracker tracker.ResourceTracker `json:"-"`
 
@@ -181,4 +191,15 @@ type Config struct {
 
 	// Tracks which validators have been sent to which peers
 	GossipTracker peer.GossipTracker `json:"-"`
+
+	// BanDB persists the nodes and IPs that have been banned.
+	BanDB database.Database `json:"-"`
+
+	// AccessListConfig restricts the peers that the network connects to. It
+	// can be replaced at runtime with [Network.SetAccessList].
+	AccessListConfig AccessListConfig `json:"accessListConfig"`
+
+	// Clock that the network's and its peers' timers, such as gossip, pings
+	// and reconnection delays, run on.
+	Clock mockable.Clock `json:"-"`
 }
@@ -11,6 +11,8 @@ import (
 
 	"go.uber.org/zap"
 
+	"golang.org/x/net/proxy"
+
 	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/utils/ips"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
@@ -26,7 +28,7 @@ type Dialer interface {
 }
 
 type dialer struct {
-	dialer    net.Dialer
+	dialer    proxy.ContextDialer
 	log       logging.Logger
 	network   string
 	throttler throttling.DialThrottler
@@ -35,6 +37,14 @@ type dialer struct {
 type Config struct {
 	ThrottleRps       uint32        `json:"throttleRps"`
 	ConnectionTimeout time.Duration `json:"connectionTimeout"`
+
+	// SOCKS5Proxy is the host:port of a SOCKS5 proxy that connections are made
+	// through. If empty, connections are made directly.
+	SOCKS5Proxy string `json:"socks5Proxy"`
+	// SOCKS5ProxyUsername and SOCKS5ProxyPassword authenticate with
+	// [SOCKS5Proxy]. If both are empty, no authentication is used.
+	SOCKS5ProxyUsername string `json:"socks5ProxyUsername"`
+	SOCKS5ProxyPassword string `json:"-"`
 }
 
 // NewDialer returns a new Dialer that calls net.Dial with the provided network.
@@ -42,7 +52,13 @@ type Config struct {
 // [dialerConfig.connectionTimeout] gives the timeout when dialing an IP.
 // [dialerConfig.throttleRps] gives the max number of outgoing connection attempts/second.
 // If [dialerConfig.throttleRps] == 0, outgoing connections aren't rate-limited.
-func NewDialer(network string, dialerConfig Config, log logging.Logger) Dialer {
+// If [dialerConfig.SOCKS5Proxy] is set, connections are made through it.
+func NewDialer(network string, dialerConfig Config, log logging.Logger) (Dialer, error) {
+	contextDialer, err := NewContextDialer(dialerConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	var throttler throttling.DialThrottler
 	if dialerConfig.ThrottleRps <= 0 {
 		throttler = throttling.NewNoDialThrottler()
@@ -53,13 +69,101 @@ func NewDialer(network string, dialerConfig Config, log logging.Logger) Dialer {
 		"creating dialer",
 		zap.Uint32("throttleRPS", dialerConfig.ThrottleRps),
 		zap.Duration("dialTimeout", dialerConfig.ConnectionTimeout),
+		zap.String("socks5Proxy", dialerConfig.SOCKS5Proxy),
 	)
 	return &dialer{
-		dialer:    net.Dialer{Timeout: dialerConfig.ConnectionTimeout},
+		dialer:    contextDialer,
 		log:       log,
 		network:   network,
 		throttler: throttler,
+	}, nil
+}
+
+// NewContextDialer returns a dialer that connects through the SOCKS5 proxy in
+// [config], if one is set, or directly otherwise. Connection attempts time
+// out after [config.ConnectionTimeout], including the proxy handshake.
+func NewContextDialer(config Config) (proxy.ContextDialer, error) {
+	direct := &net.Dialer{Timeout: config.ConnectionTimeout}
+	if config.SOCKS5Proxy == "" {
+		return direct, nil
 	}
+
+	if _, _, err := net.SplitHostPort(config.SOCKS5Proxy); err != nil {
+		return nil, fmt.Errorf("invalid SOCKS5 proxy %q: %w", config.SOCKS5Proxy, err)
+	}
+	var auth *proxy.Auth
+	if config.SOCKS5ProxyUsername != "" || config.SOCKS5ProxyPassword != "" {
+		auth = &proxy.Auth{
+			User:     config.SOCKS5ProxyUsername,
+			Password: config.SOCKS5ProxyPassword,
+		}
+	}
+	socks5Dialer, err := proxy.SOCKS5("tcp", config.SOCKS5Proxy, auth, direct)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create SOCKS5 dialer: %w", err)
+	}
+	contextDialer, ok := socks5Dialer.(proxy.ContextDialer)
+	if !ok {
+		return nil, fmt.Errorf("SOCKS5 dialer %T doesn't support contexts", socks5Dialer)
+	}
+	return &socks5ContextDialer{
+		dialer:  contextDialer,
+		timeout: config.ConnectionTimeout,
+	}, nil
+}
+
+// socks5ContextDialer bounds the time spent connecting to and negotiating
+// with a SOCKS5 proxy.
+type socks5ContextDialer struct {
+	dialer  proxy.ContextDialer
+	timeout time.Duration
+}
+
+func (d *socks5ContextDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if d.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.timeout)
+		defer cancel()
+	}
+	conn, err := d.dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	// The underlying connection's remote address is the proxy's, so the
+	// address that was dialed is reported instead. The address isn't resolved
+	// locally because name resolution may be left to the proxy.
+	return &proxiedConn{
+		Conn: conn,
+		remoteAddr: proxiedAddr{
+			network: network,
+			address: address,
+		},
+	}, nil
+}
+
+// proxiedConn is a connection made through a proxy that reports the address
+// that the proxy connected to as its remote address.
+type proxiedConn struct {
+	net.Conn
+	remoteAddr net.Addr
+}
+
+func (c *proxiedConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+type proxiedAddr struct {
+	network string
+	address string
+}
+
+func (a proxiedAddr) Network() string {
+	return a.network
+}
+
+func (a proxiedAddr) String() string {
+	return a.address
 }
 
 func (d *dialer) Dial(ctx context.Context, ip ips.IPPort) (net.Conn, error) {
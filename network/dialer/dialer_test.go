@@ -5,6 +5,7 @@ package dialer
 
 import (
 	"context"
+	"io"
 	"net"
 	"strconv"
 	"strings"
@@ -51,7 +52,7 @@ func TestDialerCancelDial(t *testing.T) {
 	}
 
 	// Create a dialer
-	dialer := NewDialer(
+	dialer, err := NewDialer(
 		"tcp",
 		Config{
 			ThrottleRps:       10,
@@ -59,6 +60,7 @@ func TestDialerCancelDial(t *testing.T) {
 		},
 		logging.NoLog{},
 	)
+	require.NoError(err)
 
 	// Make an outgoing connection with a cancelled context
 	ctx, cancel := context.WithCancel(context.Background())
@@ -74,3 +76,164 @@ func TestDialerCancelDial(t *testing.T) {
 	close(done) // stop listener goroutine
 	_ = l.Close()
 }
+
+// Returns the address of a listener that echoes back everything it receives.
+func newEchoListener(t *testing.T) ips.IPPort {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	ip, err := ips.ToIPPort(l.Addr().String())
+	require.NoError(t, err)
+	return ip
+}
+
+func TestDialerSOCKS5(t *testing.T) {
+	tests := []struct {
+		name        string
+		server      *socks5Server
+		username    string
+		password    string
+		shouldErr   bool
+		numAttempts int
+	}{
+		{
+			name:        "no auth",
+			server:      &socks5Server{},
+			numAttempts: 3,
+		},
+		{
+			name: "auth",
+			server: &socks5Server{
+				username: "user",
+				password: "pass",
+			},
+			username:    "user",
+			password:    "pass",
+			numAttempts: 1,
+		},
+		{
+			name: "wrong password",
+			server: &socks5Server{
+				username: "user",
+				password: "pass",
+			},
+			username:    "user",
+			password:    "wrong",
+			shouldErr:   true,
+			numAttempts: 1,
+		},
+		{
+			name: "missing auth",
+			server: &socks5Server{
+				username: "user",
+				password: "pass",
+			},
+			shouldErr:   true,
+			numAttempts: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			target := newEchoListener(t)
+			proxyAddr := newSOCKS5Server(t, test.server)
+
+			dialer, err := NewDialer(
+				"tcp",
+				Config{
+					ThrottleRps:         10,
+					ConnectionTimeout:   30 * time.Second,
+					SOCKS5Proxy:         proxyAddr,
+					SOCKS5ProxyUsername: test.username,
+					SOCKS5ProxyPassword: test.password,
+				},
+				logging.NoLog{},
+			)
+			require.NoError(err)
+
+			for i := 0; i < test.numAttempts; i++ {
+				conn, err := dialer.Dial(context.Background(), target)
+				if test.shouldErr {
+					require.Error(err)
+					continue
+				}
+				require.NoError(err)
+
+				// The remote address is the peer's rather than the proxy's.
+				require.Equal(target.String(), conn.RemoteAddr().String())
+
+				msg := []byte("hello")
+				_, err = conn.Write(msg)
+				require.NoError(err)
+				reply := make([]byte, len(msg))
+				_, err = io.ReadFull(conn, reply)
+				require.NoError(err)
+				require.Equal(msg, reply)
+				require.NoError(conn.Close())
+			}
+
+			if test.shouldErr {
+				require.Empty(test.server.dialedTargets())
+				return
+			}
+			for _, dialed := range test.server.dialedTargets() {
+				require.Equal(target.String(), dialed)
+			}
+			require.Len(test.server.dialedTargets(), test.numAttempts)
+		})
+	}
+}
+
+// Test that the connection timeout covers the SOCKS5 handshake
+func TestDialerSOCKS5Timeout(t *testing.T) {
+	require := require.New(t)
+
+	proxyAddr := newSOCKS5Server(t, &socks5Server{
+		unresponsive: true,
+	})
+	dialer, err := NewDialer(
+		"tcp",
+		Config{
+			ConnectionTimeout: 100 * time.Millisecond,
+			SOCKS5Proxy:       proxyAddr,
+		},
+		logging.NoLog{},
+	)
+	require.NoError(err)
+
+	start := time.Now()
+	_, err = dialer.Dial(context.Background(), ips.IPPort{
+		IP:   net.IPv4(127, 0, 0, 1),
+		Port: 9651,
+	})
+	require.Error(err)
+	require.Less(time.Since(start), 10*time.Second)
+}
+
+func TestNewDialerInvalidSOCKS5Proxy(t *testing.T) {
+	_, err := NewDialer(
+		"tcp",
+		Config{
+			SOCKS5Proxy: "127.0.0.1",
+		},
+		logging.NoLog{},
+	)
+	require.Error(t, err)
+}
@@ -0,0 +1,218 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dialer
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	socks5Version         = 0x05
+	socks5NoAuth          = 0x00
+	socks5UserPassAuth    = 0x02
+	socks5NoAcceptable    = 0xff
+	socks5UserPassVersion = 0x01
+	socks5Connect         = 0x01
+	socks5IPv4            = 0x01
+	socks5DomainName      = 0x03
+	socks5IPv6            = 0x04
+	socks5Succeeded       = 0x00
+	socks5Failure         = 0x01
+)
+
+var errUnsupportedSOCKS5Request = errors.New("unsupported SOCKS5 request")
+
+// socks5Server is a minimal SOCKS5 proxy that only supports the CONNECT
+// command.
+type socks5Server struct {
+	// If non-empty, clients must authenticate with these credentials.
+	username string
+	password string
+	// If true, the server never responds to the client's greeting.
+	unresponsive bool
+
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	lock sync.Mutex
+	// Addresses that clients asked to connect to
+	targets []string
+}
+
+func newSOCKS5Server(t *testing.T, s *socks5Server) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s.listener = listener
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				defer conn.Close()
+
+				if s.unresponsive {
+					_, _ = io.Copy(io.Discard, conn)
+					return
+				}
+				_ = s.serve(conn)
+			}()
+		}
+	}()
+	t.Cleanup(func() {
+		_ = listener.Close()
+		s.wg.Wait()
+	})
+	return listener.Addr().String()
+}
+
+func (s *socks5Server) serve(conn net.Conn) error {
+	// Greeting: version, number of methods, methods
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return err
+	}
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return err
+	}
+
+	method := byte(socks5NoAuth)
+	if s.username != "" || s.password != "" {
+		method = socks5UserPassAuth
+	}
+	if !containsByte(methods, method) {
+		_, _ = conn.Write([]byte{socks5Version, socks5NoAcceptable})
+		return errUnsupportedSOCKS5Request
+	}
+	if _, err := conn.Write([]byte{socks5Version, method}); err != nil {
+		return err
+	}
+
+	if method == socks5UserPassAuth {
+		if err := s.authenticate(conn); err != nil {
+			return err
+		}
+	}
+
+	// Request: version, command, reserved, address type, address, port
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(conn, request); err != nil {
+		return err
+	}
+	if request[1] != socks5Connect {
+		return errUnsupportedSOCKS5Request
+	}
+	var host string
+	switch request[3] {
+	case socks5IPv4, socks5IPv6:
+		ipLen := net.IPv4len
+		if request[3] == socks5IPv6 {
+			ipLen = net.IPv6len
+		}
+		ip := make(net.IP, ipLen)
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return err
+		}
+		host = ip.String()
+	case socks5DomainName:
+		nameLen := make([]byte, 1)
+		if _, err := io.ReadFull(conn, nameLen); err != nil {
+			return err
+		}
+		name := make([]byte, nameLen[0])
+		if _, err := io.ReadFull(conn, name); err != nil {
+			return err
+		}
+		host = string(name)
+	default:
+		return errUnsupportedSOCKS5Request
+	}
+	portBytes := make([]byte, 2)
+	if _, err := io.ReadFull(conn, portBytes); err != nil {
+		return err
+	}
+	target := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(portBytes))))
+
+	s.lock.Lock()
+	s.targets = append(s.targets, target)
+	s.lock.Unlock()
+
+	targetConn, err := net.Dial("tcp", target)
+	if err != nil {
+		_, _ = conn.Write([]byte{socks5Version, socks5Failure, 0, socks5IPv4, 0, 0, 0, 0, 0, 0})
+		return err
+	}
+	defer targetConn.Close()
+
+	if _, err := conn.Write([]byte{socks5Version, socks5Succeeded, 0, socks5IPv4, 0, 0, 0, 0, 0, 0}); err != nil {
+		return err
+	}
+
+	go func() {
+		_, _ = io.Copy(targetConn, conn)
+		_ = targetConn.Close()
+	}()
+	_, err = io.Copy(conn, targetConn)
+	return err
+}
+
+func (s *socks5Server) authenticate(conn net.Conn) error {
+	// Version, username length, username, password length, password
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return err
+	}
+	username := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, username); err != nil {
+		return err
+	}
+	passwordLen := make([]byte, 1)
+	if _, err := io.ReadFull(conn, passwordLen); err != nil {
+		return err
+	}
+	password := make([]byte, passwordLen[0])
+	if _, err := io.ReadFull(conn, password); err != nil {
+		return err
+	}
+
+	if string(username) != s.username || string(password) != s.password {
+		_, _ = conn.Write([]byte{socks5UserPassVersion, socks5Failure})
+		return errUnsupportedSOCKS5Request
+	}
+	_, err := conn.Write([]byte{socks5UserPassVersion, socks5Succeeded})
+	return err
+}
+
+func (s *socks5Server) dialedTargets() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return append([]string(nil), s.targets...)
+}
+
+func containsByte(bytes []byte, b byte) bool {
+	for _, elt := range bytes {
+		if elt == b {
+			return true
+		}
+	}
+	return false
+}
@@ -16,6 +16,13 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/wrappers"
 )
 
+// Stages at which a connection can be denied by the access list
+const (
+	acceptStage    = "accept"
+	handshakeStage = "handshake"
+	dialStage      = "dial"
+)
+
 type metrics struct {
 	numTracked                      prometheus.Gauge
 	numPeers                        prometheus.Gauge
@@ -29,6 +36,10 @@ type metrics struct {
 	acceptFailed                    prometheus.Counter
 	inboundConnRateLimited          prometheus.Counter
 	inboundConnAllowed              prometheus.Counter
+	inboundConnBanned               prometheus.Counter
+	connDiversityLimited            prometheus.Counter
+	connAccessDenied                *prometheus.CounterVec
+	dialDiversityDeferred           prometheus.Counter
 	numUselessPeerListBytes         prometheus.Counter
 	nodeUptimeWeightedAverage       prometheus.Gauge
 	nodeUptimeRewardingStake        prometheus.Gauge
@@ -101,6 +112,11 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 			Name:      "inbound_conn_throttler_allowed",
 			Help:      "Times this node allowed (attempted to upgrade) an inbound connection",
 		}),
+		inboundConnBanned: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "inbound_conn_banned",
+			Help:      "Times this node rejected an inbound connection from a banned IP",
+		}),
 		numUselessPeerListBytes: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: namespace,
 			Name:      "num_useless_peerlist_bytes",
@@ -111,6 +127,24 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 			Name:      "inbound_conn_throttler_rate_limited",
 			Help:      "Times this node rejected an inbound connection due to rate-limiting",
 		}),
+		connDiversityLimited: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "conn_diversity_limited",
+			Help:      "Times this node dropped a connection because too many peers share its IP prefix or ASN",
+		}),
+		dialDiversityDeferred: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "dial_diversity_deferred",
+			Help:      "Times this node deferred dialing a peer because too many peers share its IP prefix or ASN",
+		}),
+		connAccessDenied: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "conn_access_denied",
+				Help:      "Times this node rejected a connection because the peer is denied by the access list",
+			},
+			[]string{"stage"},
+		),
 		nodeUptimeWeightedAverage: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Name:      "node_uptime_weighted_average",
@@ -160,8 +194,12 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 		registerer.Register(m.disconnected),
 		registerer.Register(m.acceptFailed),
 		registerer.Register(m.inboundConnAllowed),
+		registerer.Register(m.inboundConnBanned),
 		registerer.Register(m.numUselessPeerListBytes),
 		registerer.Register(m.inboundConnRateLimited),
+		registerer.Register(m.connDiversityLimited),
+		registerer.Register(m.dialDiversityDeferred),
+		registerer.Register(m.connAccessDenied),
 		registerer.Register(m.nodeUptimeWeightedAverage),
 		registerer.Register(m.nodeUptimeRewardingStake),
 		registerer.Register(m.nodeSubnetUptimeWeightedAverage),
@@ -0,0 +1,377 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/MetalBlockchain/metalgo/network (interfaces: Network)
+
+// Package network is a generated GoMock package.
+package network
+
+import (
+	context "context"
+	net "net"
+	reflect "reflect"
+	time "time"
+
+	ids "github.com/MetalBlockchain/metalgo/ids"
+	message "github.com/MetalBlockchain/metalgo/message"
+	peer "github.com/MetalBlockchain/metalgo/network/peer"
+	p2p "github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	subnets "github.com/MetalBlockchain/metalgo/subnets"
+	ips "github.com/MetalBlockchain/metalgo/utils/ips"
+	set "github.com/MetalBlockchain/metalgo/utils/set"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNetwork is a mock of Network interface.
+type MockNetwork struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetworkMockRecorder
+}
+
+// MockNetworkMockRecorder is the mock recorder for MockNetwork.
+type MockNetworkMockRecorder struct {
+	mock *MockNetwork
+}
+
+// NewMockNetwork creates a new mock instance.
+func NewMockNetwork(ctrl *gomock.Controller) *MockNetwork {
+	mock := &MockNetwork{ctrl: ctrl}
+	mock.recorder = &MockNetworkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNetwork) EXPECT() *MockNetworkMockRecorder {
+	return m.recorder
+}
+
+// AccessList mocks base method.
+func (m *MockNetwork) AccessList() AccessListConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccessList")
+	ret0, _ := ret[0].(AccessListConfig)
+	return ret0
+}
+
+// AccessList indicates an expected call of AccessList.
+func (mr *MockNetworkMockRecorder) AccessList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccessList", reflect.TypeOf((*MockNetwork)(nil).AccessList))
+}
+
+// AllowConnection mocks base method.
+func (m *MockNetwork) AllowConnection(arg0 ids.NodeID) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllowConnection", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// AllowConnection indicates an expected call of AllowConnection.
+func (mr *MockNetworkMockRecorder) AllowConnection(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllowConnection", reflect.TypeOf((*MockNetwork)(nil).AllowConnection), arg0)
+}
+
+// BanIP mocks base method.
+func (m *MockNetwork) BanIP(arg0 net.IP, arg1 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanIP", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BanIP indicates an expected call of BanIP.
+func (mr *MockNetworkMockRecorder) BanIP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanIP", reflect.TypeOf((*MockNetwork)(nil).BanIP), arg0, arg1)
+}
+
+// BanNodeID mocks base method.
+func (m *MockNetwork) BanNodeID(arg0 ids.NodeID, arg1 time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanNodeID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BanNodeID indicates an expected call of BanNodeID.
+func (mr *MockNetworkMockRecorder) BanNodeID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanNodeID", reflect.TypeOf((*MockNetwork)(nil).BanNodeID), arg0, arg1)
+}
+
+// Bans mocks base method.
+func (m *MockNetwork) Bans() []Ban {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bans")
+	ret0, _ := ret[0].([]Ban)
+	return ret0
+}
+
+// Bans indicates an expected call of Bans.
+func (mr *MockNetworkMockRecorder) Bans() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bans", reflect.TypeOf((*MockNetwork)(nil).Bans))
+}
+
+// Connected mocks base method.
+func (m *MockNetwork) Connected(arg0 ids.NodeID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Connected", arg0)
+}
+
+// Connected indicates an expected call of Connected.
+func (mr *MockNetworkMockRecorder) Connected(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connected", reflect.TypeOf((*MockNetwork)(nil).Connected), arg0)
+}
+
+// Disconnect mocks base method.
+func (m *MockNetwork) Disconnect(arg0 ids.NodeID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Disconnect", arg0)
+}
+
+// Disconnect indicates an expected call of Disconnect.
+func (mr *MockNetworkMockRecorder) Disconnect(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnect", reflect.TypeOf((*MockNetwork)(nil).Disconnect), arg0)
+}
+
+// Disconnected mocks base method.
+func (m *MockNetwork) Disconnected(arg0 ids.NodeID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Disconnected", arg0)
+}
+
+// Disconnected indicates an expected call of Disconnected.
+func (mr *MockNetworkMockRecorder) Disconnected(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnected", reflect.TypeOf((*MockNetwork)(nil).Disconnected), arg0)
+}
+
+// Dispatch mocks base method.
+func (m *MockNetwork) Dispatch() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dispatch")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Dispatch indicates an expected call of Dispatch.
+func (mr *MockNetworkMockRecorder) Dispatch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dispatch", reflect.TypeOf((*MockNetwork)(nil).Dispatch))
+}
+
+// Gossip mocks base method.
+func (m *MockNetwork) Gossip(arg0 message.OutboundMessage, arg1 ids.ID, arg2, arg3, arg4 int, arg5 subnets.Allower) set.Set[ids.NodeID] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Gossip", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(set.Set[ids.NodeID])
+	return ret0
+}
+
+// Gossip indicates an expected call of Gossip.
+func (mr *MockNetworkMockRecorder) Gossip(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Gossip", reflect.TypeOf((*MockNetwork)(nil).Gossip), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// HealthCheck mocks base method.
+func (m *MockNetwork) HealthCheck(arg0 context.Context) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HealthCheck", arg0)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HealthCheck indicates an expected call of HealthCheck.
+func (mr *MockNetworkMockRecorder) HealthCheck(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockNetwork)(nil).HealthCheck), arg0)
+}
+
+// ManuallyTrack mocks base method.
+func (m *MockNetwork) ManuallyTrack(arg0 ids.NodeID, arg1 ips.IPPort) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ManuallyTrack", arg0, arg1)
+}
+
+// ManuallyTrack indicates an expected call of ManuallyTrack.
+func (mr *MockNetworkMockRecorder) ManuallyTrack(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ManuallyTrack", reflect.TypeOf((*MockNetwork)(nil).ManuallyTrack), arg0, arg1)
+}
+
+// MarkTracked mocks base method.
+func (m *MockNetwork) MarkTracked(arg0 ids.NodeID, arg1 []*p2p.PeerAck) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkTracked", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkTracked indicates an expected call of MarkTracked.
+func (mr *MockNetworkMockRecorder) MarkTracked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkTracked", reflect.TypeOf((*MockNetwork)(nil).MarkTracked), arg0, arg1)
+}
+
+// NodeUptime mocks base method.
+func (m *MockNetwork) NodeUptime(arg0 ids.ID) (UptimeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NodeUptime", arg0)
+	ret0, _ := ret[0].(UptimeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NodeUptime indicates an expected call of NodeUptime.
+func (mr *MockNetworkMockRecorder) NodeUptime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeUptime", reflect.TypeOf((*MockNetwork)(nil).NodeUptime), arg0)
+}
+
+// PeerCapabilities mocks base method.
+func (m *MockNetwork) PeerCapabilities(arg0 ids.NodeID) set.Set[string] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerCapabilities", arg0)
+	ret0, _ := ret[0].(set.Set[string])
+	return ret0
+}
+
+// PeerCapabilities indicates an expected call of PeerCapabilities.
+func (mr *MockNetworkMockRecorder) PeerCapabilities(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerCapabilities", reflect.TypeOf((*MockNetwork)(nil).PeerCapabilities), arg0)
+}
+
+// PeerInfo mocks base method.
+func (m *MockNetwork) PeerInfo(arg0 []ids.NodeID) []peer.Info {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerInfo", arg0)
+	ret0, _ := ret[0].([]peer.Info)
+	return ret0
+}
+
+// PeerInfo indicates an expected call of PeerInfo.
+func (mr *MockNetworkMockRecorder) PeerInfo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerInfo", reflect.TypeOf((*MockNetwork)(nil).PeerInfo), arg0)
+}
+
+// Peers mocks base method.
+func (m *MockNetwork) Peers(arg0 ids.NodeID) ([]ips.ClaimedIPPort, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Peers", arg0)
+	ret0, _ := ret[0].([]ips.ClaimedIPPort)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Peers indicates an expected call of Peers.
+func (mr *MockNetworkMockRecorder) Peers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peers", reflect.TypeOf((*MockNetwork)(nil).Peers), arg0)
+}
+
+// Send mocks base method.
+func (m *MockNetwork) Send(arg0 message.OutboundMessage, arg1 set.Set[ids.NodeID], arg2 ids.ID, arg3 subnets.Allower) set.Set[ids.NodeID] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(set.Set[ids.NodeID])
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockNetworkMockRecorder) Send(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockNetwork)(nil).Send), arg0, arg1, arg2, arg3)
+}
+
+// SetAccessList mocks base method.
+func (m *MockNetwork) SetAccessList(arg0 AccessListConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAccessList", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAccessList indicates an expected call of SetAccessList.
+func (mr *MockNetworkMockRecorder) SetAccessList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccessList", reflect.TypeOf((*MockNetwork)(nil).SetAccessList), arg0)
+}
+
+// StartClose mocks base method.
+func (m *MockNetwork) StartClose() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StartClose")
+}
+
+// StartClose indicates an expected call of StartClose.
+func (mr *MockNetworkMockRecorder) StartClose() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartClose", reflect.TypeOf((*MockNetwork)(nil).StartClose))
+}
+
+// Track mocks base method.
+func (m *MockNetwork) Track(arg0 ids.NodeID, arg1 []*ips.ClaimedIPPort) ([]*p2p.PeerAck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Track", arg0, arg1)
+	ret0, _ := ret[0].([]*p2p.PeerAck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Track indicates an expected call of Track.
+func (mr *MockNetworkMockRecorder) Track(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Track", reflect.TypeOf((*MockNetwork)(nil).Track), arg0, arg1)
+}
+
+// UnbanIP mocks base method.
+func (m *MockNetwork) UnbanIP(arg0 net.IP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbanIP", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnbanIP indicates an expected call of UnbanIP.
+func (mr *MockNetworkMockRecorder) UnbanIP(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanIP", reflect.TypeOf((*MockNetwork)(nil).UnbanIP), arg0)
+}
+
+// UnbanNodeID mocks base method.
+func (m *MockNetwork) UnbanNodeID(arg0 ids.NodeID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbanNodeID", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnbanNodeID indicates an expected call of UnbanNodeID.
+func (mr *MockNetworkMockRecorder) UnbanNodeID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanNodeID", reflect.TypeOf((*MockNetwork)(nil).UnbanNodeID), arg0)
+}
+
+// WantsConnection mocks base method.
+func (m *MockNetwork) WantsConnection(arg0 ids.NodeID) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WantsConnection", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// WantsConnection indicates an expected call of WantsConnection.
+func (mr *MockNetworkMockRecorder) WantsConnection(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WantsConnection", reflect.TypeOf((*MockNetwork)(nil).WantsConnection), arg0)
+}
@@ -61,6 +61,7 @@ var (
 	errSubnetNotExist           = errors.New("subnet does not exist")
 	errExpectedProxy            = errors.New("expected proxy")
 	errExpectedTCPProtocol      = errors.New("expected TCP protocol")
+	errInvalidBanDuration       = errors.New("ban duration must be positive")
 )
 
 // Network defines the functionality of the networking library.
@@ -88,15 +89,48 @@ type Network interface {
 	// validator or beacon.
 	WantsConnection(ids.NodeID) bool
 
-	// Attempt to connect to this IP. The network will never stop attempting to
-	// connect to this ID.
+	// Attempt to connect to this IP. The network will not stop attempting to
+	// connect to this ID unless [Disconnect] is called or the node is banned.
 	ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort)
 
+	// Disconnect stops attempting to connect to [nodeID] and closes the
+	// current connection to it, if any. The peer is able to reconnect unless
+	// it is banned.
+	Disconnect(nodeID ids.NodeID)
+
+	// BanNodeID disconnects from [nodeID] and refuses all connections with it
+	// for [duration]. The ban is kept across restarts.
+	BanNodeID(nodeID ids.NodeID, duration time.Duration) error
+
+	// BanIP disconnects from all peers using [ip] and refuses all connections
+	// with [ip] for [duration]. The ban is kept across restarts.
+	BanIP(ip net.IP, duration time.Duration) error
+
+	// UnbanNodeID removes the ban of [nodeID], if any.
+	UnbanNodeID(nodeID ids.NodeID) error
+
+	// UnbanIP removes the ban of [ip], if any.
+	UnbanIP(ip net.IP) error
+
+	// Bans returns the bans that are currently in effect.
+	Bans() []Ban
+
+	// SetAccessList replaces the access list with [config] and disconnects
+	// from all peers that it denies.
+	SetAccessList(config AccessListConfig) error
+
+	// AccessList returns the access list that is currently in effect.
+	AccessList() AccessListConfig
+
 	// PeerInfo returns information about peers. If [nodeIDs] is empty, returns
 	// info about all peers that have finished the handshake. Otherwise, returns
 	// info about the peers in [nodeIDs] that have finished the handshake.
 	PeerInfo(nodeIDs []ids.NodeID) []peer.Info
 
+	// PeerCapabilities returns the capabilities that were negotiated with
+	// [nodeID]. Returns an empty set if [nodeID] isn't connected.
+	PeerCapabilities(nodeID ids.NodeID) set.Set[string]
+
 	// NodeUptime returns given node's [subnetID] UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime(subnetID ids.ID) (UptimeResult, error)
@@ -127,6 +161,8 @@ type network struct {
 
 	// Limits the number of connection attempts based on IP.
 	inboundConnUpgradeThrottler throttling.InboundConnUpgradeThrottler
+	// Limits the number of peers that share an IP prefix or ASN.
+	peerDiversityThrottler throttling.PeerDiversityThrottler
 	// Listens for and accepts new inbound connections
 	listener net.Listener
 	// Makes new outbound connections
@@ -159,9 +195,13 @@ type network struct {
 	// finished the handshake.
 	trackedIPs         map[ids.NodeID]*trackedIP
 	manuallyTrackedIDs set.Set[ids.NodeID]
-	connectingPeers    peer.Set
-	connectedPeers     peer.Set
-	closing            bool
+	// banList contains the nodes and IPs this node refuses to connect to.
+	banList *banList
+	// accessList restricts the nodes and IPs this node connects to.
+	accessList      *accessList
+	connectingPeers peer.Set
+	connectedPeers  peer.Set
+	closing         bool
 
 	// router is notified about all peer [Connected] and [Disconnected] events
 	// as well as all non-handshake peer messages.
@@ -241,11 +281,34 @@ func NewNetwork(
 		return nil, fmt.Errorf("initializing outbound message throttler failed with: %w", err)
 	}
 
+	outboundBandwidthThrottler, err := throttling.NewOutboundBandwidthThrottler(
+		log,
+		config.Namespace,
+		metricsRegisterer,
+		config.ThrottlerConfig.OutboundBandwidthThrottlerConfig,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing outbound bandwidth throttler failed with: %w", err)
+	}
+
+	peerDiversityThrottler, err := throttling.NewPeerDiversityThrottler(config.ThrottlerConfig.PeerDiversityThrottlerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("initializing peer diversity throttler failed with: %w", err)
+	}
+
 	peerMetrics, err := peer.NewMetrics(log, config.Namespace, metricsRegisterer)
 	if err != nil {
 		return nil, fmt.Errorf("initializing peer metrics failed with: %w", err)
 	}
 
+	var peerTrafficMetrics *peer.TrafficMetrics
+	if config.PeerTrafficMetricsEnabled {
+		peerTrafficMetrics, err = peer.NewTrafficMetrics(config.Namespace, metricsRegisterer)
+		if err != nil {
+			return nil, fmt.Errorf("initializing peer traffic metrics failed with: %w", err)
+		}
+	}
+
 	metrics, err := newMetrics(config.Namespace, metricsRegisterer, config.TrackedSubnets)
 	if err != nil {
 		return nil, fmt.Errorf("initializing network metrics failed with: %w", err)
@@ -255,22 +318,36 @@ func NewNetwork(
 		ReadBufferSize:  config.PeerReadBufferSize,
 		WriteBufferSize: config.PeerWriteBufferSize,
 		Metrics:         peerMetrics,
+		TrafficMetrics:  peerTrafficMetrics,
 		MessageCreator:  msgCreator,
 
-		Log:                  log,
-		InboundMsgThrottler:  inboundMsgThrottler,
-		Network:              nil, // This is set below.
-		Router:               router,
-		VersionCompatibility: version.GetCompatibility(config.NetworkID),
-		MySubnets:            config.TrackedSubnets,
-		Beacons:              config.Beacons,
-		NetworkID:            config.NetworkID,
-		PingFrequency:        config.PingFrequency,
-		PongTimeout:          config.PingPongTimeout,
-		MaxClockDifference:   config.MaxClockDifference,
-		ResourceTracker:      config.ResourceTracker,
-		UptimeCalculator:     config.UptimeCalculator,
-		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey),
+		Log:                        log,
+		InboundMsgThrottler:        inboundMsgThrottler,
+		OutboundBandwidthThrottler: outboundBandwidthThrottler,
+		Network:                    nil, // This is set below.
+		Router:                     router,
+		VersionCompatibility:       version.GetCompatibility(config.NetworkID),
+		MySubnets:                  config.TrackedSubnets,
+		MyCapabilities:             message.SupportedCapabilities(),
+		Beacons:                    config.Beacons,
+		NetworkID:                  config.NetworkID,
+		PingFrequency:              config.PingFrequency,
+		PongTimeout:                config.PingPongTimeout,
+		MaxClockDifference:         config.MaxClockDifference,
+		ResourceTracker:            config.ResourceTracker,
+		UptimeCalculator:           config.UptimeCalculator,
+		IPSigner:                   peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.Clock),
+		Clock:                      config.Clock,
+	}
+
+	banList, err := newBanList(config.BanDB, peerConfig.Clock.Time())
+	if err != nil {
+		return nil, fmt.Errorf("initializing ban list failed with: %w", err)
+	}
+
+	accessList, err := newAccessList(config.AccessListConfig)
+	if err != nil {
+		return nil, fmt.Errorf("initializing access list failed with: %w", err)
 	}
 
 	onCloseCtx, cancel := context.WithCancel(context.Background())
@@ -281,6 +358,7 @@ func NewNetwork(
 		outboundMsgThrottler: outboundMsgThrottler,
 
 		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
+		peerDiversityThrottler:      peerDiversityThrottler,
 		listener:                    listener,
 		dialer:                      dialer,
 		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig),
@@ -292,11 +370,13 @@ func NewNetwork(
 		sendFailRateCalculator: math.NewSyncAverager(math.NewAverager(
 			0,
 			config.SendFailRateHalflife,
-			time.Now(),
+			config.Clock.Time(),
 		)),
 
 		peerIPs:         make(map[ids.NodeID]*ips.ClaimedIPPort),
 		trackedIPs:      make(map[ids.NodeID]*trackedIP),
+		banList:         banList,
+		accessList:      accessList,
 		gossipTracker:   config.GossipTracker,
 		connectingPeers: peer.NewSet(),
 		connectedPeers:  peer.NewSet(),
@@ -406,6 +486,29 @@ func (n *network) Connected(nodeID ids.NodeID) {
 		return
 	}
 
+	if n.isBanned(peer) {
+		n.peersLock.Unlock()
+
+		n.peerConfig.Log.Debug("dropping connection",
+			zap.String("reason", "peer is banned"),
+			zap.Stringer("nodeID", nodeID),
+		)
+		peer.StartClose()
+		return
+	}
+
+	if n.isDenied(peer) {
+		n.peersLock.Unlock()
+
+		n.peerConfig.Log.Debug("dropping connection",
+			zap.String("reason", "denied by access list"),
+			zap.Stringer("nodeID", nodeID),
+		)
+		n.metrics.connAccessDenied.WithLabelValues(handshakeStage).Inc()
+		peer.StartClose()
+		return
+	}
+
 	peerIP := peer.IP()
 	newIP := &ips.ClaimedIPPort{
 		Cert:      peer.Cert(),
@@ -454,6 +557,9 @@ func (n *network) Connected(nodeID ids.NodeID) {
 // of peers, then it should only connect if this node is a validator, or the
 // peer is a validator/beacon.
 func (n *network) AllowConnection(nodeID ids.NodeID) bool {
+	if n.banList.isNodeIDBanned(nodeID, n.peerConfig.Clock.Time()) {
+		return false
+	}
 	return !n.config.RequireValidatorToConnect ||
 		validators.Contains(n.config.Validators, constants.PrimaryNetworkID, n.config.MyNodeID) ||
 		n.WantsConnection(nodeID)
@@ -757,6 +863,26 @@ func (n *network) Dispatch() error {
 				return
 			}
 
+			if n.banList.isIPBanned(ip.IP, n.peerConfig.Clock.Time()) {
+				n.peerConfig.Log.Debug("failed to upgrade connection",
+					zap.String("reason", "banned"),
+					zap.Stringer("peerIP", ip),
+				)
+				n.metrics.inboundConnBanned.Inc()
+				_ = conn.Close()
+				return
+			}
+
+			if !n.accessList.isIPAllowed(ip.IP) {
+				n.peerConfig.Log.Debug("failed to upgrade connection",
+					zap.String("reason", "denied by access list"),
+					zap.Stringer("peerIP", ip),
+				)
+				n.metrics.connAccessDenied.WithLabelValues(acceptStage).Inc()
+				_ = conn.Close()
+				return
+			}
+
 			if !n.inboundConnUpgradeThrottler.ShouldUpgrade(ip) {
 				n.peerConfig.Log.Debug("failed to upgrade connection",
 					zap.String("reason", "rate-limiting"),
@@ -803,6 +929,9 @@ func (n *network) WantsConnection(nodeID ids.NodeID) bool {
 }
 
 func (n *network) wantsConnection(nodeID ids.NodeID) bool {
+	if n.banList.isNodeIDBanned(nodeID, n.peerConfig.Clock.Time()) {
+		return false
+	}
 	return validators.Contains(n.config.Validators, constants.PrimaryNetworkID, nodeID) ||
 		n.manuallyTrackedIDs.Contains(nodeID)
 }
@@ -829,6 +958,136 @@ func (n *network) ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort) {
 	}
 }
 
+func (n *network) Disconnect(nodeID ids.NodeID) {
+	n.peersLock.Lock()
+	n.manuallyTrackedIDs.Remove(nodeID)
+	if tracked, ok := n.trackedIPs[nodeID]; ok {
+		tracked.stopTracking()
+		delete(n.trackedIPs, nodeID)
+	}
+	connectingPeer, connecting := n.connectingPeers.GetByID(nodeID)
+	connectedPeer, connected := n.connectedPeers.GetByID(nodeID)
+	n.peersLock.Unlock()
+
+	if connecting {
+		connectingPeer.StartClose()
+	}
+	if connected {
+		connectedPeer.StartClose()
+	}
+}
+
+func (n *network) BanNodeID(nodeID ids.NodeID, duration time.Duration) error {
+	if duration <= 0 {
+		return errInvalidBanDuration
+	}
+
+	expiry := n.peerConfig.Clock.Time().Add(duration)
+	if err := n.banList.banNodeID(nodeID, expiry); err != nil {
+		return err
+	}
+
+	n.peerConfig.Log.Info("banned node",
+		zap.Stringer("nodeID", nodeID),
+		zap.Time("expiry", expiry),
+	)
+	n.Disconnect(nodeID)
+	return nil
+}
+
+func (n *network) BanIP(ip net.IP, duration time.Duration) error {
+	if duration <= 0 {
+		return errInvalidBanDuration
+	}
+
+	expiry := n.peerConfig.Clock.Time().Add(duration)
+	if err := n.banList.banIP(ip, expiry); err != nil {
+		return err
+	}
+
+	n.peerConfig.Log.Info("banned IP",
+		zap.Stringer("ip", ip),
+		zap.Time("expiry", expiry),
+	)
+
+	// Peers that are still connecting are dropped once they finish the
+	// handshake. Outbound connection attempts to [ip] are stopped the next time
+	// they are retried.
+	n.peersLock.RLock()
+	banned := n.connectedPeers.Sample(n.connectedPeers.Len(), n.isBanned)
+	n.peersLock.RUnlock()
+
+	for _, peer := range banned {
+		peer.StartClose()
+	}
+	return nil
+}
+
+func (n *network) UnbanNodeID(nodeID ids.NodeID) error {
+	return n.banList.unbanNodeID(nodeID)
+}
+
+func (n *network) UnbanIP(ip net.IP) error {
+	return n.banList.unbanIP(ip)
+}
+
+func (n *network) Bans() []Ban {
+	return n.banList.list(n.peerConfig.Clock.Time())
+}
+
+func (n *network) SetAccessList(config AccessListConfig) error {
+	if err := n.accessList.set(config); err != nil {
+		return err
+	}
+
+	n.peerConfig.Log.Info("updated access list",
+		zap.Strings("allowedCIDRs", config.AllowedCIDRs),
+		zap.Strings("deniedCIDRs", config.DeniedCIDRs),
+		zap.Int("numAllowedNodeIDs", len(config.AllowedNodeIDs)),
+		zap.Int("numDeniedNodeIDs", len(config.DeniedNodeIDs)),
+	)
+
+	// Peers that are still connecting are dropped once they finish the
+	// handshake. Outbound connection attempts to denied peers are stopped the
+	// next time they are retried.
+	n.peersLock.RLock()
+	denied := n.connectedPeers.Sample(n.connectedPeers.Len(), n.isDenied)
+	n.peersLock.RUnlock()
+
+	for _, peer := range denied {
+		peer.StartClose()
+	}
+	return nil
+}
+
+func (n *network) AccessList() AccessListConfig {
+	return n.accessList.get()
+}
+
+// isDenied returns true if the nodeID or the remote IP of [p] is denied by the
+// access list. [p] must have finished the handshake.
+func (n *network) isDenied(p peer.Peer) bool {
+	if !n.accessList.isNodeIDAllowed(p.ID()) {
+		return true
+	}
+	remoteIP, err := ips.ToIPPort(p.Info().IP)
+	return err != nil || !n.accessList.isIPAllowed(remoteIP.IP)
+}
+
+// isBanned returns true if the nodeID, the signed IP, or the remote IP of [p]
+// is banned. [p] must have finished the handshake.
+func (n *network) isBanned(p peer.Peer) bool {
+	now := n.peerConfig.Clock.Time()
+	if n.banList.isNodeIDBanned(p.ID(), now) {
+		return true
+	}
+	if signedIP := p.IP(); signedIP != nil && n.banList.isIPBanned(signedIP.IPPort.IP, now) {
+		return true
+	}
+	remoteIP, err := ips.ToIPPort(p.Info().IP)
+	return err == nil && n.banList.isIPBanned(remoteIP.IP, now)
+}
+
 // getPeers returns a slice of connected peers from a set of [nodeIDs].
 //
 //   - [nodeIDs] the IDs of the peers that should be returned if they are
@@ -954,6 +1213,7 @@ func (n *network) disconnectedFromConnecting(nodeID ids.NodeID) {
 	defer n.peersLock.Unlock()
 
 	n.connectingPeers.Remove(nodeID)
+	n.peerDiversityThrottler.Remove(nodeID)
 
 	// The peer that is disconnecting from us didn't finish the handshake
 	tracked, ok := n.trackedIPs[nodeID]
@@ -979,6 +1239,7 @@ func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 	defer n.peersLock.Unlock()
 
 	n.connectedPeers.Remove(nodeID)
+	n.peerDiversityThrottler.Remove(nodeID)
 
 	// The peer that is disconnecting from us finished the handshake
 	if n.wantsConnection(nodeID) {
@@ -1066,18 +1327,27 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 		n.metrics.numTracked.Inc()
 		defer n.metrics.numTracked.Dec()
 
+		var (
+			diversityDelay    time.Duration
+			diversityDeferred bool
+		)
 		for {
-			timer := time.NewTimer(ip.getDelay())
+			timer := n.peerConfig.Clock.NewTimer(ip.getDelay() + diversityDelay)
+			diversityDelay = 0
 
 			select {
 			case <-ip.onStopTracking:
 				timer.Stop()
 				return
-			case <-timer.C:
+			case <-timer.C():
 			}
 
 			n.peersLock.Lock()
-			if !n.wantsConnection(nodeID) {
+			denied := !n.accessList.isNodeIDAllowed(nodeID) || !n.accessList.isIPAllowed(ip.ip.IP)
+			if denied {
+				n.metrics.connAccessDenied.WithLabelValues(dialStage).Inc()
+			}
+			if denied || !n.wantsConnection(nodeID) || n.banList.isIPBanned(ip.ip.IP, n.peerConfig.Clock.Time()) {
 				// Typically [n.trackedIPs[nodeID]] will already equal [ip], but
 				// the reference to [ip] is refreshed to avoid any potential
 				// race conditions before removing the entry.
@@ -1091,6 +1361,10 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 			}
 			_, connecting := n.connectingPeers.GetByID(nodeID)
 			_, connected := n.connectedPeers.GetByID(nodeID)
+			if !diversityDeferred {
+				diversityDeferred = true
+				diversityDelay = n.diversityDialDelay(nodeID, ip.ip.IP)
+			}
 			n.peersLock.Unlock()
 
 			// While it may not be strictly needed to stop attempting to connect
@@ -1108,6 +1382,17 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 				return
 			}
 
+			if diversityDelay > 0 {
+				n.peerConfig.Log.Verbo(
+					"deferring attempt to dial peer",
+					zap.String("reason", "undiverse IP"),
+					zap.Stringer("nodeID", nodeID),
+					zap.Duration("delay", diversityDelay),
+				)
+				n.metrics.dialDiversityDeferred.Inc()
+				continue
+			}
+
 			// Increase the delay that we will use for a future connection
 			// attempt.
 			ip.increaseDelay(
@@ -1144,6 +1429,33 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 	}()
 }
 
+// diversityDialDelay returns how long to defer dialing [nodeID] at [ip] so
+// that we prefer connecting to validators in IP prefixes and ASNs that we
+// aren't already well connected to. The delay shrinks as the stake of [nodeID]
+// grows, so that stake, rather than control of a hosting range, determines who
+// we connect to first.
+//
+// Assumes [n.peersLock] is held.
+func (n *network) diversityDialDelay(nodeID ids.NodeID, ip net.IP) time.Duration {
+	if n.manuallyTrackedIDs.Contains(nodeID) || !n.peerDiversityThrottler.Saturated(ip) {
+		return 0
+	}
+
+	vdrs, ok := n.config.Validators.Get(constants.PrimaryNetworkID)
+	if !ok {
+		return n.config.MaxReconnectDelay
+	}
+	totalWeight := vdrs.Weight()
+	if totalWeight == 0 {
+		return n.config.MaxReconnectDelay
+	}
+
+	// A validator with the average stake is deferred for half of
+	// [MaxReconnectDelay].
+	relativeWeight := float64(vdrs.GetWeight(nodeID)) * float64(vdrs.Len()) / float64(totalWeight)
+	return time.Duration(float64(n.config.MaxReconnectDelay) / (1 + relativeWeight))
+}
+
 // upgrade the provided connection, which may be an inbound connection or an
 // outbound connection, with the provided [upgrader].
 //
@@ -1197,6 +1509,17 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		return nil
 	}
 
+	if !n.accessList.isNodeIDAllowed(nodeID) || !n.accessList.isIPAllowed(remoteIP(tlsConn)) {
+		_ = tlsConn.Close()
+		n.peerConfig.Log.Debug(
+			"dropping connection",
+			zap.String("reason", "denied by access list"),
+			zap.Stringer("nodeID", nodeID),
+		)
+		n.metrics.connAccessDenied.WithLabelValues(handshakeStage).Inc()
+		return nil
+	}
+
 	n.peersLock.Lock()
 	if n.closing {
 		n.peersLock.Unlock()
@@ -1234,6 +1557,21 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		return nil
 	}
 
+	// Peers that we don't want a connection with are cheap to create, so the
+	// number of them that share an IP prefix or ASN is limited.
+	if !n.peerDiversityThrottler.Add(nodeID, remoteIP(tlsConn), !n.wantsConnection(nodeID)) {
+		n.peersLock.Unlock()
+
+		_ = tlsConn.Close()
+		n.peerConfig.Log.Verbo(
+			"dropping connection",
+			zap.String("reason", "undiverse IP"),
+			zap.Stringer("nodeID", nodeID),
+		)
+		n.metrics.connDiversityLimited.Inc()
+		return nil
+	}
+
 	n.peerConfig.Log.Verbo("starting handshake",
 		zap.Stringer("nodeID", nodeID),
 	)
@@ -1275,6 +1613,17 @@ func (n *network) PeerInfo(nodeIDs []ids.NodeID) []peer.Info {
 	return n.connectedPeers.Info(nodeIDs)
 }
 
+func (n *network) PeerCapabilities(nodeID ids.NodeID) set.Set[string] {
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	peer, ok := n.connectedPeers.GetByID(nodeID)
+	if !ok {
+		return nil
+	}
+	return peer.Capabilities()
+}
+
 func (n *network) StartClose() {
 	n.closeOnce.Do(func() {
 		n.peerConfig.Log.Info("shutting down the p2p networking")
@@ -1365,8 +1714,8 @@ func (n *network) NodeUptime(subnetID ids.ID) (UptimeResult, error) {
 }
 
 func (n *network) runTimers() {
-	gossipPeerlists := time.NewTicker(n.config.PeerListGossipFreq)
-	updateUptimes := time.NewTicker(n.config.UptimeMetricFreq)
+	gossipPeerlists := n.peerConfig.Clock.NewTicker(n.config.PeerListGossipFreq)
+	updateUptimes := n.peerConfig.Clock.NewTicker(n.config.UptimeMetricFreq)
 	defer func() {
 		gossipPeerlists.Stop()
 		updateUptimes.Stop()
@@ -1376,9 +1725,9 @@ func (n *network) runTimers() {
 		select {
 		case <-n.onCloseCtx.Done():
 			return
-		case <-gossipPeerlists.C:
+		case <-gossipPeerlists.C():
 			n.gossipPeerLists()
-		case <-updateUptimes.C:
+		case <-updateUptimes.C():
 			primaryUptime, err := n.NodeUptime(constants.PrimaryNetworkID)
 			if err != nil {
 				n.peerConfig.Log.Debug("failed to get primary network uptime",
@@ -1418,3 +1767,13 @@ func (n *network) gossipPeerLists() {
 		p.StartSendPeerList()
 	}
 }
+
+// remoteIP returns the IP of the remote end of [conn], or nil if it can't be
+// parsed.
+func remoteIP(conn net.Conn) net.IP {
+	ip, err := ips.ToIPPort(conn.RemoteAddr().String())
+	if err != nil {
+		return nil
+	}
+	return ip.IP
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
 	"github.com/MetalBlockchain/metalgo/network/dialer"
@@ -26,6 +27,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/uptime"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/subnets"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/ips"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
@@ -110,7 +112,7 @@ var (
 		PingFrequency:      constants.DefaultPingFrequency,
 		AllowPrivateIPs:    true,
 
-		CompressionEnabled: true,
+		CompressionType: compression.TypeGzip,
 
 		UptimeCalculator:  uptime.NewManager(uptime.NewTestState()),
 		UptimeMetricFreq:  30 * time.Second,
@@ -171,6 +173,7 @@ func newTestNetwork(t *testing.T, count int) (*testDialer, []*testListener, []id
 		config.MyNodeID = nodeID
 		config.MyIPPort = ip
 		config.TLSKey = tlsCert.PrivateKey.(crypto.Signer)
+		config.BanDB = memdb.New()
 
 		listeners[i] = listener
 		nodeIDs[i] = nodeID
@@ -185,7 +188,7 @@ func newMessageCreator(t *testing.T) message.Creator {
 	mc, err := message.NewCreator(
 		prometheus.NewRegistry(),
 		"",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -311,6 +314,21 @@ func TestNewNetwork(t *testing.T) {
 	wg.Wait()
 }
 
+func TestPeerCapabilities(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil})
+
+	capabilities := networks[0].PeerCapabilities(nodeIDs[1])
+	require.True(capabilities.Contains(message.CapabilityAncestorsAtHeight))
+	require.Empty(networks[0].PeerCapabilities(ids.GenerateTestNodeID()))
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
 func TestSend(t *testing.T) {
 	require := require.New(t)
 
@@ -430,3 +448,114 @@ func TestTrackVerifiesSignatures(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestBanNodeID(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil})
+
+	net0 := networks[0]
+	require.ErrorIs(net0.BanNodeID(nodeIDs[1], 0), errInvalidBanDuration)
+	require.NoError(net0.BanNodeID(nodeIDs[1], time.Hour))
+
+	bans := net0.Bans()
+	require.Len(bans, 1)
+	require.Equal(nodeIDs[1], bans[0].NodeID)
+
+	require.Eventually(
+		func() bool {
+			return len(net0.PeerInfo(nil)) == 0 && len(networks[1].PeerInfo(nil)) == 0
+		},
+		10*time.Second,
+		10*time.Millisecond,
+	)
+	require.False(net0.WantsConnection(nodeIDs[1]))
+	require.False(net0.AllowConnection(nodeIDs[1]))
+
+	require.NoError(net0.UnbanNodeID(nodeIDs[1]))
+	require.Empty(net0.Bans())
+	require.True(net0.WantsConnection(nodeIDs[1]))
+	require.True(net0.AllowConnection(nodeIDs[1]))
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestBanIP(t *testing.T) {
+	require := require.New(t)
+
+	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil})
+
+	// All the test connections use the loopback IP.
+	net0 := networks[0]
+	require.ErrorIs(net0.BanIP(net.IPv6loopback, -time.Second), errInvalidBanDuration)
+	require.NoError(net0.BanIP(net.IPv6loopback, time.Hour))
+
+	bans := net0.Bans()
+	require.Len(bans, 1)
+	require.True(net.IPv6loopback.Equal(bans[0].IP))
+
+	require.Eventually(
+		func() bool {
+			return len(net0.PeerInfo(nil)) == 0 && len(networks[1].PeerInfo(nil)) == 0
+		},
+		10*time.Second,
+		10*time.Millisecond,
+	)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestSetAccessList(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil})
+
+	net0 := networks[0]
+	require.Error(net0.SetAccessList(AccessListConfig{
+		DeniedCIDRs: []string{"::1"},
+	}))
+
+	config := AccessListConfig{
+		DeniedNodeIDs: []ids.NodeID{nodeIDs[1]},
+	}
+	require.NoError(net0.SetAccessList(config))
+	require.Equal(config, net0.AccessList())
+
+	require.Eventually(
+		func() bool {
+			return len(net0.PeerInfo(nil)) == 0 && len(networks[1].PeerInfo(nil)) == 0
+		},
+		10*time.Second,
+		10*time.Millisecond,
+	)
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestDisconnect(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil})
+
+	// [nodeIDs[0]] was manually tracked by [networks[1]]
+	net1 := networks[1].(*network)
+	net1.Disconnect(nodeIDs[0])
+
+	net1.peersLock.RLock()
+	require.False(net1.manuallyTrackedIDs.Contains(nodeIDs[0]))
+	net1.peersLock.RUnlock()
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
@@ -26,19 +26,25 @@ type Config struct {
 	WriteBufferSize int
 	Clock           mockable.Clock
 	Metrics         *Metrics
-	MessageCreator  message.Creator
-
-	Log                  logging.Logger
-	InboundMsgThrottler  throttling.InboundMsgThrottler
-	Network              Network
-	Router               router.InboundHandler
-	VersionCompatibility version.Compatibility
-	MySubnets            set.Set[ids.ID]
-	Beacons              validators.Set
-	NetworkID            uint32
-	PingFrequency        time.Duration
-	PongTimeout          time.Duration
-	MaxClockDifference   time.Duration
+	// TrafficMetrics reports the traffic of each peer. If nil, the traffic is
+	// only reported in Info.
+	TrafficMetrics *TrafficMetrics
+	MessageCreator message.Creator
+
+	Log                 logging.Logger
+	InboundMsgThrottler throttling.InboundMsgThrottler
+	// Limits the bandwidth used to write messages to this peer
+	OutboundBandwidthThrottler throttling.OutboundBandwidthThrottler
+	Network                    Network
+	Router                     router.InboundHandler
+	VersionCompatibility       version.Compatibility
+	MySubnets                  set.Set[ids.ID]
+	MyCapabilities             set.Set[string]
+	Beacons                    validators.Set
+	NetworkID                  uint32
+	PingFrequency              time.Duration
+	PongTimeout                time.Duration
+	MaxClockDifference         time.Duration
 
 	// Unix time of the last message sent and received respectively
 	// Must only be accessed atomically
@@ -20,4 +20,6 @@ type Info struct {
 	ObservedUptime        json.Uint32            `json:"observedUptime"`
 	ObservedSubnetUptimes map[ids.ID]json.Uint32 `json:"observedSubnetUptimes"`
 	TrackedSubnets        []ids.ID               `json:"trackedSubnets"`
+	Capabilities          []string               `json:"capabilities"`
+	Traffic               *Traffic               `json:"traffic,omitempty"`
 }
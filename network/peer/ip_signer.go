@@ -27,9 +27,11 @@ type IPSigner struct {
 func NewIPSigner(
 	ip ips.DynamicIPPort,
 	signer crypto.Signer,
+	clock mockable.Clock,
 ) *IPSigner {
 	return &IPSigner{
 		ip:     ip,
+		clock:  clock,
 		signer: signer,
 	}
 }
@@ -13,6 +13,7 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/staking"
 	"github.com/MetalBlockchain/metalgo/utils/ips"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 )
 
 func TestIPSigner(t *testing.T) {
@@ -28,7 +29,7 @@ func TestIPSigner(t *testing.T) {
 
 	key := tlsCert.PrivateKey.(crypto.Signer)
 
-	s := NewIPSigner(dynIP, key)
+	s := NewIPSigner(dynIP, key, mockable.Clock{})
 
 	s.clock.Set(time.Unix(10, 0))
 
@@ -67,9 +67,11 @@ type throttledMessageQueue struct {
 	// [cond.L] must be held while accessing [closed].
 	closed bool
 
-	// queue of the messages
-	// [cond.L] must be held while accessing [queue].
-	queue buffer.Deque[message.OutboundMessage]
+	// queues of the messages, indexed by their priority. Messages of a higher
+	// priority are popped first, and messages of the same priority are popped
+	// in the order that they were pushed.
+	// [cond.L] must be held while accessing [queues].
+	queues [throttling.NumPriorities]buffer.Deque[message.OutboundMessage]
 }
 
 func NewThrottledMessageQueue(
@@ -78,14 +80,17 @@ func NewThrottledMessageQueue(
 	log logging.Logger,
 	outboundMsgThrottler throttling.OutboundMsgThrottler,
 ) MessageQueue {
-	return &throttledMessageQueue{
+	q := &throttledMessageQueue{
 		onFailed:             onFailed,
 		id:                   id,
 		log:                  log,
 		outboundMsgThrottler: outboundMsgThrottler,
 		cond:                 sync.NewCond(&sync.Mutex{}),
-		queue:                buffer.NewUnboundedDeque[message.OutboundMessage](initialQueueSize),
 	}
+	for i := range q.queues {
+		q.queues[i] = buffer.NewUnboundedDeque[message.OutboundMessage](initialQueueSize)
+	}
+	return q
 }
 
 func (q *throttledMessageQueue) Push(ctx context.Context, msg message.OutboundMessage) bool {
@@ -131,7 +136,8 @@ func (q *throttledMessageQueue) Push(ctx context.Context, msg message.OutboundMe
 		return false
 	}
 
-	q.queue.PushRight(msg)
+	priority := throttling.OutboundPriority(msg.Op())
+	q.queues[priority].PushRight(msg)
 	q.cond.Signal()
 	return true
 }
@@ -144,7 +150,7 @@ func (q *throttledMessageQueue) Pop() (message.OutboundMessage, bool) {
 		if q.closed {
 			return nil, false
 		}
-		if q.queue.Len() > 0 {
+		if q.len() > 0 {
 			// There is a message
 			break
 		}
@@ -159,7 +165,7 @@ func (q *throttledMessageQueue) PopNow() (message.OutboundMessage, bool) {
 	q.cond.L.Lock()
 	defer q.cond.L.Unlock()
 
-	if q.closed || q.queue.Len() == 0 {
+	if q.closed || q.len() == 0 {
 		// There isn't a message
 		return nil, false
 	}
@@ -167,13 +173,29 @@ func (q *throttledMessageQueue) PopNow() (message.OutboundMessage, bool) {
 	return q.pop(), true
 }
 
+// Assumes [q.cond.L] is held and that there is a message.
 func (q *throttledMessageQueue) pop() message.OutboundMessage {
-	msg, _ := q.queue.PopLeft()
+	var msg message.OutboundMessage
+	for _, queue := range q.queues {
+		if queue.Len() > 0 {
+			msg, _ = queue.PopLeft()
+			break
+		}
+	}
 
 	q.outboundMsgThrottler.Release(msg, q.id)
 	return msg
 }
 
+// Assumes [q.cond.L] is held.
+func (q *throttledMessageQueue) len() int {
+	total := 0
+	for _, queue := range q.queues {
+		total += queue.Len()
+	}
+	return total
+}
+
 func (q *throttledMessageQueue) Close() {
 	q.cond.L.Lock()
 	defer q.cond.L.Unlock()
@@ -184,12 +206,14 @@ func (q *throttledMessageQueue) Close() {
 
 	q.closed = true
 
-	for q.queue.Len() > 0 {
-		msg, _ := q.queue.PopLeft()
-		q.outboundMsgThrottler.Release(msg, q.id)
-		q.onFailed.SendFailed(msg)
+	for i, queue := range q.queues {
+		for queue.Len() > 0 {
+			msg, _ := queue.PopLeft()
+			q.outboundMsgThrottler.Release(msg, q.id)
+			q.onFailed.SendFailed(msg)
+		}
+		q.queues[i] = nil
 	}
-	q.queue = nil
 
 	q.cond.Broadcast()
 }
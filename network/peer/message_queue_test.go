@@ -6,11 +6,13 @@ package peer
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 )
@@ -86,3 +88,39 @@ func TestMessageQueue(t *testing.T) {
 	_, ok = q.Pop()
 	require.False(ok)
 }
+
+func TestThrottledMessageQueuePriority(t *testing.T) {
+	require := require.New(t)
+
+	q := NewThrottledMessageQueue(
+		SendFailedFunc(func(message.OutboundMessage) {
+			require.FailNow("unexpected send failure")
+		}),
+		ids.EmptyNodeID,
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+	)
+
+	mc := newMessageCreator(t)
+	gossip, err := mc.AppGossip(ids.Empty, []byte("gossip"))
+	require.NoError(err)
+	request, err := mc.AppRequest(context.Background(), ids.Empty, 0, time.Second, []byte("request"))
+	require.NoError(err)
+	ping0, err := mc.Ping()
+	require.NoError(err)
+	ping1, err := mc.Ping()
+	require.NoError(err)
+
+	for _, msg := range []message.OutboundMessage{gossip, request, ping0, ping1} {
+		require.True(q.Push(context.Background(), msg))
+	}
+
+	// Messages are popped by priority, and then in the order they were pushed
+	for _, expected := range []message.OutboundMessage{ping0, ping1, request, gossip} {
+		msg, ok := q.PopNow()
+		require.True(ok)
+		require.Same(expected, msg)
+	}
+	_, ok := q.PopNow()
+	require.False(ok)
+}
@@ -11,6 +11,7 @@ import (
 	"io"
 	"math"
 	"net"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -19,8 +20,10 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/ips"
 	"github.com/MetalBlockchain/metalgo/utils/json"
@@ -76,6 +79,11 @@ type Peer interface {
 	// be called after [Ready] returns true.
 	TrackedSubnets() set.Set[ids.ID]
 
+	// Capabilities returns the capabilities that both this node and the peer
+	// advertised during the handshake. It should only be called after [Ready]
+	// returns true.
+	Capabilities() set.Set[string]
+
 	// ObservedUptime returns the local node's subnet uptime according to the
 	// peer. The value ranges from [0, 100]. It should only be called after
 	// [Ready] returns true.
@@ -128,6 +136,9 @@ type peer struct {
 	// trackedSubnets is the subset of subnetIDs the peer sent us in the Version
 	// message that we are also tracking.
 	trackedSubnets set.Set[ids.ID]
+	// capabilities is the subset of capabilities the peer sent us in the
+	// Version message that we also support.
+	capabilities set.Set[string]
 
 	observedUptimesLock sync.RWMutex
 	// [observedUptimesLock] must be held while accessing [observedUptime]
@@ -167,6 +178,9 @@ type peer struct {
 	// peerListChan signals that we should attempt to send a PeerList to this
 	// peer
 	peerListChan chan struct{}
+
+	// traffic counts the messages exchanged with this peer
+	traffic *trafficTracker
 }
 
 // Start a new peer instance.
@@ -194,6 +208,7 @@ func Start(
 		onClosed:           make(chan struct{}),
 		observedUptimes:    make(map[ids.ID]uint32),
 		peerListChan:       make(chan struct{}, 1),
+		traffic:            newTrafficTracker(id, config.TrafficMetrics),
 	}
 
 	go p.readMessages()
@@ -247,6 +262,8 @@ func (p *peer) Info() Info {
 	}
 
 	trackedSubnets := p.trackedSubnets.List()
+	capabilities := p.capabilities.List()
+	sort.Strings(capabilities)
 	uptimes := make(map[ids.ID]json.Uint32, len(trackedSubnets))
 
 	for _, subnetID := range trackedSubnets {
@@ -272,6 +289,8 @@ func (p *peer) Info() Info {
 		ObservedUptime:        json.Uint32(primaryUptime),
 		ObservedSubnetUptimes: uptimes,
 		TrackedSubnets:        trackedSubnets,
+		Capabilities:          capabilities,
+		Traffic:               p.traffic.Traffic(),
 	}
 }
 
@@ -287,6 +306,10 @@ func (p *peer) TrackedSubnets() set.Set[ids.ID] {
 	return p.trackedSubnets
 }
 
+func (p *peer) Capabilities() set.Set[string] {
+	return p.capabilities
+}
+
 func (p *peer) ObservedUptime(subnetID ids.ID) (uint32, bool) {
 	p.observedUptimesLock.RLock()
 	defer p.observedUptimesLock.RUnlock()
@@ -296,7 +319,39 @@ func (p *peer) ObservedUptime(subnetID ids.ID) (uint32, bool) {
 }
 
 func (p *peer) Send(ctx context.Context, msg message.OutboundMessage) bool {
-	return p.messageQueue.Push(ctx, msg)
+	if compressionType := msg.CompressionType(); !p.supportsCompression(compressionType) {
+		recompressedMsg, err := p.MessageCreator.Recompress(msg, compression.TypeGzip)
+		if err != nil {
+			p.Log.Error("failed to recompress message",
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", msg.Op()),
+				zap.Stringer("compressionType", compressionType),
+				zap.Error(err),
+			)
+			return false
+		}
+		msg = recompressedMsg
+	}
+	if !p.messageQueue.Push(ctx, msg) {
+		p.traffic.SendFailed(msg)
+		return false
+	}
+	return true
+}
+
+// supportsCompression returns true if the peer is known to be able to
+// decompress messages with [compressionType]. Every peer supports uncompressed
+// and gzip compressed messages.
+func (p *peer) supportsCompression(compressionType compression.Type) bool {
+	switch compressionType {
+	case compression.TypeNone, compression.TypeGzip:
+		return true
+	default:
+		// [capabilities] is populated before [gotVersion] is set and is never
+		// modified afterwards.
+		capability, ok := message.CompressionCapability(compressionType)
+		return ok && p.gotVersion.Get() && p.capabilities.Contains(capability)
+	}
 }
 
 func (p *peer) StartSendPeerList() {
@@ -345,6 +400,7 @@ func (p *peer) close() {
 		return
 	}
 
+	p.traffic.Close()
 	p.Network.Disconnected(p.id)
 	close(p.onClosed)
 }
@@ -405,11 +461,13 @@ func (p *peer) readMessages() {
 		// exited before calling [Network.Disconnected] to guarantee that there
 		// can't be multiple instances of this goroutine running over different
 		// peer instances.
+		startAcquire := p.Clock.Time()
 		onFinishedHandling := p.InboundMsgThrottler.Acquire(
 			p.onClosingCtx,
 			uint64(msgLen),
 			p.id,
 		)
+		p.traffic.InboundThrottled(p.Clock.Time().Sub(startAcquire))
 
 		// If the peer is shutting down, there's no need to read the message.
 		if err := p.onClosingCtx.Err(); err != nil {
@@ -472,6 +530,7 @@ func (p *peer) readMessages() {
 		atomic.StoreInt64(&p.Config.LastReceived, now)
 		atomic.StoreInt64(&p.lastReceived, now)
 		p.Metrics.Received(msg, msgLen)
+		p.traffic.Received(msg, msgLen)
 
 		// Handle the message. Note that when we are done handling this message,
 		// we must call [msg.OnFinishedHandling()].
@@ -481,7 +540,10 @@ func (p *peer) readMessages() {
 }
 
 func (p *peer) writeMessages() {
+	// Track this node with the outbound bandwidth throttler.
+	p.OutboundBandwidthThrottler.AddNode(p.id)
 	defer func() {
+		p.OutboundBandwidthThrottler.RemoveNode(p.id)
 		p.StartClose()
 		p.close()
 	}()
@@ -505,6 +567,7 @@ func (p *peer) writeMessages() {
 		mySignedIP.Timestamp,
 		mySignedIP.Signature,
 		p.MySubnets.List(),
+		p.MyCapabilities.List(),
 	)
 	if err != nil {
 		p.Log.Error("failed to create message",
@@ -514,11 +577,17 @@ func (p *peer) writeMessages() {
 		return
 	}
 
+	if !p.acquireBandwidth(writer, msg) {
+		return
+	}
 	p.writeMessage(writer, msg)
 
 	for {
 		msg, ok := p.messageQueue.PopNow()
 		if ok {
+			if !p.acquireBandwidth(writer, msg) {
+				return
+			}
 			p.writeMessage(writer, msg)
 			continue
 		}
@@ -539,10 +608,33 @@ func (p *peer) writeMessages() {
 			return
 		}
 
+		if !p.acquireBandwidth(writer, msg) {
+			return
+		}
 		p.writeMessage(writer, msg)
 	}
 }
 
+// acquireBandwidth blocks until the outbound bandwidth throttler allows [msg]
+// to be written. The messages in [writer] are flushed before blocking, so that
+// they aren't delayed by [msg]. Returns false if the peer is closing.
+func (p *peer) acquireBandwidth(writer *bufio.Writer, msg message.OutboundMessage) bool {
+	msgSize := uint64(wrappers.IntLen + len(msg.Bytes()))
+	priority := throttling.OutboundPriority(msg.Op())
+	if p.OutboundBandwidthThrottler.TryAcquire(msgSize, p.id, priority) {
+		return true
+	}
+
+	if err := writer.Flush(); err != nil {
+		p.Log.Verbo("failed to flush writer",
+			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
+		)
+		return false
+	}
+	return p.OutboundBandwidthThrottler.Acquire(p.onClosingCtx, msgSize, p.id, priority)
+}
+
 func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	msgBytes := msg.Bytes()
 	p.Log.Verbo("sending message",
@@ -582,10 +674,11 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	atomic.StoreInt64(&p.Config.LastSent, now)
 	atomic.StoreInt64(&p.lastSent, now)
 	p.Metrics.Sent(msg)
+	p.traffic.Sent(msg)
 }
 
 func (p *peer) sendNetworkMessages() {
-	sendPingsTicker := time.NewTicker(p.PingFrequency)
+	sendPingsTicker := p.Clock.NewTicker(p.PingFrequency)
 	defer func() {
 		sendPingsTicker.Stop()
 
@@ -629,7 +722,7 @@ func (p *peer) sendNetworkMessages() {
 					zap.Stringer("nodeID", p.id),
 				)
 			}
-		case <-sendPingsTicker.C:
+		case <-sendPingsTicker.C():
 			if !p.Network.AllowConnection(p.id) {
 				p.Log.Debug("disconnecting from peer",
 					zap.String("reason", "connection is no longer desired"),
@@ -895,6 +988,14 @@ func (p *peer) handleVersion(msg *p2p.Version) {
 		}
 	}
 
+	// use only the capabilities that we also support
+	p.capabilities = set.NewSet[string](len(msg.Capabilities))
+	for capability := range message.Capabilities(msg) {
+		if p.MyCapabilities.Contains(capability) {
+			p.capabilities.Add(capability)
+		}
+	}
+
 	// "net.IP" type in Golang is 16-byte
 	if ipLen := len(msg.IpAddr); ipLen != net.IPv6len {
 		p.Log.Debug("message with invalid field",
@@ -23,12 +23,14 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/staking"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/ips"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/math/meter"
 	"github.com/MetalBlockchain/metalgo/utils/resource"
 	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 	"github.com/MetalBlockchain/metalgo/version"
 )
 
@@ -51,7 +53,7 @@ func newMessageCreator(t *testing.T) message.Creator {
 	mc, err := message.NewCreator(
 		prometheus.NewRegistry(),
 		"",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -92,25 +94,27 @@ func makeRawTestPeers(t *testing.T) (*rawTestPeer, *rawTestPeer) {
 	require.NoError(err)
 
 	sharedConfig := Config{
-		Metrics:              metrics,
-		MessageCreator:       mc,
-		Log:                  logging.NoLog{},
-		InboundMsgThrottler:  throttling.NewNoInboundThrottler(),
-		VersionCompatibility: version.GetCompatibility(constants.LocalID),
-		MySubnets:            set.Set[ids.ID]{},
-		Beacons:              validators.NewSet(),
-		NetworkID:            constants.LocalID,
-		PingFrequency:        constants.DefaultPingFrequency,
-		PongTimeout:          constants.DefaultPingPongTimeout,
-		MaxClockDifference:   time.Minute,
-		ResourceTracker:      resourceTracker,
+		Metrics:                    metrics,
+		MessageCreator:             mc,
+		Log:                        logging.NoLog{},
+		InboundMsgThrottler:        throttling.NewNoInboundThrottler(),
+		OutboundBandwidthThrottler: throttling.NewNoOutboundBandwidthThrottler(),
+		VersionCompatibility:       version.GetCompatibility(constants.LocalID),
+		MySubnets:                  set.Set[ids.ID]{},
+		MyCapabilities:             message.SupportedCapabilities(),
+		Beacons:                    validators.NewSet(),
+		NetworkID:                  constants.LocalID,
+		PingFrequency:              constants.DefaultPingFrequency,
+		PongTimeout:                constants.DefaultPingPongTimeout,
+		MaxClockDifference:         time.Minute,
+		ResourceTracker:            resourceTracker,
 	}
 	peerConfig0 := sharedConfig
 	peerConfig1 := sharedConfig
 
 	ip0 := ips.NewDynamicIPPort(net.IPv6loopback, 0)
 	tls0 := tlsCert0.PrivateKey.(crypto.Signer)
-	peerConfig0.IPSigner = NewIPSigner(ip0, tls0)
+	peerConfig0.IPSigner = NewIPSigner(ip0, tls0, mockable.Clock{})
 
 	peerConfig0.Network = TestNetwork
 	inboundMsgChan0 := make(chan message.InboundMessage)
@@ -120,7 +124,7 @@ func makeRawTestPeers(t *testing.T) (*rawTestPeer, *rawTestPeer) {
 
 	ip1 := ips.NewDynamicIPPort(net.IPv6loopback, 1)
 	tls1 := tlsCert1.PrivateKey.(crypto.Signer)
-	peerConfig1.IPSigner = NewIPSigner(ip1, tls1)
+	peerConfig1.IPSigner = NewIPSigner(ip1, tls1, mockable.Clock{})
 
 	peerConfig1.Network = TestNetwork
 	inboundMsgChan1 := make(chan message.InboundMessage)
@@ -146,8 +150,10 @@ func makeRawTestPeers(t *testing.T) (*rawTestPeer, *rawTestPeer) {
 }
 
 func makeTestPeers(t *testing.T) (*testPeer, *testPeer) {
-	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+	return startTestPeers(makeRawTestPeers(t))
+}
 
+func startTestPeers(rawPeer0 *rawTestPeer, rawPeer1 *rawTestPeer) (*testPeer, *testPeer) {
 	peer0 := &testPeer{
 		Peer: Start(
 			rawPeer0.config,
@@ -266,9 +272,101 @@ func TestSend(t *testing.T) {
 	inboundGetMsg := <-peer1.inboundMsgChan
 	require.Equal(message.GetOp, inboundGetMsg.Op())
 
+	traffic := peer1.Info().Traffic
+	require.EqualValues(1, traffic.Chains[ids.Empty][message.GetOp.String()].Received.Messages)
+
+	peer1.StartClose()
+	err = peer0.AwaitClosed(context.Background())
+	require.NoError(err)
+	err = peer1.AwaitClosed(context.Background())
+	require.NoError(err)
+}
+
+func TestSendZstd(t *testing.T) {
+	require := require.New(t)
+
+	peer0, peer1 := makeReadyTestPeers(t)
+	mc, err := message.NewCreator(
+		prometheus.NewRegistry(),
+		"",
+		compression.TypeZstd,
+		10*time.Second,
+	)
+	require.NoError(err)
+
+	outboundPutMsg, err := mc.Put(ids.Empty, 1, make([]byte, 1024), p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+	require.NoError(err)
+	require.Equal(compression.TypeZstd, outboundPutMsg.CompressionType())
+
+	sent := peer0.Send(context.Background(), outboundPutMsg)
+	require.True(sent)
+
+	inboundPutMsg := <-peer1.inboundMsgChan
+	require.Equal(message.PutOp, inboundPutMsg.Op())
+	require.Positive(inboundPutMsg.BytesSavedCompression())
+
 	peer1.StartClose()
 	err = peer0.AwaitClosed(context.Background())
 	require.NoError(err)
 	err = peer1.AwaitClosed(context.Background())
 	require.NoError(err)
 }
+
+func TestSupportsCompression(t *testing.T) {
+	require := require.New(t)
+
+	p := &peer{}
+
+	// Before the handshake, only the compression types that every peer
+	// supports should be used.
+	require.True(p.supportsCompression(compression.TypeNone))
+	require.True(p.supportsCompression(compression.TypeGzip))
+	require.False(p.supportsCompression(compression.TypeZstd))
+
+	// Peers that don't advertise zstd support should only be sent gzip.
+	p.capabilities = message.Capabilities(&p2p.Version{})
+	p.gotVersion.Set(true)
+	require.True(p.supportsCompression(compression.TypeGzip))
+	require.False(p.supportsCompression(compression.TypeZstd))
+
+	p.capabilities = message.Capabilities(&p2p.Version{
+		SupportedCompressionTypes: []p2p.CompressionType{
+			p2p.CompressionType_COMPRESSION_TYPE_ZSTD,
+		},
+	})
+	require.True(p.supportsCompression(compression.TypeGzip))
+	require.True(p.supportsCompression(compression.TypeZstd))
+
+	p.capabilities = message.Capabilities(&p2p.Version{
+		Capabilities: []string{
+			message.CapabilityCompressionZstd,
+		},
+	})
+	require.True(p.supportsCompression(compression.TypeGzip))
+	require.True(p.supportsCompression(compression.TypeZstd))
+}
+
+func TestCapabilities(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+	rawPeer0.config.MyCapabilities = set.NewSet[string](2)
+	rawPeer0.config.MyCapabilities.Add(message.CapabilityCompressionGzip, "test/both")
+	rawPeer1.config.MyCapabilities = message.SupportedCapabilities()
+	rawPeer1.config.MyCapabilities.Add("test/both", "test/one")
+
+	peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+
+	// Only the capabilities that both peers advertised are used.
+	expected := []string{message.CapabilityCompressionGzip, "test/both"}
+	require.Equal(expected, peer0.Info().Capabilities)
+	require.Equal(expected, peer1.Info().Capabilities)
+	capabilities := peer1.Capabilities()
+	require.False(capabilities.Contains(message.CapabilityCompressionZstd))
+
+	peer0.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
@@ -18,12 +18,14 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/staking"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/ips"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/math/meter"
 	"github.com/MetalBlockchain/metalgo/utils/resource"
 	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 	"github.com/MetalBlockchain/metalgo/version"
 )
 
@@ -71,7 +73,7 @@ func StartTestPeer(
 	mc, err := message.NewCreator(
 		prometheus.NewRegistry(),
 		"",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	if err != nil {
@@ -102,21 +104,23 @@ func StartTestPeer(
 
 	peer := Start(
 		&Config{
-			Metrics:              metrics,
-			MessageCreator:       mc,
-			Log:                  logging.NoLog{},
-			InboundMsgThrottler:  throttling.NewNoInboundThrottler(),
-			Network:              TestNetwork,
-			Router:               router,
-			VersionCompatibility: version.GetCompatibility(networkID),
-			MySubnets:            set.Set[ids.ID]{},
-			Beacons:              validators.NewSet(),
-			NetworkID:            networkID,
-			PingFrequency:        constants.DefaultPingFrequency,
-			PongTimeout:          constants.DefaultPingPongTimeout,
-			MaxClockDifference:   time.Minute,
-			ResourceTracker:      resourceTracker,
-			IPSigner:             NewIPSigner(signerIP, tls),
+			Metrics:                    metrics,
+			MessageCreator:             mc,
+			Log:                        logging.NoLog{},
+			InboundMsgThrottler:        throttling.NewNoInboundThrottler(),
+			OutboundBandwidthThrottler: throttling.NewNoOutboundBandwidthThrottler(),
+			Network:                    TestNetwork,
+			Router:                     router,
+			VersionCompatibility:       version.GetCompatibility(networkID),
+			MySubnets:                  set.Set[ids.ID]{},
+			MyCapabilities:             message.SupportedCapabilities(),
+			Beacons:                    validators.NewSet(),
+			NetworkID:                  networkID,
+			PingFrequency:              constants.DefaultPingFrequency,
+			PongTimeout:                constants.DefaultPingPongTimeout,
+			MaxClockDifference:         time.Minute,
+			ResourceTracker:            resourceTracker,
+			IPSigner:                   NewIPSigner(signerIP, tls, mockable.Clock{}),
 		},
 		conn,
 		cert,
@@ -0,0 +1,299 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/utils/json"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const (
+	nodeIDLabel    = "nodeID"
+	chainIDLabel   = "chainID"
+	opLabel        = "op"
+	directionLabel = "direction"
+
+	sentDirection     = "sent"
+	receivedDirection = "received"
+	failedDirection   = "failed"
+)
+
+// MessageCounts is a number of messages and their total size in bytes.
+type MessageCounts struct {
+	Messages json.Uint64 `json:"messages"`
+	Bytes    json.Uint64 `json:"bytes"`
+}
+
+func (c *MessageCounts) add(other MessageCounts) {
+	c.Messages += other.Messages
+	c.Bytes += other.Bytes
+}
+
+// OpTraffic is the traffic of a single message op.
+type OpTraffic struct {
+	Sent     MessageCounts `json:"sent"`
+	Received MessageCounts `json:"received"`
+	// Failed are the outbound messages that were dropped rather than sent,
+	// most commonly by the outbound message throttler.
+	Failed MessageCounts `json:"failed"`
+}
+
+func (t *OpTraffic) add(other *OpTraffic) {
+	t.Sent.add(other.Sent)
+	t.Received.add(other.Received)
+	t.Failed.add(other.Failed)
+}
+
+// Traffic is the traffic exchanged with a peer over its current connection.
+type Traffic struct {
+	OpTraffic
+
+	// InboundThrottleWait is the time spent waiting on the inbound message
+	// throttler before reading this peer's messages.
+	InboundThrottleWait time.Duration `json:"inboundThrottleWait"`
+
+	// Network is the traffic that isn't specific to a chain, by op.
+	Network map[string]*OpTraffic `json:"network"`
+
+	// Chains is the traffic of each chain, by op.
+	Chains map[ids.ID]map[string]*OpTraffic `json:"chains"`
+}
+
+// TrafficMetrics reports the traffic of every peer to Prometheus. Because the
+// number of series grows with the number of peers, chains and ops, these
+// metrics are optional.
+type TrafficMetrics struct {
+	messages            *prometheus.CounterVec
+	bytes               *prometheus.CounterVec
+	inboundThrottleWait *prometheus.CounterVec
+}
+
+func NewTrafficMetrics(namespace string, registerer prometheus.Registerer) (*TrafficMetrics, error) {
+	m := &TrafficMetrics{
+		messages: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "peer_messages",
+				Help:      "Number of messages sent to, received from, or failed to be sent to a peer",
+			},
+			[]string{nodeIDLabel, chainIDLabel, opLabel, directionLabel},
+		),
+		bytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "peer_bytes",
+				Help:      "Number of bytes sent to, received from, or failed to be sent to a peer",
+			},
+			[]string{nodeIDLabel, chainIDLabel, opLabel, directionLabel},
+		),
+		inboundThrottleWait: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "peer_inbound_throttle_wait",
+				Help:      "Time (in ns) spent waiting on the inbound message throttler before reading a peer's messages",
+			},
+			[]string{nodeIDLabel},
+		),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.messages),
+		registerer.Register(m.bytes),
+		registerer.Register(m.inboundThrottleWait),
+	)
+	return m, errs.Err
+}
+
+// remove drops the series of [nodeID].
+func (m *TrafficMetrics) remove(nodeID ids.NodeID) {
+	labels := prometheus.Labels{nodeIDLabel: nodeID.String()}
+	m.messages.DeletePartialMatch(labels)
+	m.bytes.DeletePartialMatch(labels)
+	m.inboundThrottleWait.DeletePartialMatch(labels)
+}
+
+type trafficKey struct {
+	chainID    ids.ID
+	hasChainID bool
+	op         message.Op
+}
+
+type directionCounters struct {
+	messages, bytes prometheus.Counter
+}
+
+type opCounters struct {
+	traffic OpTraffic
+	// Only set if per-peer metrics are enabled
+	sent, received, failed directionCounters
+}
+
+// trafficTracker counts the messages exchanged with a single peer.
+type trafficTracker struct {
+	nodeID  ids.NodeID
+	metrics *TrafficMetrics
+
+	lock                sync.Mutex
+	ops                 map[trafficKey]*opCounters
+	inboundThrottleWait time.Duration
+	// Only set if per-peer metrics are enabled
+	inboundThrottleWaitCounter prometheus.Counter
+	// closed is true once the Prometheus series of the peer have been
+	// dropped. Traffic isn't counted after that, so that the series aren't
+	// recreated for a disconnected peer.
+	closed bool
+}
+
+// newTrafficTracker returns a tracker of the traffic with [nodeID]. If
+// [metrics] is nil, the traffic isn't reported to Prometheus.
+func newTrafficTracker(nodeID ids.NodeID, metrics *TrafficMetrics) *trafficTracker {
+	t := &trafficTracker{
+		nodeID:  nodeID,
+		metrics: metrics,
+		ops:     make(map[trafficKey]*opCounters),
+	}
+	if metrics != nil {
+		t.inboundThrottleWaitCounter = metrics.inboundThrottleWait.WithLabelValues(nodeID.String())
+	}
+	return t
+}
+
+func (t *trafficTracker) Sent(msg message.OutboundMessage) {
+	chainID, hasChainID := msg.ChainID()
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.closed {
+		return
+	}
+	counters := t.counters(chainID, hasChainID, msg.Op())
+	t.add(&counters.traffic.Sent, counters.sent, len(msg.Bytes()))
+}
+
+func (t *trafficTracker) SendFailed(msg message.OutboundMessage) {
+	chainID, hasChainID := msg.ChainID()
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.closed {
+		return
+	}
+	counters := t.counters(chainID, hasChainID, msg.Op())
+	t.add(&counters.traffic.Failed, counters.failed, len(msg.Bytes()))
+}
+
+func (t *trafficTracker) Received(msg message.InboundMessage, msgLen uint32) {
+	chainID, err := message.GetChainID(msg.Message())
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.closed {
+		return
+	}
+	counters := t.counters(chainID, err == nil, msg.Op())
+	t.add(&counters.traffic.Received, counters.received, int(msgLen))
+}
+
+func (t *trafficTracker) InboundThrottled(wait time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.closed {
+		return
+	}
+	t.inboundThrottleWait += wait
+	if t.inboundThrottleWaitCounter != nil {
+		t.inboundThrottleWaitCounter.Add(float64(wait))
+	}
+}
+
+// Traffic returns a snapshot of the counted traffic.
+func (t *trafficTracker) Traffic() *Traffic {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	traffic := &Traffic{
+		InboundThrottleWait: t.inboundThrottleWait,
+		Network:             make(map[string]*OpTraffic),
+		Chains:              make(map[ids.ID]map[string]*OpTraffic),
+	}
+	for key, counters := range t.ops {
+		opTraffic := counters.traffic
+		traffic.OpTraffic.add(&opTraffic)
+
+		ops := traffic.Network
+		if key.hasChainID {
+			ops = traffic.Chains[key.chainID]
+			if ops == nil {
+				ops = make(map[string]*OpTraffic)
+				traffic.Chains[key.chainID] = ops
+			}
+		}
+		ops[key.op.String()] = &opTraffic
+	}
+	return traffic
+}
+
+// Close drops the Prometheus series of this peer. Traffic reported after Close
+// is ignored.
+func (t *trafficTracker) Close() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.closed = true
+	if t.metrics != nil {
+		t.metrics.remove(t.nodeID)
+	}
+}
+
+// assumes [t.lock] is held
+func (t *trafficTracker) counters(chainID ids.ID, hasChainID bool, op message.Op) *opCounters {
+	key := trafficKey{
+		chainID:    chainID,
+		hasChainID: hasChainID,
+		op:         op,
+	}
+	counters, ok := t.ops[key]
+	if ok {
+		return counters
+	}
+
+	counters = &opCounters{}
+	if t.metrics != nil {
+		chainIDStr := ""
+		if hasChainID {
+			chainIDStr = chainID.String()
+		}
+		newCounters := func(direction string) directionCounters {
+			labels := []string{t.nodeID.String(), chainIDStr, op.String(), direction}
+			return directionCounters{
+				messages: t.metrics.messages.WithLabelValues(labels...),
+				bytes:    t.metrics.bytes.WithLabelValues(labels...),
+			}
+		}
+		counters.sent = newCounters(sentDirection)
+		counters.received = newCounters(receivedDirection)
+		counters.failed = newCounters(failedDirection)
+	}
+	t.ops[key] = counters
+	return counters
+}
+
+// assumes [t.lock] is held
+func (*trafficTracker) add(counts *MessageCounts, counters directionCounters, numBytes int) {
+	counts.Messages++
+	counts.Bytes += json.Uint64(numBytes)
+	if counters.messages != nil {
+		counters.messages.Inc()
+		counters.bytes.Add(float64(numBytes))
+	}
+}
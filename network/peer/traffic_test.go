@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+)
+
+func TestTrafficTracker(t *testing.T) {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	metrics, err := NewTrafficMetrics("", registry)
+	require.NoError(err)
+
+	mc := newMessageCreator(t)
+	nodeID := ids.GenerateTestNodeID()
+	chainID := ids.GenerateTestID()
+	tracker := newTrafficTracker(nodeID, metrics)
+
+	getMsg, err := mc.Get(chainID, 1, time.Second, ids.Empty, p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+	require.NoError(err)
+	pingMsg, err := mc.Ping()
+	require.NoError(err)
+
+	tracker.Sent(getMsg)
+	tracker.Sent(getMsg)
+	tracker.SendFailed(getMsg)
+	tracker.Sent(pingMsg)
+	tracker.Received(message.InboundAcceptedFrontier(chainID, 1, nil, nodeID), 100)
+	tracker.InboundThrottled(time.Second)
+
+	getBytes := uint64(len(getMsg.Bytes()))
+	pingBytes := uint64(len(pingMsg.Bytes()))
+
+	traffic := tracker.Traffic()
+	require.Equal(time.Second, traffic.InboundThrottleWait)
+	require.EqualValues(3, traffic.Sent.Messages)
+	require.EqualValues(2*getBytes+pingBytes, traffic.Sent.Bytes)
+	require.EqualValues(1, traffic.Failed.Messages)
+	require.EqualValues(1, traffic.Received.Messages)
+	require.EqualValues(100, traffic.Received.Bytes)
+
+	// Ping isn't specific to a chain
+	require.Len(traffic.Network, 1)
+	require.EqualValues(1, traffic.Network[message.PingOp.String()].Sent.Messages)
+	require.EqualValues(pingBytes, traffic.Network[message.PingOp.String()].Sent.Bytes)
+
+	require.Len(traffic.Chains, 1)
+	chainTraffic := traffic.Chains[chainID]
+	require.Len(chainTraffic, 2)
+	get := chainTraffic[message.GetOp.String()]
+	require.EqualValues(2, get.Sent.Messages)
+	require.EqualValues(2*getBytes, get.Sent.Bytes)
+	require.EqualValues(1, get.Failed.Messages)
+	require.EqualValues(getBytes, get.Failed.Bytes)
+	require.Zero(get.Received.Messages)
+	require.EqualValues(1, chainTraffic[message.AcceptedFrontierOp.String()].Received.Messages)
+
+	// The snapshot isn't modified by later traffic
+	tracker.Sent(pingMsg)
+	require.EqualValues(1, traffic.Network[message.PingOp.String()].Sent.Messages)
+
+	families, err := registry.Gather()
+	require.NoError(err)
+	require.Len(families, 3)
+
+	// Closing the tracker removes the peer's series
+	tracker.Close()
+	families, err = registry.Gather()
+	require.NoError(err)
+	require.Empty(families)
+
+	// Traffic reported after closing the tracker doesn't recreate the series
+	tracker.Sent(getMsg)
+	tracker.SendFailed(pingMsg)
+	tracker.Received(message.InboundAcceptedFrontier(chainID, 2, nil, nodeID), 100)
+	tracker.InboundThrottled(time.Second)
+	families, err = registry.Gather()
+	require.NoError(err)
+	require.Empty(families)
+}
+
+func TestTrafficTrackerWithoutMetrics(t *testing.T) {
+	require := require.New(t)
+
+	mc := newMessageCreator(t)
+	tracker := newTrafficTracker(ids.GenerateTestNodeID(), nil)
+
+	pingMsg, err := mc.Ping()
+	require.NoError(err)
+
+	tracker.Sent(pingMsg)
+	tracker.InboundThrottled(time.Second)
+	tracker.Close()
+
+	traffic := tracker.Traffic()
+	require.EqualValues(1, traffic.Sent.Messages)
+	require.Equal(time.Second, traffic.InboundThrottleWait)
+}
@@ -0,0 +1,270 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+)
+
+var (
+	_ Clock = realClock{}
+	_ Clock = (*ManualClock)(nil)
+
+	_ mockable.Timer  = manualTimer{}
+	_ mockable.Ticker = manualTicker{}
+
+	_ heap.Interface = (*timerHeap)(nil)
+)
+
+// Clock is the source of time of a simulated network. It schedules the
+// delivery of bytes over simulated links and drives the clocks and timers of
+// every node.
+type Clock interface {
+	mockable.Source
+	// After returns a channel that receives the current time once [d] has
+	// elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct {
+	clock mockable.Clock
+}
+
+func (c realClock) Now() time.Time {
+	return c.clock.Time()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (c realClock) NewTimer(d time.Duration) mockable.Timer {
+	return c.clock.NewTimer(d)
+}
+
+func (c realClock) NewTicker(d time.Duration) mockable.Ticker {
+	return c.clock.NewTicker(d)
+}
+
+// ManualClock is a deterministic clock whose time only moves when Advance or
+// Set is called.
+type ManualClock struct {
+	lock   sync.Mutex
+	now    time.Time
+	timers timerHeap
+}
+
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.now
+}
+
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+func (c *ManualClock) NewTimer(d time.Duration) mockable.Timer {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	t := c.newTimer(0)
+	c.schedule(t, d)
+	return manualTimer{timer: t}
+}
+
+func (c *ManualClock) NewTicker(d time.Duration) mockable.Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	t := c.newTimer(d)
+	c.schedule(t, d)
+	return manualTicker{timer: t}
+}
+
+// Advance moves the clock forward by [d] and fires every timer that expires
+// by the new time, in deadline order.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.set(c.now.Add(d))
+}
+
+// Set moves the clock to [now]. Moving the clock backwards doesn't fire any
+// timers.
+func (c *ManualClock) Set(now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.set(now)
+}
+
+// PendingTimers returns the number of timers and tickers that are scheduled to
+// fire.
+func (c *ManualClock) PendingTimers() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.timers)
+}
+
+// NextDeadline returns the time that the next timer or ticker fires at. Returns
+// false if nothing is scheduled.
+func (c *ManualClock) NextDeadline() (time.Time, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(c.timers) == 0 {
+		return time.Time{}, false
+	}
+	return c.timers[0].deadline, true
+}
+
+// assumes [c.lock] is held
+func (c *ManualClock) set(now time.Time) {
+	c.now = now
+	for len(c.timers) > 0 && !c.timers[0].deadline.After(now) {
+		t := heap.Pop(&c.timers).(*timer)
+		t.fire(now)
+		if t.period > 0 {
+			t.deadline = t.deadline.Add(t.period)
+			heap.Push(&c.timers, t)
+		}
+	}
+}
+
+// assumes [c.lock] is held
+func (c *ManualClock) newTimer(period time.Duration) *timer {
+	return &timer{
+		clock:  c,
+		period: period,
+		ch:     make(chan time.Time, 1),
+		index:  -1,
+	}
+}
+
+// assumes [c.lock] is held
+func (c *ManualClock) schedule(t *timer, d time.Duration) {
+	t.deadline = c.now.Add(d)
+	if !t.deadline.After(c.now) && t.period == 0 {
+		t.fire(c.now)
+		return
+	}
+	heap.Push(&c.timers, t)
+}
+
+// assumes [c.lock] is held
+func (c *ManualClock) unschedule(t *timer) bool {
+	if t.index < 0 {
+		return false
+	}
+	heap.Remove(&c.timers, t.index)
+	return true
+}
+
+type timer struct {
+	clock    *ManualClock
+	deadline time.Time
+	// If positive, the timer is rescheduled every [period] after it fires.
+	period time.Duration
+	ch     chan time.Time
+	// index of the timer in [clock.timers], or -1 if it isn't scheduled
+	index int
+}
+
+// fire sends [now] on the timer's channel. Like the timers of the time
+// package, the time is dropped if the previous one wasn't received yet.
+func (t *timer) fire(now time.Time) {
+	select {
+	case t.ch <- now:
+	default:
+	}
+}
+
+func (t *timer) stop() bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+
+	return t.clock.unschedule(t)
+}
+
+type manualTimer struct {
+	timer *timer
+}
+
+func (t manualTimer) C() <-chan time.Time {
+	return t.timer.ch
+}
+
+func (t manualTimer) Stop() bool {
+	return t.timer.stop()
+}
+
+func (t manualTimer) Reset(d time.Duration) bool {
+	c := t.timer.clock
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	active := c.unschedule(t.timer)
+	c.schedule(t.timer, d)
+	return active
+}
+
+type manualTicker struct {
+	timer *timer
+}
+
+func (t manualTicker) C() <-chan time.Time {
+	return t.timer.ch
+}
+
+func (t manualTicker) Stop() {
+	t.timer.stop()
+}
+
+type timerHeap []*timer
+
+func (h timerHeap) Len() int {
+	return len(h)
+}
+
+func (h timerHeap) Less(i, j int) bool {
+	return h[i].deadline.Before(h[j].deadline)
+}
+
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *timerHeap) Push(x interface{}) {
+	t := x.(*timer)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *timerHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	t.index = -1
+	*h = old[:n-1]
+	return t
+}
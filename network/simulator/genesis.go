@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/genesis"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/reward"
+)
+
+const (
+	// DefaultNetworkID is the ID of simulated networks unless configured
+	// otherwise.
+	DefaultNetworkID uint32 = 1337
+
+	initialStakeDuration       = 365 * 24 * time.Hour
+	initialStakeDurationOffset = 90 * time.Minute
+	delegationFee              = 20 * reward.PercentDenominator / 100
+)
+
+// newGenesis returns the genesis of a network that starts at [startTime] and
+// is validated by [nodeIDs].
+//
+// The allocations are the same as on the local network, so the funds of
+// [genesis.EWOQKey] can be spent on the P-chain, X-chain and C-chain.
+func newGenesis(networkID uint32, nodeIDs []ids.NodeID, startTime time.Time) *genesis.Config {
+	rewardAddress := genesis.EWOQKey.PublicKey().Address()
+
+	config := genesis.LocalConfig
+	config.NetworkID = networkID
+	config.StartTime = uint64(startTime.Unix())
+	config.InitialStakeDuration = uint64(initialStakeDuration / time.Second)
+	config.InitialStakeDurationOffset = uint64(initialStakeDurationOffset / time.Second)
+	config.InitialStakers = make([]genesis.Staker, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		config.InitialStakers[i] = genesis.Staker{
+			NodeID:        nodeID,
+			RewardAddress: rewardAddress,
+			DelegationFee: delegationFee,
+		}
+	}
+	config.Message = "simulated network"
+	return &config
+}
+
+// genesisContent returns [config] in the format of the genesis-content flag.
+func genesisContent(config *genesis.Config) (string, error) {
+	unparsed, err := config.Unparse()
+	if err != nil {
+		return "", err
+	}
+	bytes, err := json.Marshal(unparsed)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(bytes), nil
+}
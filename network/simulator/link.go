@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+)
+
+const (
+	// maxSegmentSize is the largest number of bytes that are delivered, or
+	// lost, as a unit.
+	maxSegmentSize = 1460
+
+	// minRetransmitTimeout is the smallest delay added to a lost segment.
+	minRetransmitTimeout = 200 * time.Millisecond
+)
+
+var (
+	errInvalidLoss   = errors.New("loss must be in [0, 1)")
+	errInvalidJitter = errors.New("jitter must be non-negative")
+	errInvalidDelay  = errors.New("latency must be non-negative")
+)
+
+// Link describes the conditions of the one-way link from one host to another.
+//
+// The simulated transport is reliable and ordered, like TCP. A lost segment
+// is therefore not dropped but retransmitted, delaying it and every segment
+// sent after it.
+type Link struct {
+	// Latency is the propagation delay of every segment.
+	Latency time.Duration `json:"latency"`
+	// Jitter is the upper bound of a uniformly random delay that is added to
+	// [Latency] for every segment.
+	Jitter time.Duration `json:"jitter"`
+	// Loss is the probability that a segment is lost.
+	Loss float64 `json:"loss"`
+	// Bandwidth is the number of bytes per second the link can carry. If 0,
+	// the bandwidth is unlimited.
+	Bandwidth uint64 `json:"bandwidth"`
+}
+
+func (l Link) Verify() error {
+	switch {
+	case l.Latency < 0:
+		return errInvalidDelay
+	case l.Jitter < 0:
+		return errInvalidJitter
+	case l.Loss < 0 || l.Loss >= 1:
+		return errInvalidLoss
+	default:
+		return nil
+	}
+}
+
+// transmissionTime returns how long the link is busy sending [numBytes].
+func (l Link) transmissionTime(numBytes int) time.Duration {
+	if l.Bandwidth == 0 {
+		return 0
+	}
+	return time.Duration(uint64(numBytes) * uint64(time.Second) / l.Bandwidth)
+}
+
+// retransmitTimeout returns how long it takes the sender to notice that a
+// segment was lost.
+func (l Link) retransmitTimeout() time.Duration {
+	rto := 2 * (l.Latency + l.Jitter)
+	if rto < minRetransmitTimeout {
+		return minRetransmitTimeout
+	}
+	return rto
+}
+
+// delay returns the time between a segment being fully sent and it being
+// received, including any retransmissions.
+func (l Link) delay(rng *rand.Rand) time.Duration {
+	delay := l.Latency
+	if l.Jitter > 0 {
+		delay += time.Duration(rng.Int63n(int64(l.Jitter)))
+	}
+	for l.Loss > 0 && rng.Float64() < l.Loss {
+		delay += l.retransmitTimeout()
+	}
+	return delay
+}
@@ -0,0 +1,393 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulator runs full nodes in a single process, connected by an
+// in-memory transport whose link conditions can be controlled by tests.
+//
+// Every node runs on the network's clock: the transport delivers bytes
+// according to it, and the nodes' request timeouts, gossip, pings, dial
+// backoffs, benchlisting, block building, uptimes and the timestamps that
+// they sign and verify all follow it. With a ManualClock, time only moves when
+// the test advances it. Resource throttling, health checks, the API servers
+// and metrics still use the wall clock.
+//
+// Given the same seed, clock and sequence of writes, the transport makes the
+// same delivery, loss and jitter decisions. The nodes still run their own
+// goroutines, so two runs of the same network aren't guaranteed to interleave
+// messages identically. Tests should assert outcomes, such as which
+// transactions are accepted, rather than exact orderings.
+package simulator
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/api/health"
+	"github.com/MetalBlockchain/metalgo/config"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/genesis"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/node"
+	"github.com/MetalBlockchain/metalgo/staking"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
+	"github.com/MetalBlockchain/metalgo/utils/ips"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const (
+	stakingPort        = 9651
+	healthPollInterval = 250 * time.Millisecond
+)
+
+var (
+	errNoNodes              = errors.New("at least one node is required")
+	errNoDir                = errors.New("a directory is required")
+	errStandardNetworkID    = errors.New("can't simulate a standard network")
+	errUnknownNode          = errors.New("unknown node")
+	errUnhealthy            = errors.New("node isn't healthy")
+	errNetworkAlreadyActive = errors.New("network was already started")
+)
+
+// Config describes a simulated network.
+type Config struct {
+	// NumNodes is the number of nodes in the network. Every node is a genesis
+	// validator with the same weight.
+	NumNodes int
+	// Dir is the directory that the nodes write their logs and chain data to.
+	Dir string
+	// NetworkID of the network. If 0, [DefaultNetworkID] is used.
+	NetworkID uint32
+	// Seed of the transport's random decisions.
+	Seed int64
+	// Clock that schedules the delivery of bytes and that every node's clock
+	// and timers run on. If nil, the wall clock is used.
+	//
+	// With a ManualClock, nothing that depends on the passage of time, such
+	// as delivering bytes, timing out requests or building blocks, happens
+	// until the clock is advanced.
+	Clock Clock
+	// Link are the initial conditions of every link between two nodes.
+	Link Link
+	// Flags are passed to every node, on top of the simulator's defaults.
+	Flags map[string]interface{}
+}
+
+// Node is a full node that runs in the simulated network.
+type Node struct {
+	ID ids.NodeID
+	// IP is the address that the node is reachable at in the simulated
+	// network.
+	IP ips.IPPort
+	// URI of the node's API server, which listens on the loopback interface.
+	URI string
+
+	config     node.Config
+	logFactory logging.Factory
+	log        logging.Logger
+	node       *node.Node
+	done       chan struct{}
+}
+
+// Network runs full nodes in the current process. The nodes connect to each
+// other over a simulated transport that lets tests control the latency, loss,
+// bandwidth and partitioning of each link.
+type Network struct {
+	Transport *Transport
+	Genesis   *genesis.Config
+	Nodes     []*Node
+
+	started bool
+}
+
+// New creates the nodes of a network. The nodes are started by Start.
+func New(c Config) (*Network, error) {
+	switch {
+	case c.NumNodes <= 0:
+		return nil, errNoNodes
+	case c.Dir == "":
+		return nil, errNoDir
+	}
+	if c.NetworkID == 0 {
+		c.NetworkID = DefaultNetworkID
+	}
+	switch c.NetworkID {
+	case constants.MainnetID, constants.TahoeID, constants.LocalID:
+		return nil, fmt.Errorf("%w: %s", errStandardNetworkID, constants.NetworkName(c.NetworkID))
+	}
+
+	transport, err := NewTransport(c.Clock, c.Seed, c.Link)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		keys    = make([]stakingKeys, c.NumNodes)
+		nodeIDs = make([]ids.NodeID, c.NumNodes)
+		n       = &Network{
+			Transport: transport,
+			Nodes:     make([]*Node, c.NumNodes),
+		}
+	)
+	for i := range keys {
+		keys[i], err = newStakingKeys()
+		if err != nil {
+			return nil, err
+		}
+		nodeIDs[i] = keys[i].nodeID
+	}
+
+	n.Genesis = newGenesis(c.NetworkID, nodeIDs, transport.clock.Now().Add(-time.Minute))
+	content, err := genesisContent(n.Genesis)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, nodeID := range nodeIDs {
+		ip := ips.IPPort{
+			IP:   nodeIP(i),
+			Port: stakingPort,
+		}
+		httpPort, err := freePort()
+		if err != nil {
+			return nil, err
+		}
+
+		flags := map[string]interface{}{
+			config.NetworkNameKey:             c.NetworkID,
+			config.GenesisConfigContentKey:    content,
+			config.DataDirKey:                 filepath.Join(c.Dir, fmt.Sprintf("node%d", i)),
+			config.DBTypeKey:                  memdb.Name,
+			config.LogDisplayLevelKey:         logging.Off.String(),
+			config.HTTPHostKey:                "127.0.0.1",
+			config.HTTPPortKey:                httpPort,
+			config.PublicIPKey:                ip.IP.String(),
+			config.StakingPortKey:             ip.Port,
+			config.StakingTLSKeyContentKey:    keys[i].tlsKey,
+			config.StakingCertContentKey:      keys[i].tlsCert,
+			config.StakingSignerKeyContentKey: keys[i].signer,
+			config.NetworkAllowPrivateIPsKey:  true,
+			config.IndexEnabledKey:            false,
+		}
+		// Every node bootstraps from the first node, which bootstraps alone.
+		if i > 0 {
+			flags[config.BootstrapIPsKey] = n.Nodes[0].IP.String()
+			flags[config.BootstrapIDsKey] = n.Nodes[0].ID.String()
+		}
+		for key, value := range c.Flags {
+			flags[key] = value
+		}
+
+		nodeConfig, err := newNodeConfig(flags)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't create config of node %d: %w", i, err)
+		}
+		nodeConfig.NetworkDialer = transport.Dialer(ip.IP)
+		if c.Clock != nil {
+			nodeConfig.Clock.SetSource(c.Clock)
+		}
+
+		n.Nodes[i] = &Node{
+			ID:     nodeID,
+			IP:     ip,
+			URI:    fmt.Sprintf("http://127.0.0.1:%d", httpPort),
+			config: nodeConfig,
+			done:   make(chan struct{}),
+		}
+	}
+	return n, nil
+}
+
+// Start initializes every node and connects it to the network.
+func (n *Network) Start() error {
+	if n.started {
+		return errNetworkAlreadyActive
+	}
+	n.started = true
+
+	for i, node := range n.Nodes {
+		if err := node.start(n.Transport); err != nil {
+			return fmt.Errorf("couldn't start node %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Stop shuts down every node and waits for them to exit.
+func (n *Network) Stop() {
+	for _, node := range n.Nodes {
+		node.stop()
+	}
+}
+
+// AwaitHealthy blocks until every node reports that it is healthy.
+func (n *Network) AwaitHealthy(ctx context.Context) error {
+	for i, node := range n.Nodes {
+		client := health.NewClient(node.URI)
+		healthy, err := health.AwaitHealthy(ctx, client, healthPollInterval)
+		if err != nil {
+			return fmt.Errorf("node %d didn't become healthy: %w", i, err)
+		}
+		if !healthy {
+			return fmt.Errorf("%w: node %d", errUnhealthy, i)
+		}
+	}
+	return nil
+}
+
+// SetLink sets the conditions of the link from node [from] to node [to].
+func (n *Network) SetLink(from, to int, link Link) error {
+	fromNode, err := n.node(from)
+	if err != nil {
+		return err
+	}
+	toNode, err := n.node(to)
+	if err != nil {
+		return err
+	}
+	return n.Transport.SetLink(fromNode.IP.IP, toNode.IP.IP, link)
+}
+
+// Partition splits the nodes, identified by their index, into [groups]. See
+// Transport.Partition.
+func (n *Network) Partition(groups ...[]int) error {
+	ipGroups := make([][]net.IP, len(groups))
+	for i, group := range groups {
+		for _, index := range group {
+			node, err := n.node(index)
+			if err != nil {
+				return err
+			}
+			ipGroups[i] = append(ipGroups[i], node.IP.IP)
+		}
+	}
+	n.Transport.Partition(ipGroups...)
+	return nil
+}
+
+// Heal removes any partition.
+func (n *Network) Heal() {
+	n.Transport.Heal()
+}
+
+func (n *Network) node(index int) (*Node, error) {
+	if index < 0 || index >= len(n.Nodes) {
+		return nil, fmt.Errorf("%w: %d", errUnknownNode, index)
+	}
+	return n.Nodes[index], nil
+}
+
+func (n *Node) start(transport *Transport) error {
+	listener, err := transport.Listen(n.IP)
+	if err != nil {
+		return err
+	}
+	n.config.NetworkListener = listener
+
+	n.logFactory = logging.NewFactory(n.config.LoggingConfig)
+	n.log, err = n.logFactory.Make("main")
+	if err != nil {
+		n.logFactory.Close()
+		_ = listener.Close()
+		return err
+	}
+
+	n.node = &node.Node{}
+	if err := n.node.Initialize(&n.config, n.log, n.logFactory); err != nil {
+		n.node = nil
+		n.log.Stop()
+		n.logFactory.Close()
+		_ = listener.Close()
+		return err
+	}
+
+	go func() {
+		defer close(n.done)
+		defer n.logFactory.Close()
+		defer n.log.Stop()
+
+		err := n.node.Dispatch()
+		n.log.Debug("dispatch returned",
+			zap.Error(err),
+		)
+	}()
+	return nil
+}
+
+func (n *Node) stop() {
+	if n.node == nil {
+		return
+	}
+	n.node.Shutdown(0)
+	<-n.done
+}
+
+type stakingKeys struct {
+	nodeID ids.NodeID
+	// base64 encoded keys, in the format of the node's flags
+	tlsKey  string
+	tlsCert string
+	signer  string
+}
+
+func newStakingKeys() (stakingKeys, error) {
+	certBytes, keyBytes, err := staking.NewCertAndKeyBytes()
+	if err != nil {
+		return stakingKeys{}, err
+	}
+	cert, err := staking.LoadTLSCertFromBytes(keyBytes, certBytes)
+	if err != nil {
+		return stakingKeys{}, err
+	}
+	signer, err := bls.NewSecretKey()
+	if err != nil {
+		return stakingKeys{}, err
+	}
+	return stakingKeys{
+		nodeID:  ids.NodeIDFromCert(cert.Leaf),
+		tlsKey:  base64.StdEncoding.EncodeToString(keyBytes),
+		tlsCert: base64.StdEncoding.EncodeToString(certBytes),
+		signer:  base64.StdEncoding.EncodeToString(bls.SecretKeyToBytes(signer)),
+	}, nil
+}
+
+// newNodeConfig parses [flags] the same way as command line arguments.
+func newNodeConfig(flags map[string]interface{}) (node.Config, error) {
+	args := make([]string, 0, len(flags))
+	for key, value := range flags {
+		args = append(args, fmt.Sprintf("--%s=%v", key, value))
+	}
+
+	v, err := config.BuildViper(config.BuildFlagSet(), args)
+	if err != nil {
+		return node.Config{}, err
+	}
+	return config.GetNodeConfig(v)
+}
+
+// nodeIP returns the IP of the [i]'th node in the simulated network.
+func nodeIP(i int) net.IP {
+	i++
+	return net.IPv4(10, byte(i>>16), byte(i>>8), byte(i))
+}
+
+// freePort returns a port that is currently available on the loopback
+// interface.
+func freePort() (uint16, error) {
+	l, err := net.Listen(constants.NetworkType, "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	errs := wrappers.Errs{}
+	ip, err := ips.ToIPPort(l.Addr().String())
+	errs.Add(err, l.Close())
+	return ip.Port, errs.Err
+}
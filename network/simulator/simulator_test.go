@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+)
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		err    error
+	}{
+		{
+			name: "no nodes",
+			config: Config{
+				Dir: t.TempDir(),
+			},
+			err: errNoNodes,
+		},
+		{
+			name: "no dir",
+			config: Config{
+				NumNodes: 1,
+			},
+			err: errNoDir,
+		},
+		{
+			name: "standard network",
+			config: Config{
+				NumNodes:  1,
+				Dir:       t.TempDir(),
+				NetworkID: constants.LocalID,
+			},
+			err: errStandardNetworkID,
+		},
+		{
+			name: "invalid link",
+			config: Config{
+				NumNodes: 1,
+				Dir:      t.TempDir(),
+				Link:     Link{Loss: 1},
+			},
+			err: errInvalidLoss,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := New(test.config)
+			require.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+func TestNewUsesClock(t *testing.T) {
+	require := require.New(t)
+
+	clock := NewManualClock(time.Unix(1_000_000, 0))
+	n, err := New(Config{
+		NumNodes: 2,
+		Dir:      t.TempDir(),
+		Clock:    clock,
+	})
+	require.NoError(err)
+
+	require.Equal(uint64(clock.Now().Add(-time.Minute).Unix()), n.Genesis.StartTime)
+	for _, node := range n.Nodes {
+		require.Equal(clock, node.config.Clock.Source())
+		require.Equal(clock.Now(), node.config.Clock.Time())
+	}
+}
@@ -0,0 +1,612 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/network/dialer"
+	"github.com/MetalBlockchain/metalgo/utils/ips"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+)
+
+const (
+	firstEphemeralPort = 49152
+	acceptBacklog      = 128
+)
+
+var (
+	_ net.Listener  = (*listener)(nil)
+	_ net.Conn      = (*conn)(nil)
+	_ dialer.Dialer = (*hostDialer)(nil)
+
+	errAddressInUse      = errors.New("address already in use")
+	errConnectionRefused = errors.New("connection refused")
+	errConnectionReset   = errors.New("connection reset by peer")
+	errHostUnreachable   = errors.New("host unreachable")
+)
+
+// Transport is an in-memory network that connects simulated hosts, identified
+// by their IPs, with reliable and ordered byte streams.
+//
+// The delivery of every segment is scheduled on the transport's clock
+// according to the conditions of the link it is sent over. Random decisions,
+// such as jitter and loss, are drawn from a seeded source, so a transport that
+// is driven by a single goroutine and a ManualClock behaves deterministically.
+// When full nodes write to the transport concurrently, the order in which their
+// writes draw from the source, and therefore the run, isn't reproducible.
+type Transport struct {
+	clock Clock
+
+	lock        sync.Mutex
+	rng         *rand.Rand
+	defaultLink Link
+	// (from, to) --> link conditions that override [defaultLink]
+	links map[route]Link
+	// host --> partition the host belongs to. If nil, the network isn't
+	// partitioned.
+	partitions map[string]int
+	// IP:port --> listener bound to it
+	listeners map[string]*listener
+	// pipes that must be notified when the network's conditions change
+	pipes    map[*pipe]struct{}
+	nextPort uint16
+}
+
+type route struct {
+	from, to string
+}
+
+// NewTransport returns a transport whose links all have the [defaultLink]
+// conditions. If [clock] is nil, the wall clock is used.
+func NewTransport(clock Clock, seed int64, defaultLink Link) (*Transport, error) {
+	if err := defaultLink.Verify(); err != nil {
+		return nil, err
+	}
+	if clock == nil {
+		clock = realClock{}
+	}
+	return &Transport{
+		clock:       clock,
+		rng:         rand.New(rand.NewSource(seed)), // #nosec G404
+		defaultLink: defaultLink,
+		links:       make(map[route]Link),
+		listeners:   make(map[string]*listener),
+		pipes:       make(map[*pipe]struct{}),
+		nextPort:    firstEphemeralPort,
+	}, nil
+}
+
+// SetLink sets the conditions of the link from [from] to [to]. Segments that
+// were already sent are not affected.
+func (t *Transport) SetLink(from, to net.IP, link Link) error {
+	if err := link.Verify(); err != nil {
+		return err
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.links[route{from: hostKey(from), to: hostKey(to)}] = link
+	return nil
+}
+
+// Partition splits the hosts into [groups]. Hosts can only reach hosts in
+// the same group. Hosts that aren't in any group can only reach each other.
+//
+// Bytes sent across a partition are held, rather than dropped, until the
+// partition is healed. New connections across a partition fail.
+func (t *Transport) Partition(groups ...[]net.IP) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.partitions = make(map[string]int)
+	for i, group := range groups {
+		for _, ip := range group {
+			// Group 0 is reserved for the hosts that aren't in any group.
+			t.partitions[hostKey(ip)] = i + 1
+		}
+	}
+	t.notifyAll()
+}
+
+// Heal removes any partition.
+func (t *Transport) Heal() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.partitions = nil
+	t.notifyAll()
+}
+
+// Listen returns a listener that accepts the connections dialed to [ip].
+func (t *Transport) Listen(ip ips.IPPort) (net.Listener, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	key := ip.String()
+	if _, ok := t.listeners[key]; ok {
+		return nil, fmt.Errorf("%w: %s", errAddressInUse, key)
+	}
+	l := &listener{
+		transport: t,
+		key:       key,
+		addr: &net.TCPAddr{
+			IP:   ip.IP,
+			Port: int(ip.Port),
+		},
+		conns:  make(chan *conn, acceptBacklog),
+		closed: make(chan struct{}),
+	}
+	t.listeners[key] = l
+	return l, nil
+}
+
+// Dialer returns a dialer that opens connections from the host [ip].
+func (t *Transport) Dialer(ip net.IP) dialer.Dialer {
+	return &hostDialer{
+		transport: t,
+		ip:        ip,
+	}
+}
+
+// Dial opens a connection from the host [from] to the listener bound to [to].
+// Establishing the connection takes a round trip.
+func (t *Transport) Dial(ctx context.Context, from net.IP, to ips.IPPort) (net.Conn, error) {
+	fromKey := hostKey(from)
+	toKey := hostKey(to.IP)
+
+	t.lock.Lock()
+	if !t.reachable(fromKey, toKey) {
+		t.lock.Unlock()
+		return nil, fmt.Errorf("%w: %s", errHostUnreachable, to)
+	}
+	l, ok := t.listeners[to.String()]
+	if !ok {
+		t.lock.Unlock()
+		return nil, fmt.Errorf("%w: %s", errConnectionRefused, to)
+	}
+	port := t.nextPort
+	t.nextPort++
+	if t.nextPort == 0 {
+		t.nextPort = firstEphemeralPort
+	}
+	rtt := t.link(fromKey, toKey).delay(t.rng) + t.link(toKey, fromKey).delay(t.rng)
+	forward := t.newPipe(fromKey, toKey)
+	backward := t.newPipe(toKey, fromKey)
+	t.lock.Unlock()
+
+	select {
+	case <-t.clock.After(rtt):
+	case <-ctx.Done():
+		forward.close()
+		backward.close()
+		return nil, ctx.Err()
+	}
+
+	localAddr := &net.TCPAddr{
+		IP:   from,
+		Port: int(port),
+	}
+	remoteAddr := &net.TCPAddr{
+		IP:   to.IP,
+		Port: int(to.Port),
+	}
+	client := &conn{
+		localAddr:  localAddr,
+		remoteAddr: remoteAddr,
+		reader:     backward,
+		writer:     forward,
+	}
+	server := &conn{
+		localAddr:  remoteAddr,
+		remoteAddr: localAddr,
+		reader:     forward,
+		writer:     backward,
+	}
+
+	if !t.isReachable(fromKey, toKey) || !l.enqueue(server) {
+		forward.close()
+		backward.close()
+		return nil, fmt.Errorf("%w: %s", errConnectionRefused, to)
+	}
+	return client, nil
+}
+
+// assumes [t.lock] is held
+func (t *Transport) link(from, to string) Link {
+	if link, ok := t.links[route{from: from, to: to}]; ok {
+		return link
+	}
+	return t.defaultLink
+}
+
+// assumes [t.lock] is held
+func (t *Transport) reachable(from, to string) bool {
+	return t.partitions == nil || t.partitions[from] == t.partitions[to]
+}
+
+func (t *Transport) isReachable(from, to string) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.reachable(from, to)
+}
+
+// assumes [t.lock] is held
+func (t *Transport) notifyAll() {
+	for p := range t.pipes {
+		p.notify()
+	}
+}
+
+// assumes [t.lock] is held
+func (t *Transport) newPipe(from, to string) *pipe {
+	p := &pipe{
+		transport: t,
+		from:      from,
+		to:        to,
+		signal:    make(chan struct{}, 1),
+	}
+	t.pipes[p] = struct{}{}
+	return p
+}
+
+func (t *Transport) removePipe(p *pipe) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.pipes, p)
+}
+
+func (t *Transport) removeListener(l *listener) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.listeners, l.key)
+}
+
+// schedule returns when each of the segments of a message of [numBytes],
+// sent at [now] after the previous transmission ends at [busyUntil], is
+// delivered.
+func (t *Transport) schedule(from, to string, now, busyUntil time.Time, numBytes int) ([]time.Time, time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	link := t.link(from, to)
+	numSegments := (numBytes + maxSegmentSize - 1) / maxSegmentSize
+	deliveries := make([]time.Time, numSegments)
+	for i := range deliveries {
+		segmentSize := maxSegmentSize
+		if i == numSegments-1 {
+			segmentSize = numBytes - i*maxSegmentSize
+		}
+		if busyUntil.Before(now) {
+			busyUntil = now
+		}
+		busyUntil = busyUntil.Add(link.transmissionTime(segmentSize))
+		deliveries[i] = busyUntil.Add(link.delay(t.rng))
+	}
+	return deliveries, busyUntil
+}
+
+// hostKey returns the representation of [ip] that is used to look up the
+// conditions of its links.
+func hostKey(ip net.IP) string {
+	return ip.To16().String()
+}
+
+type hostDialer struct {
+	transport *Transport
+	ip        net.IP
+}
+
+func (d *hostDialer) Dial(ctx context.Context, ip ips.IPPort) (net.Conn, error) {
+	return d.transport.Dial(ctx, d.ip, ip)
+}
+
+type listener struct {
+	transport *Transport
+	key       string
+	addr      net.Addr
+
+	conns     chan *conn
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *listener) Close() error {
+	l.closeOnce.Do(func() {
+		l.transport.removeListener(l)
+		close(l.closed)
+	})
+	return nil
+}
+
+func (l *listener) Addr() net.Addr {
+	return l.addr
+}
+
+// enqueue returns false if [c] can't be accepted.
+func (l *listener) enqueue(c *conn) bool {
+	select {
+	case <-l.closed:
+		return false
+	default:
+	}
+
+	select {
+	case l.conns <- c:
+		return true
+	default:
+		return false
+	}
+}
+
+type segment struct {
+	bytes     []byte
+	deliverAt time.Time
+}
+
+// pipe carries bytes in one direction of a connection.
+type pipe struct {
+	transport *Transport
+	from, to  string
+
+	// signal is notified when the pipe may have become readable
+	signal chan struct{}
+
+	lock         sync.Mutex
+	segments     []segment
+	busyUntil    time.Time
+	lastDelivery time.Time
+	readDeadline time.Time
+	writeClosed  bool
+	readClosed   bool
+}
+
+func (p *pipe) notify() {
+	select {
+	case p.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (p *pipe) write(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+
+	now := p.transport.clock.Now()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	switch {
+	case p.writeClosed:
+		return 0, net.ErrClosed
+	case p.readClosed:
+		return 0, errConnectionReset
+	}
+
+	deliveries, busyUntil := p.transport.schedule(p.from, p.to, now, p.busyUntil, len(b))
+	p.busyUntil = busyUntil
+	for i, deliverAt := range deliveries {
+		// Segments are delivered in order, so a segment can't overtake the
+		// segments that were sent before it.
+		if deliverAt.Before(p.lastDelivery) {
+			deliverAt = p.lastDelivery
+		}
+		p.lastDelivery = deliverAt
+
+		end := (i + 1) * maxSegmentSize
+		if end > len(b) {
+			end = len(b)
+		}
+		p.segments = append(p.segments, segment{
+			bytes:     append([]byte(nil), b[i*maxSegmentSize:end]...),
+			deliverAt: deliverAt,
+		})
+	}
+	p.notify()
+	return len(b), nil
+}
+
+func (p *pipe) read(b []byte) (int, error) {
+	for {
+		reachable := p.transport.isReachable(p.from, p.to)
+		now := p.transport.clock.Now()
+
+		p.lock.Lock()
+		if p.readClosed {
+			p.lock.Unlock()
+			return 0, net.ErrClosed
+		}
+
+		var (
+			nextDelivery time.Duration
+			waitDelivery bool
+		)
+		switch {
+		case len(p.segments) > 0 && reachable:
+			next := &p.segments[0]
+			if !next.deliverAt.After(now) {
+				n := copy(b, next.bytes)
+				next.bytes = next.bytes[n:]
+				if len(next.bytes) == 0 {
+					p.segments[0] = segment{}
+					p.segments = p.segments[1:]
+				}
+				p.lock.Unlock()
+				return n, nil
+			}
+			nextDelivery = next.deliverAt.Sub(now)
+			waitDelivery = true
+		case len(p.segments) == 0 && p.writeClosed:
+			p.lock.Unlock()
+			return 0, io.EOF
+		}
+
+		var deadlineTimer mockable.Timer
+		if !p.readDeadline.IsZero() {
+			untilDeadline := p.readDeadline.Sub(now)
+			if untilDeadline <= 0 {
+				p.lock.Unlock()
+				return 0, os.ErrDeadlineExceeded
+			}
+			deadlineTimer = p.transport.clock.NewTimer(untilDeadline)
+		}
+		p.lock.Unlock()
+
+		if err := p.wait(waitDelivery, nextDelivery, deadlineTimer); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// wait blocks until the pipe is notified, the next segment is delivered in
+// [nextDelivery] or [deadlineTimer] fires.
+func (p *pipe) wait(waitDelivery bool, nextDelivery time.Duration, deadlineTimer mockable.Timer) error {
+	var (
+		delivered <-chan time.Time
+		deadline  <-chan time.Time
+	)
+	if waitDelivery {
+		delivered = p.transport.clock.After(nextDelivery)
+	}
+	if deadlineTimer != nil {
+		defer deadlineTimer.Stop()
+		deadline = deadlineTimer.C()
+	}
+
+	select {
+	case <-p.signal:
+		return nil
+	case <-delivered:
+		return nil
+	case <-deadline:
+		return os.ErrDeadlineExceeded
+	}
+}
+
+func (p *pipe) setReadDeadline(t time.Time) {
+	p.lock.Lock()
+	p.readDeadline = t
+	p.lock.Unlock()
+
+	p.notify()
+}
+
+// closeWrite stops the writer from sending more bytes. The reader receives
+// io.EOF once it has read the bytes that were already sent.
+func (p *pipe) closeWrite() {
+	p.lock.Lock()
+	p.writeClosed = true
+	done := p.readClosed
+	p.lock.Unlock()
+
+	p.notify()
+	if done {
+		p.transport.removePipe(p)
+	}
+}
+
+// closeRead discards any unread bytes and fails future writes.
+func (p *pipe) closeRead() {
+	p.lock.Lock()
+	p.readClosed = true
+	p.segments = nil
+	done := p.writeClosed
+	p.lock.Unlock()
+
+	p.notify()
+	if done {
+		p.transport.removePipe(p)
+	}
+}
+
+func (p *pipe) close() {
+	p.closeWrite()
+	p.closeRead()
+}
+
+type conn struct {
+	localAddr  net.Addr
+	remoteAddr net.Addr
+	reader     *pipe
+	writer     *pipe
+
+	closeOnce     sync.Once
+	lock          sync.Mutex
+	writeDeadline time.Time
+}
+
+func (c *conn) Read(b []byte) (int, error) {
+	return c.reader.read(b)
+}
+
+// Write never blocks, the bytes are queued for delivery immediately.
+func (c *conn) Write(b []byte) (int, error) {
+	c.lock.Lock()
+	writeDeadline := c.writeDeadline
+	c.lock.Unlock()
+
+	if !writeDeadline.IsZero() && !c.writer.transport.clock.Now().Before(writeDeadline) {
+		return 0, os.ErrDeadlineExceeded
+	}
+	return c.writer.write(b)
+}
+
+func (c *conn) Close() error {
+	c.closeOnce.Do(func() {
+		c.writer.closeWrite()
+		c.reader.closeRead()
+	})
+	return nil
+}
+
+func (c *conn) LocalAddr() net.Addr {
+	return c.localAddr
+}
+
+func (c *conn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+// Deadlines are measured with the transport's clock, which also drives the
+// nodes that set them.
+func (c *conn) SetDeadline(t time.Time) error {
+	c.reader.setReadDeadline(t)
+	return c.SetWriteDeadline(t)
+}
+
+func (c *conn) SetReadDeadline(t time.Time) error {
+	c.reader.setReadDeadline(t)
+	return nil
+}
+
+func (c *conn) SetWriteDeadline(t time.Time) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.writeDeadline = t
+	return nil
+}
@@ -0,0 +1,415 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/utils/ips"
+)
+
+var (
+	hostA = net.IPv4(10, 0, 0, 1)
+	hostB = net.IPv4(10, 0, 0, 2)
+	hostC = net.IPv4(10, 0, 0, 3)
+)
+
+// connect returns both ends of a connection from [from] to [to] over links
+// without any delay.
+func connect(t *testing.T, transport *Transport, from net.IP, to net.IP) (net.Conn, net.Conn) {
+	require := require.New(t)
+
+	l, err := transport.Listen(ips.IPPort{IP: to, Port: 9651})
+	require.NoError(err)
+	defer l.Close()
+
+	client, err := transport.Dial(context.Background(), from, ips.IPPort{IP: to, Port: 9651})
+	require.NoError(err)
+	server, err := l.Accept()
+	require.NoError(err)
+	return client, server
+}
+
+// readNow returns the bytes that have been delivered to [c] by the current time
+// of its transport's clock.
+func readNow(t *testing.T, c net.Conn, size int) []byte {
+	require := require.New(t)
+
+	now := c.(*conn).reader.transport.clock.Now()
+	require.NoError(c.SetReadDeadline(now))
+	var read []byte
+	for len(read) < size {
+		b := make([]byte, size-len(read))
+		n, err := c.Read(b)
+		if err == os.ErrDeadlineExceeded {
+			break
+		}
+		require.NoError(err)
+		read = append(read, b[:n]...)
+	}
+	require.NoError(c.SetReadDeadline(time.Time{}))
+	return read
+}
+
+func TestLinkVerify(t *testing.T) {
+	tests := []struct {
+		name string
+		link Link
+		err  error
+	}{
+		{
+			name: "valid",
+			link: Link{
+				Latency:   time.Millisecond,
+				Jitter:    time.Millisecond,
+				Loss:      .5,
+				Bandwidth: 1,
+			},
+		},
+		{
+			name: "negative latency",
+			link: Link{Latency: -1},
+			err:  errInvalidDelay,
+		},
+		{
+			name: "negative jitter",
+			link: Link{Jitter: -1},
+			err:  errInvalidJitter,
+		},
+		{
+			name: "total loss",
+			link: Link{Loss: 1},
+			err:  errInvalidLoss,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.link.Verify(), test.err)
+		})
+	}
+}
+
+func TestLinkDelayDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	link := Link{
+		Latency: 10 * time.Millisecond,
+		Jitter:  5 * time.Millisecond,
+		Loss:    .5,
+	}
+	rng0 := rand.New(rand.NewSource(1)) // #nosec G404
+	rng1 := rand.New(rand.NewSource(1)) // #nosec G404
+
+	retransmitted := false
+	for i := 0; i < 100; i++ {
+		delay := link.delay(rng0)
+		require.Equal(delay, link.delay(rng1))
+		require.GreaterOrEqual(delay, link.Latency)
+		if delay >= link.Latency+link.retransmitTimeout() {
+			retransmitted = true
+		}
+	}
+	require.True(retransmitted)
+}
+
+func TestTransportLatency(t *testing.T) {
+	require := require.New(t)
+
+	clock := NewManualClock(time.Unix(0, 0))
+	transport, err := NewTransport(clock, 0, Link{})
+	require.NoError(err)
+
+	client, server := connect(t, transport, hostA, hostB)
+	require.NoError(transport.SetLink(hostA, hostB, Link{Latency: 100 * time.Millisecond}))
+
+	_, err = client.Write([]byte("hello"))
+	require.NoError(err)
+	require.Empty(readNow(t, server, 5))
+
+	clock.Advance(99 * time.Millisecond)
+	require.Empty(readNow(t, server, 5))
+
+	clock.Advance(time.Millisecond)
+	require.Equal([]byte("hello"), readNow(t, server, 5))
+
+	// The reverse link isn't affected
+	_, err = server.Write([]byte("world"))
+	require.NoError(err)
+	require.Equal([]byte("world"), readNow(t, client, 5))
+}
+
+func TestTransportDialLatency(t *testing.T) {
+	require := require.New(t)
+
+	clock := NewManualClock(time.Unix(0, 0))
+	transport, err := NewTransport(clock, 0, Link{Latency: time.Second})
+	require.NoError(err)
+
+	l, err := transport.Listen(ips.IPPort{IP: hostB, Port: 9651})
+	require.NoError(err)
+
+	dialed := make(chan error, 1)
+	go func() {
+		_, err := transport.Dial(context.Background(), hostA, ips.IPPort{IP: hostB, Port: 9651})
+		dialed <- err
+	}()
+
+	// The connection is established after a round trip
+	require.Eventually(func() bool {
+		return clock.PendingTimers() == 1
+	}, time.Second, time.Millisecond)
+	clock.Advance(2*time.Second - 1)
+	require.Empty(dialed)
+	clock.Advance(1)
+	require.NoError(<-dialed)
+
+	server, err := l.Accept()
+	require.NoError(err)
+	require.Equal(hostA.String(), server.RemoteAddr().(*net.TCPAddr).IP.String())
+}
+
+func TestTransportBandwidth(t *testing.T) {
+	require := require.New(t)
+
+	clock := NewManualClock(time.Unix(0, 0))
+	transport, err := NewTransport(clock, 0, Link{})
+	require.NoError(err)
+
+	client, server := connect(t, transport, hostA, hostB)
+	require.NoError(transport.SetLink(hostA, hostB, Link{Bandwidth: 1000}))
+
+	msg := make([]byte, 2*maxSegmentSize)
+	_, err = client.Write(msg)
+	require.NoError(err)
+
+	require.Empty(readNow(t, server, len(msg)))
+
+	// A segment is delivered once all of its bytes have been sent
+	clock.Advance(time.Second)
+	require.Empty(readNow(t, server, len(msg)))
+
+	clock.Advance(time.Duration(maxSegmentSize)*time.Millisecond - time.Second)
+	require.Len(readNow(t, server, len(msg)), maxSegmentSize)
+
+	clock.Advance(time.Duration(maxSegmentSize) * time.Millisecond)
+	require.Len(readNow(t, server, len(msg)), maxSegmentSize)
+}
+
+func TestTransportInOrderDelivery(t *testing.T) {
+	require := require.New(t)
+
+	clock := NewManualClock(time.Unix(0, 0))
+	transport, err := NewTransport(clock, 0, Link{})
+	require.NoError(err)
+
+	client, server := connect(t, transport, hostA, hostB)
+
+	require.NoError(transport.SetLink(hostA, hostB, Link{Latency: time.Second}))
+	_, err = client.Write([]byte("slow"))
+	require.NoError(err)
+
+	// A faster link can't deliver the later bytes before the earlier ones
+	require.NoError(transport.SetLink(hostA, hostB, Link{}))
+	_, err = client.Write([]byte("fast"))
+	require.NoError(err)
+	require.Empty(readNow(t, server, 8))
+
+	clock.Advance(time.Second)
+	require.Equal([]byte("slowfast"), readNow(t, server, 8))
+}
+
+func TestTransportPartition(t *testing.T) {
+	require := require.New(t)
+
+	transport, err := NewTransport(nil, 0, Link{})
+	require.NoError(err)
+
+	client, server := connect(t, transport, hostA, hostB)
+
+	transport.Partition([]net.IP{hostA}, []net.IP{hostB, hostC})
+
+	// Bytes are held until the partition is healed
+	_, err = client.Write([]byte("hello"))
+	require.NoError(err)
+	require.Empty(readNow(t, server, 5))
+
+	// New connections across the partition fail
+	_, err = transport.Listen(ips.IPPort{IP: hostC, Port: 9651})
+	require.NoError(err)
+	_, err = transport.Dial(context.Background(), hostA, ips.IPPort{IP: hostC, Port: 9651})
+	require.ErrorIs(err, errHostUnreachable)
+
+	// Hosts in the same group can still connect
+	_, err = transport.Dial(context.Background(), hostB, ips.IPPort{IP: hostC, Port: 9651})
+	require.NoError(err)
+
+	transport.Heal()
+	require.Equal([]byte("hello"), readNow(t, server, 5))
+
+	_, err = transport.Dial(context.Background(), hostA, ips.IPPort{IP: hostC, Port: 9651})
+	require.NoError(err)
+}
+
+func TestTransportPartitionWakesReader(t *testing.T) {
+	require := require.New(t)
+
+	transport, err := NewTransport(nil, 0, Link{})
+	require.NoError(err)
+
+	client, server := connect(t, transport, hostA, hostB)
+	transport.Partition([]net.IP{hostA}, []net.IP{hostB})
+
+	_, err = client.Write([]byte("hello"))
+	require.NoError(err)
+
+	read := make(chan []byte)
+	go func() {
+		b := make([]byte, 5)
+		n, _ := io.ReadFull(server, b)
+		read <- b[:n]
+	}()
+
+	transport.Heal()
+	require.Equal([]byte("hello"), <-read)
+}
+
+func TestTransportClose(t *testing.T) {
+	require := require.New(t)
+
+	transport, err := NewTransport(nil, 0, Link{})
+	require.NoError(err)
+
+	client, server := connect(t, transport, hostA, hostB)
+
+	_, err = client.Write([]byte("bye"))
+	require.NoError(err)
+	require.NoError(client.Close())
+
+	// Bytes that were sent before closing are still delivered
+	b, err := io.ReadAll(server)
+	require.NoError(err)
+	require.Equal([]byte("bye"), b)
+
+	_, err = client.Write([]byte("more"))
+	require.ErrorIs(err, net.ErrClosed)
+	_, err = client.Read(b)
+	require.ErrorIs(err, net.ErrClosed)
+
+	_, err = server.Write([]byte("reply"))
+	require.ErrorIs(err, errConnectionReset)
+
+	require.NoError(server.Close())
+	require.Empty(transport.pipes)
+}
+
+func TestTransportListener(t *testing.T) {
+	require := require.New(t)
+
+	transport, err := NewTransport(nil, 0, Link{})
+	require.NoError(err)
+
+	ip := ips.IPPort{IP: hostB, Port: 9651}
+	_, err = transport.Dial(context.Background(), hostA, ip)
+	require.ErrorIs(err, errConnectionRefused)
+
+	l, err := transport.Listen(ip)
+	require.NoError(err)
+	require.Equal(ip.String(), l.Addr().String())
+
+	_, err = transport.Listen(ip)
+	require.ErrorIs(err, errAddressInUse)
+
+	require.NoError(l.Close())
+	_, err = l.Accept()
+	require.ErrorIs(err, net.ErrClosed)
+
+	_, err = transport.Dial(context.Background(), hostA, ip)
+	require.ErrorIs(err, errConnectionRefused)
+
+	// The address can be reused once the listener is closed
+	_, err = transport.Listen(ip)
+	require.NoError(err)
+}
+
+func TestManualClock(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(0, 0)
+	clock := NewManualClock(start)
+
+	fired := clock.After(0)
+	require.Equal(start, <-fired)
+
+	second := clock.After(2 * time.Second)
+	first := clock.After(time.Second)
+	require.Equal(2, clock.PendingTimers())
+
+	clock.Advance(time.Second)
+	require.Equal(start.Add(time.Second), <-first)
+	require.Empty(second)
+
+	clock.Set(start.Add(time.Minute))
+	require.Equal(start.Add(time.Minute), <-second)
+	require.Zero(clock.PendingTimers())
+	require.Equal(start.Add(time.Minute), clock.Now())
+}
+
+func TestManualClockTimer(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(0, 0)
+	clock := NewManualClock(start)
+
+	timer := clock.NewTimer(time.Second)
+	deadline, ok := clock.NextDeadline()
+	require.True(ok)
+	require.Equal(start.Add(time.Second), deadline)
+
+	// Stopping the timer prevents it from firing
+	require.True(timer.Stop())
+	require.False(timer.Stop())
+	clock.Advance(time.Second)
+	require.Empty(timer.C())
+
+	// Resetting the timer schedules it relative to the current time
+	require.False(timer.Reset(time.Second))
+	require.True(timer.Reset(2 * time.Second))
+	clock.Advance(time.Second)
+	require.Empty(timer.C())
+	clock.Advance(time.Second)
+	require.Equal(start.Add(3*time.Second), <-timer.C())
+
+	_, ok = clock.NextDeadline()
+	require.False(ok)
+}
+
+func TestManualClockTicker(t *testing.T) {
+	require := require.New(t)
+
+	start := time.Unix(0, 0)
+	clock := NewManualClock(start)
+
+	ticker := clock.NewTicker(time.Second)
+	clock.Advance(time.Second)
+	require.Equal(start.Add(time.Second), <-ticker.C())
+
+	// Ticks that aren't received are dropped
+	clock.Advance(3 * time.Second)
+	require.Equal(start.Add(4*time.Second), <-ticker.C())
+	require.Empty(ticker.C())
+	require.Equal(1, clock.PendingTimers())
+
+	ticker.Stop()
+	require.Zero(clock.PendingTimers())
+	clock.Advance(time.Second)
+	require.Empty(ticker.C())
+}
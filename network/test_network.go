@@ -13,6 +13,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
 	"github.com/MetalBlockchain/metalgo/network/dialer"
@@ -81,7 +82,7 @@ func NewTestNetwork(
 	msgCreator, err := message.NewCreator(
 		metrics,
 		"",
-		constants.DefaultNetworkCompressionEnabled,
+		constants.DefaultNetworkCompressionType,
 		constants.DefaultNetworkMaximumInboundTimeout,
 	)
 	if err != nil {
@@ -163,7 +164,7 @@ func NewTestNetwork(
 		},
 
 		MaxClockDifference:           constants.DefaultNetworkMaxClockDifference,
-		CompressionEnabled:           constants.DefaultNetworkCompressionEnabled,
+		CompressionType:              constants.DefaultNetworkCompressionType,
 		PingFrequency:                constants.DefaultPingFrequency,
 		AllowPrivateIPs:              constants.DefaultNetworkAllowPrivateIPs,
 		UptimeMetricFreq:             constants.DefaultUptimeMetricFreq,
@@ -230,6 +231,19 @@ func NewTestNetwork(
 	if err != nil {
 		return nil, err
 	}
+	networkConfig.BanDB = memdb.New()
+
+	networkDialer, err := dialer.NewDialer(
+		constants.NetworkType,
+		dialer.Config{
+			ThrottleRps:       constants.DefaultOutboundConnectionThrottlingRps,
+			ConnectionTimeout: constants.DefaultOutboundConnectionTimeout,
+		},
+		log,
+	)
+	if err != nil {
+		return nil, err
+	}
 
 	return NewNetwork(
 		&networkConfig,
@@ -237,14 +251,7 @@ func NewTestNetwork(
 		metrics,
 		log,
 		newNoopListener(),
-		dialer.NewDialer(
-			constants.NetworkType,
-			dialer.Config{
-				ThrottleRps:       constants.DefaultOutboundConnectionThrottlingRps,
-				ConnectionTimeout: constants.DefaultOutboundConnectionTimeout,
-			},
-			log,
-		),
+		networkDialer,
 		router,
 	)
 }
@@ -0,0 +1,470 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/metric"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const (
+	// HighPriority messages are required for consensus and the peer-to-peer
+	// network to make progress.
+	HighPriority Priority = iota
+	NormalPriority
+	// LowPriority messages are large or may be dropped without halting
+	// consensus, such as the Ancestors sent to bootstrapping peers.
+	LowPriority
+
+	// NumPriorities is the number of distinct priorities
+	NumPriorities = int(LowPriority) + 1
+)
+
+var (
+	_ OutboundBandwidthThrottler = (*outboundBandwidthThrottler)(nil)
+	_ OutboundBandwidthThrottler = noOutboundBandwidthThrottler{}
+)
+
+// Priority of an outbound message. Messages with a lower value are sent first
+// when the outbound bandwidth is limited.
+type Priority uint8
+
+func (p Priority) String() string {
+	switch p {
+	case HighPriority:
+		return "high"
+	case NormalPriority:
+		return "normal"
+	case LowPriority:
+		return "low"
+	default:
+		return "unknown"
+	}
+}
+
+// OutboundPriority returns the priority of sending a message with [op].
+func OutboundPriority(op message.Op) Priority {
+	switch op {
+	case message.AncestorsOp, message.AppGossipOp:
+		return LowPriority
+	case message.AppRequestOp,
+		message.AppResponseOp,
+		message.CrossChainAppRequestOp,
+		message.CrossChainAppResponseOp,
+		message.StateSummaryFrontierOp,
+		message.AcceptedStateSummaryOp:
+		return NormalPriority
+	default:
+		return HighPriority
+	}
+}
+
+// OutboundBandwidthThrottler rate-limits the bytes that are written to peers
+// with a token bucket model, where each token is 1 byte. The bytes written to
+// all peers and the bytes written to each peer are limited separately.
+//
+// When the bytes written to all peers are limited, the messages with a higher
+// priority are sent first.
+type OutboundBandwidthThrottler interface {
+	// TryAcquire returns true if [msgSize] bytes can be written to [nodeID]
+	// without waiting. If true is returned, the bytes are consumed.
+	TryAcquire(msgSize uint64, nodeID ids.NodeID, priority Priority) bool
+
+	// Acquire blocks until [msgSize] bytes can be written to [nodeID].
+	// Returns false if [ctx] is canceled before the bytes were acquired.
+	Acquire(ctx context.Context, msgSize uint64, nodeID ids.NodeID, priority Priority) bool
+
+	// AddNode must be called before bytes are acquired for [nodeID].
+	AddNode(nodeID ids.NodeID)
+
+	// RemoveNode must be called when we stop writing to [nodeID].
+	RemoveNode(nodeID ids.NodeID)
+}
+
+type OutboundBandwidthThrottlerConfig struct {
+	// Rate, in bytes per second, at which the bandwidth consumable by all
+	// peers replenishes. If 0, the bandwidth of all peers isn't limited.
+	RefillRate uint64 `json:"refillRate"`
+	// Max amount of bandwidth that can accumulate for all peers. Must be at
+	// least the max message size.
+	MaxBurstSize uint64 `json:"maxBurstSize"`
+	// Rate, in bytes per second, at which the bandwidth consumable by a peer
+	// replenishes. If 0, the bandwidth of each peer isn't limited.
+	NodeRefillRate uint64 `json:"nodeRefillRate"`
+	// Max amount of bandwidth that can accumulate for a given peer. Must be
+	// at least the max message size.
+	NodeMaxBurstSize uint64 `json:"nodeMaxBurstSize"`
+}
+
+// NewOutboundBandwidthThrottler returns a throttler that doesn't limit the
+// bandwidth if neither [config.RefillRate] nor [config.NodeRefillRate] is set.
+func NewOutboundBandwidthThrottler(
+	log logging.Logger,
+	namespace string,
+	registerer prometheus.Registerer,
+	config OutboundBandwidthThrottlerConfig,
+) (OutboundBandwidthThrottler, error) {
+	if config.RefillRate == 0 && config.NodeRefillRate == 0 {
+		return noOutboundBandwidthThrottler{}, nil
+	}
+
+	t := &outboundBandwidthThrottler{
+		OutboundBandwidthThrottlerConfig: config,
+		log:                              log,
+		nodes:                            make(map[ids.NodeID]*tokenBucket),
+	}
+	t.global = newTokenBucket(config.RefillRate, config.MaxBurstSize, t.clock.Time())
+	return t, t.metrics.initialize(namespace, registerer)
+}
+
+type outboundBandwidthThrottlerMetrics struct {
+	acquireLatency  metric.Averager
+	awaitingAcquire *prometheus.GaugeVec
+}
+
+func (m *outboundBandwidthThrottlerMetrics) initialize(namespace string, registerer prometheus.Registerer) error {
+	errs := wrappers.Errs{}
+	m.acquireLatency = metric.NewAveragerWithErrs(
+		namespace,
+		"bandwidth_throttler_outbound_acquire_latency",
+		"average time (in ns) to acquire bytes from the outbound bandwidth throttler",
+		registerer,
+		&errs,
+	)
+	m.awaitingAcquire = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "bandwidth_throttler_outbound_awaiting_acquire",
+			Help:      "Number of outbound messages waiting to acquire bandwidth from the outbound bandwidth throttler",
+		},
+		[]string{"priority"},
+	)
+	errs.Add(registerer.Register(m.awaitingAcquire))
+	return errs.Err
+}
+
+// tokenBucket holds up to [burst] tokens and gains [rate] tokens per second.
+// If [rate] is 0, the bucket is unlimited.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst uint64, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		rate:   float64(rate),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+// wait returns how long until [n] tokens are available.
+func (b *tokenBucket) wait(n float64) time.Duration {
+	if b.rate == 0 || b.tokens >= n {
+		return 0
+	}
+	return time.Duration((n - b.tokens) / b.rate * float64(time.Second))
+}
+
+func (b *tokenBucket) take(n float64) {
+	if b.rate != 0 {
+		b.tokens -= n
+	}
+}
+
+// size returns the number of tokens that a message of [msgSize] bytes takes.
+// Messages larger than the burst would never be sent, so they take the whole
+// burst.
+func (b *tokenBucket) size(msgSize uint64) float64 {
+	return math.Min(float64(msgSize), b.burst)
+}
+
+type bandwidthWaiter struct {
+	nodeID   ids.NodeID
+	msgSize  uint64
+	priority Priority
+	// closed when the bytes were acquired
+	acquired chan struct{}
+}
+
+type outboundBandwidthThrottler struct {
+	OutboundBandwidthThrottlerConfig
+	metrics outboundBandwidthThrottlerMetrics
+	log     logging.Logger
+	clock   mockable.Clock
+
+	lock   sync.Mutex
+	global *tokenBucket
+	// Node ID --> the bandwidth that can be written to the node
+	nodes map[ids.NodeID]*tokenBucket
+	// The waiters of each priority, in the order that they started waiting
+	waiters [NumPriorities][]*bandwidthWaiter
+	// Fires when the next waiter may be able to acquire its bytes
+	timer *time.Timer
+}
+
+func (t *outboundBandwidthThrottler) TryAcquire(msgSize uint64, nodeID ids.NodeID, priority Priority) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.tryAcquire(msgSize, nodeID, priority)
+}
+
+func (t *outboundBandwidthThrottler) Acquire(
+	ctx context.Context,
+	msgSize uint64,
+	nodeID ids.NodeID,
+	priority Priority,
+) bool {
+	t.lock.Lock()
+	if t.tryAcquire(msgSize, nodeID, priority) {
+		t.lock.Unlock()
+		return true
+	}
+
+	startTime := t.clock.Time()
+	awaitingAcquire := t.metrics.awaitingAcquire.WithLabelValues(priority.String())
+	awaitingAcquire.Inc()
+	defer func() {
+		t.metrics.acquireLatency.Observe(float64(t.clock.Time().Sub(startTime)))
+		awaitingAcquire.Dec()
+	}()
+
+	w := &bandwidthWaiter{
+		nodeID:   nodeID,
+		msgSize:  msgSize,
+		priority: priority,
+		acquired: make(chan struct{}),
+	}
+	t.waiters[priority] = append(t.waiters[priority], w)
+	t.schedule()
+	t.lock.Unlock()
+
+	select {
+	case <-w.acquired:
+		return true
+	case <-ctx.Done():
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	select {
+	case <-w.acquired:
+		// The bytes were acquired concurrently with [ctx] being canceled.
+		return true
+	default:
+	}
+
+	t.removeWaiter(w)
+	// Waiters of a lower priority may have been waiting on [w].
+	t.schedule()
+	t.log.Debug("stopped waiting for outbound bandwidth",
+		zap.Uint64("messageSize", msgSize),
+		zap.Stringer("nodeID", nodeID),
+		zap.Error(ctx.Err()),
+	)
+	return false
+}
+
+func (t *outboundBandwidthThrottler) AddNode(nodeID ids.NodeID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.nodes[nodeID]; ok {
+		t.log.Debug("tried to add peer but it's already registered",
+			zap.Stringer("nodeID", nodeID),
+		)
+		return
+	}
+	t.nodes[nodeID] = newTokenBucket(t.NodeRefillRate, t.NodeMaxBurstSize, t.clock.Time())
+}
+
+func (t *outboundBandwidthThrottler) RemoveNode(nodeID ids.NodeID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.nodes[nodeID]; !ok {
+		t.log.Debug("tried to remove peer but it isn't registered",
+			zap.Stringer("nodeID", nodeID),
+		)
+		return
+	}
+	delete(t.nodes, nodeID)
+}
+
+// tryAcquire takes the bytes if they are available and no message of the same
+// or a higher priority is waiting for them.
+//
+// Assumes [t.lock] is held.
+func (t *outboundBandwidthThrottler) tryAcquire(msgSize uint64, nodeID ids.NodeID, priority Priority) bool {
+	for p := HighPriority; p <= priority; p++ {
+		if len(t.waiters[p]) > 0 {
+			return false
+		}
+	}
+
+	node, ok := t.nodes[nodeID]
+	if !ok {
+		// This should never happen. If it is, the caller is misusing this
+		// struct.
+		t.log.Debug("tried to acquire throttler but the node isn't registered",
+			zap.Uint64("messageSize", msgSize),
+			zap.Stringer("nodeID", nodeID),
+		)
+		return true
+	}
+
+	now := t.clock.Time()
+	t.global.refill(now)
+	node.refill(now)
+	globalSize := t.global.size(msgSize)
+	nodeSize := node.size(msgSize)
+	if t.global.wait(globalSize) > 0 || node.wait(nodeSize) > 0 {
+		return false
+	}
+	t.global.take(globalSize)
+	node.take(nodeSize)
+	return true
+}
+
+// schedule hands out the available bytes to the waiters in order of priority,
+// and then in the order that they started waiting. A waiter that is limited by
+// its own node's bandwidth doesn't block the waiters of other nodes, but a
+// waiter that is limited by the bandwidth of all peers blocks all of the
+// waiters after it.
+//
+// Assumes [t.lock] is held.
+func (t *outboundBandwidthThrottler) schedule() {
+	now := t.clock.Time()
+	t.global.refill(now)
+
+	var nextWait time.Duration
+	for p := range t.waiters {
+		for i := 0; i < len(t.waiters[p]); {
+			w := t.waiters[p][i]
+			node, ok := t.nodes[w.nodeID]
+			if !ok {
+				// The node was removed while waiting, so the bytes will never
+				// be written.
+				t.grant(p, i, w)
+				continue
+			}
+
+			node.refill(now)
+			nodeSize := node.size(w.msgSize)
+			if wait := node.wait(nodeSize); wait > 0 {
+				nextWait = minPositive(nextWait, wait)
+				i++
+				continue
+			}
+
+			globalSize := t.global.size(w.msgSize)
+			if wait := t.global.wait(globalSize); wait > 0 {
+				t.resetTimer(minPositive(nextWait, wait))
+				return
+			}
+
+			t.global.take(globalSize)
+			node.take(nodeSize)
+			t.grant(p, i, w)
+		}
+	}
+	if nextWait > 0 {
+		t.resetTimer(nextWait)
+	}
+}
+
+// grant removes the [i]th waiter of priority [p] and wakes it up.
+//
+// Assumes [t.lock] is held.
+func (t *outboundBandwidthThrottler) grant(p int, i int, w *bandwidthWaiter) {
+	t.removeIndex(p, i)
+	close(w.acquired)
+}
+
+// Assumes [t.lock] is held.
+func (t *outboundBandwidthThrottler) removeWaiter(w *bandwidthWaiter) {
+	for i, other := range t.waiters[w.priority] {
+		if other == w {
+			t.removeIndex(int(w.priority), i)
+			return
+		}
+	}
+}
+
+// removeIndex removes the [i]th waiter of priority [p] while keeping the order
+// of the other waiters.
+//
+// Assumes [t.lock] is held.
+func (t *outboundBandwidthThrottler) removeIndex(p int, i int) {
+	waiters := t.waiters[p]
+	copy(waiters[i:], waiters[i+1:])
+	waiters[len(waiters)-1] = nil
+	t.waiters[p] = waiters[:len(waiters)-1]
+}
+
+// Assumes [t.lock] is held.
+func (t *outboundBandwidthThrottler) resetTimer(wait time.Duration) {
+	if t.timer == nil {
+		t.timer = time.AfterFunc(wait, func() {
+			t.lock.Lock()
+			defer t.lock.Unlock()
+
+			t.schedule()
+		})
+		return
+	}
+	t.timer.Reset(wait)
+}
+
+func minPositive(a, b time.Duration) time.Duration {
+	if a <= 0 || b < a {
+		return b
+	}
+	return a
+}
+
+// NewNoOutboundBandwidthThrottler returns a throttler that never limits the
+// outbound bandwidth.
+func NewNoOutboundBandwidthThrottler() OutboundBandwidthThrottler {
+	return noOutboundBandwidthThrottler{}
+}
+
+type noOutboundBandwidthThrottler struct{}
+
+func (noOutboundBandwidthThrottler) TryAcquire(uint64, ids.NodeID, Priority) bool {
+	return true
+}
+
+func (noOutboundBandwidthThrottler) Acquire(context.Context, uint64, ids.NodeID, Priority) bool {
+	return true
+}
+
+func (noOutboundBandwidthThrottler) AddNode(ids.NodeID) {}
+
+func (noOutboundBandwidthThrottler) RemoveNode(ids.NodeID) {}
@@ -0,0 +1,192 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+func newTestOutboundBandwidthThrottler(t *testing.T, config OutboundBandwidthThrottlerConfig) *outboundBandwidthThrottler {
+	throttlerIntf, err := NewOutboundBandwidthThrottler(logging.NoLog{}, "", prometheus.NewRegistry(), config)
+	require.NoError(t, err)
+	throttler, ok := throttlerIntf.(*outboundBandwidthThrottler)
+	require.True(t, ok)
+
+	now := time.Now()
+	throttler.clock.Set(now)
+	throttler.global.last = now
+	return throttler
+}
+
+// numWaiters returns the number of waiters of [priority].
+func (t *outboundBandwidthThrottler) numWaiters(priority Priority) int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return len(t.waiters[priority])
+}
+
+// advance moves the clock forward by [d] and hands out the refilled bytes.
+func (t *outboundBandwidthThrottler) advance(d time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.clock.Set(t.clock.Time().Add(d))
+	t.schedule()
+}
+
+func TestNewOutboundBandwidthThrottlerNoLimit(t *testing.T) {
+	require := require.New(t)
+
+	throttler, err := NewOutboundBandwidthThrottler(logging.NoLog{}, "", prometheus.NewRegistry(), OutboundBandwidthThrottlerConfig{})
+	require.NoError(err)
+	require.IsType(noOutboundBandwidthThrottler{}, throttler)
+}
+
+func TestOutboundBandwidthThrottlerNodeLimit(t *testing.T) {
+	require := require.New(t)
+
+	throttler := newTestOutboundBandwidthThrottler(t, OutboundBandwidthThrottlerConfig{
+		NodeRefillRate:   1000,
+		NodeMaxBurstSize: 100,
+	})
+	nodeID1, nodeID2 := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID1)
+	throttler.AddNode(nodeID2)
+
+	// Each node has its own allocation
+	require.True(throttler.TryAcquire(100, nodeID1, HighPriority))
+	require.False(throttler.TryAcquire(1, nodeID1, HighPriority))
+	require.True(throttler.TryAcquire(100, nodeID2, HighPriority))
+
+	// Messages larger than the burst take the whole burst
+	throttler.advance(100 * time.Millisecond)
+	require.True(throttler.TryAcquire(1_000, nodeID1, HighPriority))
+
+	acquired := make(chan bool)
+	go func() {
+		acquired <- throttler.Acquire(context.Background(), 50, nodeID1, HighPriority)
+	}()
+	require.Eventually(func() bool {
+		return throttler.numWaiters(HighPriority) == 1
+	}, time.Second, time.Millisecond)
+
+	throttler.advance(50 * time.Millisecond)
+	require.True(<-acquired)
+}
+
+func TestOutboundBandwidthThrottlerPriority(t *testing.T) {
+	require := require.New(t)
+
+	throttler := newTestOutboundBandwidthThrottler(t, OutboundBandwidthThrottlerConfig{
+		RefillRate:   1000,
+		MaxBurstSize: 100,
+	})
+	nodeID1, nodeID2 := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID1)
+	throttler.AddNode(nodeID2)
+
+	// Use the entire allocation
+	require.True(throttler.TryAcquire(100, nodeID1, LowPriority))
+
+	lowAcquired := make(chan bool, 1)
+	go func() {
+		lowAcquired <- throttler.Acquire(context.Background(), 100, nodeID1, LowPriority)
+	}()
+	require.Eventually(func() bool {
+		return throttler.numWaiters(LowPriority) == 1
+	}, time.Second, time.Millisecond)
+
+	highAcquired := make(chan bool, 1)
+	go func() {
+		highAcquired <- throttler.Acquire(context.Background(), 100, nodeID2, HighPriority)
+	}()
+	require.Eventually(func() bool {
+		return throttler.numWaiters(HighPriority) == 1
+	}, time.Second, time.Millisecond)
+
+	// A low priority message can't skip ahead of the waiting messages
+	require.False(throttler.TryAcquire(1, nodeID1, LowPriority))
+
+	// The high priority message preempts the low priority message that started
+	// waiting before it
+	throttler.advance(100 * time.Millisecond)
+	require.True(<-highAcquired)
+	require.Equal(1, throttler.numWaiters(LowPriority))
+
+	throttler.advance(100 * time.Millisecond)
+	require.True(<-lowAcquired)
+	require.Zero(throttler.numWaiters(LowPriority))
+}
+
+func TestOutboundBandwidthThrottlerCancel(t *testing.T) {
+	require := require.New(t)
+
+	throttler := newTestOutboundBandwidthThrottler(t, OutboundBandwidthThrottlerConfig{
+		RefillRate:   1000,
+		MaxBurstSize: 100,
+	})
+	nodeID := ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID)
+	require.True(throttler.TryAcquire(100, nodeID, HighPriority))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	acquired := make(chan bool)
+	go func() {
+		acquired <- throttler.Acquire(ctx, 100, nodeID, HighPriority)
+	}()
+	require.Eventually(func() bool {
+		return throttler.numWaiters(HighPriority) == 1
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	require.False(<-acquired)
+	require.Zero(throttler.numWaiters(HighPriority))
+}
+
+func TestOutboundBandwidthThrottlerRemoveNode(t *testing.T) {
+	require := require.New(t)
+
+	throttler := newTestOutboundBandwidthThrottler(t, OutboundBandwidthThrottlerConfig{
+		NodeRefillRate:   1000,
+		NodeMaxBurstSize: 100,
+	})
+	nodeID := ids.GenerateTestNodeID()
+	throttler.AddNode(nodeID)
+	require.True(throttler.TryAcquire(100, nodeID, HighPriority))
+
+	acquired := make(chan bool)
+	go func() {
+		acquired <- throttler.Acquire(context.Background(), 100, nodeID, HighPriority)
+	}()
+	require.Eventually(func() bool {
+		return throttler.numWaiters(HighPriority) == 1
+	}, time.Second, time.Millisecond)
+
+	// The waiter isn't blocked forever if its node is removed
+	throttler.RemoveNode(nodeID)
+	throttler.advance(0)
+	require.True(<-acquired)
+}
+
+func TestOutboundPriority(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(HighPriority, OutboundPriority(message.PushQueryOp))
+	require.Equal(HighPriority, OutboundPriority(message.ChitsOp))
+	require.Equal(HighPriority, OutboundPriority(message.PingOp))
+	require.Equal(NormalPriority, OutboundPriority(message.AppRequestOp))
+	require.Equal(LowPriority, OutboundPriority(message.AncestorsOp))
+	require.Equal(LowPriority, OutboundPriority(message.AppGossipOp))
+}
@@ -0,0 +1,247 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"sync"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+const (
+	ipv4PrefixLen = 24
+	ipv6PrefixLen = 48
+)
+
+var (
+	_ PeerDiversityThrottler = (*peerDiversityThrottler)(nil)
+	_ PeerDiversityThrottler = noPeerDiversityThrottler{}
+)
+
+// PeerDiversityThrottler limits the number of peers that share an IP prefix or
+// an autonomous system, so that an attacker controlling a single hosting range
+// can't monopolize our connections.
+type PeerDiversityThrottler interface {
+	// Add registers that [nodeID] is connected from [ip].
+	// If [limited] is true and registering the peer would exceed the maximum
+	// number of limited peers for any of the IP's groups, the peer isn't
+	// registered and false is returned.
+	Add(nodeID ids.NodeID, ip net.IP, limited bool) bool
+	// Remove unregisters [nodeID]. If [nodeID] isn't registered, this is a
+	// no-op.
+	Remove(nodeID ids.NodeID)
+	// Saturated returns true if any of the groups that [ip] is in already
+	// contain at least the maximum number of peers.
+	Saturated(ip net.IP) bool
+}
+
+type PeerDiversityThrottlerConfig struct {
+	// MaxPeersPerIPv4Prefix is the maximum number of limited peers with an
+	// IPv4 address in the same /24. If 0, the number isn't limited.
+	MaxPeersPerIPv4Prefix int `json:"maxPeersPerIPv4Prefix"`
+	// MaxPeersPerIPv6Prefix is the maximum number of limited peers with an
+	// IPv6 address in the same /48. If 0, the number isn't limited.
+	MaxPeersPerIPv6Prefix int `json:"maxPeersPerIPv6Prefix"`
+	// MaxPeersPerASN is the maximum number of limited peers with an address
+	// announced by the same autonomous system in [ASNs]. If 0, the number isn't
+	// limited.
+	MaxPeersPerASN int `json:"maxPeersPerASN"`
+	// ASNs maps autonomous system numbers to the CIDR prefixes they announce.
+	ASNs map[uint32][]string `json:"asns"`
+}
+
+// Returns a PeerDiversityThrottler that enforces the limits in [config].
+// Loopback and private addresses are never limited.
+func NewPeerDiversityThrottler(config PeerDiversityThrottlerConfig) (PeerDiversityThrottler, error) {
+	if config.MaxPeersPerIPv4Prefix <= 0 && config.MaxPeersPerIPv6Prefix <= 0 && config.MaxPeersPerASN <= 0 {
+		return noPeerDiversityThrottler{}, nil
+	}
+
+	t := &peerDiversityThrottler{
+		PeerDiversityThrottlerConfig: config,
+		peers:                        make(map[ids.NodeID]diversityPeer),
+		numPeers:                     make(map[diversityGroup]int),
+		numLimitedPeers:              make(map[diversityGroup]int),
+	}
+	for asn, prefixStrs := range config.ASNs {
+		for _, prefixStr := range prefixStrs {
+			prefix, err := netip.ParsePrefix(prefixStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid prefix %q of ASN %d: %w", prefixStr, asn, err)
+			}
+			t.asnPrefixes = append(t.asnPrefixes, asnPrefix{
+				asn:    asn,
+				prefix: prefix.Masked(),
+			})
+		}
+	}
+	return t, nil
+}
+
+// noPeerDiversityThrottler doesn't limit any peers.
+type noPeerDiversityThrottler struct{}
+
+func (noPeerDiversityThrottler) Add(ids.NodeID, net.IP, bool) bool {
+	return true
+}
+
+func (noPeerDiversityThrottler) Remove(ids.NodeID) {}
+
+func (noPeerDiversityThrottler) Saturated(net.IP) bool {
+	return false
+}
+
+// diversityGroup is a set of IPs that are likely to be controlled by the same
+// entity. Exactly one of [prefix] and [asn] is set.
+type diversityGroup struct {
+	prefix netip.Prefix
+	asn    uint32
+}
+
+type diversityPeer struct {
+	groups  []diversityGroup
+	limited bool
+}
+
+type asnPrefix struct {
+	asn    uint32
+	prefix netip.Prefix
+}
+
+type peerDiversityThrottler struct {
+	PeerDiversityThrottlerConfig
+	asnPrefixes []asnPrefix
+
+	lock sync.Mutex
+	// Node ID --> The groups of the IP the node is connected from
+	peers map[ids.NodeID]diversityPeer
+	// Group --> Number of registered peers in the group
+	numPeers map[diversityGroup]int
+	// Group --> Number of registered limited peers in the group
+	numLimitedPeers map[diversityGroup]int
+}
+
+func (t *peerDiversityThrottler) Add(nodeID ids.NodeID, ip net.IP, limited bool) bool {
+	groups := t.groups(ip)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.remove(nodeID)
+	if limited {
+		for _, group := range groups {
+			if limit := t.maxPeers(group); limit > 0 && t.numLimitedPeers[group] >= limit {
+				return false
+			}
+		}
+	}
+
+	t.peers[nodeID] = diversityPeer{
+		groups:  groups,
+		limited: limited,
+	}
+	for _, group := range groups {
+		t.numPeers[group]++
+		if limited {
+			t.numLimitedPeers[group]++
+		}
+	}
+	return true
+}
+
+func (t *peerDiversityThrottler) Remove(nodeID ids.NodeID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.remove(nodeID)
+}
+
+// Assumes [t.lock] is held.
+func (t *peerDiversityThrottler) remove(nodeID ids.NodeID) {
+	peer, ok := t.peers[nodeID]
+	if !ok {
+		return
+	}
+	delete(t.peers, nodeID)
+
+	for _, group := range peer.groups {
+		decrement(t.numPeers, group)
+		if peer.limited {
+			decrement(t.numLimitedPeers, group)
+		}
+	}
+}
+
+func (t *peerDiversityThrottler) Saturated(ip net.IP) bool {
+	groups := t.groups(ip)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for _, group := range groups {
+		if limit := t.maxPeers(group); limit > 0 && t.numPeers[group] >= limit {
+			return true
+		}
+	}
+	return false
+}
+
+// groups returns the groups that [ip] is in.
+func (t *peerDiversityThrottler) groups(ip net.IP) []diversityGroup {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return nil
+	}
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() {
+		return nil
+	}
+
+	prefixLen := ipv6PrefixLen
+	if addr.Is4() {
+		prefixLen = ipv4PrefixLen
+	}
+	prefix, err := addr.Prefix(prefixLen)
+	if err != nil {
+		return nil
+	}
+	groups := []diversityGroup{{prefix: prefix}}
+
+	// If multiple ASNs announce the IP, the most specific prefix is used.
+	var (
+		bestASN       uint32
+		bestPrefixLen = -1
+	)
+	for _, asnPrefix := range t.asnPrefixes {
+		if asnPrefix.prefix.Bits() > bestPrefixLen && asnPrefix.prefix.Contains(addr) {
+			bestASN = asnPrefix.asn
+			bestPrefixLen = asnPrefix.prefix.Bits()
+		}
+	}
+	if bestPrefixLen >= 0 {
+		groups = append(groups, diversityGroup{asn: bestASN})
+	}
+	return groups
+}
+
+func (t *peerDiversityThrottler) maxPeers(group diversityGroup) int {
+	switch {
+	case !group.prefix.IsValid():
+		return t.MaxPeersPerASN
+	case group.prefix.Addr().Is4():
+		return t.MaxPeersPerIPv4Prefix
+	default:
+		return t.MaxPeersPerIPv6Prefix
+	}
+}
+
+func decrement(counts map[diversityGroup]int, group diversityGroup) {
+	counts[group]--
+	if counts[group] <= 0 {
+		delete(counts, group)
+	}
+}
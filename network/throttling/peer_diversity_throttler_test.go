@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+func TestNoPeerDiversityThrottler(t *testing.T) {
+	require := require.New(t)
+
+	throttler, err := NewPeerDiversityThrottler(PeerDiversityThrottlerConfig{})
+	require.NoError(err)
+	require.IsType(noPeerDiversityThrottler{}, throttler)
+
+	for i := 0; i < 10; i++ {
+		require.True(throttler.Add(ids.GenerateTestNodeID(), net.IPv4(1, 2, 3, 4), true))
+	}
+	require.False(throttler.Saturated(net.IPv4(1, 2, 3, 4)))
+}
+
+func TestPeerDiversityThrottlerIPv4Prefix(t *testing.T) {
+	require := require.New(t)
+
+	throttler, err := NewPeerDiversityThrottler(PeerDiversityThrottlerConfig{
+		MaxPeersPerIPv4Prefix: 2,
+	})
+	require.NoError(err)
+
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+	nodeID3 := ids.GenerateTestNodeID()
+	vdrID := ids.GenerateTestNodeID()
+
+	require.True(throttler.Add(nodeID1, net.IPv4(1, 2, 3, 4), true))
+	require.False(throttler.Saturated(net.IPv4(1, 2, 3, 4)))
+	require.True(throttler.Add(nodeID2, net.IPv4(1, 2, 3, 5), true))
+	require.True(throttler.Saturated(net.IPv4(1, 2, 3, 6)))
+
+	// The /24 is full of limited peers.
+	require.False(throttler.Add(nodeID3, net.IPv4(1, 2, 3, 6), true))
+	// Peers in other prefixes aren't affected.
+	require.True(throttler.Add(nodeID3, net.IPv4(1, 2, 4, 6), true))
+	require.False(throttler.Saturated(net.IPv4(1, 2, 4, 6)))
+	// Unlimited peers are always added.
+	require.True(throttler.Add(vdrID, net.IPv4(1, 2, 3, 7), false))
+
+	// Removing a limited peer frees a slot.
+	throttler.Remove(nodeID1)
+	require.True(throttler.Saturated(net.IPv4(1, 2, 3, 4))) // still counts [vdrID]
+	require.True(throttler.Add(nodeID3, net.IPv4(1, 2, 3, 6), true))
+	require.False(throttler.Add(nodeID1, net.IPv4(1, 2, 3, 4), true))
+
+	// IPv4-mapped IPv6 addresses are treated as IPv4.
+	require.False(throttler.Add(nodeID1, net.ParseIP("::ffff:1.2.3.4"), true))
+
+	// Removing an unknown peer is a no-op.
+	throttler.Remove(ids.GenerateTestNodeID())
+}
+
+func TestPeerDiversityThrottlerIPv6Prefix(t *testing.T) {
+	require := require.New(t)
+
+	throttler, err := NewPeerDiversityThrottler(PeerDiversityThrottlerConfig{
+		MaxPeersPerIPv6Prefix: 1,
+	})
+	require.NoError(err)
+
+	require.True(throttler.Add(ids.GenerateTestNodeID(), net.ParseIP("2001:db8:1::1"), true))
+	require.False(throttler.Add(ids.GenerateTestNodeID(), net.ParseIP("2001:db8:1:ffff::1"), true))
+	require.True(throttler.Add(ids.GenerateTestNodeID(), net.ParseIP("2001:db8:2::1"), true))
+
+	// IPv4 prefixes aren't limited.
+	require.True(throttler.Add(ids.GenerateTestNodeID(), net.IPv4(1, 2, 3, 4), true))
+	require.True(throttler.Add(ids.GenerateTestNodeID(), net.IPv4(1, 2, 3, 5), true))
+}
+
+func TestPeerDiversityThrottlerASN(t *testing.T) {
+	require := require.New(t)
+
+	throttler, err := NewPeerDiversityThrottler(PeerDiversityThrottlerConfig{
+		MaxPeersPerASN: 2,
+		ASNs: map[uint32][]string{
+			1: {"1.0.0.0/8", "2001:db8::/32"},
+			2: {"1.2.0.0/16"},
+		},
+	})
+	require.NoError(err)
+
+	// Both IPs are announced by ASN 1 through different prefixes.
+	require.True(throttler.Add(ids.GenerateTestNodeID(), net.IPv4(1, 3, 0, 1), true))
+	require.True(throttler.Add(ids.GenerateTestNodeID(), net.ParseIP("2001:db8::1"), true))
+	require.True(throttler.Saturated(net.IPv4(1, 4, 0, 1)))
+	require.False(throttler.Add(ids.GenerateTestNodeID(), net.IPv4(1, 4, 0, 1), true))
+
+	// The most specific prefix determines the ASN.
+	require.False(throttler.Saturated(net.IPv4(1, 2, 0, 1)))
+	require.True(throttler.Add(ids.GenerateTestNodeID(), net.IPv4(1, 2, 0, 1), true))
+
+	// IPs outside of any ASN aren't limited.
+	require.True(throttler.Add(ids.GenerateTestNodeID(), net.IPv4(3, 0, 0, 1), true))
+}
+
+func TestPeerDiversityThrottlerPrivateIPs(t *testing.T) {
+	require := require.New(t)
+
+	throttler, err := NewPeerDiversityThrottler(PeerDiversityThrottlerConfig{
+		MaxPeersPerIPv4Prefix: 1,
+		MaxPeersPerIPv6Prefix: 1,
+	})
+	require.NoError(err)
+
+	for i := 0; i < 5; i++ {
+		require.True(throttler.Add(ids.GenerateTestNodeID(), net.IPv4(127, 0, 0, 1), true))
+		require.True(throttler.Add(ids.GenerateTestNodeID(), net.IPv4(10, 0, 0, 1), true))
+		require.True(throttler.Add(ids.GenerateTestNodeID(), net.IPv6loopback, true))
+		require.True(throttler.Add(ids.GenerateTestNodeID(), nil, true))
+	}
+	require.False(throttler.Saturated(net.IPv4(127, 0, 0, 1)))
+}
+
+func TestPeerDiversityThrottlerInvalidASNPrefix(t *testing.T) {
+	_, err := NewPeerDiversityThrottler(PeerDiversityThrottlerConfig{
+		MaxPeersPerASN: 1,
+		ASNs: map[uint32][]string{
+			1: {"1.0.0.0"},
+		},
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,219 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+var (
+	_ SubnetSystemThrottler = (*subnetSystemThrottler)(nil)
+	_ SubnetSystemThrottler = (*subnetResourceThrottler)(nil)
+	_ SubnetSystemThrottler = noSubnetSystemThrottler{}
+)
+
+// SubnetSystemThrottler rate-limits based on the system metrics usage caused by
+// processing the messages of each subnet. We will not process messages of
+// subnets whose messages cause excessive usage until the usage caused by the
+// subnet drops to an acceptable level.
+type SubnetSystemThrottler interface {
+	// Blocks until we can process a message of the given subnet.
+	// If [ctx] is canceled, returns immediately.
+	Acquire(ctx context.Context, subnetID ids.ID)
+}
+
+// A subnet system throttler that always immediately returns on [Acquire].
+type noSubnetSystemThrottler struct{}
+
+func NewNoSubnetSystemThrottler() SubnetSystemThrottler {
+	return noSubnetSystemThrottler{}
+}
+
+func (noSubnetSystemThrottler) Acquire(context.Context, ids.ID) {}
+
+type subnetSystemThrottler struct {
+	SystemThrottlerConfig
+	metrics *subnetSystemThrottlerMetrics
+	// Tells us the target utilization of each subnet.
+	targeter tracker.SubnetTargeter
+	// Tells us the utilization of each subnet.
+	tracker tracker.Tracker
+}
+
+type subnetSystemThrottlerMetrics struct {
+	totalWaits      *prometheus.CounterVec
+	totalNoWaits    *prometheus.CounterVec
+	awaitingAcquire *prometheus.GaugeVec
+}
+
+func newSubnetSystemThrottlerMetrics(namespace string, reg prometheus.Registerer) (*subnetSystemThrottlerMetrics, error) {
+	labels := []string{"subnetID"}
+	m := &subnetSystemThrottlerMetrics{
+		totalWaits: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "subnet_throttler_total_waits",
+				Help:      "Number of times we've waited to process a message of a subnet because its usage was too high",
+			},
+			labels,
+		),
+		totalNoWaits: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "subnet_throttler_total_no_waits",
+				Help:      "Number of times we didn't wait to process a message of a subnet because its usage was acceptable",
+			},
+			labels,
+		),
+		awaitingAcquire: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "subnet_throttler_awaiting_acquire",
+				Help:      "Number of messages of a subnet we're waiting to process because its usage is too high",
+			},
+			labels,
+		),
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.totalWaits),
+		reg.Register(m.totalNoWaits),
+		reg.Register(m.awaitingAcquire),
+	)
+	return m, errs.Err
+}
+
+func NewSubnetSystemThrottler(
+	namespace string,
+	reg prometheus.Registerer,
+	config SystemThrottlerConfig,
+	tracker tracker.Tracker,
+	targeter tracker.SubnetTargeter,
+) (SubnetSystemThrottler, error) {
+	metrics, err := newSubnetSystemThrottlerMetrics(namespace, reg)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize subnet system throttler metrics: %w", err)
+	}
+	return &subnetSystemThrottler{
+		metrics:               metrics,
+		SystemThrottlerConfig: config,
+		targeter:              targeter,
+		tracker:               tracker,
+	}, nil
+}
+
+func (t *subnetSystemThrottler) Acquire(ctx context.Context, subnetID ids.ID) {
+	subnetIDStr := subnetID.String()
+
+	// [timer] fires when we should re-check whether this subnet's usage has
+	// fallen to an acceptable level.
+	// Lazily initialize timer only if we actually need to wait.
+	var timer *time.Timer
+	defer func() {
+		if timer != nil { // We waited at least once for usage to fall.
+			timer.Stop()
+			t.metrics.totalWaits.WithLabelValues(subnetIDStr).Inc()
+			t.metrics.awaitingAcquire.WithLabelValues(subnetIDStr).Dec()
+		} else {
+			t.metrics.totalNoWaits.WithLabelValues(subnetIDStr).Inc()
+		}
+	}()
+
+	for {
+		now := t.Clock.Time()
+		// Get target usage for this subnet.
+		target := t.targeter.TargetUsage(subnetID)
+		// Get actual usage for this subnet.
+		usage := t.tracker.SubnetUsage(subnetID, now)
+		if usage <= target {
+			return
+		}
+		// See how long it will take for actual usage to drop to the target,
+		// assuming this subnet uses no more resources.
+		waitDuration := t.tracker.TimeUntilSubnetUsage(subnetID, now, target)
+		if waitDuration < epsilon {
+			// If the amount of time until we reach the target is very small,
+			// just return to avoid a situation where we excessively re-check.
+			return
+		}
+		if waitDuration > t.MaxRecheckDelay {
+			// Re-check at least every [t.MaxRecheckDelay] because the target
+			// of this subnet increases as the usage of the other subnets
+			// decreases.
+			waitDuration = t.MaxRecheckDelay
+		}
+
+		if timer == nil {
+			// Note this is called at most once.
+			t.metrics.awaitingAcquire.WithLabelValues(subnetIDStr).Inc()
+			timer = time.NewTimer(waitDuration)
+		} else {
+			timer.Reset(waitDuration)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// subnetResourceThrottler waits until both the CPU and disk usage caused by a
+// subnet drop to an acceptable level.
+type subnetResourceThrottler struct {
+	cpuThrottler  SubnetSystemThrottler
+	diskThrottler SubnetSystemThrottler
+}
+
+// Returns a throttler that limits the CPU and disk usage caused by processing
+// the messages of each subnet.
+func NewSubnetResourceThrottler(
+	namespace string,
+	registerer prometheus.Registerer,
+	cpuConfig SystemThrottlerConfig,
+	diskConfig SystemThrottlerConfig,
+	resourceTracker tracker.ResourceTracker,
+	cpuTargeter tracker.SubnetTargeter,
+	diskTargeter tracker.SubnetTargeter,
+) (SubnetSystemThrottler, error) {
+	cpuThrottler, err := NewSubnetSystemThrottler(
+		fmt.Sprintf("%s_cpu", namespace),
+		registerer,
+		cpuConfig,
+		resourceTracker.CPUTracker(),
+		cpuTargeter,
+	)
+	if err != nil {
+		return nil, err
+	}
+	diskThrottler, err := NewSubnetSystemThrottler(
+		fmt.Sprintf("%s_disk", namespace),
+		registerer,
+		diskConfig,
+		resourceTracker.DiskTracker(),
+		diskTargeter,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &subnetResourceThrottler{
+		cpuThrottler:  cpuThrottler,
+		diskThrottler: diskThrottler,
+	}, nil
+}
+
+func (t *subnetResourceThrottler) Acquire(ctx context.Context, subnetID ids.ID) {
+	// Wait until our CPU usage drops to an acceptable level.
+	t.cpuThrottler.Acquire(ctx, subnetID)
+	// Wait until our disk usage drops to an acceptable level.
+	t.diskThrottler.Acquire(ctx, subnetID)
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
+)
+
+func TestSubnetSystemThrottler(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTracker := tracker.NewMockTracker(ctrl)
+	maxRecheckDelay := 100 * time.Millisecond
+	config := SystemThrottlerConfig{
+		MaxRecheckDelay: maxRecheckDelay,
+	}
+	subnetID := ids.GenerateTestID()
+	targeter := tracker.NewMockSubnetTargeter(ctrl)
+	throttler, err := NewSubnetSystemThrottler("", prometheus.NewRegistry(), config, mockTracker, targeter)
+	require.NoError(err)
+
+	// Case: Actual usage <= target usage; should return immediately
+	targeter.EXPECT().TargetUsage(subnetID).Return(1.0).Times(1)
+	mockTracker.EXPECT().SubnetUsage(subnetID, gomock.Any()).Return(0.9).Times(1)
+
+	throttler.Acquire(context.Background(), subnetID)
+
+	// Case: Actual usage > target usage; we should wait.
+	targeter.EXPECT().TargetUsage(subnetID).Return(0.0).Times(1)
+	mockTracker.EXPECT().SubnetUsage(subnetID, gomock.Any()).Return(1.0).Times(1)
+	// Note we'll only actually wait [maxRecheckDelay].
+	mockTracker.EXPECT().TimeUntilSubnetUsage(subnetID, gomock.Any(), gomock.Any()).Return(100 * maxRecheckDelay).Times(1)
+
+	// The second iteration, say the usage is OK.
+	targeter.EXPECT().TargetUsage(subnetID).Return(1.0).Times(1)
+	mockTracker.EXPECT().SubnetUsage(subnetID, gomock.Any()).Return(0.0).Times(1)
+
+	onAcquire := make(chan struct{})
+	go func() {
+		throttler.Acquire(context.Background(), subnetID)
+		close(onAcquire)
+	}()
+	select {
+	case <-time.After(5 * maxRecheckDelay):
+		require.FailNow("should have returned after about [maxRecheckDelay]")
+	case <-onAcquire:
+	}
+}
+
+func TestSubnetSystemThrottlerContextCancel(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTracker := tracker.NewMockTracker(ctrl)
+	maxRecheckDelay := 10 * time.Second
+	config := SystemThrottlerConfig{
+		MaxRecheckDelay: maxRecheckDelay,
+	}
+	subnetID := ids.GenerateTestID()
+	targeter := tracker.NewMockSubnetTargeter(ctrl)
+	throttler, err := NewSubnetSystemThrottler("", prometheus.NewRegistry(), config, mockTracker, targeter)
+	require.NoError(err)
+
+	targeter.EXPECT().TargetUsage(subnetID).Return(0.0).Times(1)
+	mockTracker.EXPECT().SubnetUsage(subnetID, gomock.Any()).Return(1.0).Times(1)
+	mockTracker.EXPECT().TimeUntilSubnetUsage(subnetID, gomock.Any(), gomock.Any()).Return(maxRecheckDelay).Times(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	onAcquire := make(chan struct{})
+	go func() {
+		throttler.Acquire(ctx, subnetID)
+		close(onAcquire)
+	}()
+	select {
+	case <-onAcquire:
+	case <-time.After(maxRecheckDelay / 2):
+		require.Fail("should have returned immediately")
+	}
+}
@@ -5,6 +5,7 @@ package node
 
 import (
 	"crypto/tls"
+	"net"
 	"time"
 
 	"github.com/MetalBlockchain/metalgo/api/server"
@@ -13,7 +14,9 @@ import (
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/nat"
 	"github.com/MetalBlockchain/metalgo/network"
+	"github.com/MetalBlockchain/metalgo/network/dialer"
 	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
+	"github.com/MetalBlockchain/metalgo/snow/networking/capture"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/subnets"
@@ -25,6 +28,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/profiler"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 	"github.com/MetalBlockchain/metalgo/utils/timer"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 )
 
 type IPCConfig struct {
@@ -157,12 +161,23 @@ type Config struct {
 	// Network configuration
 	NetworkConfig network.Config `json:"networkConfig"`
 
+	// If non-nil, p2p connections are accepted from [NetworkListener] rather
+	// than from a listener bound to the staking port. Used to run nodes over a
+	// simulated transport.
+	NetworkListener net.Listener `json:"-"`
+
+	// If non-nil, outbound p2p connections are made with [NetworkDialer].
+	NetworkDialer dialer.Dialer `json:"-"`
+
 	AdaptiveTimeoutConfig timer.AdaptiveTimeoutConfig `json:"adaptiveTimeoutConfig"`
 
 	BenchlistConfig benchlist.Config `json:"benchlistConfig"`
 
 	ProfilerConfig profiler.Config `json:"profilerConfig"`
 
+	// CaptureConfig defines which inbound messages are captured to disk
+	CaptureConfig capture.Config `json:"captureConfig"`
+
 	LoggingConfig logging.Config `json:"loggingConfig"`
 
 	PluginDir string `json:"pluginDir"`
@@ -228,4 +243,8 @@ type Config struct {
 	// ChainDataDir is the root path for per-chain directories where VMs can
 	// write arbitrary data.
 	ChainDataDir string `json:"chainDataDir"`
+
+	// Clock that the node's timers, and those of its network and chains, run
+	// on
+	Clock mockable.Clock `json:"-"`
 }
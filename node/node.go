@@ -49,6 +49,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
+	"github.com/MetalBlockchain/metalgo/snow/networking/capture"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 	"github.com/MetalBlockchain/metalgo/snow/networking/timeout"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
@@ -86,8 +87,10 @@ import (
 )
 
 var (
-	genesisHashKey  = []byte("genesisID")
-	indexerDBPrefix = []byte{0x00}
+	genesisHashKey     = []byte("genesisID")
+	indexerDBPrefix    = []byte{0x00}
+	networkBanDBPrefix = []byte("network bans")
+	benchlistDBPrefix  = []byte("benchlist")
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
@@ -131,6 +134,11 @@ type Node struct {
 	// Manages validator benching
 	benchlistManager benchlist.Manager
 
+	// Subnet ID --> Minimum portion of the subnet's stake this node must be
+	// connected to in order to report healthy. Updated when the consensus
+	// parameters of a subnet change.
+	minPercentConnectedStakeHealthy *utils.Atomic[map[ids.ID]float64]
+
 	uptimeCalculator uptime.LockedCalculator
 
 	// dispatcher for events as they happen in consensus
@@ -148,6 +156,11 @@ type Node struct {
 	// session keys. This value should only be non-nil during debugging.
 	tlsKeyLogWriterCloser io.WriteCloser
 
+	// captureRecorder writes the chain messages selected by
+	// [Config.CaptureConfig] to disk. This value is nil if capturing is
+	// disabled.
+	captureRecorder capture.Recorder
+
 	// this node's initial connections to the network
 	beacons validators.Set
 
@@ -200,6 +213,10 @@ type Node struct {
 	// Specifies how much disk usage each peer can cause before
 	// we rate-limit them.
 	diskTargeter tracker.Targeter
+
+	// Specifies how much CPU/disk usage the messages of each subnet can cause
+	// before we rate-limit them.
+	subnetResourceThrottler throttling.SubnetSystemThrottler
 }
 
 /*
@@ -212,9 +229,13 @@ type Node struct {
 // Assumes [n.CPUTracker] and [n.CPUTargeter] have been initialized.
 func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 	currentIPPort := n.Config.IPPort.IPPort()
-	listener, err := net.Listen(constants.NetworkType, fmt.Sprintf(":%d", currentIPPort.Port))
-	if err != nil {
-		return err
+	listener := n.Config.NetworkListener
+	if listener == nil {
+		var err error
+		listener, err = net.Listen(constants.NetworkType, fmt.Sprintf(":%d", currentIPPort.Port))
+		if err != nil {
+			return err
+		}
 	}
 	// Wrap listener so it will only accept a certain number of incoming connections per second
 	listener = throttling.NewThrottledListener(listener, n.Config.NetworkConfig.ThrottlerConfig.MaxInboundConnsPerSec)
@@ -255,6 +276,7 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 	n.Config.BenchlistConfig.Validators = n.vdrs
 	n.Config.BenchlistConfig.Benchable = n.Config.ConsensusRouter
 	n.Config.BenchlistConfig.StakingEnabled = n.Config.EnableStaking
+	n.Config.BenchlistConfig.DB = prefixdb.New(benchlistDBPrefix, n.DB)
 	n.benchlistManager = benchlist.NewManager(&n.Config.BenchlistConfig)
 
 	n.uptimeCalculator = uptime.NewLockedCalculator()
@@ -291,7 +313,7 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 		// Set a timer that will fire after a given timeout unless we connect
 		// to a sufficient portion of nodes. If the timeout fires, the node will
 		// shutdown.
-		timer := timer.NewTimer(func() {
+		timer := timer.NewTimerWithClock(n.Config.Clock, func() {
 			// If the timeout fires and we're already shutting down, nothing to do.
 			if !n.shuttingDown.Get() {
 				n.Log.Warn("failed to connect to bootstrap nodes",
@@ -312,6 +334,14 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 		}
 	}
 
+	if n.Config.CaptureConfig.Enabled {
+		n.Log.Warn("inbound message capture is enabled",
+			zap.String("directory", n.Config.CaptureConfig.Directory),
+		)
+		n.captureRecorder = capture.NewRecorder(n.Log, n.Config.CaptureConfig)
+		consensusRouter = capture.Router(consensusRouter, n.captureRecorder)
+	}
+
 	// initialize gossip tracker
 	gossipTracker, err := peer.NewGossipTracker(n.MetricsRegisterer, n.networkNamespace)
 	if err != nil {
@@ -340,6 +370,16 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
 	n.Config.NetworkConfig.GossipTracker = gossipTracker
+	n.Config.NetworkConfig.BanDB = prefixdb.New(networkBanDBPrefix, n.DB)
+	n.Config.NetworkConfig.Clock = n.Config.Clock
+
+	networkDialer := n.Config.NetworkDialer
+	if networkDialer == nil {
+		networkDialer, err = dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log)
+		if err != nil {
+			return fmt.Errorf("failed to initialize dialer: %w", err)
+		}
+	}
 
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
@@ -347,7 +387,7 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 		n.MetricsRegisterer,
 		n.Log,
 		listener,
-		dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log),
+		networkDialer,
 		consensusRouter,
 	)
 
@@ -676,7 +716,11 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		cChainID,
 	)
 
+	n.minPercentConnectedStakeHealthy = &utils.Atomic[map[ids.ID]float64]{}
+	n.minPercentConnectedStakeHealthy.Set(n.Config.MinPercentConnectedStakeHealthy)
+
 	// Manages network timeouts
+	n.Config.AdaptiveTimeoutConfig.Clock = n.Config.Clock
 	timeoutManager, err := timeout.NewManager(
 		&n.Config.AdaptiveTimeoutConfig,
 		n.benchlistManager,
@@ -746,10 +790,14 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		ApricotPhase4Time:                       version.GetApricotPhase4Time(n.Config.NetworkID),
 		ApricotPhase4MinPChainHeight:            version.GetApricotPhase4MinPChainHeight(n.Config.NetworkID),
 		ResourceTracker:                         n.resourceTracker,
+		SubnetResourceThrottler:                 n.subnetResourceThrottler,
+		Benchlist:                               n.benchlistManager,
+		MinPercentConnectedStakeHealthy:         n.minPercentConnectedStakeHealthy,
 		StateSyncBeacons:                        n.Config.StateSyncIDs,
 		TracingEnabled:                          n.Config.TraceConfig.Enabled,
 		Tracer:                                  n.tracer,
 		ChainDataDir:                            n.Config.ChainDataDir,
+		Clock:                                   n.Config.Clock,
 	})
 
 	// Notify the API server when new chains are created
@@ -809,7 +857,7 @@ func (n *Node) initVMs() error {
 				ApricotPhase3Time:               version.GetApricotPhase3Time(n.Config.NetworkID),
 				ApricotPhase5Time:               version.GetApricotPhase5Time(n.Config.NetworkID),
 				BanffTime:                       version.GetBanffTime(n.Config.NetworkID),
-				MinPercentConnectedStakeHealthy: n.Config.MinPercentConnectedStakeHealthy,
+				MinPercentConnectedStakeHealthy: n.minPercentConnectedStakeHealthy,
 				UseCurrentHeight:                n.Config.UseCurrentHeight,
 			},
 		}),
@@ -941,6 +989,8 @@ func (n *Node) initAdminAPI() error {
 			NodeConfig:   n.Config,
 			VMManager:    n.VMManager,
 			VMRegistry:   n.VMRegistry,
+			Network:      n.Net,
+			Benchlist:    n.benchlistManager,
 		},
 	)
 	if err != nil {
@@ -1233,6 +1283,36 @@ func (n *Node) initDiskTargeter(
 	)
 }
 
+// Initialize [n.subnetResourceThrottler].
+// Assumes [n.resourceTracker] is already initialized.
+func (n *Node) initSubnetResourceThrottler() error {
+	cpuConfig := &tracker.SubnetTargeterConfig{
+		Target:      n.Config.CPUTargeterConfig.VdrAlloc,
+		Allocations: make(map[ids.ID]tracker.SubnetAllocation, len(n.Config.SubnetConfigs)),
+	}
+	diskConfig := &tracker.SubnetTargeterConfig{
+		Target:      n.Config.DiskTargeterConfig.VdrAlloc,
+		Allocations: make(map[ids.ID]tracker.SubnetAllocation, len(n.Config.SubnetConfigs)),
+	}
+	for subnetID, subnetConfig := range n.Config.SubnetConfigs {
+		cpuConfig.Allocations[subnetID] = subnetConfig.CPUAllocation
+		diskConfig.Allocations[subnetID] = subnetConfig.DiskAllocation
+	}
+
+	throttlerConfig := n.Config.NetworkConfig.ThrottlerConfig.InboundMsgThrottlerConfig
+	var err error
+	n.subnetResourceThrottler, err = throttling.NewSubnetResourceThrottler(
+		"subnet_resource_throttler",
+		n.MetricsRegisterer,
+		throttlerConfig.CPUThrottlerConfig,
+		throttlerConfig.DiskThrottlerConfig,
+		n.resourceTracker,
+		tracker.NewSubnetTargeter(cpuConfig, n.resourceTracker.CPUTracker()),
+		tracker.NewSubnetTargeter(diskConfig, n.resourceTracker.DiskTracker()),
+	)
+	return err
+}
+
 // Initialize this node
 func (n *Node) Initialize(
 	config *Config,
@@ -1301,11 +1381,12 @@ func (n *Node) Initialize(
 	// and the engine (initChains) but after the metrics (initMetricsAPI)
 	// message.Creator currently record metrics under network namespace
 	n.networkNamespace = "network"
-	n.msgCreator, err = message.NewCreator(
+	n.msgCreator, err = message.NewCreatorWithClock(
 		n.MetricsRegisterer,
 		n.networkNamespace,
-		n.Config.NetworkConfig.CompressionEnabled,
+		n.Config.NetworkConfig.CompressionType,
 		n.Config.NetworkConfig.MaximumInboundMessageTimeout,
+		n.Config.Clock,
 	)
 	if err != nil {
 		return fmt.Errorf("problem initializing message creator: %w", err)
@@ -1317,6 +1398,9 @@ func (n *Node) Initialize(
 	}
 	n.initCPUTargeter(&config.CPUTargeterConfig, primaryNetVdrs)
 	n.initDiskTargeter(&config.DiskTargeterConfig, primaryNetVdrs)
+	if err := n.initSubnetResourceThrottler(); err != nil {
+		return fmt.Errorf("problem initializing subnet resource throttler: %w", err)
+	}
 	if err := n.initNetworking(primaryNetVdrs); err != nil { // Set up networking layer.
 		return fmt.Errorf("problem initializing networking: %w", err)
 	}
@@ -1422,6 +1506,13 @@ func (n *Node) shutdown() {
 	if n.Net != nil {
 		n.Net.StartClose()
 	}
+	if n.captureRecorder != nil {
+		if err := n.captureRecorder.Close(); err != nil {
+			n.Log.Debug("error closing message capture",
+				zap.Error(err),
+			)
+		}
+	}
 	if err := n.APIServer.Shutdown(); err != nil {
 		n.Log.Debug("error during API shutdown",
 			zap.Error(err),
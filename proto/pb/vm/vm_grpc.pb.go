@@ -88,6 +88,11 @@ type VMClient interface {
 	// GetStateSummary retrieves the state summary that was generated at height
 	// [summaryHeight].
 	GetStateSummary(ctx context.Context, in *GetStateSummaryRequest, opts ...grpc.CallOption) (*GetStateSummaryResponse, error)
+	// ConcurrentVerifyChainVM
+	//
+	// ConcurrentVerifyEnabled indicates whether BlockVerify may be called
+	// concurrently on different blocks.
+	ConcurrentVerifyEnabled(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ConcurrentVerifyEnabledResponse, error)
 	// Block
 	BlockVerify(ctx context.Context, in *BlockVerifyRequest, opts ...grpc.CallOption) (*BlockVerifyResponse, error)
 	BlockAccept(ctx context.Context, in *BlockAcceptRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -374,6 +379,15 @@ func (c *vMClient) GetStateSummary(ctx context.Context, in *GetStateSummaryReque
 	return out, nil
 }
 
+func (c *vMClient) ConcurrentVerifyEnabled(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ConcurrentVerifyEnabledResponse, error) {
+	out := new(ConcurrentVerifyEnabledResponse)
+	err := c.cc.Invoke(ctx, "/vm.VM/ConcurrentVerifyEnabled", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vMClient) BlockVerify(ctx context.Context, in *BlockVerifyRequest, opts ...grpc.CallOption) (*BlockVerifyResponse, error) {
 	out := new(BlockVerifyResponse)
 	err := c.cc.Invoke(ctx, "/vm.VM/BlockVerify", in, out, opts...)
@@ -479,6 +493,11 @@ type VMServer interface {
 	// GetStateSummary retrieves the state summary that was generated at height
 	// [summaryHeight].
 	GetStateSummary(context.Context, *GetStateSummaryRequest) (*GetStateSummaryResponse, error)
+	// ConcurrentVerifyChainVM
+	//
+	// ConcurrentVerifyEnabled indicates whether BlockVerify may be called
+	// concurrently on different blocks.
+	ConcurrentVerifyEnabled(context.Context, *emptypb.Empty) (*ConcurrentVerifyEnabledResponse, error)
 	// Block
 	BlockVerify(context.Context, *BlockVerifyRequest) (*BlockVerifyResponse, error)
 	BlockAccept(context.Context, *BlockAcceptRequest) (*emptypb.Empty, error)
@@ -582,6 +601,9 @@ func (UnimplementedVMServer) ParseStateSummary(context.Context, *ParseStateSumma
 func (UnimplementedVMServer) GetStateSummary(context.Context, *GetStateSummaryRequest) (*GetStateSummaryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStateSummary not implemented")
 }
+func (UnimplementedVMServer) ConcurrentVerifyEnabled(context.Context, *emptypb.Empty) (*ConcurrentVerifyEnabledResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConcurrentVerifyEnabled not implemented")
+}
 func (UnimplementedVMServer) BlockVerify(context.Context, *BlockVerifyRequest) (*BlockVerifyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BlockVerify not implemented")
 }
@@ -1147,6 +1169,24 @@ func _VM_GetStateSummary_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VM_ConcurrentVerifyEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).ConcurrentVerifyEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vm.VM/ConcurrentVerifyEnabled",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).ConcurrentVerifyEnabled(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VM_BlockVerify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BlockVerifyRequest)
 	if err := dec(in); err != nil {
@@ -1346,6 +1386,10 @@ var VM_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStateSummary",
 			Handler:    _VM_GetStateSummary_Handler,
 		},
+		{
+			MethodName: "ConcurrentVerifyEnabled",
+			Handler:    _VM_ConcurrentVerifyEnabled_Handler,
+		},
 		{
 			MethodName: "BlockVerify",
 			Handler:    _VM_BlockVerify_Handler,
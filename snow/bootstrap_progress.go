@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snow
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	PhaseNotStarted BootstrapPhase = iota
+	PhaseStateSyncing
+	PhaseFetching
+	PhaseExecuting
+	PhaseDone
+)
+
+// BootstrapPhase is the step of bootstrapping that a chain is performing.
+type BootstrapPhase uint8
+
+func (p BootstrapPhase) String() string {
+	switch p {
+	case PhaseNotStarted:
+		return "notStarted"
+	case PhaseStateSyncing:
+		return "stateSyncing"
+	case PhaseFetching:
+		return "fetching"
+	case PhaseExecuting:
+		return "executing"
+	case PhaseDone:
+		return "done"
+	default:
+		return "unknown"
+	}
+}
+
+func (p BootstrapPhase) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// BootstrapProgress reports how far a chain has progressed in bootstrapping.
+type BootstrapProgress struct {
+	Phase BootstrapPhase `json:"phase"`
+	// Number of containers that have been fetched and are waiting to be
+	// executed.
+	Fetched uint64 `json:"fetched"`
+	// Number of containers that have been executed in the current execution
+	// phase.
+	Executed uint64 `json:"executed"`
+	// Height of the accepted frontier that is being bootstrapped to. This is
+	// only reported by linear chains.
+	TargetHeight uint64 `json:"targetHeight"`
+	// Number of containers processed per second in the current phase.
+	Rate float64 `json:"rate"`
+	// Estimated time until the current phase finishes. Zero if unknown.
+	ETA time.Duration `json:"eta"`
+}
@@ -5,6 +5,7 @@ package avalanche
 
 import (
 	"context"
+	"time"
 
 	"github.com/MetalBlockchain/metalgo/api/health"
 	"github.com/MetalBlockchain/metalgo/ids"
@@ -32,6 +33,10 @@ type Consensus interface {
 	// the join status map.
 	Initialize(context.Context, *snow.ConsensusContext, Parameters, []Vertex) error
 
+	// SetParameters replaces the parameters used to decide the processing
+	// vertices and transactions.
+	SetParameters(Parameters) error
+
 	// Returns the number of vertices processing
 	NumProcessing() int
 
@@ -75,4 +80,30 @@ type Consensus interface {
 	// finalized. Note, it is possible that after returning finalized, a new
 	// decision may be added such that this instance is no longer finalized.
 	Finalized() bool
+
+	// Inspect returns the state of every processing vertex, sorted by height.
+	Inspect(context.Context) ([]VertexState, error)
+}
+
+// VertexState is a snapshot of a processing vertex.
+type VertexState struct {
+	ID        ids.ID   `json:"id"`
+	ParentIDs []ids.ID `json:"parentIDs"`
+	Height    uint64   `json:"height"`
+	TxIDs     []ids.ID `json:"txIDs"`
+
+	// Preferred is true if the vertex was strongly preferred as of the last
+	// update.
+	Preferred bool `json:"preferred"`
+
+	// Virtuous is true if the vertex was strongly virtuous as of the last
+	// update.
+	Virtuous bool `json:"virtuous"`
+
+	// ProcessingTime is the amount of time the vertex has been processing.
+	ProcessingTime time.Duration `json:"processingTime"`
+
+	// Snowball describes the preference strength and confidence of the vertex
+	// and of its transactions that are still processing, keyed by their IDs.
+	Snowball map[ids.ID]string `json:"snowball"`
 }
@@ -15,6 +15,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/choices"
@@ -55,6 +57,8 @@ var (
 		ErrorOnParentVtxRejectTest,
 		ErrorOnTransitiveVtxRejectTest,
 		SilenceTransactionVertexEventsTest,
+		InspectTest,
+		SetParametersTest,
 	}
 
 	errTest = errors.New("non-nil error")
@@ -80,7 +84,8 @@ func MetricsTest(t *testing.T, factory Factory) {
 		params := Parameters{
 			Parameters: snowball.Parameters{
 				K:                 2,
-				Alpha:             2,
+				AlphaPreference:   2,
+				AlphaConfidence:   2,
 				BetaVirtuous:      1,
 				BetaRogue:         2,
 				ConcurrentRepolls: 1,
@@ -104,7 +109,8 @@ func MetricsTest(t *testing.T, factory Factory) {
 		params := Parameters{
 			Parameters: snowball.Parameters{
 				K:                 2,
-				Alpha:             2,
+				AlphaPreference:   2,
+				AlphaConfidence:   2,
 				BetaVirtuous:      1,
 				BetaRogue:         2,
 				ConcurrentRepolls: 1,
@@ -128,7 +134,8 @@ func MetricsTest(t *testing.T, factory Factory) {
 		params := Parameters{
 			Parameters: snowball.Parameters{
 				K:                 2,
-				Alpha:             2,
+				AlphaPreference:   2,
+				AlphaConfidence:   2,
 				BetaVirtuous:      1,
 				BetaRogue:         2,
 				ConcurrentRepolls: 1,
@@ -155,7 +162,8 @@ func NumProcessingTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          1,
 			BetaRogue:             1,
 			ConcurrentRepolls:     1,
@@ -267,7 +275,8 @@ func AddTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     2,
-			Alpha:                 2,
+			AlphaPreference:       2,
+			AlphaConfidence:       2,
 			BetaVirtuous:          1,
 			BetaRogue:             2,
 			ConcurrentRepolls:     1,
@@ -406,7 +415,8 @@ func VertexIssuedTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     2,
-			Alpha:                 2,
+			AlphaPreference:       2,
+			AlphaConfidence:       2,
 			BetaVirtuous:          1,
 			BetaRogue:             2,
 			ConcurrentRepolls:     1,
@@ -468,7 +478,8 @@ func TxIssuedTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     2,
-			Alpha:                 2,
+			AlphaPreference:       2,
+			AlphaConfidence:       2,
 			BetaVirtuous:          1,
 			BetaRogue:             2,
 			ConcurrentRepolls:     1,
@@ -531,7 +542,8 @@ func VirtuousTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     2,
-			Alpha:                 2,
+			AlphaPreference:       2,
+			AlphaConfidence:       2,
 			BetaVirtuous:          10,
 			BetaRogue:             20,
 			ConcurrentRepolls:     1,
@@ -684,7 +696,8 @@ func VirtuousSkippedUpdateTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     2,
-			Alpha:                 2,
+			AlphaPreference:       2,
+			AlphaConfidence:       2,
 			BetaVirtuous:          10,
 			BetaRogue:             20,
 			ConcurrentRepolls:     1,
@@ -786,7 +799,8 @@ func VotingTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     2,
-			Alpha:                 2,
+			AlphaPreference:       2,
+			AlphaConfidence:       2,
 			BetaVirtuous:          1,
 			BetaRogue:             2,
 			ConcurrentRepolls:     1,
@@ -902,7 +916,8 @@ func IgnoreInvalidVotingTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     3,
-			Alpha:                 2,
+			AlphaPreference:       2,
+			AlphaConfidence:       2,
 			BetaVirtuous:          1,
 			BetaRogue:             1,
 			ConcurrentRepolls:     1,
@@ -989,7 +1004,8 @@ func IgnoreInvalidTransactionVertexVotingTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     3,
-			Alpha:                 2,
+			AlphaPreference:       2,
+			AlphaConfidence:       2,
 			BetaVirtuous:          1,
 			BetaRogue:             1,
 			ConcurrentRepolls:     1,
@@ -1070,7 +1086,8 @@ func TransitiveVotingTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     2,
-			Alpha:                 2,
+			AlphaPreference:       2,
+			AlphaConfidence:       2,
 			BetaVirtuous:          1,
 			BetaRogue:             2,
 			ConcurrentRepolls:     1,
@@ -1189,7 +1206,8 @@ func SplitVotingTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     2,
-			Alpha:                 2,
+			AlphaPreference:       2,
+			AlphaConfidence:       2,
 			BetaVirtuous:          1,
 			BetaRogue:             2,
 			ConcurrentRepolls:     1,
@@ -1289,7 +1307,8 @@ func TransitiveRejectionTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     2,
-			Alpha:                 2,
+			AlphaPreference:       2,
+			AlphaConfidence:       2,
 			BetaVirtuous:          1,
 			BetaRogue:             2,
 			ConcurrentRepolls:     1,
@@ -1426,7 +1445,8 @@ func IsVirtuousTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     2,
-			Alpha:                 2,
+			AlphaPreference:       2,
+			AlphaConfidence:       2,
 			BetaVirtuous:          1,
 			BetaRogue:             2,
 			ConcurrentRepolls:     1,
@@ -1529,7 +1549,8 @@ func QuiesceTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          1,
 			BetaRogue:             2,
 			ConcurrentRepolls:     1,
@@ -1661,7 +1682,8 @@ func QuiesceAfterVotingTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          2,
 			BetaRogue:             2,
 			ConcurrentRepolls:     1,
@@ -1813,7 +1835,8 @@ func TransactionVertexTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          1,
 			BetaRogue:             1,
 			ConcurrentRepolls:     1,
@@ -1888,7 +1911,8 @@ func OrphansTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          math.MaxInt32,
 			BetaRogue:             math.MaxInt32,
 			ConcurrentRepolls:     1,
@@ -2016,7 +2040,8 @@ func OrphansUpdateTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          math.MaxInt32,
 			BetaRogue:             math.MaxInt32,
 			ConcurrentRepolls:     1,
@@ -2153,7 +2178,8 @@ func ErrorOnVacuousAcceptTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          math.MaxInt32,
 			BetaRogue:             math.MaxInt32,
 			ConcurrentRepolls:     1,
@@ -2201,7 +2227,8 @@ func ErrorOnTxAcceptTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          1,
 			BetaRogue:             1,
 			ConcurrentRepolls:     1,
@@ -2257,7 +2284,8 @@ func ErrorOnVtxAcceptTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          1,
 			BetaRogue:             1,
 			ConcurrentRepolls:     1,
@@ -2313,7 +2341,8 @@ func ErrorOnVtxRejectTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          1,
 			BetaRogue:             1,
 			ConcurrentRepolls:     1,
@@ -2387,7 +2416,8 @@ func ErrorOnParentVtxRejectTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          1,
 			BetaRogue:             1,
 			ConcurrentRepolls:     1,
@@ -2473,7 +2503,8 @@ func ErrorOnTransitiveVtxRejectTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          1,
 			BetaRogue:             1,
 			ConcurrentRepolls:     1,
@@ -2558,7 +2589,8 @@ func SilenceTransactionVertexEventsTest(t *testing.T, factory Factory) {
 	params := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          1,
 			BetaRogue:             1,
 			ConcurrentRepolls:     1,
@@ -2606,3 +2638,160 @@ func SilenceTransactionVertexEventsTest(t *testing.T, factory Factory) {
 		t.Fatalf("Shouldn't have reported the transaction vertex as accepted")
 	}
 }
+
+func InspectTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+	avl := factory.New()
+
+	params := Parameters{
+		Parameters: snowball.Parameters{
+			K:                     1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
+			BetaVirtuous:          1,
+			BetaRogue:             2,
+			ConcurrentRepolls:     1,
+			OptimalProcessing:     1,
+			MaxOutstandingItems:   1,
+			MaxItemProcessingTime: 1,
+		},
+		Parents:   2,
+		BatchSize: 1,
+	}
+	vts := []Vertex{
+		&TestVertex{TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		}},
+	}
+	utxos := []ids.ID{ids.GenerateTestID()}
+
+	require.NoError(avl.Initialize(context.Background(), snow.DefaultConsensusContextTest(), params, vts))
+
+	states, err := avl.Inspect(context.Background())
+	require.NoError(err)
+	require.Empty(states)
+
+	tx0 := &snowstorm.TestTx{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Processing,
+	}}
+	tx0.InputIDsV = append(tx0.InputIDsV, utxos[0])
+
+	tx1 := &snowstorm.TestTx{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Processing,
+	}}
+	tx1.InputIDsV = append(tx1.InputIDsV, utxos[0])
+
+	vtx0 := &TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: vts,
+		HeightV:  1,
+		TxsV:     []snowstorm.Tx{tx0},
+	}
+	vtx1 := &TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: []Vertex{vtx0},
+		HeightV:  2,
+		TxsV:     []snowstorm.Tx{tx1},
+	}
+
+	require.NoError(avl.Add(context.Background(), vtx0))
+	require.NoError(avl.Add(context.Background(), vtx1))
+
+	// Polling updates the preferred and virtuous frontiers.
+	votes := bag.UniqueBag[ids.ID]{}
+	votes.Add(0, vtx0.ID())
+	require.NoError(avl.RecordPoll(context.Background(), votes))
+
+	states, err = avl.Inspect(context.Background())
+	require.NoError(err)
+	require.Len(states, 2)
+
+	require.Equal(vtx0.ID(), states[0].ID)
+	require.Equal([]ids.ID{vts[0].ID()}, states[0].ParentIDs)
+	require.Equal(uint64(1), states[0].Height)
+	require.Equal([]ids.ID{tx0.ID()}, states[0].TxIDs)
+	require.True(states[0].Preferred)
+	require.False(states[0].Virtuous)
+	require.Contains(states[0].Snowball, tx0.ID())
+
+	require.Equal(vtx1.ID(), states[1].ID)
+	require.Equal([]ids.ID{vtx0.ID()}, states[1].ParentIDs)
+	require.Equal(uint64(2), states[1].Height)
+	require.Equal([]ids.ID{tx1.ID()}, states[1].TxIDs)
+	require.False(states[1].Preferred)
+	require.False(states[1].Virtuous)
+}
+
+func SetParametersTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+	avl := factory.New()
+
+	params := Parameters{
+		Parameters: snowball.Parameters{
+			K:                     1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
+			BetaVirtuous:          1,
+			BetaRogue:             2,
+			ConcurrentRepolls:     1,
+			OptimalProcessing:     1,
+			MaxOutstandingItems:   1,
+			MaxItemProcessingTime: 1,
+		},
+		Parents:   2,
+		BatchSize: 1,
+	}
+	vts := []Vertex{
+		&TestVertex{TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		}},
+	}
+
+	require.NoError(avl.Initialize(context.Background(), snow.DefaultConsensusContextTest(), params, vts))
+
+	tx0 := &snowstorm.TestTx{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Processing,
+	}}
+	tx0.InputIDsV = append(tx0.InputIDsV, ids.GenerateTestID())
+
+	vtx0 := &TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: vts,
+		HeightV:  1,
+		TxsV:     []snowstorm.Tx{tx0},
+	}
+	require.NoError(avl.Add(context.Background(), vtx0))
+
+	invalidParams := params
+	invalidParams.Parents = 1
+	require.Error(avl.SetParameters(invalidParams))
+
+	params.BetaVirtuous = 2
+	require.NoError(avl.SetParameters(params))
+
+	votes := bag.UniqueBag[ids.ID]{}
+	votes.Add(0, vtx0.ID())
+
+	// The first poll no longer finalizes the vertex.
+	require.NoError(avl.RecordPoll(context.Background(), votes))
+	require.Equal(choices.Processing, tx0.Status())
+	require.Equal(choices.Processing, vtx0.Status())
+
+	require.NoError(avl.RecordPoll(context.Background(), votes))
+	require.Equal(choices.Accepted, tx0.Status())
+	require.Equal(choices.Accepted, vtx0.Status())
+}
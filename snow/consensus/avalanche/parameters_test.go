@@ -13,7 +13,8 @@ func TestParametersValid(t *testing.T) {
 	p := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          1,
 			BetaRogue:             1,
 			ConcurrentRepolls:     1,
@@ -34,7 +35,8 @@ func TestParametersInvalidParents(t *testing.T) {
 	p := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          1,
 			BetaRogue:             1,
 			ConcurrentRepolls:     1,
@@ -55,7 +57,8 @@ func TestParametersInvalidBatchSize(t *testing.T) {
 	p := Parameters{
 		Parameters: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          1,
 			BetaRogue:             1,
 			ConcurrentRepolls:     1,
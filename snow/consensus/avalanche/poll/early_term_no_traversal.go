@@ -18,19 +18,24 @@ var (
 )
 
 type earlyTermNoTraversalFactory struct {
-	alpha int
+	alphaPreference int
+	alphaConfidence int
 }
 
 // NewEarlyTermNoTraversalFactory returns a factory that returns polls with
 // early termination, without doing DAG traversals
-func NewEarlyTermNoTraversalFactory(alpha int) Factory {
-	return &earlyTermNoTraversalFactory{alpha: alpha}
+func NewEarlyTermNoTraversalFactory(alphaPreference, alphaConfidence int) Factory {
+	return &earlyTermNoTraversalFactory{
+		alphaPreference: alphaPreference,
+		alphaConfidence: alphaConfidence,
+	}
 }
 
 func (f *earlyTermNoTraversalFactory) New(vdrs bag.Bag[ids.NodeID]) Poll {
 	return &earlyTermNoTraversalPoll{
-		polled: vdrs,
-		alpha:  f.alpha,
+		polled:          vdrs,
+		alphaPreference: f.alphaPreference,
+		alphaConfidence: f.alphaConfidence,
 	}
 }
 
@@ -38,9 +43,10 @@ func (f *earlyTermNoTraversalFactory) New(vdrs bag.Bag[ids.NodeID]) Poll {
 // the result of the poll. However, does not terminate tightly with this bound.
 // It terminates as quickly as it can without performing any DAG traversals.
 type earlyTermNoTraversalPoll struct {
-	votes  bag.UniqueBag[ids.ID]
-	polled bag.Bag[ids.NodeID]
-	alpha  int
+	votes           bag.UniqueBag[ids.ID]
+	polled          bag.Bag[ids.NodeID]
+	alphaPreference int
+	alphaConfidence int
 }
 
 // Vote registers a response for this poll
@@ -64,22 +70,32 @@ func (p *earlyTermNoTraversalPoll) Finished() bool {
 	}
 	// If there are still enough pending responses to include another vertex,
 	// then the poll must wait for more responses
-	if numPending > p.alpha {
+	if numPending > p.alphaPreference {
 		return false
 	}
 
-	// Ignore any vertex that has already received alpha votes. To safely skip
-	// DAG traversal, assume that all votes for vertices with less than alpha
-	// votes will be applied to a single shared ancestor. In this case, the poll
-	// can terminate early, iff there are not enough pending votes for this
-	// ancestor to receive alpha votes.
+	// The poll can only terminate early if no vertex can reach either the
+	// alphaPreference or the alphaConfidence threshold.
+	return !p.canReach(p.alphaPreference, numPending) &&
+		!p.canReach(p.alphaConfidence, numPending)
+}
+
+// canReach returns true if a vertex that hasn't received [alpha] votes yet
+// could still receive [alpha] votes with [numPending] additional responses.
+//
+// Ignore any vertex that has already received alpha votes. To safely skip DAG
+// traversal, assume that all votes for vertices with less than alpha votes
+// will be applied to a single shared ancestor. In this case, alpha votes can
+// be reached iff there are enough pending votes for this ancestor to receive
+// alpha votes.
+func (p *earlyTermNoTraversalPoll) canReach(alpha int, numPending int) bool {
 	partialVotes := sets.Bits64(0)
 	for _, vote := range p.votes.List() {
-		if voters := p.votes.GetSet(vote); voters.Len() < p.alpha {
+		if voters := p.votes.GetSet(vote); voters.Len() < alpha {
 			partialVotes.Union(voters)
 		}
 	}
-	return partialVotes.Len()+numPending < p.alpha
+	return partialVotes.Len()+numPending >= alpha
 }
 
 // Result returns the result of this poll
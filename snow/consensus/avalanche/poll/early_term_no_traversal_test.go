@@ -21,7 +21,7 @@ func TestEarlyTermNoTraversalResults(t *testing.T) {
 	vdrs := bag.Bag[ids.NodeID]{}
 	vdrs.Add(vdr1)
 
-	factory := NewEarlyTermNoTraversalFactory(alpha)
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
 	poll := factory.New(vdrs)
 
 	poll.Vote(vdr1, votes)
@@ -54,7 +54,7 @@ func TestEarlyTermNoTraversalString(t *testing.T) {
 		vdr2,
 	)
 
-	factory := NewEarlyTermNoTraversalFactory(alpha)
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
 	poll := factory.New(vdrs)
 
 	poll.Vote(vdr1, votes)
@@ -83,7 +83,7 @@ func TestEarlyTermNoTraversalDropsDuplicatedVotes(t *testing.T) {
 		vdr2,
 	)
 
-	factory := NewEarlyTermNoTraversalFactory(alpha)
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
 	poll := factory.New(vdrs)
 
 	poll.Vote(vdr1, votes)
@@ -121,7 +121,7 @@ func TestEarlyTermNoTraversalTerminatesEarly(t *testing.T) {
 		vdr5,
 	)
 
-	factory := NewEarlyTermNoTraversalFactory(alpha)
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
 	poll := factory.New(vdrs)
 
 	poll.Vote(vdr1, votes)
@@ -162,7 +162,7 @@ func TestEarlyTermNoTraversalForSharedAncestor(t *testing.T) {
 	vdrs.Add(vdr3)
 	vdrs.Add(vdr4)
 
-	factory := NewEarlyTermNoTraversalFactory(alpha)
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
 	poll := factory.New(vdrs)
 
 	poll.Vote(vdr1, []ids.ID{vtxB})
@@ -197,7 +197,7 @@ func TestEarlyTermNoTraversalWithFastDrops(t *testing.T) {
 		vdr3,
 	)
 
-	factory := NewEarlyTermNoTraversalFactory(alpha)
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
 	poll := factory.New(vdrs)
 
 	poll.Vote(vdr1, nil)
@@ -209,3 +209,41 @@ func TestEarlyTermNoTraversalWithFastDrops(t *testing.T) {
 		t.Fatalf("Poll did not terminate after dropping two votes")
 	}
 }
+
+func TestEarlyTermNoTraversalTerminatesEarlyWithAlphaPreference(t *testing.T) {
+	alphaPreference := 3
+	alphaConfidence := 5
+
+	vtxID := ids.ID{1}
+	votes := []ids.ID{vtxID}
+
+	vdr1 := ids.NodeID{1}
+	vdr2 := ids.NodeID{2}
+	vdr3 := ids.NodeID{3}
+	vdr4 := ids.NodeID{4}
+	vdr5 := ids.NodeID{5} // k = 5
+
+	vdrs := bag.Bag[ids.NodeID]{}
+	vdrs.Add(
+		vdr1,
+		vdr2,
+		vdr3,
+		vdr4,
+		vdr5,
+	)
+
+	factory := NewEarlyTermNoTraversalFactory(alphaPreference, alphaConfidence)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, votes)
+	poll.Vote(vdr2, votes)
+	poll.Vote(vdr3, votes)
+	if poll.Finished() {
+		t.Fatalf("Poll finished while alphaConfidence could still be reached")
+	}
+
+	poll.Vote(vdr4, nil)
+	if !poll.Finished() {
+		t.Fatalf("Poll did not terminate early after alphaConfidence became unreachable")
+	}
+}
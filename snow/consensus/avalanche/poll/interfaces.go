@@ -5,6 +5,7 @@ package poll
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/utils/bag"
@@ -18,6 +19,25 @@ type Set interface {
 	Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool
 	Vote(requestID uint32, vdr ids.NodeID, votes []ids.ID) []bag.UniqueBag[ids.ID]
 	Len() int
+
+	// SetFactory replaces the factory used to create new polls. Outstanding
+	// polls aren't affected.
+	SetFactory(Factory)
+
+	// Inspect returns the state of the outstanding polls, from oldest to
+	// newest.
+	Inspect() []Info
+}
+
+// Info is a snapshot of an outstanding poll
+type Info struct {
+	RequestID uint32        `json:"requestID"`
+	Duration  time.Duration `json:"duration"`
+
+	// Responded are the validators that have voted in the poll.
+	Responded []ids.NodeID `json:"responded"`
+	// Pending are the validators that haven't responded to the poll.
+	Pending []ids.NodeID `json:"pending"`
 }
 
 // Poll is an outstanding poll
@@ -13,10 +13,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/bag"
 	"github.com/MetalBlockchain/metalgo/utils/linkedhashmap"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/metric"
+	sets "github.com/MetalBlockchain/metalgo/utils/set"
 )
 
 var (
@@ -24,22 +26,13 @@ var (
 	_ Poll = (*poll)(nil)
 )
 
-type pollHolder interface {
-	GetPoll() Poll
-	StartTime() time.Time
-}
-
 type poll struct {
 	Poll
 	start time.Time
-}
-
-func (p poll) GetPoll() Poll {
-	return p
-}
-
-func (p poll) StartTime() time.Time {
-	return p.start
+	// vdrs are the validators that were queried
+	vdrs []ids.NodeID
+	// responded are the validators that have voted
+	responded sets.Set[ids.NodeID]
 }
 
 type set struct {
@@ -48,7 +41,7 @@ type set struct {
 	durPolls metric.Averager
 	factory  Factory
 	// maps requestID -> poll
-	polls linkedhashmap.LinkedHashmap[uint32, pollHolder]
+	polls linkedhashmap.LinkedHashmap[uint32, *poll]
 }
 
 // NewSet returns a new empty set of polls
@@ -86,7 +79,7 @@ func NewSet(
 		numPolls: numPolls,
 		durPolls: durPolls,
 		factory:  factory,
-		polls:    linkedhashmap.New[uint32, pollHolder](),
+		polls:    linkedhashmap.New[uint32, *poll](),
 	}
 }
 
@@ -107,9 +100,12 @@ func (s *set) Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool {
 		zap.Stringer("validators", &vdrs),
 	)
 
-	s.polls.Put(requestID, poll{
+	vdrList := vdrs.List()
+	utils.Sort(vdrList)
+	s.polls.Put(requestID, &poll{
 		Poll:  s.factory.New(vdrs), // create the new poll
 		start: time.Now(),
+		vdrs:  vdrList,
 	})
 	s.numPolls.Inc() // increase the metrics
 	return true
@@ -118,7 +114,7 @@ func (s *set) Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool {
 // Vote registers the connections response to a query for [id]. If there was no
 // query, or the response has already be registered, nothing is performed.
 func (s *set) Vote(requestID uint32, vdr ids.NodeID, votes []ids.ID) []bag.UniqueBag[ids.ID] {
-	holder, exists := s.polls.Get(requestID)
+	p, exists := s.polls.Get(requestID)
 	if !exists {
 		s.log.Verbo("dropping vote",
 			zap.String("reason", "unknown poll"),
@@ -128,14 +124,13 @@ func (s *set) Vote(requestID uint32, vdr ids.NodeID, votes []ids.ID) []bag.Uniqu
 		return nil
 	}
 
-	p := holder.GetPoll()
-
 	s.log.Verbo("processing votes",
 		zap.Stringer("validator", vdr),
 		zap.Uint32("requestID", requestID),
 		zap.Stringers("votes", votes),
 	)
 
+	p.responded.Add(vdr)
 	p.Vote(vdr, votes)
 	if !p.Finished() {
 		return nil
@@ -146,8 +141,7 @@ func (s *set) Vote(requestID uint32, vdr ids.NodeID, votes []ids.ID) []bag.Uniqu
 	// iterate from oldest to newest
 	iter := s.polls.NewIterator()
 	for iter.Next() {
-		holder := iter.Value()
-		p := holder.GetPoll()
+		p := iter.Value()
 		if !p.Finished() {
 			// since we're iterating from oldest to newest, if the next poll has not finished,
 			// we can break and return what we have so far
@@ -158,7 +152,7 @@ func (s *set) Vote(requestID uint32, vdr ids.NodeID, votes []ids.ID) []bag.Uniqu
 			zap.Uint32("requestID", requestID),
 			zap.Stringer("poll", p),
 		)
-		s.durPolls.Observe(float64(time.Since(holder.StartTime())))
+		s.durPolls.Observe(float64(time.Since(p.start)))
 		s.numPolls.Dec() // decrease the metrics
 
 		results = append(results, p.Result())
@@ -175,13 +169,39 @@ func (s *set) Len() int {
 	return s.polls.Len()
 }
 
+func (s *set) SetFactory(factory Factory) {
+	s.factory = factory
+}
+
+func (s *set) Inspect() []Info {
+	infos := make([]Info, 0, s.polls.Len())
+	iter := s.polls.NewIterator()
+	for iter.Next() {
+		p := iter.Value()
+		info := Info{
+			RequestID: iter.Key(),
+			Duration:  time.Since(p.start),
+		}
+		for _, vdr := range p.vdrs {
+			switch {
+			case p.responded.Contains(vdr):
+				info.Responded = append(info.Responded, vdr)
+			default:
+				info.Pending = append(info.Pending, vdr)
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
 func (s *set) String() string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("current polls: (Size = %d)", s.polls.Len()))
 	iter := s.polls.NewIterator()
 	for iter.Next() {
 		requestID := iter.Key()
-		poll := iter.Value().(Poll)
+		poll := iter.Value()
 		sb.WriteString(fmt.Sprintf("\n    RequestID %d:\n        %s", requestID, poll.PrefixedString("        ")))
 	}
 	return sb.String()
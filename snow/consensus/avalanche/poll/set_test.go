@@ -235,3 +235,32 @@ func TestSetString(t *testing.T) {
 			str)
 	}
 }
+
+func TestSetInspect(t *testing.T) {
+	require := require.New(t)
+
+	factory := NewNoEarlyTermFactory()
+	log := logging.NoLog{}
+	namespace := ""
+	registerer := prometheus.NewRegistry()
+	s := NewSet(factory, log, namespace, registerer)
+
+	vdr1 := ids.NodeID{1}
+	vdr2 := ids.NodeID{2} // k = 2
+
+	vdrs := bag.Bag[ids.NodeID]{}
+	vdrs.Add(vdr2, vdr1)
+
+	require.Empty(s.Inspect())
+	require.True(s.Add(0, vdrs))
+	require.Empty(s.Vote(0, vdr2, []ids.ID{ids.GenerateTestID()}))
+
+	infos := s.Inspect()
+	require.Len(infos, 1)
+	require.Equal(uint32(0), infos[0].RequestID)
+	require.Equal([]ids.NodeID{vdr2}, infos[0].Responded)
+	require.Equal([]ids.NodeID{vdr1}, infos[0].Pending)
+
+	require.Len(s.Vote(0, vdr1, nil), 1)
+	require.Empty(s.Inspect())
+}
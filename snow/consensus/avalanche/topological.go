@@ -12,6 +12,7 @@ import (
 	"go.uber.org/zap"
 
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow"
@@ -144,6 +145,18 @@ func (ta *Topological) Initialize(
 	return ta.updateFrontiers(ctx)
 }
 
+func (ta *Topological) SetParameters(params Parameters) error {
+	if err := params.Valid(); err != nil {
+		return err
+	}
+	if err := ta.cg.SetParameters(params.Parameters); err != nil {
+		return err
+	}
+
+	ta.params = params
+	return nil
+}
+
 func (ta *Topological) NumProcessing() int {
 	return len(ta.nodes)
 }
@@ -238,20 +251,20 @@ func (ta *Topological) RecordPoll(ctx context.Context, responses bag.UniqueBag[i
 	// Register a new poll call
 	ta.pollNumber++
 
-	// If it isn't possible to have alpha votes for any transaction, then we can
-	// just reset the confidence values in the conflict graph and not perform
-	// any traversals.
+	// If it isn't possible to have alphaPreference votes for any transaction,
+	// then we can just reset the confidence values in the conflict graph and
+	// not perform any traversals.
 	partialVotes := set.Bits64(0)
 	for vote := range responses {
 		votes := responses.GetSet(vote)
 		partialVotes.Union(votes)
-		if partialVotes.Len() >= ta.params.Alpha {
+		if partialVotes.Len() >= ta.params.AlphaPreference {
 			break
 		}
 	}
-	if partialVotes.Len() < ta.params.Alpha {
-		// Because there were less than alpha total returned votes, we can skip
-		// the traversals and fail the poll.
+	if partialVotes.Len() < ta.params.AlphaPreference {
+		// Because there were less than alphaPreference total returned votes,
+		// we can skip the traversals and fail the poll.
 		_, err := ta.cg.RecordPoll(ctx, bag.Bag[ids.ID]{})
 		return err
 	}
@@ -287,6 +300,54 @@ func (ta *Topological) Finalized() bool {
 }
 
 // HealthCheck returns information about the consensus health.
+func (ta *Topological) Inspect(ctx context.Context) ([]VertexState, error) {
+	states := make([]VertexState, 0, len(ta.nodes))
+	for vtxID, txv := range ta.nodes {
+		parents, err := txv.vtx.Parents()
+		if err != nil {
+			return nil, err
+		}
+		height, err := txv.vtx.Height()
+		if err != nil {
+			return nil, err
+		}
+		txs, err := txv.vtx.Txs(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		state := VertexState{
+			ID:        vtxID,
+			ParentIDs: make([]ids.ID, len(parents)),
+			Height:    height,
+			TxIDs:     make([]ids.ID, len(txs)),
+			Preferred: ta.preferenceCache[vtxID],
+			Virtuous:  ta.virtuousCache[vtxID],
+			Snowball:  make(map[ids.ID]string),
+		}
+		for i, parent := range parents {
+			state.ParentIDs[i] = parent.ID()
+		}
+		for i, tx := range txs {
+			state.TxIDs[i] = tx.ID()
+		}
+		for _, id := range append(state.TxIDs, vtxID) {
+			if sb, ok := ta.cg.Snowball(id); ok {
+				state.Snowball[id] = sb
+			}
+		}
+		state.ProcessingTime, _ = ta.ProcessingDuration(vtxID)
+		states = append(states, state)
+	}
+	slices.SortFunc(states, func(i, j VertexState) bool {
+		if i.Height != j.Height {
+			return i.Height < j.Height
+		}
+		return i.ID.Less(j.ID)
+	})
+	return states, nil
+}
+
 func (ta *Topological) HealthCheck(ctx context.Context) (interface{}, error) {
 	numOutstandingVtx := ta.Latency.NumProcessing()
 	isOutstandingVtx := numOutstandingVtx <= ta.params.MaxOutstandingItems
@@ -476,7 +537,7 @@ func (ta *Topological) pushVotes(ctx context.Context) (bag.Bag[ids.ID], error) {
 	}
 
 	ta.votes.Difference(&conflictingVotes)
-	return ta.votes.Bag(ta.params.Alpha), nil
+	return ta.votes.Bag(ta.params.AlphaPreference), nil
 }
 
 // If I've already checked, do nothing
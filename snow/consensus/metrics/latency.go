@@ -37,6 +37,10 @@ type Latency interface {
 	// has been processing.
 	MeasureAndGetOldestDuration() time.Duration
 
+	// ProcessingDuration returns the amount of time the item has been
+	// processing. Returns false if the item isn't processing.
+	ProcessingDuration(id ids.ID) (time.Duration, bool)
+
 	// NumProcessing returns the number of currently processing items.
 	NumProcessing() int
 }
@@ -210,6 +214,14 @@ func (l *latency) MeasureAndGetOldestDuration() time.Duration {
 	return time.Since(oldestOp.time)
 }
 
+func (l *latency) ProcessingDuration(id ids.ID) (time.Duration, bool) {
+	start, ok := l.processingEntries.Get(id)
+	if !ok {
+		return 0, false
+	}
+	return time.Since(start.time), true
+}
+
 func (l *latency) NumProcessing() int {
 	return l.processingEntries.Len()
 }
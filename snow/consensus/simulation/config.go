@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
+	safemath "github.com/MetalBlockchain/metalgo/utils/math"
+)
+
+var (
+	errNoValidators       = errors.New("no validators")
+	errNoHonestValidators = errors.New("no honest validators")
+	errZeroWeight         = errors.New("validator has zero weight")
+	errNoChoices          = errors.New("numChoices must be positive")
+	errNoDelay            = errors.New("no delay model")
+	errNoQueryTimeout     = errors.New("queryTimeout must be positive")
+	errNoMaxDuration      = errors.New("maxDuration must be positive")
+	errInsufficientWeight = errors.New("total weight is less than k")
+)
+
+// Validator is a virtual validator of the simulated network.
+type Validator struct {
+	Weight uint64
+
+	// Strategy is how the validator misbehaves. Honest validators have a nil
+	// Strategy.
+	Strategy Strategy
+}
+
+// Config describes a simulation.
+type Config struct {
+	// Seed makes the simulation deterministic. Running the same Config twice
+	// produces the same Report.
+	Seed int64
+
+	// Params are the snowball parameters that the honest validators use.
+	Params snowball.Parameters
+
+	// Validators are the validators of the simulated network.
+	Validators []Validator
+
+	// NumChoices is the number of conflicting blocks that the honest
+	// validators decide between. Each honest validator initially prefers one
+	// of them at random.
+	NumChoices int
+
+	// Delay is the delay of each message sent between two validators.
+	Delay DelayModel
+
+	// QueryTimeout is how long a validator waits for the response to a query
+	// before dropping it from the poll.
+	QueryTimeout time.Duration
+
+	// MaxDuration is the simulated time after which the simulation stops, even
+	// if some honest validators haven't finalized.
+	MaxDuration time.Duration
+}
+
+func (c *Config) Verify() error {
+	if err := c.Params.Verify(); err != nil {
+		return fmt.Errorf("invalid parameters: %w", err)
+	}
+
+	switch {
+	case len(c.Validators) == 0:
+		return errNoValidators
+	case c.NumChoices <= 0:
+		return errNoChoices
+	case c.Delay == nil:
+		return errNoDelay
+	case c.QueryTimeout <= 0:
+		return errNoQueryTimeout
+	case c.MaxDuration <= 0:
+		return errNoMaxDuration
+	}
+
+	var (
+		totalWeight uint64
+		numHonest   int
+		err         error
+	)
+	for i, vdr := range c.Validators {
+		if vdr.Weight == 0 {
+			return fmt.Errorf("%w: %d", errZeroWeight, i)
+		}
+		totalWeight, err = safemath.Add64(totalWeight, vdr.Weight)
+		if err != nil {
+			return err
+		}
+		if vdr.Strategy == nil {
+			numHonest++
+		}
+	}
+	if numHonest == 0 {
+		return errNoHonestValidators
+	}
+	if totalWeight < uint64(c.Params.K) {
+		return fmt.Errorf("%w: %d < %d", errInsufficientWeight, totalWeight, c.Params.K)
+	}
+	return nil
+}
+
+// EqualStake returns [n] honest validators that all have the same weight.
+func EqualStake(n int) []Validator {
+	vdrs := make([]Validator, n)
+	for i := range vdrs {
+		vdrs[i].Weight = 1
+	}
+	return vdrs
+}
+
+// ZipfStake returns [n] honest validators whose weights follow a Zipf
+// distribution with exponent [s] > 1, so that a few validators hold most of
+// the stake. The weights are generated deterministically from [seed].
+func ZipfStake(seed int64, n int, s float64) []Validator {
+	rng := rand.New(rand.NewSource(seed)) // #nosec G404
+	zipf := rand.NewZipf(rng, s, 1, math.MaxUint32)
+	vdrs := make([]Validator, n)
+	for i := range vdrs {
+		vdrs[i].Weight = zipf.Uint64() + 1
+	}
+	return vdrs
+}
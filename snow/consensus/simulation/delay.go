@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"math/rand"
+	"time"
+)
+
+var (
+	_ DelayModel = ConstantDelay(0)
+	_ DelayModel = (*UniformDelay)(nil)
+	_ DelayModel = (*RegionDelay)(nil)
+)
+
+// DelayModel describes how long messages take to be delivered.
+type DelayModel interface {
+	// Delay returns how long a message sent by the validator at index [from]
+	// to the validator at index [to] takes to be delivered.
+	Delay(rng *rand.Rand, from, to int) time.Duration
+}
+
+// ConstantDelay delivers every message after the same delay.
+type ConstantDelay time.Duration
+
+func (d ConstantDelay) Delay(*rand.Rand, int, int) time.Duration {
+	return time.Duration(d)
+}
+
+// UniformDelay delivers each message after a delay drawn uniformly from
+// [Min, Max].
+type UniformDelay struct {
+	Min, Max time.Duration
+}
+
+func (d *UniformDelay) Delay(rng *rand.Rand, _, _ int) time.Duration {
+	if d.Max <= d.Min {
+		return d.Min
+	}
+	return d.Min + time.Duration(rng.Int63n(int64(d.Max-d.Min)+1))
+}
+
+// RegionDelay places the validators in [Regions] regions, round robin by
+// index. Messages within a region take [Local] to be delivered and messages
+// between regions take [Remote]. Each message is delayed by up to [Jitter]
+// more.
+type RegionDelay struct {
+	Regions int
+	Local   time.Duration
+	Remote  time.Duration
+	Jitter  time.Duration
+}
+
+func (d *RegionDelay) Delay(rng *rand.Rand, from, to int) time.Duration {
+	delay := d.Remote
+	if d.Regions <= 1 || from%d.Regions == to%d.Regions {
+		delay = d.Local
+	}
+	if d.Jitter > 0 {
+		delay += time.Duration(rng.Int63n(int64(d.Jitter) + 1))
+	}
+	return delay
+}
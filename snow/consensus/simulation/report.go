@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"math"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+// Report is the outcome of a simulation.
+type Report struct {
+	// Duration is the simulated time the simulation ran for.
+	Duration time.Duration
+
+	// Latencies are the times at which the honest validators finalized, in
+	// increasing order.
+	Latencies []time.Duration
+
+	// Undecided is the number of honest validators that didn't finalize
+	// before the simulation stopped.
+	Undecided int
+
+	// Accepted maps each accepted choice to the number of honest validators
+	// that accepted it.
+	Accepted map[ids.ID]int
+
+	// SafetyViolations is the number of honest validators that accepted a
+	// choice that conflicts with the choice accepted by the first honest
+	// validator to finalize.
+	SafetyViolations int
+
+	// Polls is the number of polls issued by the honest validators.
+	Polls uint64
+
+	// Messages is the number of queries and responses sent.
+	Messages uint64
+}
+
+// Percentile returns the finality latency below which [p] of the honest
+// validators finalized, where [p] is in [0, 1]. Returns 0 if no honest
+// validator finalized.
+func (r *Report) Percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	switch {
+	case p <= 0:
+		return r.Latencies[0]
+	case p >= 1:
+		return r.Latencies[len(r.Latencies)-1]
+	}
+	index := int(math.Ceil(p*float64(len(r.Latencies)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return r.Latencies[index]
+}
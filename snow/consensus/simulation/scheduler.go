@@ -0,0 +1,78 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"container/heap"
+	"time"
+)
+
+var _ heap.Interface = (*eventQueue)(nil)
+
+type event struct {
+	time time.Duration
+	// seq breaks ties between events scheduled for the same time, so that
+	// they are executed in the order they were scheduled.
+	seq uint64
+	f   func() error
+}
+
+type eventQueue []*event
+
+func (q eventQueue) Len() int {
+	return len(q)
+}
+
+func (q eventQueue) Less(i, j int) bool {
+	if q[i].time != q[j].time {
+		return q[i].time < q[j].time
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q eventQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *eventQueue) Push(x any) {
+	*q = append(*q, x.(*event))
+}
+
+func (q *eventQueue) Pop() any {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return e
+}
+
+// scheduler executes events in simulated time. Events are executed in a
+// deterministic order.
+type scheduler struct {
+	now    time.Duration
+	seq    uint64
+	events eventQueue
+}
+
+// schedule [f] to be executed after [delay].
+func (s *scheduler) schedule(delay time.Duration, f func() error) {
+	heap.Push(&s.events, &event{
+		time: s.now + delay,
+		seq:  s.seq,
+		f:    f,
+	})
+	s.seq++
+}
+
+// step executes the next event. Returns false if there are no events left or
+// if the next event is after [end].
+func (s *scheduler) step(end time.Duration) (bool, error) {
+	if len(s.events) == 0 || s.events[0].time > end {
+		return false, nil
+	}
+	e := heap.Pop(&s.events).(*event)
+	s.now = e.time
+	return true, e.f()
+}
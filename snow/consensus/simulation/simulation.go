@@ -0,0 +1,331 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulation runs deterministic simulations of snowman consensus
+// between many virtual validators, some of which may be byzantine.
+package simulation
+
+import (
+	"context"
+	"encoding/binary"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/choices"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman/poll"
+	"github.com/MetalBlockchain/metalgo/utils/bag"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/sampler"
+)
+
+var (
+	_ View          = (*simulation)(nil)
+	_ snow.Acceptor = (*acceptor)(nil)
+
+	genesisID = ids.Empty
+)
+
+type node struct {
+	index    int
+	nodeID   ids.NodeID
+	strategy Strategy
+
+	// The following fields are only set for honest validators.
+	consensus  snowman.Consensus
+	polls      poll.Set
+	requestID  uint32
+	preference ids.ID
+	finalized  bool
+}
+
+type simulation struct {
+	scheduler
+
+	config  Config
+	rng     *rand.Rand
+	sampler sampler.WeightedWithoutReplacement
+	choices []ids.ID
+
+	nodes []*node
+	// preferenceWeights maps each choice to the weight of the honest
+	// validators that currently prefer it.
+	preferenceWeights map[ids.ID]uint64
+	running           int
+
+	firstAccepted ids.ID
+	report        *Report
+}
+
+// Run simulates [config] and reports the outcome. Running the same config
+// multiple times produces the same report.
+func Run(config Config) (*Report, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	s := &simulation{
+		config:            config,
+		rng:               rand.New(rand.NewSource(config.Seed)), // #nosec G404
+		sampler:           sampler.NewDeterministicWeightedWithoutReplacement(),
+		choices:           make([]ids.ID, config.NumChoices),
+		nodes:             make([]*node, len(config.Validators)),
+		preferenceWeights: make(map[ids.ID]uint64, config.NumChoices),
+		report: &Report{
+			Accepted: make(map[ids.ID]int),
+		},
+	}
+
+	weights := make([]uint64, len(config.Validators))
+	for i, vdr := range config.Validators {
+		weights[i] = vdr.Weight
+	}
+	if err := s.sampler.Initialize(weights); err != nil {
+		return nil, err
+	}
+	s.sampler.Seed(s.rng.Int63())
+
+	for i := range s.choices {
+		s.choices[i] = ids.Empty.Prefix(uint64(i + 1))
+	}
+
+	for i, vdr := range config.Validators {
+		n := &node{
+			index:    i,
+			strategy: vdr.Strategy,
+		}
+		binary.BigEndian.PutUint64(n.nodeID[:], uint64(i))
+		s.nodes[i] = n
+
+		if n.strategy != nil {
+			continue
+		}
+		if err := s.initialize(n); err != nil {
+			return nil, err
+		}
+		s.running++
+	}
+
+	for _, n := range s.nodes {
+		if n.strategy != nil {
+			continue
+		}
+		for n.polls.Len() < config.Params.ConcurrentRepolls {
+			if err := s.query(n); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for s.running > 0 {
+		ok, err := s.step(config.MaxDuration)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+	}
+
+	s.report.Duration = s.now
+	s.report.Undecided = s.running
+	sort.Slice(s.report.Latencies, func(i, j int) bool {
+		return s.report.Latencies[i] < s.report.Latencies[j]
+	})
+	return s.report, nil
+}
+
+// initialize the consensus instance of the honest validator [n]. The
+// conflicting choices are issued in a random order, so that the initial
+// preferences are randomly distributed.
+func (s *simulation) initialize(n *node) error {
+	registerer := prometheus.NewRegistry()
+	ctx := &snow.ConsensusContext{
+		Context: &snow.Context{
+			NodeID: n.nodeID,
+			Log:    logging.NoLog{},
+		},
+		Registerer:          registerer,
+		AvalancheRegisterer: registerer,
+		BlockAcceptor:       &acceptor{sim: s},
+	}
+
+	n.consensus = &snowman.Topological{}
+	if err := n.consensus.Initialize(ctx, s.config.Params, genesisID, 0, time.Time{}); err != nil {
+		return err
+	}
+	n.polls = poll.NewSet(
+		poll.NewEarlyTermNoTraversalFactory(
+			s.config.Params.AlphaPreference,
+			s.config.Params.AlphaConfidence,
+		),
+		ctx.Log,
+		"",
+		registerer,
+	)
+
+	for _, i := range s.rng.Perm(len(s.choices)) {
+		blk := &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     s.choices[i],
+				StatusV: choices.Processing,
+			},
+			ParentV: genesisID,
+			HeightV: 1,
+		}
+		if err := n.consensus.Add(context.Background(), blk); err != nil {
+			return err
+		}
+	}
+
+	n.preference = n.consensus.Preference()
+	s.preferenceWeights[n.preference] += s.config.Validators[n.index].Weight
+	return nil
+}
+
+// query issues a new poll from the honest validator [n].
+func (s *simulation) query(n *node) error {
+	indices, err := s.sampler.Sample(s.config.Params.K)
+	if err != nil {
+		return err
+	}
+
+	// The queried validators are sorted so that the order of the queries
+	// doesn't depend on map iteration.
+	sort.Ints(indices)
+	var (
+		vdrs    bag.Bag[ids.NodeID]
+		queried []*node
+	)
+	for _, index := range indices {
+		to := s.nodes[index]
+		if vdrs.Count(to.nodeID) == 0 {
+			queried = append(queried, to)
+		}
+		vdrs.Add(to.nodeID)
+	}
+
+	n.requestID++
+	requestID := n.requestID
+	if !n.polls.Add(requestID, vdrs) {
+		return nil
+	}
+	s.report.Polls++
+
+	for _, to := range queried {
+		to := to
+		s.report.Messages++
+		s.schedule(s.config.Delay.Delay(s.rng, n.index, to.index), func() error {
+			return s.respond(n, to, requestID)
+		})
+	}
+	s.schedule(s.config.QueryTimeout, func() error {
+		return s.timeout(n, queried, requestID)
+	})
+	return nil
+}
+
+// respond to the query [requestID] sent by [from] to [to].
+func (s *simulation) respond(from, to *node, requestID uint32) error {
+	vote := to.preference
+	if to.strategy != nil {
+		var ok bool
+		vote, ok = to.strategy.Respond(s, from.index)
+		if !ok {
+			return nil
+		}
+	}
+
+	s.report.Messages++
+	s.schedule(s.config.Delay.Delay(s.rng, to.index, from.index), func() error {
+		return s.record(from, from.polls.Vote(requestID, to.nodeID, vote))
+	})
+	return nil
+}
+
+// timeout drops all the validators that haven't responded to [requestID].
+func (s *simulation) timeout(n *node, queried []*node, requestID uint32) error {
+	for _, vdr := range queried {
+		if err := s.record(n, n.polls.Drop(requestID, vdr.nodeID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// record the finished polls of [n] into its consensus instance and issue new
+// polls until [n] has finalized.
+func (s *simulation) record(n *node, results []bag.Bag[ids.ID]) error {
+	if n.finalized {
+		return nil
+	}
+
+	for _, result := range results {
+		if err := n.consensus.RecordPoll(context.Background(), result); err != nil {
+			return err
+		}
+	}
+
+	weight := s.config.Validators[n.index].Weight
+	s.preferenceWeights[n.preference] -= weight
+	n.preference = n.consensus.Preference()
+	s.preferenceWeights[n.preference] += weight
+
+	if n.consensus.Finalized() {
+		n.finalized = true
+		s.running--
+		return nil
+	}
+
+	for n.polls.Len() < s.config.Params.ConcurrentRepolls {
+		if err := s.query(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *simulation) Now() time.Duration {
+	return s.now
+}
+
+func (s *simulation) Rand() *rand.Rand {
+	return s.rng
+}
+
+func (s *simulation) Choices() []ids.ID {
+	return s.choices
+}
+
+func (s *simulation) Preference(vdr int) ids.ID {
+	return s.nodes[vdr].preference
+}
+
+func (s *simulation) PreferenceWeight(choice ids.ID) uint64 {
+	return s.preferenceWeights[choice]
+}
+
+// acceptor records the finality of the choice accepted by an honest
+// validator.
+type acceptor struct {
+	sim *simulation
+}
+
+func (a *acceptor) Accept(_ *snow.ConsensusContext, containerID ids.ID, _ []byte) error {
+	r := a.sim.report
+	r.Latencies = append(r.Latencies, a.sim.now)
+	r.Accepted[containerID]++
+
+	switch {
+	case len(r.Latencies) == 1:
+		a.sim.firstAccepted = containerID
+	case containerID != a.sim.firstAccepted:
+		r.SafetyViolations++
+	}
+	return nil
+}
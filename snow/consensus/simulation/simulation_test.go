@@ -0,0 +1,249 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
+)
+
+func testConfig(vdrs []Validator) Config {
+	return Config{
+		Seed: 1,
+		Params: snowball.Parameters{
+			K:                     20,
+			AlphaPreference:       11,
+			AlphaConfidence:       15,
+			BetaVirtuous:          15,
+			BetaRogue:             20,
+			ConcurrentRepolls:     4,
+			OptimalProcessing:     10,
+			MaxOutstandingItems:   256,
+			MaxItemProcessingTime: 30 * time.Second,
+		},
+		Validators: vdrs,
+		NumChoices: 2,
+		Delay: &UniformDelay{
+			Min: 10 * time.Millisecond,
+			Max: 100 * time.Millisecond,
+		},
+		QueryTimeout: time.Second,
+		MaxDuration:  time.Minute,
+	}
+}
+
+func TestRunHonest(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig(ZipfStake(1, 500, 1.5))
+	report, err := Run(config)
+	require.NoError(err)
+
+	require.Zero(report.Undecided)
+	require.Zero(report.SafetyViolations)
+	require.Len(report.Latencies, 500)
+	require.Len(report.Accepted, 1)
+	require.Positive(report.Polls)
+	require.Positive(report.Messages)
+	require.LessOrEqual(report.Percentile(.5), report.Percentile(.99))
+	require.LessOrEqual(report.Percentile(1), report.Duration)
+}
+
+func TestRunDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := EqualStake(200)
+	for i := 0; i < 20; i++ {
+		vdrs[i].Strategy = Equivocating{}
+	}
+	config := testConfig(vdrs)
+	config.NumChoices = 3
+
+	report0, err := Run(config)
+	require.NoError(err)
+	report1, err := Run(config)
+	require.NoError(err)
+	require.Equal(report0, report1)
+
+	config.Seed++
+	report2, err := Run(config)
+	require.NoError(err)
+	require.NotEqual(report0, report2)
+}
+
+func TestRunByzantine(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy Strategy
+	}{
+		{
+			name:     "silent",
+			strategy: Silent{},
+		},
+		{
+			name:     "equivocating",
+			strategy: Equivocating{},
+		},
+		{
+			name:     "adaptive",
+			strategy: Adaptive{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			vdrs := EqualStake(200)
+			for i := 0; i < 20; i++ {
+				vdrs[i].Strategy = test.strategy
+			}
+			config := testConfig(vdrs)
+			config.Delay = &RegionDelay{
+				Regions: 3,
+				Local:   5 * time.Millisecond,
+				Remote:  80 * time.Millisecond,
+				Jitter:  20 * time.Millisecond,
+			}
+
+			report, err := Run(config)
+			require.NoError(err)
+			require.Zero(report.Undecided)
+			require.Zero(report.SafetyViolations)
+			require.Len(report.Latencies, 180)
+		})
+	}
+}
+
+func TestRunMaxDuration(t *testing.T) {
+	require := require.New(t)
+
+	// Half of the weight never responds, so no poll can succeed before
+	// timing out.
+	vdrs := EqualStake(100)
+	for i := 0; i < 50; i++ {
+		vdrs[i].Strategy = Silent{}
+	}
+	config := testConfig(vdrs)
+	config.MaxDuration = 10 * time.Second
+
+	report, err := Run(config)
+	require.NoError(err)
+	require.Equal(50, report.Undecided)
+	require.Empty(report.Latencies)
+	require.LessOrEqual(report.Duration, config.MaxDuration)
+	require.Zero(report.Percentile(.5))
+}
+
+func TestAdaptiveRespond(t *testing.T) {
+	require := require.New(t)
+
+	s := &simulation{
+		choices: []ids.ID{{1}, {2}, {3}},
+		preferenceWeights: map[ids.ID]uint64{
+			{1}: 3,
+			{2}: 5,
+			{3}: 2,
+		},
+	}
+	vote, ok := Adaptive{}.Respond(s, 0)
+	require.True(ok)
+	require.Equal(ids.ID{1}, vote)
+}
+
+func TestReportPercentile(t *testing.T) {
+	require := require.New(t)
+
+	report := &Report{
+		Latencies: []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+	}
+	require.Equal(time.Duration(1), report.Percentile(0))
+	require.Equal(time.Duration(1), report.Percentile(.1))
+	require.Equal(time.Duration(5), report.Percentile(.5))
+	require.Equal(time.Duration(10), report.Percentile(.99))
+	require.Equal(time.Duration(10), report.Percentile(1))
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			modify: func(*Config) {},
+		},
+		{
+			name: "no validators",
+			modify: func(c *Config) {
+				c.Validators = nil
+			},
+			expectedErr: errNoValidators,
+		},
+		{
+			name: "zero weight",
+			modify: func(c *Config) {
+				c.Validators[0].Weight = 0
+			},
+			expectedErr: errZeroWeight,
+		},
+		{
+			name: "no honest validators",
+			modify: func(c *Config) {
+				for i := range c.Validators {
+					c.Validators[i].Strategy = Silent{}
+				}
+			},
+			expectedErr: errNoHonestValidators,
+		},
+		{
+			name: "insufficient weight",
+			modify: func(c *Config) {
+				c.Validators = c.Validators[:c.Params.K-1]
+			},
+			expectedErr: errInsufficientWeight,
+		},
+		{
+			name: "no choices",
+			modify: func(c *Config) {
+				c.NumChoices = 0
+			},
+			expectedErr: errNoChoices,
+		},
+		{
+			name: "no delay",
+			modify: func(c *Config) {
+				c.Delay = nil
+			},
+			expectedErr: errNoDelay,
+		},
+		{
+			name: "no query timeout",
+			modify: func(c *Config) {
+				c.QueryTimeout = 0
+			},
+			expectedErr: errNoQueryTimeout,
+		},
+		{
+			name: "no max duration",
+			modify: func(c *Config) {
+				c.MaxDuration = 0
+			},
+			expectedErr: errNoMaxDuration,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testConfig(EqualStake(100))
+			test.modify(&config)
+			err := config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
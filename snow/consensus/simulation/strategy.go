@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulation
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+var (
+	_ Strategy = Silent{}
+	_ Strategy = Equivocating{}
+	_ Strategy = Adaptive{}
+)
+
+// View is what a byzantine validator is able to observe of the simulated
+// network. Byzantine validators are assumed to be omniscient.
+type View interface {
+	// Now returns the current simulated time.
+	Now() time.Duration
+
+	// Rand returns the source of randomness of the simulation.
+	Rand() *rand.Rand
+
+	// Choices returns the conflicting blocks being decided.
+	Choices() []ids.ID
+
+	// Preference returns the current preference of the honest validator at
+	// index [vdr].
+	Preference(vdr int) ids.ID
+
+	// PreferenceWeight returns the weight of the honest validators that
+	// currently prefer [choice].
+	PreferenceWeight(choice ids.ID) uint64
+}
+
+// Strategy decides how a byzantine validator responds to queries.
+type Strategy interface {
+	// Respond returns the choice to vote for in response to a query from the
+	// honest validator at index [querier]. If false is returned, the query is
+	// never responded to.
+	Respond(view View, querier int) (ids.ID, bool)
+}
+
+// Silent never responds to queries.
+type Silent struct{}
+
+func (Silent) Respond(View, int) (ids.ID, bool) {
+	return ids.Empty, false
+}
+
+// Equivocating votes for a random choice in response to every query, so
+// different validators are told different things.
+type Equivocating struct{}
+
+func (Equivocating) Respond(view View, _ int) (ids.ID, bool) {
+	choices := view.Choices()
+	return choices[view.Rand().Intn(len(choices))], true
+}
+
+// Adaptive tries to prevent the honest validators from converging. It votes
+// against the choice that the most honest weight prefers, for the strongest
+// competing choice.
+type Adaptive struct{}
+
+func (Adaptive) Respond(view View, _ int) (ids.ID, bool) {
+	choices := view.Choices()
+	if len(choices) == 1 {
+		return choices[0], true
+	}
+
+	var (
+		leader, runnerUp             ids.ID
+		leaderWeight, runnerUpWeight uint64
+	)
+	for i, choice := range choices {
+		weight := view.PreferenceWeight(choice)
+		switch {
+		case i == 0 || weight > leaderWeight:
+			if i != 0 {
+				runnerUp, runnerUpWeight = leader, leaderWeight
+			}
+			leader, leaderWeight = choice, weight
+		case i == 1 || weight > runnerUpWeight:
+			runnerUp, runnerUpWeight = choice, weight
+		}
+	}
+	return runnerUp, true
+}
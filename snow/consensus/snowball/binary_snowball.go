@@ -18,8 +18,8 @@ type binarySnowball struct {
 	// Ties are broken by switching choice lazily
 	preference int
 
-	// numSuccessfulPolls tracks the total number of successful network polls of
-	// the 0 and 1 choices
+	// numSuccessfulPolls tracks the total number of network polls of the 0 and
+	// 1 choices that reached the alphaPreference threshold
 	numSuccessfulPolls [2]int
 }
 
@@ -40,11 +40,20 @@ func (sb *binarySnowball) Preference() int {
 }
 
 func (sb *binarySnowball) RecordSuccessfulPoll(choice int) {
+	sb.increasePreferenceStrength(choice)
+	sb.binarySnowflake.RecordSuccessfulPoll(choice)
+}
+
+func (sb *binarySnowball) RecordPollPreference(choice int) {
+	sb.increasePreferenceStrength(choice)
+	sb.binarySnowflake.RecordPollPreference(choice)
+}
+
+func (sb *binarySnowball) increasePreferenceStrength(choice int) {
 	sb.numSuccessfulPolls[choice]++
 	if sb.numSuccessfulPolls[choice] > sb.numSuccessfulPolls[1-choice] {
 		sb.preference = choice
 	}
-	sb.binarySnowflake.RecordSuccessfulPoll(choice)
 }
 
 func (sb *binarySnowball) String() string {
@@ -195,3 +195,62 @@ func TestBinarySnowballLockColor(t *testing.T) {
 		t.Fatalf("Wrong state. Expected:\n%s\nGot:\n%s", expected, str)
 	}
 }
+
+func TestBinarySnowballRecordPollPreference(t *testing.T) {
+	red := 0
+	blue := 1
+
+	beta := 2
+
+	sb := binarySnowball{}
+	sb.Initialize(beta, red)
+
+	sb.RecordSuccessfulPoll(blue)
+
+	if pref := sb.Preference(); pref != blue {
+		t.Fatalf("Wrong preference. Expected %d got %d", blue, pref)
+	} else if sb.Finalized() {
+		t.Fatalf("Finalized too early")
+	}
+
+	sb.RecordPollPreference(red)
+
+	if pref := sb.Preference(); pref != blue {
+		t.Fatalf("Wrong preference. Expected %d got %d", blue, pref)
+	} else if sb.Finalized() {
+		t.Fatalf("Finalized too early")
+	}
+
+	sb.RecordPollPreference(red)
+
+	if pref := sb.Preference(); pref != red {
+		t.Fatalf("Wrong preference. Expected %d got %d", red, pref)
+	} else if sb.Finalized() {
+		t.Fatalf("Finalized too early")
+	}
+
+	// Polls that only strengthen the preference never finalize
+	for i := 0; i < beta; i++ {
+		sb.RecordPollPreference(red)
+	}
+
+	if pref := sb.Preference(); pref != red {
+		t.Fatalf("Wrong preference. Expected %d got %d", red, pref)
+	} else if sb.Finalized() {
+		t.Fatalf("Finalized too early")
+	}
+
+	sb.RecordSuccessfulPoll(red)
+	sb.RecordSuccessfulPoll(red)
+
+	if pref := sb.Preference(); pref != red {
+		t.Fatalf("Wrong preference. Expected %d got %d", red, pref)
+	} else if !sb.Finalized() {
+		t.Fatalf("Didn't finalized correctly")
+	}
+
+	expected := "SB(Preference = 0, NumSuccessfulPolls[0] = 6, NumSuccessfulPolls[1] = 1, SF(Confidence = 2, Finalized = true, SL(Preference = 0)))"
+	if str := sb.String(); str != expected {
+		t.Fatalf("Wrong state. Expected:\n%s\nGot:\n%s", expected, str)
+	}
+}
@@ -32,6 +32,10 @@ func (sf *binarySnowflake) Initialize(beta, choice int) {
 	sf.beta = beta
 }
 
+func (sf *binarySnowflake) SetBeta(beta int) {
+	sf.beta = beta
+}
+
 func (sf *binarySnowflake) RecordSuccessfulPoll(choice int) {
 	if sf.finalized {
 		return // This instace is already decided.
@@ -49,6 +53,15 @@ func (sf *binarySnowflake) RecordSuccessfulPoll(choice int) {
 	sf.binarySlush.RecordSuccessfulPoll(choice)
 }
 
+func (sf *binarySnowflake) RecordPollPreference(choice int) {
+	if sf.finalized {
+		return // This instance is already decided.
+	}
+
+	sf.confidence = 0
+	sf.binarySlush.RecordSuccessfulPoll(choice)
+}
+
 func (sf *binarySnowflake) RecordUnsuccessfulPoll() {
 	sf.confidence = 0
 }
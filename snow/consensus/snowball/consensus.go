@@ -18,6 +18,11 @@ type Consensus interface {
 	// Takes in alpha, beta1, beta2, and the initial choice
 	Initialize(params Parameters, initialPreference ids.ID)
 
+	// SetParameters replaces the parameters of this instance, keeping the
+	// confidence that has already been gathered. The new parameters are
+	// applied from the next poll onwards.
+	SetParameters(params Parameters)
+
 	// Adds a new choice to vote on
 	Add(newChoice ids.ID)
 
@@ -28,8 +33,8 @@ type Consensus interface {
 	// have been previously added.
 	//
 	// If the consensus instance was not previously finalized, this function
-	// will return true if the poll was successful and false if the poll was
-	// unsuccessful.
+	// will return true if the poll reached the alphaPreference threshold and
+	// false otherwise.
 	//
 	// If the consensus instance was previously finalized, the function may
 	// return true or false.
@@ -57,6 +62,9 @@ type NnarySnowflake interface {
 	// Takes in beta1, beta2, and the initial choice
 	Initialize(betaVirtuous, betaRogue int, initialPreference ids.ID)
 
+	// SetBeta replaces beta1 and beta2
+	SetBeta(betaVirtuous, betaRogue int)
+
 	// Adds a new possible choice
 	Add(newChoice ids.ID)
 
@@ -67,6 +75,12 @@ type NnarySnowflake interface {
 	// specified choice. Assumes the choice was previously added.
 	RecordSuccessfulPoll(choice ids.ID)
 
+	// RecordPollPreference records a poll that preferred the specified choice
+	// but did not get enough votes to increase the confidence in the choice.
+	// This resets the snowflake counter of this instance. Assumes the choice
+	// was previously added.
+	RecordPollPreference(choice ids.ID)
+
 	// RecordUnsuccessfulPoll resets the snowflake counter of this instance
 	RecordUnsuccessfulPoll()
 
@@ -105,6 +119,9 @@ type BinarySnowflake interface {
 	// Takes in the beta value, and the initial choice
 	Initialize(beta, initialPreference int)
 
+	// SetBeta replaces the beta value
+	SetBeta(beta int)
+
 	// Returns the currently preferred choice to be finalized
 	Preference() int
 
@@ -112,6 +129,11 @@ type BinarySnowflake interface {
 	// specified choice
 	RecordSuccessfulPoll(choice int)
 
+	// RecordPollPreference records a poll that preferred the specified choice
+	// but did not get enough votes to increase the confidence in the choice.
+	// This resets the snowflake counter of this instance.
+	RecordPollPreference(choice int)
+
 	// RecordUnsuccessfulPoll resets the snowflake counter of this instance
 	RecordUnsuccessfulPoll()
 
@@ -145,9 +167,17 @@ type UnarySnowball interface {
 	// Takes in the beta value
 	Initialize(beta int)
 
+	// SetBeta replaces the beta value
+	SetBeta(beta int)
+
 	// RecordSuccessfulPoll records a successful poll towards finalizing
 	RecordSuccessfulPoll()
 
+	// RecordPollPreference records a poll that got enough votes to strengthen
+	// the preference but not enough to increase the confidence. This resets
+	// the snowflake counter of this instance.
+	RecordPollPreference()
+
 	// RecordUnsuccessfulPoll resets the snowflake counter of this instance
 	RecordUnsuccessfulPoll()
 
@@ -171,9 +201,17 @@ type UnarySnowflake interface {
 	// Takes in the beta value
 	Initialize(beta int)
 
+	// SetBeta replaces the beta value
+	SetBeta(beta int)
+
 	// RecordSuccessfulPoll records a successful poll towards finalizing
 	RecordSuccessfulPoll()
 
+	// RecordPollPreference records a poll that got enough votes to strengthen
+	// the preference but not enough to increase the confidence. This resets
+	// the snowflake counter of this instance.
+	RecordPollPreference()
+
 	// RecordUnsuccessfulPoll resets the snowflake counter of this instance
 	RecordUnsuccessfulPoll()
 
@@ -13,7 +13,7 @@ func TestSnowballOptimized(t *testing.T) {
 	numColors := 10
 	numNodes := 100
 	params := Parameters{
-		K: 20, Alpha: 15, BetaVirtuous: 20, BetaRogue: 30,
+		K: 20, AlphaPreference: 15, AlphaConfidence: 15, BetaVirtuous: 20, BetaRogue: 30,
 	}
 	seed := int64(0)
 
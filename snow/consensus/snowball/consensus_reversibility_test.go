@@ -15,7 +15,7 @@ func TestSnowballGovernance(t *testing.T) {
 	numByzantine := 10
 	numRed := 55
 	params := Parameters{
-		K: 20, Alpha: 15, BetaVirtuous: 20, BetaRogue: 30,
+		K: 20, AlphaPreference: 15, AlphaConfidence: 15, BetaVirtuous: 20, BetaRogue: 30,
 	}
 	seed := int64(0)
 
@@ -26,6 +26,8 @@ func (b *Byzantine) Initialize(_ Parameters, choice ids.ID) {
 	b.preference = choice
 }
 
+func (*Byzantine) SetParameters(Parameters) {}
+
 func (*Byzantine) Add(ids.ID) {}
 
 func (b *Byzantine) Preference() ids.ID {
@@ -34,12 +34,22 @@ func (f *Flat) Initialize(params Parameters, choice ids.ID) {
 	f.params = params
 }
 
+func (f *Flat) SetParameters(params Parameters) {
+	f.nnarySnowball.SetBeta(params.BetaVirtuous, params.BetaRogue)
+	f.params = params
+}
+
 func (f *Flat) RecordPoll(votes bag.Bag[ids.ID]) bool {
-	if pollMode, numVotes := votes.Mode(); numVotes >= f.params.Alpha {
+	pollMode, numVotes := votes.Mode()
+	switch {
+	case numVotes >= f.params.AlphaConfidence:
 		f.RecordSuccessfulPoll(pollMode)
 		return true
+	case numVotes >= f.params.AlphaPreference:
+		f.RecordPollPreference(pollMode)
+		return true
+	default:
+		f.RecordUnsuccessfulPoll()
+		return false
 	}
-
-	f.RecordUnsuccessfulPoll()
-	return false
 }
@@ -16,7 +16,7 @@ func TestFlat(t *testing.T) {
 	require := require.New(t)
 
 	params := Parameters{
-		K: 2, Alpha: 2, BetaVirtuous: 1, BetaRogue: 2,
+		K: 2, AlphaPreference: 2, AlphaConfidence: 2, BetaVirtuous: 1, BetaRogue: 2,
 	}
 	f := Flat{}
 	f.Initialize(params, Red)
@@ -49,3 +49,63 @@ func TestFlat(t *testing.T) {
 	expected := "SB(Preference = TtF4d2QWbk5vzQGTEPrN48x6vwgAoAmKQ9cbp79inpQmcRKES, NumSuccessfulPolls = 3, SF(Confidence = 2, Finalized = true, SL(Preference = TtF4d2QWbk5vzQGTEPrN48x6vwgAoAmKQ9cbp79inpQmcRKES)))"
 	require.Equal(expected, f.String())
 }
+
+func TestFlatAlphaPreference(t *testing.T) {
+	require := require.New(t)
+
+	params := Parameters{
+		K: 3, AlphaPreference: 2, AlphaConfidence: 3, BetaVirtuous: 1, BetaRogue: 1,
+	}
+	f := Flat{}
+	f.Initialize(params, Red)
+	f.Add(Blue)
+
+	// Reaching alphaPreference changes the preference without increasing the
+	// confidence.
+	twoBlue := bag.Bag[ids.ID]{}
+	twoBlue.Add(Blue, Blue, Red)
+	require.True(f.RecordPoll(twoBlue))
+	require.Equal(Blue, f.Preference())
+	require.False(f.Finalized())
+
+	require.True(f.RecordPoll(twoBlue))
+	require.Equal(Blue, f.Preference())
+	require.False(f.Finalized())
+
+	threeBlue := bag.Bag[ids.ID]{}
+	threeBlue.Add(Blue, Blue, Blue)
+	require.True(f.RecordPoll(threeBlue))
+	require.Equal(Blue, f.Preference())
+	require.True(f.Finalized())
+}
+
+func TestFlatSetParameters(t *testing.T) {
+	require := require.New(t)
+
+	params := Parameters{
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 1, BetaRogue: 1,
+	}
+	f := Flat{}
+	f.Initialize(params, Red)
+	f.Add(Blue)
+
+	params = Parameters{
+		K: 2, AlphaPreference: 2, AlphaConfidence: 2, BetaVirtuous: 2, BetaRogue: 2,
+	}
+	f.SetParameters(params)
+
+	// A single vote no longer reaches the preference threshold.
+	oneBlue := bag.Bag[ids.ID]{}
+	oneBlue.Add(Blue)
+	require.False(f.RecordPoll(oneBlue))
+	require.Equal(Red, f.Preference())
+
+	twoBlue := bag.Bag[ids.ID]{}
+	twoBlue.Add(Blue, Blue)
+	require.True(f.RecordPoll(twoBlue))
+	require.Equal(Blue, f.Preference())
+	require.False(f.Finalized())
+
+	require.True(f.RecordPoll(twoBlue))
+	require.True(f.Finalized())
+}
@@ -24,8 +24,8 @@ type nnarySnowball struct {
 	// gotten for any choice
 	maxSuccessfulPolls int
 
-	// numSuccessfulPolls tracks the total number of successful network polls of
-	// the choices
+	// numSuccessfulPolls tracks the total number of network polls of the
+	// choices that reached the alphaPreference threshold
 	numSuccessfulPolls map[ids.ID]int
 }
 
@@ -47,6 +47,16 @@ func (sb *nnarySnowball) Preference() ids.ID {
 }
 
 func (sb *nnarySnowball) RecordSuccessfulPoll(choice ids.ID) {
+	sb.increasePreferenceStrength(choice)
+	sb.nnarySnowflake.RecordSuccessfulPoll(choice)
+}
+
+func (sb *nnarySnowball) RecordPollPreference(choice ids.ID) {
+	sb.increasePreferenceStrength(choice)
+	sb.nnarySnowflake.RecordPollPreference(choice)
+}
+
+func (sb *nnarySnowball) increasePreferenceStrength(choice ids.ID) {
 	numSuccessfulPolls := sb.numSuccessfulPolls[choice] + 1
 	sb.numSuccessfulPolls[choice] = numSuccessfulPolls
 
@@ -54,8 +64,6 @@ func (sb *nnarySnowball) RecordSuccessfulPoll(choice ids.ID) {
 		sb.preference = choice
 		sb.maxSuccessfulPolls = numSuccessfulPolls
 	}
-
-	sb.nnarySnowflake.RecordSuccessfulPoll(choice)
 }
 
 func (sb *nnarySnowball) String() string {
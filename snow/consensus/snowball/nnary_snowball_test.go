@@ -161,3 +161,46 @@ func TestNarySnowballDifferentSnowflakeColor(t *testing.T) {
 		t.Fatalf("Wrong preference. Expected %s got %s", Blue, pref)
 	}
 }
+
+func TestNnarySnowballRecordPollPreference(t *testing.T) {
+	betaVirtuous := 1
+	betaRogue := 2
+
+	sb := nnarySnowball{}
+	sb.Initialize(betaVirtuous, betaRogue, Red)
+	sb.Add(Blue)
+
+	sb.RecordPollPreference(Blue)
+
+	if pref := sb.Preference(); Blue != pref {
+		t.Fatalf("Wrong preference. Expected %s got %s", Blue, pref)
+	} else if sb.Finalized() {
+		t.Fatalf("Finalized too early")
+	}
+
+	sb.RecordSuccessfulPoll(Blue)
+
+	if pref := sb.Preference(); Blue != pref {
+		t.Fatalf("Wrong preference. Expected %s got %s", Blue, pref)
+	} else if sb.Finalized() {
+		t.Fatalf("Finalized too early")
+	}
+
+	// The confidence is reset by polls that only strengthen the preference
+	sb.RecordPollPreference(Blue)
+	sb.RecordSuccessfulPoll(Blue)
+
+	if pref := sb.Preference(); Blue != pref {
+		t.Fatalf("Wrong preference. Expected %s got %s", Blue, pref)
+	} else if sb.Finalized() {
+		t.Fatalf("Finalized too early")
+	}
+
+	sb.RecordSuccessfulPoll(Blue)
+
+	if pref := sb.Preference(); Blue != pref {
+		t.Fatalf("Wrong preference. Expected %s got %s", Blue, pref)
+	} else if !sb.Finalized() {
+		t.Fatalf("Should have finalized")
+	}
+}
@@ -43,6 +43,11 @@ func (sf *nnarySnowflake) Initialize(betaVirtuous, betaRogue int, choice ids.ID)
 	sf.betaRogue = betaRogue
 }
 
+func (sf *nnarySnowflake) SetBeta(betaVirtuous, betaRogue int) {
+	sf.betaVirtuous = betaVirtuous
+	sf.betaRogue = betaRogue
+}
+
 func (sf *nnarySnowflake) Add(choice ids.ID) {
 	sf.rogue = sf.rogue || choice != sf.preference
 }
@@ -65,6 +70,15 @@ func (sf *nnarySnowflake) RecordSuccessfulPoll(choice ids.ID) {
 	sf.nnarySlush.RecordSuccessfulPoll(choice)
 }
 
+func (sf *nnarySnowflake) RecordPollPreference(choice ids.ID) {
+	if sf.finalized {
+		return // This instance is already decided.
+	}
+
+	sf.confidence = 0
+	sf.nnarySlush.RecordSuccessfulPoll(choice)
+}
+
 func (sf *nnarySnowflake) RecordUnsuccessfulPoll() {
 	sf.confidence = 0
 }
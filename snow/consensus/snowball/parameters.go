@@ -28,8 +28,15 @@ const (
 
 // Parameters required for snowball consensus
 type Parameters struct {
-	K                 int `json:"k" yaml:"k"`
-	Alpha             int `json:"alpha" yaml:"alpha"`
+	K int `json:"k" yaml:"k"`
+
+	// AlphaPreference is the number of votes a choice must receive in a poll
+	// for the poll to change the preference.
+	AlphaPreference int `json:"alphaPreference" yaml:"alphaPreference"`
+	// AlphaConfidence is the number of votes a choice must receive in a poll
+	// for the poll to increase the confidence in the preference.
+	AlphaConfidence int `json:"alphaConfidence" yaml:"alphaConfidence"`
+
 	BetaVirtuous      int `json:"betaVirtuous" yaml:"betaVirtuous"`
 	BetaRogue         int `json:"betaRogue" yaml:"betaRogue"`
 	ConcurrentRepolls int `json:"concurrentRepolls" yaml:"concurrentRepolls"`
@@ -56,10 +63,12 @@ type Parameters struct {
 // Verify returns nil if the parameters describe a valid initialization.
 func (p Parameters) Verify() error {
 	switch {
-	case p.Alpha <= p.K/2:
-		return fmt.Errorf("k = %d, alpha = %d: fails the condition that: k/2 < alpha", p.K, p.Alpha)
-	case p.K < p.Alpha:
-		return fmt.Errorf("k = %d, alpha = %d: fails the condition that: alpha <= k", p.K, p.Alpha)
+	case p.AlphaPreference <= p.K/2:
+		return fmt.Errorf("k = %d, alphaPreference = %d: fails the condition that: k/2 < alphaPreference", p.K, p.AlphaPreference)
+	case p.AlphaConfidence < p.AlphaPreference:
+		return fmt.Errorf("alphaPreference = %d, alphaConfidence = %d: fails the condition that: alphaPreference <= alphaConfidence", p.AlphaPreference, p.AlphaConfidence)
+	case p.K < p.AlphaConfidence:
+		return fmt.Errorf("k = %d, alphaConfidence = %d: fails the condition that: alphaConfidence <= k", p.K, p.AlphaConfidence)
 	case p.BetaVirtuous <= 0:
 		return fmt.Errorf("betaVirtuous = %d: fails the condition that: 0 < betaVirtuous", p.BetaVirtuous)
 	case p.BetaRogue == 3 && p.BetaVirtuous == 28:
@@ -12,7 +12,8 @@ import (
 func TestParametersVerify(t *testing.T) {
 	p := Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -29,7 +30,8 @@ func TestParametersVerify(t *testing.T) {
 func TestParametersAnotherVerify(t *testing.T) {
 	p := Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          28,
 		BetaRogue:             30,
 		ConcurrentRepolls:     1,
@@ -46,7 +48,8 @@ func TestParametersAnotherVerify(t *testing.T) {
 func TestParametersYetAnotherVerify(t *testing.T) {
 	p := Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          3,
 		BetaRogue:             3,
 		ConcurrentRepolls:     1,
@@ -63,7 +66,8 @@ func TestParametersYetAnotherVerify(t *testing.T) {
 func TestParametersInvalidK(t *testing.T) {
 	p := Parameters{
 		K:                     0,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -80,7 +84,8 @@ func TestParametersInvalidK(t *testing.T) {
 func TestParametersInvalidAlpha(t *testing.T) {
 	p := Parameters{
 		K:                     1,
-		Alpha:                 0,
+		AlphaPreference:       0,
+		AlphaConfidence:       0,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -94,10 +99,40 @@ func TestParametersInvalidAlpha(t *testing.T) {
 	}
 }
 
+func TestParametersInvalidAlphaConfidence(t *testing.T) {
+	p := Parameters{
+		K:                     3,
+		AlphaPreference:       3,
+		AlphaConfidence:       2,
+		BetaVirtuous:          1,
+		BetaRogue:             1,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+
+	if err := p.Verify(); err == nil {
+		t.Fatalf("Should have failed due to alphaConfidence < alphaPreference")
+	}
+
+	p.AlphaPreference = 2
+	p.AlphaConfidence = 4
+	if err := p.Verify(); err == nil {
+		t.Fatalf("Should have failed due to k < alphaConfidence")
+	}
+
+	p.AlphaConfidence = 3
+	if err := p.Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestParametersInvalidBetaVirtuous(t *testing.T) {
 	p := Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          0,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -114,7 +149,8 @@ func TestParametersInvalidBetaVirtuous(t *testing.T) {
 func TestParametersInvalidBetaRogue(t *testing.T) {
 	p := Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             0,
 		ConcurrentRepolls:     1,
@@ -131,7 +167,8 @@ func TestParametersInvalidBetaRogue(t *testing.T) {
 func TestParametersAnotherInvalidBetaRogue(t *testing.T) {
 	p := Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          28,
 		BetaRogue:             3,
 		ConcurrentRepolls:     1,
@@ -151,7 +188,8 @@ func TestParametersInvalidConcurrentRepolls(t *testing.T) {
 	tests := []Parameters{
 		{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          1,
 			BetaRogue:             1,
 			ConcurrentRepolls:     2,
@@ -161,7 +199,8 @@ func TestParametersInvalidConcurrentRepolls(t *testing.T) {
 		},
 		{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          1,
 			BetaRogue:             1,
 			ConcurrentRepolls:     0,
@@ -183,7 +222,8 @@ func TestParametersInvalidConcurrentRepolls(t *testing.T) {
 func TestParametersInvalidOptimalProcessing(t *testing.T) {
 	p := Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -200,7 +240,8 @@ func TestParametersInvalidOptimalProcessing(t *testing.T) {
 func TestParametersInvalidMaxOutstandingItems(t *testing.T) {
 	p := Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -217,7 +258,8 @@ func TestParametersInvalidMaxOutstandingItems(t *testing.T) {
 func TestParametersInvalidMaxItemProcessingTime(t *testing.T) {
 	p := Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -60,6 +60,11 @@ func (t *Tree) Initialize(params Parameters, choice ids.ID) {
 	}
 }
 
+func (t *Tree) SetParameters(params Parameters) {
+	t.params = params
+	t.node.SetBeta()
+}
+
 func (t *Tree) Add(choice ids.ID) {
 	prefix := t.node.DecidedPrefix()
 	// Make sure that we haven't already decided against this new id
@@ -138,6 +143,8 @@ type node interface {
 	RecordPoll(votes bag.Bag[ids.ID], shouldReset bool) (newChild node, successful bool)
 	// Returns true if consensus has been reached on this node
 	Finalized() bool
+	// Applies the beta values of the tree's parameters to this sub-tree
+	SetBeta()
 
 	Printable() (string, []node)
 }
@@ -422,7 +429,15 @@ func (u *unaryNode) RecordPoll(votes bag.Bag[ids.ID], reset bool) (node, bool) {
 		u.shouldReset = true // Make sure my child is also reset correctly
 	}
 
-	if votes.Len() < u.tree.params.Alpha {
+	switch numVotes := votes.Len(); {
+	case numVotes >= u.tree.params.AlphaConfidence:
+		// I got enough votes to increase my confidence
+		u.snowball.RecordSuccessfulPoll()
+	case numVotes >= u.tree.params.AlphaPreference:
+		// I got enough votes to strengthen my preference, but not to increase
+		// my confidence
+		u.snowball.RecordPollPreference()
+	default:
 		// I didn't get enough votes, I must reset and my child must reset as
 		// well
 		u.snowball.RecordUnsuccessfulPoll()
@@ -430,9 +445,6 @@ func (u *unaryNode) RecordPoll(votes bag.Bag[ids.ID], reset bool) (node, bool) {
 		return u, false
 	}
 
-	// I got enough votes this time
-	u.snowball.RecordSuccessfulPoll()
-
 	if u.child != nil {
 		// We are guaranteed that u.commonPrefix will equal
 		// u.child.DecidedPrefix(). Otherwise, there must have been a
@@ -461,6 +473,13 @@ func (u *unaryNode) Finalized() bool {
 	return u.snowball.Finalized()
 }
 
+func (u *unaryNode) SetBeta() {
+	u.snowball.SetBeta(u.tree.params.BetaVirtuous)
+	if u.child != nil {
+		u.child.SetBeta()
+	}
+}
+
 func (u *unaryNode) Printable() (string, []node) {
 	s := fmt.Sprintf("%s Bits = [%d, %d)",
 		u.snowball, u.decidedPrefix, u.commonPrefix)
@@ -530,7 +549,7 @@ func (b *binaryNode) RecordPoll(votes bag.Bag[ids.ID], reset bool) (node, bool)
 
 	bit := 0
 	// We only care about which bit is set if a successful poll can happen
-	if splitVotes[1].Len() >= b.tree.params.Alpha {
+	if splitVotes[1].Len() >= b.tree.params.AlphaPreference {
 		bit = 1
 	}
 
@@ -542,16 +561,21 @@ func (b *binaryNode) RecordPoll(votes bag.Bag[ids.ID], reset bool) (node, bool)
 	b.shouldReset[1-bit] = true // They didn't get the threshold of votes
 
 	prunedVotes := splitVotes[bit]
-	if prunedVotes.Len() < b.tree.params.Alpha {
+	switch numVotes := prunedVotes.Len(); {
+	case numVotes >= b.tree.params.AlphaConfidence:
+		// This bit got alphaConfidence votes, it was a successful poll
+		b.snowball.RecordSuccessfulPoll(bit)
+	case numVotes >= b.tree.params.AlphaPreference:
+		// This bit got alphaPreference votes, it strengthens the preference
+		// but doesn't increase the confidence
+		b.snowball.RecordPollPreference(bit)
+	default:
 		b.snowball.RecordUnsuccessfulPoll()
 		// The winning child didn't get enough votes either
 		b.shouldReset[bit] = true
 		return b, false
 	}
 
-	// This bit got alpha votes, it was a successful poll
-	b.snowball.RecordSuccessfulPoll(bit)
-
 	if child := b.children[bit]; child != nil {
 		// The votes are filtered to ensure that they are votes that should
 		// count for the child
@@ -577,6 +601,15 @@ func (b *binaryNode) Finalized() bool {
 	return b.snowball.Finalized()
 }
 
+func (b *binaryNode) SetBeta() {
+	b.snowball.SetBeta(b.tree.params.BetaRogue)
+	for _, child := range b.children {
+		if child != nil {
+			child.SetBeta()
+		}
+	}
+}
+
 func (b *binaryNode) Printable() (string, []node) {
 	s := fmt.Sprintf("%s Bit = %d", b.snowball, b.bit)
 	if b.children[0] == nil {
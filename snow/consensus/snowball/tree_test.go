@@ -22,7 +22,7 @@ func TestSnowballSingleton(t *testing.T) {
 	require := require.New(t)
 
 	params := Parameters{
-		K: 1, Alpha: 1, BetaVirtuous: 2, BetaRogue: 5,
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 2, BetaRogue: 5,
 	}
 	tree := Tree{}
 	tree.Initialize(params, Red)
@@ -62,7 +62,7 @@ func TestSnowballRecordUnsuccessfulPoll(t *testing.T) {
 	require := require.New(t)
 
 	params := Parameters{
-		K: 1, Alpha: 1, BetaVirtuous: 3, BetaRogue: 5,
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 3, BetaRogue: 5,
 	}
 	tree := Tree{}
 	tree.Initialize(params, Red)
@@ -90,7 +90,7 @@ func TestSnowballBinary(t *testing.T) {
 	require := require.New(t)
 
 	params := Parameters{
-		K: 1, Alpha: 1, BetaVirtuous: 1, BetaRogue: 2,
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 1, BetaRogue: 2,
 	}
 	tree := Tree{}
 	tree.Initialize(params, Red)
@@ -132,7 +132,7 @@ func TestSnowballLastBinary(t *testing.T) {
 	}
 
 	params := Parameters{
-		K: 1, Alpha: 1, BetaVirtuous: 2, BetaRogue: 2,
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 2, BetaRogue: 2,
 	}
 	tree := Tree{}
 	tree.Initialize(params, zero)
@@ -174,7 +174,7 @@ func TestSnowballAddPreviouslyRejected(t *testing.T) {
 	four := ids.ID{0b00000100}
 
 	params := Parameters{
-		K: 1, Alpha: 1, BetaVirtuous: 1, BetaRogue: 2,
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 1, BetaRogue: 2,
 	}
 	tree := Tree{}
 	tree.Initialize(params, zero)
@@ -229,7 +229,7 @@ func TestSnowballNewUnary(t *testing.T) {
 	one := ids.ID{0b00000001}
 
 	params := Parameters{
-		K: 1, Alpha: 1, BetaVirtuous: 2, BetaRogue: 3,
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 2, BetaRogue: 3,
 	}
 	tree := Tree{}
 	tree.Initialize(params, zero)
@@ -277,7 +277,7 @@ func TestSnowballTransitiveReset(t *testing.T) {
 	eight := ids.ID{0b00001000}
 
 	params := Parameters{
-		K: 1, Alpha: 1, BetaVirtuous: 2, BetaRogue: 2,
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 2, BetaRogue: 2,
 	}
 	tree := Tree{}
 	tree.Initialize(params, zero)
@@ -359,7 +359,7 @@ func TestSnowballTrinary(t *testing.T) {
 	require := require.New(t)
 
 	params := Parameters{
-		K: 1, Alpha: 1, BetaVirtuous: 1, BetaRogue: 2,
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 1, BetaRogue: 2,
 	}
 	tree := Tree{}
 	tree.Initialize(params, Green)
@@ -413,7 +413,7 @@ func TestSnowballCloseTrinary(t *testing.T) {
 	magenta := ids.ID{0x03}
 
 	params := Parameters{
-		K: 1, Alpha: 1, BetaVirtuous: 1, BetaRogue: 2,
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 1, BetaRogue: 2,
 	}
 	tree := Tree{}
 	tree.Initialize(params, yellow)
@@ -462,7 +462,7 @@ func TestSnowballAddRejected(t *testing.T) {
 	c0010 := ids.ID{0x04} // 0010
 
 	params := Parameters{
-		K: 1, Alpha: 1, BetaVirtuous: 1, BetaRogue: 2,
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 1, BetaRogue: 2,
 	}
 	tree := Tree{}
 	tree.Initialize(params, c0000)
@@ -509,7 +509,7 @@ func TestSnowballResetChild(t *testing.T) {
 	c1000 := ids.ID{0x01} // 1000
 
 	params := Parameters{
-		K: 1, Alpha: 1, BetaVirtuous: 1, BetaRogue: 2,
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 1, BetaRogue: 2,
 	}
 	tree := Tree{}
 	tree.Initialize(params, c0000)
@@ -570,7 +570,7 @@ func TestSnowballResetSibling(t *testing.T) {
 	c1000 := ids.ID{0x01} // 1000
 
 	params := Parameters{
-		K: 1, Alpha: 1, BetaVirtuous: 1, BetaRogue: 2,
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 1, BetaRogue: 2,
 	}
 	tree := Tree{}
 	tree.Initialize(params, c0000)
@@ -629,7 +629,7 @@ func TestSnowball5Colors(t *testing.T) {
 
 	numColors := 5
 	params := Parameters{
-		K: 5, Alpha: 5, BetaVirtuous: 20, BetaRogue: 30,
+		K: 5, AlphaPreference: 5, AlphaConfidence: 5, BetaVirtuous: 20, BetaRogue: 30,
 	}
 
 	colors := []ids.ID{}
@@ -667,7 +667,7 @@ func TestSnowballFineGrained(t *testing.T) {
 	c0010 := ids.ID{0x04}
 
 	params := Parameters{
-		K: 1, Alpha: 1, BetaVirtuous: 1, BetaRogue: 2,
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 1, BetaRogue: 2,
 	}
 	tree := Tree{}
 	tree.Initialize(params, c0000)
@@ -760,7 +760,7 @@ func TestSnowballDoubleAdd(t *testing.T) {
 	require := require.New(t)
 
 	params := Parameters{
-		K: 1, Alpha: 1, BetaVirtuous: 3, BetaRogue: 5,
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 3, BetaRogue: 5,
 	}
 	tree := Tree{}
 	tree.Initialize(params, Red)
@@ -777,7 +777,7 @@ func TestSnowballConsistent(t *testing.T) {
 	numColors := 50
 	numNodes := 100
 	params := Parameters{
-		K: 20, Alpha: 15, BetaVirtuous: 20, BetaRogue: 30,
+		K: 20, AlphaPreference: 15, AlphaConfidence: 15, BetaVirtuous: 20, BetaRogue: 30,
 	}
 	seed := int64(0)
 
@@ -806,7 +806,7 @@ func TestSnowballFilterBinaryChildren(t *testing.T) {
 	c0010 := ids.ID{0b00000100}
 
 	params := Parameters{
-		K: 1, Alpha: 1, BetaVirtuous: 1, BetaRogue: 2,
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 1, BetaRogue: 2,
 	}
 	tree := Tree{}
 	tree.Initialize(params, c0000)
@@ -881,3 +881,75 @@ func TestSnowballFilterBinaryChildren(t *testing.T) {
 		require.False(tree.Finalized())
 	}
 }
+
+func TestSnowballAlphaPreference(t *testing.T) {
+	require := require.New(t)
+
+	params := Parameters{
+		K: 3, AlphaPreference: 2, AlphaConfidence: 3, BetaVirtuous: 1, BetaRogue: 1,
+	}
+	tree := Tree{}
+	tree.Initialize(params, Red)
+	tree.Add(Blue)
+
+	// Reaching alphaPreference changes the preference without increasing the
+	// confidence.
+	twoBlue := bag.Bag[ids.ID]{}
+	twoBlue.Add(Blue, Blue, Red)
+	require.True(tree.RecordPoll(twoBlue))
+	require.Equal(Blue, tree.Preference())
+	require.False(tree.Finalized())
+
+	require.True(tree.RecordPoll(twoBlue))
+	require.Equal(Blue, tree.Preference())
+	require.False(tree.Finalized())
+
+	// Not reaching alphaPreference doesn't change the preference.
+	oneRed := bag.Bag[ids.ID]{}
+	oneRed.Add(Red)
+	require.False(tree.RecordPoll(oneRed))
+	require.Equal(Blue, tree.Preference())
+	require.False(tree.Finalized())
+
+	threeBlue := bag.Bag[ids.ID]{}
+	threeBlue.Add(Blue, Blue, Blue)
+	require.True(tree.RecordPoll(threeBlue))
+	require.Equal(Blue, tree.Preference())
+	require.True(tree.Finalized())
+}
+
+func TestSnowballSetParameters(t *testing.T) {
+	require := require.New(t)
+
+	params := Parameters{
+		K: 1, AlphaPreference: 1, AlphaConfidence: 1, BetaVirtuous: 1, BetaRogue: 2,
+	}
+	tree := Tree{}
+	tree.Initialize(params, Red)
+	tree.Add(Blue)
+
+	params = Parameters{
+		K: 2, AlphaPreference: 2, AlphaConfidence: 2, BetaVirtuous: 3, BetaRogue: 3,
+	}
+	tree.SetParameters(params)
+
+	// A single vote no longer reaches the preference threshold.
+	oneBlue := bag.Bag[ids.ID]{}
+	oneBlue.Add(Blue)
+	require.False(tree.RecordPoll(oneBlue))
+	require.Equal(Red, tree.Preference())
+
+	twoBlue := bag.Bag[ids.ID]{}
+	twoBlue.Add(Blue, Blue)
+	require.True(tree.RecordPoll(twoBlue))
+	require.Equal(Blue, tree.Preference())
+	require.False(tree.Finalized())
+
+	// The previous betaRogue would have finalized the tree by now.
+	require.True(tree.RecordPoll(twoBlue))
+	require.False(tree.Finalized())
+
+	require.True(tree.RecordPoll(twoBlue))
+	require.Equal(Blue, tree.Preference())
+	require.True(tree.Finalized())
+}
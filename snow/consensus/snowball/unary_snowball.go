@@ -14,7 +14,8 @@ type unarySnowball struct {
 	// wrap the unary snowflake logic
 	unarySnowflake
 
-	// numSuccessfulPolls tracks the total number of successful network polls
+	// numSuccessfulPolls tracks the total number of network polls that reached
+	// the alphaPreference threshold
 	numSuccessfulPolls int
 }
 
@@ -23,6 +24,11 @@ func (sb *unarySnowball) RecordSuccessfulPoll() {
 	sb.unarySnowflake.RecordSuccessfulPoll()
 }
 
+func (sb *unarySnowball) RecordPollPreference() {
+	sb.numSuccessfulPolls++
+	sb.unarySnowflake.RecordPollPreference()
+}
+
 func (sb *unarySnowball) Extend(beta int, choice int) BinarySnowball {
 	bs := &binarySnowball{
 		binarySnowflake: binarySnowflake{
@@ -84,3 +84,23 @@ func TestUnarySnowball(t *testing.T) {
 		t.Fatalf("Wrong state. Expected:\n%s\nGot:\n%s", expected, str)
 	}
 }
+
+func TestUnarySnowballRecordPollPreference(t *testing.T) {
+	beta := 2
+
+	sb := &unarySnowball{}
+	sb.Initialize(beta)
+
+	sb.RecordSuccessfulPoll()
+	UnarySnowballStateTest(t, sb, 1, 1, false)
+
+	// Strengthening the preference resets the confidence
+	sb.RecordPollPreference()
+	UnarySnowballStateTest(t, sb, 2, 0, false)
+
+	sb.RecordSuccessfulPoll()
+	UnarySnowballStateTest(t, sb, 3, 1, false)
+
+	sb.RecordSuccessfulPoll()
+	UnarySnowballStateTest(t, sb, 4, 2, true)
+}
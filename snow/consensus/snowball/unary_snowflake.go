@@ -28,11 +28,19 @@ func (sf *unarySnowflake) Initialize(beta int) {
 	sf.beta = beta
 }
 
+func (sf *unarySnowflake) SetBeta(beta int) {
+	sf.beta = beta
+}
+
 func (sf *unarySnowflake) RecordSuccessfulPoll() {
 	sf.confidence++
 	sf.finalized = sf.finalized || sf.confidence >= sf.beta
 }
 
+func (sf *unarySnowflake) RecordPollPreference() {
+	sf.confidence = 0
+}
+
 func (sf *unarySnowflake) RecordUnsuccessfulPoll() {
 	sf.confidence = 0
 }
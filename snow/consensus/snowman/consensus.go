@@ -10,6 +10,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/api/health"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/choices"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
 	"github.com/MetalBlockchain/metalgo/utils/bag"
 )
@@ -28,6 +29,11 @@ type Consensus interface {
 		lastAcceptedTime time.Time,
 	) error
 
+	// SetParameters replaces the snowball parameters. Blocks that already
+	// have processing children keep deciding between them with the previous
+	// parameters.
+	SetParameters(snowball.Parameters) error
+
 	// Returns the number of blocks processing
 	NumProcessing() int
 
@@ -60,4 +66,28 @@ type Consensus interface {
 	// finalized. Note, it is possible that after returning finalized, a new
 	// decision may be added such that this instance is no longer finalized.
 	Finalized() bool
+
+	// Inspect returns the state of the last accepted block followed by the
+	// state of every processing block. Parents are always returned before
+	// their children.
+	Inspect() []BlockState
+}
+
+// BlockState is a snapshot of a block in the consensus instance.
+type BlockState struct {
+	ID       ids.ID         `json:"id"`
+	ParentID ids.ID         `json:"parentID"`
+	Height   uint64         `json:"height"`
+	Status   choices.Status `json:"status"`
+
+	// Preferred is true if the block is on the preferred chain.
+	Preferred bool `json:"preferred"`
+
+	// ProcessingTime is the amount of time the block has been processing.
+	ProcessingTime time.Duration `json:"processingTime"`
+
+	// Snowball describes the snowball instance deciding between the children
+	// of this block, including its preference and confidence counters. Empty
+	// if the block has no children.
+	Snowball string `json:"snowball,omitempty"`
 }
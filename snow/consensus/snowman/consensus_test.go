@@ -67,6 +67,9 @@ var (
 		RandomizedConsistencyTest,
 		ErrorOnAddDecidedBlock,
 		ErrorOnAddDuplicateBlockID,
+		InspectTest,
+		SetParametersTest,
+		SetParametersProcessingBlockTest,
 	}
 
 	errTest = errors.New("non-nil error")
@@ -92,7 +95,8 @@ func InitializeTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          3,
 		BetaRogue:             5,
 		ConcurrentRepolls:     1,
@@ -119,7 +123,8 @@ func NumProcessingTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -171,7 +176,8 @@ func AddToTailTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          3,
 		BetaRogue:             5,
 		ConcurrentRepolls:     1,
@@ -209,7 +215,8 @@ func AddToNonTailTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          3,
 		BetaRogue:             5,
 		ConcurrentRepolls:     1,
@@ -262,7 +269,8 @@ func AddToUnknownTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          3,
 		BetaRogue:             5,
 		ConcurrentRepolls:     1,
@@ -305,7 +313,8 @@ func StatusOrProcessingPreviouslyAcceptedTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          3,
 		BetaRogue:             5,
 		ConcurrentRepolls:     1,
@@ -337,7 +346,8 @@ func StatusOrProcessingPreviouslyRejectedTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          3,
 		BetaRogue:             5,
 		ConcurrentRepolls:     1,
@@ -378,7 +388,8 @@ func StatusOrProcessingUnissuedTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          3,
 		BetaRogue:             5,
 		ConcurrentRepolls:     1,
@@ -419,7 +430,8 @@ func StatusOrProcessingIssuedTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          3,
 		BetaRogue:             5,
 		ConcurrentRepolls:     1,
@@ -463,7 +475,8 @@ func RecordPollAcceptSingleBlockTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          2,
 		BetaRogue:             3,
 		ConcurrentRepolls:     1,
@@ -515,7 +528,8 @@ func RecordPollAcceptAndRejectTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -586,7 +600,8 @@ func RecordPollSplitVoteNoChangeTest(t *testing.T, factory Factory) {
 
 	params := snowball.Parameters{
 		K:                     2,
-		Alpha:                 2,
+		AlphaPreference:       2,
+		AlphaConfidence:       2,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -645,7 +660,8 @@ func RecordPollWhenFinalizedTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -674,7 +690,8 @@ func RecordPollRejectTransitivelyTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -756,7 +773,8 @@ func RecordPollTransitivelyResetConfidenceTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          2,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -874,7 +892,8 @@ func RecordPollInvalidVoteTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          2,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -925,7 +944,8 @@ func RecordPollTransitiveVotingTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     3,
-		Alpha:                 3,
+		AlphaPreference:       3,
+		AlphaConfidence:       3,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -1072,7 +1092,8 @@ func RecordPollDivergedVotingTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -1183,7 +1204,8 @@ func RecordPollDivergedVotingWithNoConflictingBitTest(t *testing.T, factory Fact
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -1286,7 +1308,8 @@ func RecordPollChangePreferredChainTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          10,
 		BetaRogue:             10,
 		ConcurrentRepolls:     1,
@@ -1419,7 +1442,8 @@ func MetricsProcessingErrorTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -1448,7 +1472,8 @@ func MetricsAcceptedErrorTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -1477,7 +1502,8 @@ func MetricsRejectedErrorTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -1506,7 +1532,8 @@ func ErrorOnInitialRejectionTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -1545,7 +1572,8 @@ func ErrorOnAcceptTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -1585,7 +1613,8 @@ func ErrorOnRejectSiblingTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -1635,7 +1664,8 @@ func ErrorOnTransitiveRejectionTest(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -1694,7 +1724,8 @@ func RandomizedConsistencyTest(t *testing.T, factory Factory) {
 	numNodes := 100
 	params := snowball.Parameters{
 		K:                     20,
-		Alpha:                 15,
+		AlphaPreference:       15,
+		AlphaConfidence:       15,
 		BetaVirtuous:          20,
 		BetaRogue:             30,
 		ConcurrentRepolls:     1,
@@ -1733,7 +1764,8 @@ func ErrorOnAddDecidedBlock(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -1761,7 +1793,8 @@ func ErrorOnAddDuplicateBlockID(t *testing.T, factory Factory) {
 	ctx := snow.DefaultConsensusContextTest()
 	params := snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -1815,3 +1848,190 @@ func gatherCounterGauge(t *testing.T, reg *prometheus.Registry) map[string]float
 	}
 	return mss
 }
+
+func InspectTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+	sm := factory.New()
+
+	ctx := snow.DefaultConsensusContextTest()
+	params := snowball.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		BetaVirtuous:          2,
+		BetaRogue:             2,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(ctx, params, GenesisID, GenesisHeight, GenesisTimestamp))
+
+	states := sm.Inspect()
+	require.Len(states, 1)
+	require.Equal(GenesisID, states[0].ID)
+	require.Equal(GenesisHeight, states[0].Height)
+	require.Equal(choices.Accepted, states[0].Status)
+	require.Empty(states[0].Snowball)
+
+	block0 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	block1 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	block2 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(3),
+			StatusV: choices.Processing,
+		},
+		ParentV: block1.IDV,
+		HeightV: block1.HeightV + 1,
+	}
+	require.NoError(sm.Add(context.Background(), block0))
+	require.NoError(sm.Add(context.Background(), block1))
+	require.NoError(sm.Add(context.Background(), block2))
+
+	votes := bag.Bag[ids.ID]{}
+	votes.Add(block2.ID())
+	require.NoError(sm.RecordPoll(context.Background(), votes))
+
+	states = sm.Inspect()
+	require.Len(states, 4)
+
+	// Parents are returned before their children.
+	require.Equal(GenesisID, states[0].ID)
+	require.NotEmpty(states[0].Snowball)
+	require.ElementsMatch(
+		[]ids.ID{block0.ID(), block1.ID()},
+		[]ids.ID{states[1].ID, states[2].ID},
+	)
+	require.Equal(block2.ID(), states[3].ID)
+
+	for _, state := range states[1:] {
+		require.Equal(choices.Processing, state.Status)
+		require.Equal(state.ID == block1.ID() || state.ID == block2.ID(), state.Preferred)
+		switch state.ID {
+		case block1.ID():
+			require.Equal(GenesisID, state.ParentID)
+			require.Equal(block1.HeightV, state.Height)
+			require.NotEmpty(state.Snowball)
+		case block2.ID():
+			require.Equal(block1.ID(), state.ParentID)
+			require.Equal(block2.HeightV, state.Height)
+			require.Empty(state.Snowball)
+		}
+	}
+}
+
+func SetParametersTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+	sm := factory.New()
+
+	ctx := snow.DefaultConsensusContextTest()
+	params := snowball.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		BetaVirtuous:          1,
+		BetaRogue:             2,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(ctx, params, GenesisID, GenesisHeight, GenesisTimestamp))
+
+	firstBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	secondBlock := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+
+	require.NoError(sm.Add(context.Background(), firstBlock))
+	require.NoError(sm.Add(context.Background(), secondBlock))
+
+	invalidParams := params
+	invalidParams.AlphaPreference = 0
+	require.Error(sm.SetParameters(invalidParams))
+
+	params.K = 2
+	params.AlphaPreference = 2
+	params.AlphaConfidence = 2
+	require.NoError(sm.SetParameters(params))
+
+	// A single vote no longer reaches the preference threshold.
+	votes := bag.Bag[ids.ID]{}
+	votes.Add(secondBlock.ID())
+	require.NoError(sm.RecordPoll(context.Background(), votes))
+	require.Equal(firstBlock.ID(), sm.Preference())
+	require.Equal(choices.Processing, secondBlock.Status())
+
+	votes.Add(secondBlock.ID())
+	require.NoError(sm.RecordPoll(context.Background(), votes))
+	require.Equal(secondBlock.ID(), sm.Preference())
+}
+
+func SetParametersProcessingBlockTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+	sm := factory.New()
+
+	ctx := snow.DefaultConsensusContextTest()
+	params := snowball.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		BetaVirtuous:          1,
+		BetaRogue:             1,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(ctx, params, GenesisID, GenesisHeight, GenesisTimestamp))
+
+	block := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	require.NoError(sm.Add(context.Background(), block))
+
+	// The snowball instance deciding the child of the genesis was already
+	// initialized with the previous parameters.
+	params.BetaVirtuous = 2
+	params.BetaRogue = 2
+	require.NoError(sm.SetParameters(params))
+
+	votes := bag.Bag[ids.ID]{}
+	votes.Add(block.ID())
+	require.NoError(sm.RecordPoll(context.Background(), votes))
+	require.Equal(choices.Processing, block.Status())
+
+	require.NoError(sm.RecordPoll(context.Background(), votes))
+	require.Equal(choices.Accepted, block.Status())
+}
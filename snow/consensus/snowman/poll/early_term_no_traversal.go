@@ -11,19 +11,24 @@ import (
 )
 
 type earlyTermNoTraversalFactory struct {
-	alpha int
+	alphaPreference int
+	alphaConfidence int
 }
 
 // NewEarlyTermNoTraversalFactory returns a factory that returns polls with
 // early termination, without doing DAG traversals
-func NewEarlyTermNoTraversalFactory(alpha int) Factory {
-	return &earlyTermNoTraversalFactory{alpha: alpha}
+func NewEarlyTermNoTraversalFactory(alphaPreference, alphaConfidence int) Factory {
+	return &earlyTermNoTraversalFactory{
+		alphaPreference: alphaPreference,
+		alphaConfidence: alphaConfidence,
+	}
 }
 
 func (f *earlyTermNoTraversalFactory) New(vdrs bag.Bag[ids.NodeID]) Poll {
 	return &earlyTermNoTraversalPoll{
-		polled: vdrs,
-		alpha:  f.alpha,
+		polled:          vdrs,
+		alphaPreference: f.alphaPreference,
+		alphaConfidence: f.alphaConfidence,
 	}
 }
 
@@ -31,9 +36,10 @@ func (f *earlyTermNoTraversalFactory) New(vdrs bag.Bag[ids.NodeID]) Poll {
 // the result of the poll. However, does not terminate tightly with this bound.
 // It terminates as quickly as it can without performing any DAG traversals.
 type earlyTermNoTraversalPoll struct {
-	votes  bag.Bag[ids.ID]
-	polled bag.Bag[ids.NodeID]
-	alpha  int
+	votes           bag.Bag[ids.ID]
+	polled          bag.Bag[ids.NodeID]
+	alphaPreference int
+	alphaConfidence int
 }
 
 // Vote registers a response for this poll
@@ -55,10 +61,13 @@ func (p *earlyTermNoTraversalPoll) Drop(vdr ids.NodeID) {
 func (p *earlyTermNoTraversalPoll) Finished() bool {
 	remaining := p.polled.Len()
 	received := p.votes.Len()
+	maxPossibleVotes := received + remaining
 	_, freq := p.votes.Mode()
 	return remaining == 0 || // All k nodes responded
-		freq >= p.alpha || // An alpha majority has returned
-		received+remaining < p.alpha // An alpha majority can never return
+		freq >= p.alphaConfidence || // An alphaConfidence majority has returned
+		maxPossibleVotes < p.alphaPreference || // An alphaPreference majority can never return
+		(freq >= p.alphaPreference && // An alphaPreference majority has returned
+			freq+remaining < p.alphaConfidence) // but an alphaConfidence majority can never return
 }
 
 // Result returns the result of this poll
@@ -20,7 +20,7 @@ func TestEarlyTermNoTraversalResults(t *testing.T) {
 	vdrs := bag.Bag[ids.NodeID]{}
 	vdrs.Add(vdr1)
 
-	factory := NewEarlyTermNoTraversalFactory(alpha)
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
 	poll := factory.New(vdrs)
 
 	poll.Vote(vdr1, vtxID)
@@ -52,7 +52,7 @@ func TestEarlyTermNoTraversalString(t *testing.T) {
 		vdr2,
 	)
 
-	factory := NewEarlyTermNoTraversalFactory(alpha)
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
 	poll := factory.New(vdrs)
 
 	poll.Vote(vdr1, vtxID)
@@ -80,7 +80,7 @@ func TestEarlyTermNoTraversalDropsDuplicatedVotes(t *testing.T) {
 		vdr2,
 	)
 
-	factory := NewEarlyTermNoTraversalFactory(alpha)
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
 	poll := factory.New(vdrs)
 
 	poll.Vote(vdr1, vtxID)
@@ -117,7 +117,7 @@ func TestEarlyTermNoTraversalTerminatesEarly(t *testing.T) {
 		vdr5,
 	)
 
-	factory := NewEarlyTermNoTraversalFactory(alpha)
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
 	poll := factory.New(vdrs)
 
 	poll.Vote(vdr1, vtxID)
@@ -160,7 +160,7 @@ func TestEarlyTermNoTraversalForSharedAncestor(t *testing.T) {
 		vdr4,
 	)
 
-	factory := NewEarlyTermNoTraversalFactory(alpha)
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
 	poll := factory.New(vdrs)
 
 	poll.Vote(vdr1, vtxB)
@@ -195,7 +195,7 @@ func TestEarlyTermNoTraversalWithFastDrops(t *testing.T) {
 		vdr3,
 	)
 
-	factory := NewEarlyTermNoTraversalFactory(alpha)
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
 	poll := factory.New(vdrs)
 
 	poll.Drop(vdr1)
@@ -223,7 +223,7 @@ func TestEarlyTermNoTraversalWithWeightedResponses(t *testing.T) {
 		vdr2,
 	) // k = 3
 
-	factory := NewEarlyTermNoTraversalFactory(alpha)
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
 	poll := factory.New(vdrs)
 
 	poll.Vote(vdr2, vtxID)
@@ -254,7 +254,7 @@ func TestEarlyTermNoTraversalDropWithWeightedResponses(t *testing.T) {
 		vdr2,
 	) // k = 3
 
-	factory := NewEarlyTermNoTraversalFactory(alpha)
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
 	poll := factory.New(vdrs)
 
 	poll.Drop(vdr2)
@@ -262,3 +262,77 @@ func TestEarlyTermNoTraversalDropWithWeightedResponses(t *testing.T) {
 		t.Fatalf("Poll did not terminate after dropping two votes")
 	}
 }
+
+func TestEarlyTermNoTraversalTerminatesEarlyWithAlphaPreference(t *testing.T) {
+	alphaPreference := 3
+	alphaConfidence := 5
+
+	vtxA := ids.ID{1}
+	vtxB := ids.ID{2}
+
+	vdr1 := ids.NodeID{1}
+	vdr2 := ids.NodeID{2}
+	vdr3 := ids.NodeID{3}
+	vdr4 := ids.NodeID{4}
+	vdr5 := ids.NodeID{5} // k = 5
+
+	vdrs := bag.Bag[ids.NodeID]{}
+	vdrs.Add(
+		vdr1,
+		vdr2,
+		vdr3,
+		vdr4,
+		vdr5,
+	)
+
+	factory := NewEarlyTermNoTraversalFactory(alphaPreference, alphaConfidence)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, vtxA)
+	poll.Vote(vdr2, vtxA)
+	poll.Vote(vdr3, vtxA)
+	if poll.Finished() {
+		t.Fatalf("Poll finished while alphaConfidence could still be reached")
+	}
+
+	poll.Vote(vdr4, vtxB)
+	if !poll.Finished() {
+		t.Fatalf("Poll did not terminate early after alphaConfidence became unreachable")
+	}
+}
+
+func TestEarlyTermNoTraversalTerminatesEarlyWithAlphaConfidence(t *testing.T) {
+	alphaPreference := 3
+	alphaConfidence := 3
+
+	vtxID := ids.ID{1}
+
+	vdr1 := ids.NodeID{1}
+	vdr2 := ids.NodeID{2}
+	vdr3 := ids.NodeID{3}
+	vdr4 := ids.NodeID{4}
+	vdr5 := ids.NodeID{5} // k = 5
+
+	vdrs := bag.Bag[ids.NodeID]{}
+	vdrs.Add(
+		vdr1,
+		vdr2,
+		vdr3,
+		vdr4,
+		vdr5,
+	)
+
+	factory := NewEarlyTermNoTraversalFactory(alphaPreference, alphaConfidence)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, vtxID)
+	poll.Vote(vdr2, vtxID)
+	if poll.Finished() {
+		t.Fatalf("Poll finished after less than alphaConfidence votes")
+	}
+
+	poll.Vote(vdr3, vtxID)
+	if !poll.Finished() {
+		t.Fatalf("Poll did not terminate early after receiving alphaConfidence votes")
+	}
+}
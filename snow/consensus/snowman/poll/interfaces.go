@@ -5,6 +5,7 @@ package poll
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/utils/bag"
@@ -19,6 +20,27 @@ type Set interface {
 	Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.ID]
 	Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID]
 	Len() int
+
+	// SetFactory replaces the factory used to create new polls. Outstanding
+	// polls aren't affected.
+	SetFactory(Factory)
+
+	// Inspect returns the state of the outstanding polls, from oldest to
+	// newest.
+	Inspect() []Info
+}
+
+// Info is a snapshot of an outstanding poll
+type Info struct {
+	RequestID uint32        `json:"requestID"`
+	Duration  time.Duration `json:"duration"`
+
+	// Responded are the validators that have voted in the poll.
+	Responded []ids.NodeID `json:"responded"`
+	// Dropped are the validators whose queries failed.
+	Dropped []ids.NodeID `json:"dropped"`
+	// Pending are the validators that haven't responded to the poll.
+	Pending []ids.NodeID `json:"pending"`
 }
 
 // Poll is an outstanding poll
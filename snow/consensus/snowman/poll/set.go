@@ -13,28 +13,23 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/bag"
 	"github.com/MetalBlockchain/metalgo/utils/linkedhashmap"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/metric"
+	sets "github.com/MetalBlockchain/metalgo/utils/set"
 )
 
-type pollHolder interface {
-	GetPoll() Poll
-	StartTime() time.Time
-}
-
 type poll struct {
 	Poll
 	start time.Time
-}
-
-func (p poll) GetPoll() Poll {
-	return p
-}
-
-func (p poll) StartTime() time.Time {
-	return p.start
+	// vdrs are the validators that were queried
+	vdrs []ids.NodeID
+	// responded are the validators that have voted
+	responded sets.Set[ids.NodeID]
+	// dropped are the validators whose queries failed
+	dropped sets.Set[ids.NodeID]
 }
 
 type set struct {
@@ -43,7 +38,7 @@ type set struct {
 	durPolls metric.Averager
 	factory  Factory
 	// maps requestID -> poll
-	polls linkedhashmap.LinkedHashmap[uint32, pollHolder]
+	polls linkedhashmap.LinkedHashmap[uint32, *poll]
 }
 
 // NewSet returns a new empty set of polls
@@ -81,7 +76,7 @@ func NewSet(
 		numPolls: numPolls,
 		durPolls: durPolls,
 		factory:  factory,
-		polls:    linkedhashmap.New[uint32, pollHolder](),
+		polls:    linkedhashmap.New[uint32, *poll](),
 	}
 }
 
@@ -102,9 +97,12 @@ func (s *set) Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool {
 		zap.Stringer("validators", &vdrs),
 	)
 
-	s.polls.Put(requestID, poll{
+	vdrList := vdrs.List()
+	utils.Sort(vdrList)
+	s.polls.Put(requestID, &poll{
 		Poll:  s.factory.New(vdrs), // create the new poll
 		start: time.Now(),
+		vdrs:  vdrList,
 	})
 	s.numPolls.Inc() // increase the metrics
 	return true
@@ -113,7 +111,7 @@ func (s *set) Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool {
 // Vote registers the connections response to a query for [id]. If there was no
 // query, or the response has already be registered, nothing is performed.
 func (s *set) Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.ID] {
-	holder, exists := s.polls.Get(requestID)
+	p, exists := s.polls.Get(requestID)
 	if !exists {
 		s.log.Verbo("dropping vote",
 			zap.String("reason", "unknown poll"),
@@ -123,14 +121,13 @@ func (s *set) Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.
 		return nil
 	}
 
-	p := holder.GetPoll()
-
 	s.log.Verbo("processing vote",
 		zap.Stringer("validator", vdr),
 		zap.Uint32("requestID", requestID),
 		zap.Stringer("vote", vote),
 	)
 
+	p.responded.Add(vdr)
 	p.Vote(vdr, vote)
 	if !p.Finished() {
 		return nil
@@ -146,8 +143,7 @@ func (s *set) processFinishedPolls() []bag.Bag[ids.ID] {
 	// iterate from oldest to newest
 	iter := s.polls.NewIterator()
 	for iter.Next() {
-		holder := iter.Value()
-		p := holder.GetPoll()
+		p := iter.Value()
 		if !p.Finished() {
 			// since we're iterating from oldest to newest, if the next poll has not finished,
 			// we can break and return what we have so far
@@ -156,9 +152,9 @@ func (s *set) processFinishedPolls() []bag.Bag[ids.ID] {
 
 		s.log.Verbo("poll finished",
 			zap.Uint32("requestID", iter.Key()),
-			zap.Stringer("poll", holder.GetPoll()),
+			zap.Stringer("poll", p),
 		)
-		s.durPolls.Observe(float64(time.Since(holder.StartTime())))
+		s.durPolls.Observe(float64(time.Since(p.start)))
 		s.numPolls.Dec() // decrease the metrics
 
 		results = append(results, p.Result())
@@ -173,7 +169,7 @@ func (s *set) processFinishedPolls() []bag.Bag[ids.ID] {
 // Drop registers the connections response to a query for [id]. If there was no
 // query, or the response has already be registered, nothing is performed.
 func (s *set) Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID] {
-	holder, exists := s.polls.Get(requestID)
+	p, exists := s.polls.Get(requestID)
 	if !exists {
 		s.log.Verbo("dropping vote",
 			zap.String("reason", "unknown poll"),
@@ -188,10 +184,9 @@ func (s *set) Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID] {
 		zap.Uint32("requestID", requestID),
 	)
 
-	poll := holder.GetPoll()
-
-	poll.Drop(vdr)
-	if !poll.Finished() {
+	p.dropped.Add(vdr)
+	p.Drop(vdr)
+	if !p.Finished() {
 		return nil
 	}
 
@@ -203,13 +198,41 @@ func (s *set) Len() int {
 	return s.polls.Len()
 }
 
+func (s *set) SetFactory(factory Factory) {
+	s.factory = factory
+}
+
+func (s *set) Inspect() []Info {
+	infos := make([]Info, 0, s.polls.Len())
+	iter := s.polls.NewIterator()
+	for iter.Next() {
+		p := iter.Value()
+		info := Info{
+			RequestID: iter.Key(),
+			Duration:  time.Since(p.start),
+		}
+		for _, vdr := range p.vdrs {
+			switch {
+			case p.responded.Contains(vdr):
+				info.Responded = append(info.Responded, vdr)
+			case p.dropped.Contains(vdr):
+				info.Dropped = append(info.Dropped, vdr)
+			default:
+				info.Pending = append(info.Pending, vdr)
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
 func (s *set) String() string {
 	sb := strings.Builder{}
 	sb.WriteString(fmt.Sprintf("current polls: (Size = %d)", s.polls.Len()))
 	iter := s.polls.NewIterator()
 	for iter.Next() {
 		requestID := iter.Key()
-		poll := iter.Value().(Poll)
+		poll := iter.Value()
 		sb.WriteString(fmt.Sprintf("\n    RequestID %d:\n        %s", requestID, poll.PrefixedString("        ")))
 	}
 	return sb.String()
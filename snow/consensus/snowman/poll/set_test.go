@@ -327,3 +327,40 @@ func TestSetString(t *testing.T) {
 			str)
 	}
 }
+
+func TestSetInspect(t *testing.T) {
+	require := require.New(t)
+
+	factory := NewNoEarlyTermFactory()
+	log := logging.NoLog{}
+	namespace := ""
+	registerer := prometheus.NewRegistry()
+	s := NewSet(factory, log, namespace, registerer)
+
+	vdr1 := ids.NodeID{1}
+	vdr2 := ids.NodeID{2}
+	vdr3 := ids.NodeID{3} // k = 3
+
+	vdrs := bag.Bag[ids.NodeID]{}
+	vdrs.Add(vdr3, vdr2, vdr1)
+
+	require.Empty(s.Inspect())
+	require.True(s.Add(0, vdrs))
+	require.True(s.Add(1, vdrs))
+
+	require.Empty(s.Vote(0, vdr1, ids.GenerateTestID()))
+	require.Empty(s.Drop(0, vdr2))
+
+	infos := s.Inspect()
+	require.Len(infos, 2)
+
+	require.Equal(uint32(0), infos[0].RequestID)
+	require.Equal([]ids.NodeID{vdr1}, infos[0].Responded)
+	require.Equal([]ids.NodeID{vdr2}, infos[0].Dropped)
+	require.Equal([]ids.NodeID{vdr3}, infos[0].Pending)
+
+	require.Equal(uint32(1), infos[1].RequestID)
+	require.Empty(infos[1].Responded)
+	require.Empty(infos[1].Dropped)
+	require.Equal([]ids.NodeID{vdr1, vdr2, vdr3}, infos[1].Pending)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/choices"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/metrics"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
+	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/bag"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 )
@@ -148,6 +149,23 @@ func (ts *Topological) Initialize(ctx *snow.ConsensusContext, params snowball.Pa
 	return nil
 }
 
+func (ts *Topological) SetParameters(params snowball.Parameters) error {
+	if err := params.Verify(); err != nil {
+		return err
+	}
+
+	ts.params = params
+	for _, blk := range ts.blocks {
+		blk.params = params
+		// The snowball instances of blocks that already have children were
+		// initialized with the previous parameters.
+		if blk.sb != nil {
+			blk.sb.SetParameters(params)
+		}
+	}
+	return nil
+}
+
 func (ts *Topological) NumProcessing() int {
 	return len(ts.blocks) - 1
 }
@@ -232,6 +250,42 @@ func (ts *Topological) Preference() ids.ID {
 	return ts.tail
 }
 
+func (ts *Topological) Inspect() []BlockState {
+	states := make([]BlockState, 0, len(ts.blocks))
+	queue := []ids.ID{ts.head}
+	for len(queue) > 0 {
+		blkID := queue[0]
+		queue = queue[1:]
+
+		n := ts.blocks[blkID]
+		state := BlockState{
+			ID:        blkID,
+			Status:    choices.Accepted,
+			Preferred: true,
+		}
+		if blkID == ts.head {
+			state.Height = ts.height
+		} else {
+			state.Status = choices.Processing
+			state.Preferred = ts.preferredIDs.Contains(blkID)
+			state.ProcessingTime, _ = ts.ProcessingDuration(blkID)
+		}
+		if n.blk != nil {
+			state.ParentID = n.blk.Parent()
+			state.Height = n.blk.Height()
+		}
+		if n.sb != nil {
+			state.Snowball = n.sb.String()
+		}
+		states = append(states, state)
+
+		childIDs := maps.Keys(n.children)
+		utils.Sort(childIDs)
+		queue = append(queue, childIDs...)
+	}
+	return states
+}
+
 // The votes bag contains at most K votes for blocks in the tree. If there is a
 // vote for a block that isn't in the tree, the vote is dropped.
 //
@@ -258,11 +312,11 @@ func (ts *Topological) RecordPoll(ctx context.Context, voteBag bag.Bag[ids.ID])
 	ts.pollNumber++
 
 	var voteStack []votes
-	if voteBag.Len() >= ts.params.Alpha {
-		// Since we received at least alpha votes, it's possible that
-		// we reached an alpha majority on a processing block.
+	if voteBag.Len() >= ts.params.AlphaPreference {
+		// Since we received at least alphaPreference votes, it's possible that
+		// we reached an alphaPreference majority on a processing block.
 		// We must perform the traversals to calculate all block
-		// that reached an alpha majority.
+		// that reached an alphaPreference majority.
 
 		// Populates [ts.kahnNodes] and [ts.leaves]
 		// Runtime = |live set| + |votes| ; Space = |live set| + |votes|
@@ -420,9 +474,9 @@ func (ts *Topological) pushVotes() []votes {
 		kahnNode := ts.kahnNodes[leafID]
 		block := ts.blocks[leafID]
 
-		// If there are at least Alpha votes, then this block needs to record
-		// the poll on the snowball instance
-		if kahnNode.votes.Len() >= ts.params.Alpha {
+		// If there are at least AlphaPreference votes, then this block needs
+		// to record the poll on the snowball instance
+		if kahnNode.votes.Len() >= ts.params.AlphaPreference {
 			voteStack = append(voteStack, votes{
 				parentID: leafID,
 				votes:    kahnNode.votes,
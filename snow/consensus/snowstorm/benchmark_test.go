@@ -59,7 +59,8 @@ func BenchmarkVirtuousDirected(b *testing.B) {
 			/*numNodes=*/ 50,
 			/*params=*/ sbcon.Parameters{
 				K:                 20,
-				Alpha:             11,
+				AlphaPreference:   11,
+				AlphaConfidence:   11,
 				BetaVirtuous:      20,
 				BetaRogue:         30,
 				ConcurrentRepolls: 1,
@@ -88,7 +89,8 @@ func BenchmarkRogueDirected(b *testing.B) {
 			/*numNodes=*/ 50,
 			/*params=*/ sbcon.Parameters{
 				K:                 20,
-				Alpha:             11,
+				AlphaPreference:   11,
+				AlphaConfidence:   11,
 				BetaVirtuous:      20,
 				BetaRogue:         30,
 				ConcurrentRepolls: 1,
@@ -117,7 +119,8 @@ func BenchmarkMultiDirected(b *testing.B) {
 			/*numNodes=*/ 50,
 			/*params=*/ sbcon.Parameters{
 				K:                 20,
-				Alpha:             11,
+				AlphaPreference:   11,
+				AlphaConfidence:   11,
 				BetaVirtuous:      20,
 				BetaRogue:         30,
 				ConcurrentRepolls: 1,
@@ -146,7 +149,8 @@ func BenchmarkMultiRogueDirected(b *testing.B) {
 			/*numNodes=*/ 50,
 			/*params=*/ sbcon.Parameters{
 				K:                 20,
-				Alpha:             11,
+				AlphaPreference:   11,
+				AlphaConfidence:   11,
 				BetaVirtuous:      20,
 				BetaRogue:         30,
 				ConcurrentRepolls: 1,
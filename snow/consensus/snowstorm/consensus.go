@@ -26,6 +26,10 @@ type Consensus interface {
 	// Takes in the context, alpha, betaVirtuous, and betaRogue
 	Initialize(*snow.ConsensusContext, sbcon.Parameters) error
 
+	// SetParameters replaces the parameters used to decide the processing
+	// transactions.
+	SetParameters(sbcon.Parameters) error
+
 	// Returns true if transaction <Tx> is virtuous.
 	// That is, no transaction has been added that conflicts with <Tx>
 	IsVirtuous(Tx) bool
@@ -54,6 +58,10 @@ type Consensus interface {
 	// Returns the set of transactions conflicting with <Tx>
 	Conflicts(Tx) set.Set[ids.ID]
 
+	// Snowball describes the snowball counters of the processing transaction
+	// [txID]. Returns false if [txID] isn't processing.
+	Snowball(txID ids.ID) (string, bool)
+
 	// Collects the results of a network poll. Assumes all transactions
 	// have been previously added. Returns true if any statuses or preferences
 	// changed. Returns if a critical error has occurred.
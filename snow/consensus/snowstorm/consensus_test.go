@@ -36,6 +36,7 @@ var (
 		LeftoverInputTest,
 		LowerConfidenceTest,
 		MiddleConfidenceTest,
+		AlphaPreferenceTest,
 		IndependentTest,
 		VirtuousTest,
 		IsVirtuousTest,
@@ -55,6 +56,7 @@ var (
 		ErrorOnRejectingHigherConfidenceConflictTest,
 		UTXOCleanupTest,
 		RemoveVirtuousTest,
+		SetParametersTest,
 	}
 
 	Red, Green, Blue, Alpha *TestTx
@@ -127,7 +129,8 @@ func MetricsTest(t *testing.T, factory Factory) {
 		ctx := snow.DefaultConsensusContextTest()
 		params := sbcon.Parameters{
 			K:                 2,
-			Alpha:             2,
+			AlphaPreference:   2,
+			AlphaConfidence:   2,
 			BetaVirtuous:      1,
 			BetaRogue:         2,
 			ConcurrentRepolls: 1,
@@ -147,7 +150,8 @@ func MetricsTest(t *testing.T, factory Factory) {
 		ctx := snow.DefaultConsensusContextTest()
 		params := sbcon.Parameters{
 			K:                 2,
-			Alpha:             2,
+			AlphaPreference:   2,
+			AlphaConfidence:   2,
 			BetaVirtuous:      1,
 			BetaRogue:         2,
 			ConcurrentRepolls: 1,
@@ -167,7 +171,8 @@ func MetricsTest(t *testing.T, factory Factory) {
 		ctx := snow.DefaultConsensusContextTest()
 		params := sbcon.Parameters{
 			K:                 2,
-			Alpha:             2,
+			AlphaPreference:   2,
+			AlphaConfidence:   2,
 			BetaVirtuous:      1,
 			BetaRogue:         2,
 			ConcurrentRepolls: 1,
@@ -190,7 +195,8 @@ func IssuedTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     2,
-		Alpha:                 2,
+		AlphaPreference:       2,
+		AlphaConfidence:       2,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -223,7 +229,8 @@ func LeftoverInputTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     2,
-		Alpha:                 2,
+		AlphaPreference:       2,
+		AlphaConfidence:       2,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -279,7 +286,8 @@ func LowerConfidenceTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     2,
-		Alpha:                 2,
+		AlphaPreference:       2,
+		AlphaConfidence:       2,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -332,12 +340,74 @@ func LowerConfidenceTest(t *testing.T, factory Factory) {
 	}
 }
 
+func AlphaPreferenceTest(t *testing.T, factory Factory) {
+	graph := factory.New()
+
+	params := sbcon.Parameters{
+		K:                     3,
+		AlphaPreference:       2,
+		AlphaConfidence:       3,
+		BetaVirtuous:          1,
+		BetaRogue:             1,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	err := graph.Initialize(snow.DefaultConsensusContextTest(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := graph.Add(context.Background(), Red); err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.Add(context.Background(), Green); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reaching alphaPreference changes the preference without increasing the
+	// confidence.
+	g := bag.Bag[ids.ID]{}
+	g.AddCount(Green.ID(), 2)
+	if updated, err := graph.RecordPoll(context.Background(), g); err != nil {
+		t.Fatal(err)
+	} else if !updated {
+		t.Fatalf("Should have updated the frontiers")
+	}
+
+	prefs := graph.Preferences()
+	switch {
+	case prefs.Len() != 1:
+		t.Fatalf("Wrong number of preferences.")
+	case !prefs.Contains(Green.ID()):
+		t.Fatalf("Wrong preference. Expected %s", Green.ID())
+	case graph.Finalized():
+		t.Fatalf("Finalized too early")
+	}
+
+	g.AddCount(Green.ID(), 1)
+	if _, err := graph.RecordPoll(context.Background(), g); err != nil {
+		t.Fatal(err)
+	}
+
+	switch {
+	case Green.Status() != choices.Accepted:
+		t.Fatalf("%s should have been accepted", Green.ID())
+	case Red.Status() != choices.Rejected:
+		t.Fatalf("%s should have been rejected", Red.ID())
+	case !graph.Finalized():
+		t.Fatalf("Should have finalized")
+	}
+}
+
 func MiddleConfidenceTest(t *testing.T, factory Factory) {
 	graph := factory.New()
 
 	params := sbcon.Parameters{
 		K:                     2,
-		Alpha:                 2,
+		AlphaPreference:       2,
+		AlphaConfidence:       2,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -400,7 +470,8 @@ func IndependentTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     2,
-		Alpha:                 2,
+		AlphaPreference:       2,
+		AlphaConfidence:       2,
 		BetaVirtuous:          2,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -464,7 +535,8 @@ func VirtuousTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     2,
-		Alpha:                 2,
+		AlphaPreference:       2,
+		AlphaConfidence:       2,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -509,7 +581,8 @@ func IsVirtuousTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     2,
-		Alpha:                 2,
+		AlphaPreference:       2,
+		AlphaConfidence:       2,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -564,7 +637,8 @@ func QuiesceTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     2,
-		Alpha:                 2,
+		AlphaPreference:       2,
+		AlphaConfidence:       2,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -595,7 +669,8 @@ func AddNonEmptyWhitelistTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -801,7 +876,8 @@ func AddWhitelistedVirtuousTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -852,7 +928,8 @@ func WhitelistConflictsTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -944,7 +1021,8 @@ func AcceptingDependencyTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -1085,7 +1163,8 @@ func AcceptingSlowDependencyTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -1231,7 +1310,8 @@ func RejectingDependencyTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -1332,7 +1412,8 @@ func VacuouslyAcceptedTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -1359,7 +1440,8 @@ func ConflictsTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -1410,7 +1492,8 @@ func VirtuousDependsOnRogueTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -1484,7 +1567,8 @@ func ErrorOnVacuouslyAcceptedTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -1514,7 +1598,8 @@ func ErrorOnAcceptedTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -1558,7 +1643,8 @@ func ErrorOnRejectingLowerConfidenceConflictTest(t *testing.T, factory Factory)
 
 	params := sbcon.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -1604,7 +1690,8 @@ func ErrorOnRejectingHigherConfidenceConflictTest(t *testing.T, factory Factory)
 
 	params := sbcon.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -1635,7 +1722,8 @@ func UTXOCleanupTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -1682,7 +1770,8 @@ func RemoveVirtuousTest(t *testing.T, factory Factory) {
 
 	params := sbcon.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -1711,7 +1800,8 @@ func StringTest(t *testing.T, factory Factory, prefix string) {
 
 	params := sbcon.Parameters{
 		K:                     2,
-		Alpha:                 2,
+		AlphaPreference:       2,
+		AlphaConfidence:       2,
 		BetaVirtuous:          1,
 		BetaRogue:             2,
 		ConcurrentRepolls:     1,
@@ -1936,3 +2026,46 @@ func StringTest(t *testing.T, factory Factory, prefix string) {
 		t.Fatalf("%s should have been rejected", Blue.ID())
 	}
 }
+
+func SetParametersTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	graph := factory.New()
+
+	params := sbcon.Parameters{
+		K:                     1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
+		BetaVirtuous:          1,
+		BetaRogue:             1,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(graph.Initialize(snow.DefaultConsensusContextTest(), params))
+	require.NoError(graph.Add(context.Background(), Red))
+	require.NoError(graph.Add(context.Background(), Green))
+
+	invalidParams := params
+	invalidParams.AlphaPreference = 0
+	require.Error(graph.SetParameters(invalidParams))
+
+	// The processing transactions use the new parameters from the next poll.
+	params.BetaVirtuous = 2
+	params.BetaRogue = 2
+	require.NoError(graph.SetParameters(params))
+
+	r := bag.Bag[ids.ID]{}
+	r.SetThreshold(1)
+	r.Add(Red.ID())
+	_, err := graph.RecordPoll(context.Background(), r)
+	require.NoError(err)
+	require.Equal(choices.Processing, Red.Status())
+	require.Equal(choices.Processing, Green.Status())
+
+	_, err = graph.RecordPoll(context.Background(), r)
+	require.NoError(err)
+	require.Equal(choices.Accepted, Red.Status())
+	require.Equal(choices.Rejected, Green.Status())
+}
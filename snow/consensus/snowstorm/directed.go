@@ -150,6 +150,17 @@ func (dg *Directed) Initialize(
 	return params.Verify()
 }
 
+func (dg *Directed) SetParameters(params sbcon.Parameters) error {
+	if err := params.Verify(); err != nil {
+		return err
+	}
+
+	// The snowball instances of the processing transactions don't hold any
+	// parameters of their own, so [params] applies to them from the next poll.
+	dg.params = params
+	return nil
+}
+
 func (dg *Directed) Virtuous() set.Set[ids.ID] {
 	return dg.virtuous
 }
@@ -456,9 +467,9 @@ func (dg *Directed) RecordPoll(ctx context.Context, votes bag.Bag[ids.ID]) (bool
 	// or if a tx was accepted.
 	changed := false
 
-	// We only want to iterate over txs that received alpha votes
-	votes.SetThreshold(dg.params.Alpha)
-	// Get the set of IDs that meet this alpha threshold
+	// We only want to iterate over txs that received alphaPreference votes
+	votes.SetThreshold(dg.params.AlphaPreference)
+	// Get the set of IDs that meet this alphaPreference threshold
 	metThreshold := votes.Threshold()
 	for txIDKey := range metThreshold {
 		// Get the node this tx represents
@@ -469,7 +480,11 @@ func (dg *Directed) RecordPoll(ctx context.Context, votes bag.Bag[ids.ID]) (bool
 			continue
 		}
 
-		txNode.recordSuccessfulPoll(dg.pollNumber)
+		if votes.Count(txIDKey) >= dg.params.AlphaConfidence {
+			txNode.recordSuccessfulPoll(dg.pollNumber)
+		} else {
+			txNode.recordPollPreference(dg.pollNumber)
+		}
 
 		// If the tx should be accepted, then we should defer its acceptance
 		// until its dependencies are decided. If this tx was already marked to
@@ -528,6 +543,19 @@ func (dg *Directed) String() string {
 	return consensusString(nodes)
 }
 
+func (dg *Directed) Snowball(txID ids.ID) (string, bool) {
+	txNode, exists := dg.txs[txID]
+	if !exists {
+		return "", false
+	}
+	node := &snowballNode{
+		txID:               txID,
+		numSuccessfulPolls: txNode.numSuccessfulPolls,
+		confidence:         txNode.getConfidence(dg.pollNumber),
+	}
+	return node.String(), true
+}
+
 // accept the named txID and remove it from the graph
 func (dg *Directed) accept(ctx context.Context, txID ids.ID) error {
 	txNode, exists := dg.txs[txID]
@@ -142,7 +142,7 @@ func (n *Network) Round() error {
 	_ = s.Initialize(uint64(len(n.nodes)))
 	indices, _ := s.Sample(n.params.K)
 	sampledColors := bag.Bag[ids.ID]{}
-	sampledColors.SetThreshold(n.params.Alpha)
+	sampledColors.SetThreshold(n.params.AlphaPreference)
 	for _, index := range indices {
 		peer := n.nodes[int(index)]
 		peerTxs := n.nodeTxs[int(index)]
@@ -4,8 +4,8 @@
 package snowstorm
 
 type snowball struct {
-	// numSuccessfulPolls is the number of times this choice was the successful
-	// result of a network poll
+	// numSuccessfulPolls is the number of times this choice received at least
+	// alphaPreference votes in a network poll
 	numSuccessfulPolls int
 
 	// confidence is the number of consecutive times this choice was the
@@ -42,6 +42,15 @@ func (sb *snowball) recordSuccessfulPoll(currentVote uint64) {
 	sb.confidence++
 }
 
+func (sb *snowball) recordPollPreference(currentVote uint64) {
+	// This choice was voted for in this poll, but not by enough validators to
+	// increase the confidence. So, only the snowball counter is increased and
+	// the snowflake counter is reset.
+	sb.lastVote = currentVote
+	sb.numSuccessfulPolls++
+	sb.confidence = 0
+}
+
 func (sb *snowball) finalized(betaVirtuous, betaRogue int) bool {
 	// This choice is finalized if the snowflake counter is at least
 	// [betaRogue]. If there are no known conflicts with this operation, it can
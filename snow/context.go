@@ -16,6 +16,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/warp"
 )
 
@@ -48,6 +49,9 @@ type Context struct {
 	BCLookup     ids.AliaserReader
 	Metrics      metrics.OptionalGatherer
 
+	// Clock that the chain's engine and VM timers run on
+	Clock mockable.Clock
+
 	WarpSigner warp.Signer
 
 	// snowman++ attributes
@@ -95,6 +99,10 @@ type ConsensusContext struct {
 
 	// True iff this chain is currently state-syncing
 	StateSyncing utils.Atomic[bool]
+
+	// BootstrapProgress reports how far this chain has progressed in
+	// bootstrapping.
+	BootstrapProgress utils.Atomic[BootstrapProgress]
 }
 
 func DefaultContextTest() *Context {
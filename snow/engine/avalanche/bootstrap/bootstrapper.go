@@ -21,6 +21,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/engine/avalanche/vertex"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/timer"
 	"github.com/MetalBlockchain/metalgo/version"
 )
 
@@ -95,12 +96,13 @@ func New(
 		return nil, err
 	}
 
-	if err := b.TxBlocked.SetParser(&txParser{
+	b.txParser = &txParser{
 		log:         config.Ctx.Log,
 		numAccepted: b.numAcceptedTxs,
 		numDropped:  b.numDroppedTxs,
 		vm:          b.VM,
-	}); err != nil {
+	}
+	if err := b.TxBlocked.SetParser(b.txParser); err != nil {
 		return nil, err
 	}
 
@@ -132,9 +134,17 @@ type bootstrapper struct {
 
 	// Contains IDs of vertices that have recently been processed
 	processedCache *cache.LRU[ids.ID, struct{}]
+
+	// txParser parses the transactions that are executed by [TxBlocked]
+	txParser *txParser
 	// number of state transitions executed
 	executedStateTransitions int
 
+	// Number of vertices that were fetched on ForceAccepted
+	initiallyFetched uint64
+	// Time that ForceAccepted was last called
+	startTime time.Time
+
 	awaitingTimeout bool
 }
 
@@ -358,6 +368,17 @@ func (b *bootstrapper) Start(ctx context.Context, startReqID uint32) error {
 
 	b.Config.SharedCfg.RequestID = startReqID
 
+	if cvVM, ok := b.VM.(vertex.ConcurrentVerifyDAGVM); ok {
+		verifyConcurrently, err := cvVM.ConcurrentTxVerifyEnabled(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check if transactions can be verified concurrently: %w", err)
+		}
+		b.txParser.verifyConcurrently = verifyConcurrently
+	}
+	b.Ctx.Log.Info("verifying transactions",
+		zap.Bool("verifyConcurrently", b.txParser.verifyConcurrently),
+	)
+
 	if !b.StartupTracker.ShouldStart() {
 		return nil
 	}
@@ -369,8 +390,9 @@ func (b *bootstrapper) Start(ctx context.Context, startReqID uint32) error {
 func (b *bootstrapper) HealthCheck(ctx context.Context) (interface{}, error) {
 	vmIntf, vmErr := b.VM.HealthCheck(ctx)
 	intf := map[string]interface{}{
-		"consensus": struct{}{},
-		"vm":        vmIntf,
+		"consensus":     struct{}{},
+		"vm":            vmIntf,
+		"bootstrapping": b.Ctx.BootstrapProgress.Get(),
 	}
 	return intf, vmErr
 }
@@ -488,6 +510,7 @@ func (b *bootstrapper) process(ctx context.Context, vtxs ...avalanche.Vertex) er
 
 			verticesFetchedSoFar := b.VtxBlocked.Jobs.PendingJobs()
 			if verticesFetchedSoFar%common.StatusUpdateFrequency == 0 { // Periodically print progress
+				b.reportFetchingProgress()
 				if !b.Config.SharedCfg.Restarted {
 					b.Ctx.Log.Info("fetched vertices",
 						zap.Uint64("numVerticesFetched", verticesFetchedSoFar),
@@ -562,9 +585,24 @@ func (b *bootstrapper) ForceAccepted(ctx context.Context, acceptedContainerIDs [
 			b.needToFetch.Add(vtxID) // We don't have this vertex. Mark that we have to fetch it.
 		}
 	}
+
+	b.initiallyFetched = b.VtxBlocked.PendingJobs()
+	b.startTime = time.Now()
+	b.reportFetchingProgress()
 	return b.process(ctx, toProcess...)
 }
 
+// reportFetchingProgress updates the bootstrapping progress of this chain to
+// report that it is fetching vertices.
+func (b *bootstrapper) reportFetchingProgress() {
+	fetched := b.VtxBlocked.PendingJobs()
+	b.Ctx.BootstrapProgress.Set(snow.BootstrapProgress{
+		Phase:   snow.PhaseFetching,
+		Fetched: fetched,
+		Rate:    timer.EstimateRate(b.startTime, fetched-b.initiallyFetched),
+	})
+}
+
 // checkFinish repeatedly executes pending transactions and requests new frontier blocks until there aren't any new ones
 // after which it finishes the bootstrap process
 func (b *bootstrapper) checkFinish(ctx context.Context) error {
@@ -9,6 +9,10 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
 	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/database/prefixdb"
 	"github.com/MetalBlockchain/metalgo/ids"
@@ -23,6 +27,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/engine/common/queue"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
 )
 
 var (
@@ -1324,3 +1329,68 @@ func TestRestartBootstrapping(t *testing.T) {
 		t.Fatalf("Vertex should be accepted")
 	}
 }
+
+// Test that transactions parsed for a VM that supports concurrent verification
+// are verified in a separate step from being accepted.
+func TestTxParserVerifyConcurrently(t *testing.T) {
+	require := require.New(t)
+
+	errVerify := errors.New("failed to verify")
+	txs := map[byte]*snowstorm.TestTx{
+		0: {
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			BytesV: []byte{0},
+		},
+		1: {
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			VerifyV: errVerify,
+			BytesV:  []byte{1},
+		},
+	}
+
+	vm := &vertex.TestVM{}
+	vm.T = t
+	vm.ParseTxF = func(_ context.Context, b []byte) (snowstorm.Tx, error) {
+		tx, ok := txs[b[0]]
+		require.True(ok)
+		return tx, nil
+	}
+
+	parser := &txParser{
+		log:                logging.NoLog{},
+		numAccepted:        prometheus.NewCounter(prometheus.CounterOpts{}),
+		numDropped:         prometheus.NewCounter(prometheus.CounterOpts{}),
+		vm:                 vm,
+		verifyConcurrently: true,
+	}
+
+	job, err := parser.Parse(context.Background(), []byte{0})
+	require.NoError(err)
+	parallelJob, ok := job.(queue.ParallelJob)
+	require.True(ok)
+	require.NoError(parallelJob.Verify(context.Background()))
+
+	// The transaction must not be re-verified when it is executed.
+	txs[0].VerifyV = errVerify
+	require.NoError(parallelJob.Execute(context.Background()))
+	require.Equal(choices.Accepted, txs[0].Status())
+
+	job, err = parser.Parse(context.Background(), []byte{1})
+	require.NoError(err)
+	parallelJob, ok = job.(queue.ParallelJob)
+	require.True(ok)
+	require.ErrorIs(parallelJob.Verify(context.Background()), errVerify)
+	require.Equal(choices.Processing, txs[1].Status())
+
+	parser.verifyConcurrently = false
+	job, err = parser.Parse(context.Background(), []byte{1})
+	require.NoError(err)
+	_, ok = job.(queue.ParallelJob)
+	require.False(ok)
+}
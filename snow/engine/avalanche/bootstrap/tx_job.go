@@ -21,12 +21,21 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/set"
 )
 
-var errMissingTxDependenciesOnAccept = errors.New("attempting to accept a transaction with missing dependencies")
+var (
+	_ queue.Job         = (*txJob)(nil)
+	_ queue.ParallelJob = (*parallelTxJob)(nil)
+
+	errMissingTxDependenciesOnAccept = errors.New("attempting to accept a transaction with missing dependencies")
+)
 
 type txParser struct {
 	log                     logging.Logger
 	numAccepted, numDropped prometheus.Counter
 	vm                      vertex.LinearizableVM
+
+	// verifyConcurrently is true if the VM allows transactions to be
+	// verified concurrently.
+	verifyConcurrently bool
 }
 
 func (p *txParser) Parse(ctx context.Context, txBytes []byte) (queue.Job, error) {
@@ -34,18 +43,40 @@ func (p *txParser) Parse(ctx context.Context, txBytes []byte) (queue.Job, error)
 	if err != nil {
 		return nil, err
 	}
-	return &txJob{
+	job := &txJob{
 		log:         p.log,
 		numAccepted: p.numAccepted,
 		numDropped:  p.numDropped,
 		tx:          tx,
-	}, nil
+	}
+	if p.verifyConcurrently {
+		return &parallelTxJob{txJob: job}, nil
+	}
+	return job, nil
 }
 
 type txJob struct {
 	log                     logging.Logger
 	numAccepted, numDropped prometheus.Counter
 	tx                      snowstorm.Tx
+
+	// verified is true if [tx] has been verified, and only needs to be
+	// accepted when this job is executed.
+	verified bool
+}
+
+// parallelTxJob is a txJob whose transaction can be verified concurrently with
+// the transactions of other jobs.
+type parallelTxJob struct {
+	*txJob
+}
+
+func (t *parallelTxJob) Verify(ctx context.Context) error {
+	if err := t.verify(ctx); err != nil {
+		return err
+	}
+	t.verified = true
+	return nil
 }
 
 func (t *txJob) ID() ids.ID {
@@ -81,6 +112,34 @@ func (t *txJob) HasMissingDependencies(context.Context) (bool, error) {
 }
 
 func (t *txJob) Execute(ctx context.Context) error {
+	if !t.verified {
+		if err := t.verify(ctx); err != nil {
+			return err
+		}
+	}
+
+	if t.tx.Status() != choices.Processing {
+		// The transaction was previously accepted.
+		return nil
+	}
+
+	txID := t.tx.ID()
+	t.numAccepted.Inc()
+	t.log.Trace("accepting transaction in bootstrapping",
+		zap.Stringer("txID", txID),
+	)
+	if err := t.tx.Accept(ctx); err != nil {
+		t.log.Error("transaction failed to accept during bootstrapping",
+			zap.Stringer("txID", txID),
+			zap.Error(err),
+		)
+		return fmt.Errorf("failed to accept transaction in bootstrapping: %w", err)
+	}
+	return nil
+}
+
+// verify checks that [tx] can be accepted.
+func (t *txJob) verify(ctx context.Context) error {
 	hasMissingDeps, err := t.HasMissingDependencies(ctx)
 	if err != nil {
 		return err
@@ -96,26 +155,13 @@ func (t *txJob) Execute(ctx context.Context) error {
 		t.numDropped.Inc()
 		return fmt.Errorf("attempting to execute transaction with status %s", status)
 	case choices.Processing:
-		txID := t.tx.ID()
 		if err := t.tx.Verify(ctx); err != nil {
 			t.log.Error("transaction failed verification during bootstrapping",
-				zap.Stringer("txID", txID),
+				zap.Stringer("txID", t.tx.ID()),
 				zap.Error(err),
 			)
 			return fmt.Errorf("failed to verify transaction in bootstrapping: %w", err)
 		}
-
-		t.numAccepted.Inc()
-		t.log.Trace("accepting transaction in bootstrapping",
-			zap.Stringer("txID", txID),
-		)
-		if err := t.tx.Accept(ctx); err != nil {
-			t.log.Error("transaction failed to accept during bootstrapping",
-				zap.Stringer("txID", txID),
-				zap.Error(err),
-			)
-			return fmt.Errorf("failed to accept transaction in bootstrapping: %w", err)
-		}
 	}
 	return nil
 }
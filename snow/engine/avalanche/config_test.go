@@ -39,7 +39,8 @@ func DefaultConfig() (common.Config, bootstrap.Config, Config) {
 		Params: avalanche.Parameters{
 			Parameters: snowball.Parameters{
 				K:                       1,
-				Alpha:                   1,
+				AlphaPreference:         1,
+				AlphaConfidence:         1,
 				BetaVirtuous:            1,
 				BetaRogue:               2,
 				ConcurrentRepolls:       1,
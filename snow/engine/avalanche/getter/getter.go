@@ -89,6 +89,19 @@ func (gh *getter) GetAccepted(ctx context.Context, nodeID ids.NodeID, requestID
 	return nil
 }
 
+// GetAncestorsAtHeight replies with an empty Ancestors message, as vertices
+// aren't indexed by height.
+func (gh *getter) GetAncestorsAtHeight(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) error {
+	gh.log.Verbo("dropping GetAncestorsAtHeight message",
+		zap.String("reason", "vertices aren't indexed by height"),
+		zap.Stringer("nodeID", nodeID),
+		zap.Uint32("requestID", requestID),
+		zap.Uint64("height", height),
+	)
+	gh.sender.SendAncestors(ctx, nodeID, requestID, nil)
+	return nil
+}
+
 func (gh *getter) GetAncestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, vtxID ids.ID) error {
 	startTime := time.Now()
 	gh.log.Verbo("called GetAncestors",
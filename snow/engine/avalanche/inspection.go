@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avalanche
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche/poll"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common/tracker"
+	"github.com/MetalBlockchain/metalgo/snow/events"
+	"github.com/MetalBlockchain/metalgo/utils"
+)
+
+var _ common.Inspector = (*Transitive)(nil)
+
+// Inspection is a snapshot of the state of the Transitive engine.
+type Inspection struct {
+	// Vertices are the processing vertices, sorted by height.
+	Vertices []avalanche.VertexState `json:"vertices"`
+
+	// Polls are the outstanding polls, from oldest to newest.
+	Polls []poll.Info `json:"polls"`
+
+	// Pending are the vertices waiting on their dependencies to be issued.
+	Pending []ids.ID `json:"pending"`
+
+	// MissingTxs are the transactions that pending vertices are waiting on.
+	MissingTxs []ids.ID `json:"missingTxs"`
+
+	// BlockedOnVertices are the operations waiting on vertices to be issued.
+	BlockedOnVertices []events.Job `json:"blockedOnVertices"`
+
+	// BlockedOnTxs are the operations waiting on transactions to be issued.
+	BlockedOnTxs []events.Job `json:"blockedOnTxs"`
+
+	// Voters are the response stats of the validators that have been polled.
+	Voters []tracker.VoterStats `json:"voters"`
+
+	// Graphviz describes the processing vertices in the DOT language, if it
+	// was requested.
+	Graphviz string `json:"graphviz,omitempty"`
+}
+
+func (t *Transitive) Inspect(ctx context.Context, graphviz bool) (interface{}, error) {
+	vertices, err := t.Consensus.Inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := t.pending.List()
+	utils.Sort(pending)
+	missingTxs := t.missingTxs.List()
+	utils.Sort(missingTxs)
+
+	inspection := &Inspection{
+		Vertices:          vertices,
+		Polls:             t.polls.Inspect(),
+		Pending:           pending,
+		MissingTxs:        missingTxs,
+		BlockedOnVertices: t.vtxBlocked.Jobs(),
+		BlockedOnTxs:      t.txBlocked.Jobs(),
+		Voters:            t.voters.Stats(),
+	}
+	if graphviz {
+		inspection.Graphviz = verticesGraphviz(vertices)
+	}
+	return inspection, nil
+}
+
+// verticesGraphviz describes [vertices] as a DAG in the DOT language. Strongly
+// preferred vertices are bold and strongly virtuous vertices are boxed.
+// Accepted parents are drawn as points.
+func verticesGraphviz(vertices []avalanche.VertexState) string {
+	declared := make(map[ids.ID]struct{}, len(vertices))
+	for _, vtx := range vertices {
+		declared[vtx.ID] = struct{}{}
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("digraph avalanche {\n")
+	for _, vtx := range vertices {
+		attributes := fmt.Sprintf("label=\"%s\\nheight %d\\n%d txs\"", vtx.ID, vtx.Height, len(vtx.TxIDs))
+		if vtx.Virtuous {
+			attributes += ", shape=box"
+		}
+		if vtx.Preferred {
+			attributes += ", style=bold"
+		}
+		sb.WriteString(fmt.Sprintf("    %q [%s];\n", vtx.ID, attributes))
+		for _, parentID := range vtx.ParentIDs {
+			if _, ok := declared[parentID]; !ok {
+				declared[parentID] = struct{}{}
+				sb.WriteString(fmt.Sprintf("    %q [shape=point];\n", parentID))
+			}
+			sb.WriteString(fmt.Sprintf("    %q -> %q;\n", parentID, vtx.ID))
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
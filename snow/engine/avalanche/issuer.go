@@ -5,6 +5,7 @@ package avalanche
 
 import (
 	"context"
+	"fmt"
 
 	"go.uber.org/zap"
 
@@ -123,7 +124,10 @@ func (i *issuer) Update(ctx context.Context) {
 	)
 
 	// Add this vertex to consensus.
-	if err := i.t.Consensus.Add(ctx, i.vtx); err != nil {
+	if err := i.t.Consensus.Add(ctx, &votedVertex{
+		Vertex: i.vtx,
+		voters: i.t.voters,
+	}); err != nil {
 		i.t.errs.Add(err)
 		return
 	}
@@ -141,7 +145,12 @@ func (i *issuer) Update(ctx context.Context) {
 	vdrBag.Add(vdrIDs...)
 
 	i.t.RequestID++
-	if err == nil && i.t.polls.Add(i.t.RequestID, vdrBag) {
+	// New polls are held back until the pending parameters are applied.
+	if err == nil && i.t.pendingParams == nil && i.t.polls.Add(i.t.RequestID, vdrBag) {
+		for _, vdrID := range vdrBag.List() {
+			i.t.voters.Queried(vdrID, i.t.RequestID)
+		}
+
 		numPushTo := i.t.Params.MixedQueryNumPushVdr
 		if !i.t.Validators.Contains(i.t.Ctx.NodeID) {
 			numPushTo = i.t.Params.MixedQueryNumPushNonVdr
@@ -182,6 +191,10 @@ func (vi *vtxIssuer) Dependencies() set.Set[ids.ID] {
 	return vi.i.vtxDeps
 }
 
+func (vi *vtxIssuer) String() string {
+	return fmt.Sprintf("issue vertex %s after its parents", vi.i.vtx.ID())
+}
+
 func (vi *vtxIssuer) Fulfill(ctx context.Context, id ids.ID) {
 	vi.i.FulfillVtx(ctx, id)
 }
@@ -200,6 +213,10 @@ func (ti *txIssuer) Dependencies() set.Set[ids.ID] {
 	return ti.i.txDeps
 }
 
+func (ti *txIssuer) String() string {
+	return fmt.Sprintf("issue vertex %s after its transactions", ti.i.vtx.ID())
+}
+
 func (ti *txIssuer) Fulfill(ctx context.Context, id ids.ID) {
 	ti.i.FulfillTx(ctx, id)
 }
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avalanche
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche/poll"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+)
+
+var _ common.Reconfigurer = (*Transitive)(nil)
+
+// Reconfigure schedules [params] to be applied once all the outstanding polls
+// have finished. No new polls are issued until then.
+func (t *Transitive) Reconfigure(_ context.Context, params avalanche.Parameters) error {
+	if err := params.Valid(); err != nil {
+		return err
+	}
+
+	t.pendingParams = &params
+	return t.applyPendingParams()
+}
+
+// applyPendingParams applies the pending parameters if there are no
+// outstanding polls.
+func (t *Transitive) applyPendingParams() error {
+	if t.pendingParams == nil || t.polls.Len() != 0 {
+		return nil
+	}
+
+	params := *t.pendingParams
+	t.pendingParams = nil
+
+	// Consensus is only initialized once the engine has started. Until then,
+	// the parameters only need to be recorded for the initialization.
+	state := t.Ctx.State.Get()
+	if state.Type == p2p.EngineType_ENGINE_TYPE_AVALANCHE && state.State == snow.NormalOp {
+		if err := t.Consensus.SetParameters(params); err != nil {
+			return err
+		}
+	}
+
+	t.Params = params
+	t.polls.SetFactory(poll.NewEarlyTermNoTraversalFactory(
+		params.AlphaPreference,
+		params.AlphaConfidence,
+	))
+
+	t.Ctx.Log.Info("applied consensus parameters",
+		zap.Reflect("parameters", params),
+	)
+	return nil
+}
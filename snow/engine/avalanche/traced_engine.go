@@ -16,7 +16,11 @@ import (
 	"github.com/MetalBlockchain/metalgo/trace"
 )
 
-var _ Engine = (*tracedEngine)(nil)
+var (
+	_ Engine              = (*tracedEngine)(nil)
+	_ common.Inspector    = (*tracedEngine)(nil)
+	_ common.Reconfigurer = (*tracedEngine)(nil)
+)
 
 type tracedEngine struct {
 	common.Engine
@@ -40,3 +44,17 @@ func (e *tracedEngine) GetVtx(ctx context.Context, vtxID ids.ID) (avalanche.Vert
 
 	return e.engine.GetVtx(ctx, vtxID)
 }
+
+func (e *tracedEngine) Inspect(ctx context.Context, graphviz bool) (interface{}, error) {
+	ctx, span := e.tracer.Start(ctx, "tracedEngine.Inspect")
+	defer span.End()
+
+	return common.Inspect(ctx, e.engine, graphviz)
+}
+
+func (e *tracedEngine) Reconfigure(ctx context.Context, params avalanche.Parameters) error {
+	ctx, span := e.tracer.Start(ctx, "tracedEngine.Reconfigure")
+	defer span.End()
+
+	return common.Reconfigure(ctx, e.engine, params)
+}
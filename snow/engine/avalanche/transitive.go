@@ -59,6 +59,13 @@ type Transitive struct {
 
 	polls poll.Set // track people I have asked for their preference
 
+	// consensus parameters that will be applied once the outstanding polls
+	// have finished
+	pendingParams *avalanche.Parameters
+
+	// tracks the quality of the responses of the validators we poll
+	voters tracker.Voters
+
 	// The set of vertices that have been requested in Get messages but not yet received
 	outstandingVtxReqs common.Requests
 
@@ -95,7 +102,15 @@ func newTransitive(
 	acceptedFrontiers := tracker.NewAccepted()
 	config.Validators.RegisterCallbackListener(acceptedFrontiers)
 
-	factory := poll.NewEarlyTermNoTraversalFactory(config.Params.Alpha)
+	voters, err := tracker.NewVoters("", config.Ctx.AvalancheRegisterer)
+	if err != nil {
+		return nil, err
+	}
+
+	factory := poll.NewEarlyTermNoTraversalFactory(
+		config.Params.AlphaPreference,
+		config.Params.AlphaConfidence,
+	)
 
 	t := &Transitive{
 		Config:                      config,
@@ -107,6 +122,7 @@ func newTransitive(
 		AppHandler:                  config.VM,
 		Connector:                   config.VM,
 		acceptedFrontiers:           acceptedFrontiers,
+		voters:                      voters,
 		polls: poll.NewSet(factory,
 			config.Ctx.Log,
 			"",
@@ -300,6 +316,7 @@ func (t *Transitive) Chits(ctx context.Context, nodeID ids.NodeID, requestID uin
 	}
 
 	t.acceptedFrontiers.SetAcceptedFrontier(nodeID, accepted)
+	t.voters.Responded(nodeID, requestID, votes...)
 
 	v := &voter{
 		t:         t,
@@ -330,6 +347,8 @@ func (t *Transitive) QueryFailed(ctx context.Context, nodeID ids.NodeID, request
 		return nil
 	}
 
+	t.voters.Failed(nodeID, requestID)
+
 	lastAccepted := t.acceptedFrontiers.AcceptedFrontier(nodeID)
 	return t.Chits(ctx, nodeID, requestID, lastAccepted, lastAccepted)
 }
@@ -441,6 +460,12 @@ func (t *Transitive) Start(ctx context.Context, startReqID uint32) error {
 		Type:  p2p.EngineType_ENGINE_TYPE_AVALANCHE,
 		State: snow.NormalOp,
 	})
+
+	progress := t.Ctx.BootstrapProgress.Get()
+	progress.Phase = snow.PhaseDone
+	progress.ETA = 0
+	t.Ctx.BootstrapProgress.Set(progress)
+
 	if err := t.VM.SetState(ctx, snow.NormalOp); err != nil {
 		return fmt.Errorf("failed to notify VM that consensus has started: %w",
 			err)
@@ -694,6 +719,11 @@ func (t *Transitive) batch(ctx context.Context, txs []snowstorm.Tx, opt batchOpt
 
 // Issues a new poll for a preferred vertex in order to move consensus along
 func (t *Transitive) issueRepoll(ctx context.Context) {
+	// New polls are held back until the pending parameters are applied.
+	if t.pendingParams != nil {
+		return
+	}
+
 	preferredIDs := t.Consensus.Preferences()
 	if preferredIDs.Len() == 0 {
 		t.Ctx.Log.Error("re-query attempt was dropped due to no pending vertices")
@@ -721,6 +751,9 @@ func (t *Transitive) issueRepoll(ctx context.Context) {
 	// Poll the network
 	t.RequestID++
 	if t.polls.Add(t.RequestID, vdrBag) {
+		for _, vdrID := range vdrList {
+			t.voters.Queried(vdrID, t.RequestID)
+		}
 		t.Sender.SendPullQuery(ctx, vdrSet, t.RequestID, vtxID)
 	}
 }
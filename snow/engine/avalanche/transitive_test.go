@@ -479,7 +479,8 @@ func TestEngineMultipleQuery(t *testing.T) {
 	engCfg.Params = avalanche.Parameters{
 		Parameters: snowball.Parameters{
 			K:                       3,
-			Alpha:                   2,
+			AlphaPreference:         2,
+			AlphaConfidence:         2,
 			BetaVirtuous:            1,
 			BetaRogue:               2,
 			ConcurrentRepolls:       1,
@@ -4192,7 +4193,8 @@ func TestEngineDuplicatedIssuance(t *testing.T) {
 func TestEngineDoubleChit(t *testing.T) {
 	_, _, engCfg := DefaultConfig()
 
-	engCfg.Params.Alpha = 2
+	engCfg.Params.AlphaPreference = 2
+	engCfg.Params.AlphaConfidence = 2
 	engCfg.Params.K = 2
 	engCfg.Params.MixedQueryNumPushNonVdr = 2
 
@@ -4779,7 +4781,8 @@ func TestSendMixedQuery(t *testing.T) {
 				// Override the parameters k, MixedQueryNumPushVdr, MixedQueryNumPushNonVdr,
 				// and update the validator set to have k validators.
 				engCfg.Params.K = 20
-				engCfg.Params.Alpha = 12
+				engCfg.Params.AlphaPreference = 12
+				engCfg.Params.AlphaConfidence = 12
 				engCfg.Params.MixedQueryNumPushVdr = 12
 				engCfg.Params.MixedQueryNumPushNonVdr = 11
 				te, err := newTransitive(engCfg, noopStarter)
@@ -5016,3 +5019,229 @@ func TestEngineApplyAcceptedFrontierInQueryFailed(t *testing.T) {
 
 	require.Equal(choices.Accepted, vtx.Status())
 }
+
+func TestEngineInspect(t *testing.T) {
+	require := require.New(t)
+
+	_, _, engCfg := DefaultConfig()
+
+	vals := validators.NewSet()
+	engCfg.Validators = vals
+
+	vdr := ids.GenerateTestNodeID()
+	require.NoError(vals.Add(vdr, nil, ids.Empty, 1))
+
+	manager := vertex.NewTestManager(t)
+	engCfg.Manager = manager
+
+	gVtx := &avalanche.TestVertex{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Accepted,
+	}}
+
+	tx0 := &snowstorm.TestTx{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Processing,
+	}}
+	tx0.InputIDsV = append(tx0.InputIDsV, ids.GenerateTestID())
+
+	vtx0 := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: []avalanche.Vertex{gVtx},
+		HeightV:  1,
+		TxsV:     []snowstorm.Tx{tx0},
+	}
+	vtx1 := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: []avalanche.Vertex{
+			&avalanche.TestVertex{TestDecidable: choices.TestDecidable{
+				IDV:     vtx0.IDV,
+				StatusV: choices.Unknown,
+			}},
+		},
+		HeightV: 2,
+	}
+
+	te, err := newTransitive(engCfg, noopStarter)
+	require.NoError(err)
+	require.NoError(te.Start(context.Background(), 0))
+
+	require.NoError(te.issue(context.Background(), vtx1))
+
+	intf, err := te.Inspect(context.Background(), false)
+	require.NoError(err)
+	inspection := intf.(*Inspection)
+	require.Empty(inspection.Vertices)
+	require.Equal([]ids.ID{vtx1.ID()}, inspection.Pending)
+	require.Len(inspection.BlockedOnVertices, 1)
+	require.Equal([]ids.ID{vtx0.ID()}, inspection.BlockedOnVertices[0].Dependencies)
+	require.Empty(inspection.Graphviz)
+
+	vtx1.ParentsV[0] = vtx0
+	require.NoError(te.issue(context.Background(), vtx0))
+
+	intf, err = te.Inspect(context.Background(), true)
+	require.NoError(err)
+	inspection = intf.(*Inspection)
+	require.Len(inspection.Vertices, 2)
+	require.Equal(vtx0.ID(), inspection.Vertices[0].ID)
+	require.Equal([]ids.ID{tx0.ID()}, inspection.Vertices[0].TxIDs)
+	require.Equal(vtx1.ID(), inspection.Vertices[1].ID)
+	require.NotEmpty(inspection.Polls)
+	require.Len(inspection.Voters, 1)
+	require.Equal(vdr, inspection.Voters[0].NodeID)
+	require.EqualValues(len(inspection.Polls), inspection.Voters[0].Queries)
+	require.Zero(inspection.Voters[0].Responses)
+	require.Empty(inspection.Pending)
+	require.Empty(inspection.BlockedOnVertices)
+	require.Empty(inspection.BlockedOnTxs)
+	require.Contains(inspection.Graphviz, fmt.Sprintf("%q -> %q", gVtx.ID(), vtx0.ID()))
+	require.Contains(inspection.Graphviz, fmt.Sprintf("%q -> %q", vtx0.ID(), vtx1.ID()))
+}
+
+func TestEngineVoterStats(t *testing.T) {
+	require := require.New(t)
+
+	_, _, engCfg := DefaultConfig()
+
+	vals := validators.NewSet()
+	engCfg.Validators = vals
+
+	vdr := ids.GenerateTestNodeID()
+	require.NoError(vals.Add(vdr, nil, ids.Empty, 1))
+
+	sender := &common.SenderTest{T: t}
+	sender.Default(true)
+	sender.CantSendGetAcceptedFrontier = false
+	engCfg.Sender = sender
+
+	manager := vertex.NewTestManager(t)
+	manager.Default(true)
+	engCfg.Manager = manager
+
+	gVtx := &avalanche.TestVertex{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Accepted,
+	}}
+
+	tx0 := &snowstorm.TestTx{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Processing,
+	}}
+	tx0.InputIDsV = append(tx0.InputIDsV, ids.GenerateTestID())
+
+	vtx0 := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: []avalanche.Vertex{gVtx},
+		HeightV:  1,
+		TxsV:     []snowstorm.Tx{tx0},
+		BytesV:   []byte{0},
+	}
+
+	manager.EdgeF = func(context.Context) []ids.ID {
+		return []ids.ID{gVtx.ID()}
+	}
+	manager.GetVtxF = func(_ context.Context, vtxID ids.ID) (avalanche.Vertex, error) {
+		switch vtxID {
+		case gVtx.ID():
+			return gVtx, nil
+		case vtx0.ID():
+			return vtx0, nil
+		default:
+			return nil, errUnknownVertex
+		}
+	}
+
+	te, err := newTransitive(engCfg, noopStarter)
+	require.NoError(err)
+	require.NoError(te.Start(context.Background(), 0))
+
+	var queryRequestID uint32
+	sender.SendPushQueryF = func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ []byte) {
+		queryRequestID = requestID
+	}
+
+	require.NoError(te.issue(context.Background(), vtx0))
+	require.NoError(te.Chits(context.Background(), vdr, queryRequestID, []ids.ID{vtx0.ID()}, nil))
+	require.Equal(choices.Accepted, vtx0.Status())
+
+	intf, err := te.Inspect(context.Background(), false)
+	require.NoError(err)
+	inspection := intf.(*Inspection)
+	require.Len(inspection.Voters, 1)
+
+	stats := inspection.Voters[0]
+	require.Equal(vdr, stats.NodeID)
+	require.EqualValues(1, stats.Queries)
+	require.EqualValues(1, stats.Responses)
+	require.EqualValues(1, stats.Agreements)
+	require.Zero(stats.Disagreements)
+}
+
+func TestEngineReconfigure(t *testing.T) {
+	require := require.New(t)
+
+	_, _, engCfg := DefaultConfig()
+
+	vals := validators.NewSet()
+	engCfg.Validators = vals
+
+	vdr := ids.GenerateTestNodeID()
+	require.NoError(vals.Add(vdr, nil, ids.Empty, 1))
+
+	manager := vertex.NewTestManager(t)
+	engCfg.Manager = manager
+
+	gVtx := &avalanche.TestVertex{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Accepted,
+	}}
+
+	tx0 := &snowstorm.TestTx{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Processing,
+	}}
+	tx0.InputIDsV = append(tx0.InputIDsV, ids.GenerateTestID())
+
+	vtx0 := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentsV: []avalanche.Vertex{gVtx},
+		HeightV:  1,
+		TxsV:     []snowstorm.Tx{tx0},
+	}
+
+	te, err := newTransitive(engCfg, noopStarter)
+	require.NoError(err)
+	require.NoError(te.Start(context.Background(), 0))
+
+	params := te.Params
+	params.BatchSize++
+
+	invalidParams := params
+	invalidParams.Parents = 0
+	require.Error(te.Reconfigure(context.Background(), invalidParams))
+
+	// Without outstanding polls, the parameters are applied immediately.
+	require.NoError(te.Reconfigure(context.Background(), params))
+	require.Equal(params, te.Params)
+
+	require.NoError(te.issue(context.Background(), vtx0))
+	require.Equal(1, te.polls.Len())
+
+	// The parameters can't be applied while a poll is outstanding.
+	params.BatchSize++
+	require.NoError(te.Reconfigure(context.Background(), params))
+	require.NotEqual(params, te.Params)
+}
@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vertex
+
+import "context"
+
+// ConcurrentVerifyDAGVM defines the interface a DAGVM can optionally implement
+// to allow transactions to be verified concurrently during bootstrapping.
+type ConcurrentVerifyDAGVM interface {
+	// ConcurrentTxVerifyEnabled returns true if Verify may be called
+	// concurrently on different transactions.
+	//
+	// It is still guaranteed that the dependencies of a transaction have been
+	// accepted before Verify is called on the transaction. While transactions
+	// are being verified concurrently, the only calls made into the VM are
+	// Verify, Status and Dependencies on the transactions being verified.
+	// Transactions are always accepted one at a time, in a deterministic order.
+	ConcurrentTxVerifyEnabled(context.Context) (bool, error)
+}
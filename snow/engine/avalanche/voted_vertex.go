@@ -0,0 +1,35 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avalanche
+
+import (
+	"context"
+
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common/tracker"
+)
+
+var _ avalanche.Vertex = (*votedVertex)(nil)
+
+// votedVertex wraps an avalanche Vertex to report the decision of the vertex
+// to the voter tracker
+type votedVertex struct {
+	avalanche.Vertex
+
+	voters tracker.Voters
+}
+
+// Accept reports the acceptance to the voter tracker & accepts the underlying
+// vertex
+func (vv *votedVertex) Accept(ctx context.Context) error {
+	vv.voters.Decided(vv.ID(), true)
+	return vv.Vertex.Accept(ctx)
+}
+
+// Reject reports the rejection to the voter tracker & rejects the underlying
+// vertex
+func (vv *votedVertex) Reject(ctx context.Context) error {
+	vv.voters.Decided(vv.ID(), false)
+	return vv.Vertex.Reject(ctx)
+}
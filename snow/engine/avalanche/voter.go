@@ -5,6 +5,7 @@ package avalanche
 
 import (
 	"context"
+	"fmt"
 
 	"go.uber.org/zap"
 
@@ -24,6 +25,10 @@ type voter struct {
 	deps      set.Set[ids.ID]
 }
 
+func (v *voter) String() string {
+	return fmt.Sprintf("record votes from %s for request %d", v.vdr, v.requestID)
+}
+
 func (v *voter) Dependencies() set.Set[ids.ID] {
 	return v.deps
 }
@@ -76,6 +81,12 @@ func (v *voter) Update(ctx context.Context) {
 		}
 	}
 
+	// The finished polls may have been the last ones outstanding.
+	if err := v.t.applyPendingParams(); err != nil {
+		v.t.errs.Add(err)
+		return
+	}
+
 	linearized, err := v.t.Manager.StopVertexAccepted(ctx)
 	if err != nil {
 		v.t.errs.Add(err)
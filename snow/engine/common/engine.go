@@ -252,6 +252,18 @@ type GetAncestorsHandler interface {
 	// effort attempt at getting them. If this engine doesn't have [containerID]
 	// it can ignore this message.
 	GetAncestors(ctx context.Context, validatorID ids.NodeID, requestID uint32, containerID ids.ID) error
+
+	// Notify this engine of a request for the container accepted at [height]
+	// and its ancestors.
+	//
+	// This function can be called by any validator. It is not safe to assume
+	// this message is utilizing a unique requestID.
+	//
+	// This engine should respond like it would to a GetAncestors message for
+	// the container accepted at [height]. If this engine can't look up accepted
+	// containers by height, it should reply with an empty Ancestors message so
+	// that the requester doesn't wait for a response.
+	GetAncestorsAtHeight(ctx context.Context, validatorID ids.NodeID, requestID uint32, height uint64) error
 }
 
 // AncestorsHandler defines how a consensus engine reacts to bootstrapping
@@ -0,0 +1,29 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrNotInspectable = errors.New("engine doesn't support inspection")
+
+// Inspector is implemented by engines that can report a snapshot of their
+// internal state, to help debug chains that have stalled.
+type Inspector interface {
+	// Inspect returns a JSON serializable snapshot of the engine's state. If
+	// [graphviz] is true, the snapshot additionally describes the processing
+	// containers in the Graphviz DOT language.
+	Inspect(ctx context.Context, graphviz bool) (interface{}, error)
+}
+
+// Inspect returns the snapshot of [engine]'s state if it implements Inspector.
+func Inspect(ctx context.Context, engine Engine, graphviz bool) (interface{}, error) {
+	inspector, ok := engine.(Inspector)
+	if !ok {
+		return nil, ErrNotInspectable
+	}
+	return inspector.Inspect(ctx, graphviz)
+}
@@ -19,3 +19,14 @@ type Job interface {
 	Execute(context.Context) error
 	Bytes() []byte
 }
+
+// ParallelJob is a Job that can be verified concurrently with other jobs.
+//
+// Verify is called before Execute, and may be called concurrently with the
+// Verify of other jobs whose dependencies have been executed. Verify is never
+// called concurrently with Execute. Jobs are always executed one at a time, in
+// a deterministic order.
+type ParallelJob interface {
+	Job
+	Verify(context.Context) error
+}
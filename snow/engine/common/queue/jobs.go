@@ -22,7 +22,13 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/wrappers"
 )
 
-const progressUpdateFrequency = 30 * time.Second
+const (
+	progressUpdateFrequency = 30 * time.Second
+
+	// maxBatchSize is the maximum number of jobs that are verified
+	// concurrently before being executed.
+	maxBatchSize = 256
+)
 
 // Jobs tracks a series of jobs that form a DAG of dependencies.
 type Jobs struct {
@@ -124,6 +130,7 @@ func (j *Jobs) ExecuteAll(
 	numToExecute := j.state.numJobs
 	startTime := time.Now()
 	lastProgressUpdate := startTime
+	reportProgress(chainCtx, numExecuted, 0, 0)
 
 	// Disable and clear state caches to prevent us from attempting to execute
 	// a vertex that was previously parsed, but not saved to the VM. Some VMs
@@ -141,84 +148,97 @@ func (j *Jobs) ExecuteAll(
 			return numExecuted, nil
 		}
 
-		job, err := j.state.RemoveRunnableJob(ctx)
-		if err == database.ErrNotFound {
-			break
-		}
+		batch, err := j.removeRunnableBatch(ctx)
 		if err != nil {
-			return 0, fmt.Errorf("failed to removing runnable job with %w", err)
-		}
-
-		jobID := job.ID()
-		chainCtx.Log.Debug("executing",
-			zap.Stringer("jobID", jobID),
-		)
-		jobBytes := job.Bytes()
-		// Note that acceptor.Accept must be called before executing [job] to
-		// honor Acceptor.Accept's invariant.
-		for _, acceptor := range acceptors {
-			if err := acceptor.Accept(chainCtx, jobID, jobBytes); err != nil {
-				return numExecuted, err
-			}
-		}
-		if err := job.Execute(ctx); err != nil {
-			return 0, fmt.Errorf("failed to execute job %s due to %w", jobID, err)
+			return 0, err
 		}
-
-		dependentIDs, err := j.state.RemoveDependencies(jobID)
-		if err != nil {
-			return 0, fmt.Errorf("failed to remove blocking jobs for %s due to %w", jobID, err)
+		if len(batch) == 0 {
+			break
 		}
 
-		for _, dependentID := range dependentIDs {
-			job, err := j.state.GetJob(ctx, dependentID)
-			if err != nil {
-				return 0, fmt.Errorf("failed to get job %s from blocking jobs due to %w", dependentID, err)
+		verifyErrs := common.VerifyAll(ctx, batch, verifyJob)
+		for i, job := range batch {
+			jobID := job.ID()
+			if err := verifyErrs[i]; err != nil {
+				return 0, fmt.Errorf("failed to verify job %s due to %w", jobID, err)
 			}
-			hasMissingDeps, err := job.HasMissingDependencies(ctx)
-			if err != nil {
-				return 0, fmt.Errorf("failed to get missing dependencies for %s due to %w", dependentID, err)
+
+			chainCtx.Log.Debug("executing",
+				zap.Stringer("jobID", jobID),
+			)
+			jobBytes := job.Bytes()
+			// Note that acceptor.Accept must be called before executing [job]
+			// to honor Acceptor.Accept's invariant.
+			for _, acceptor := range acceptors {
+				if err := acceptor.Accept(chainCtx, jobID, jobBytes); err != nil {
+					return numExecuted, err
+				}
 			}
-			if hasMissingDeps {
-				continue
+			if err := job.Execute(ctx); err != nil {
+				return 0, fmt.Errorf("failed to execute job %s due to %w", jobID, err)
 			}
-			if err := j.state.AddRunnableJob(dependentID); err != nil {
-				return 0, fmt.Errorf("failed to add %s as a runnable job due to %w", dependentID, err)
+
+			dependentIDs, err := j.state.RemoveDependencies(jobID)
+			if err != nil {
+				return 0, fmt.Errorf("failed to remove blocking jobs for %s due to %w", jobID, err)
 			}
-		}
-		if err := j.Commit(); err != nil {
-			return 0, err
-		}
 
-		numExecuted++
-		if time.Since(lastProgressUpdate) > progressUpdateFrequency { // Periodically print progress
-			eta := timer.EstimateETA(
-				startTime,
-				uint64(numExecuted),
-				numToExecute,
-			)
-			j.etaMetric.Set(float64(eta))
+			for _, dependentID := range dependentIDs {
+				job, err := j.state.GetJob(ctx, dependentID)
+				if err != nil {
+					return 0, fmt.Errorf("failed to get job %s from blocking jobs due to %w", dependentID, err)
+				}
+				hasMissingDeps, err := job.HasMissingDependencies(ctx)
+				if err != nil {
+					return 0, fmt.Errorf("failed to get missing dependencies for %s due to %w", dependentID, err)
+				}
+				if hasMissingDeps {
+					continue
+				}
+				if err := j.state.AddRunnableJob(dependentID); err != nil {
+					return 0, fmt.Errorf("failed to add %s as a runnable job due to %w", dependentID, err)
+				}
+			}
 
-			if !restarted {
-				chainCtx.Log.Info("executing operations",
-					zap.Int("numExecuted", numExecuted),
-					zap.Uint64("numToExecute", numToExecute),
-					zap.Duration("eta", eta),
-				)
-			} else {
-				chainCtx.Log.Debug("executing operations",
-					zap.Int("numExecuted", numExecuted),
-					zap.Uint64("numToExecute", numToExecute),
-					zap.Duration("eta", eta),
+			numExecuted++
+			if time.Since(lastProgressUpdate) > progressUpdateFrequency { // Periodically print progress
+				eta := timer.EstimateETA(
+					startTime,
+					uint64(numExecuted),
+					numToExecute,
 				)
+				j.etaMetric.Set(float64(eta))
+				reportProgress(chainCtx, numExecuted, timer.EstimateRate(startTime, uint64(numExecuted)), eta)
+
+				if !restarted {
+					chainCtx.Log.Info("executing operations",
+						zap.Int("numExecuted", numExecuted),
+						zap.Uint64("numToExecute", numToExecute),
+						zap.Duration("eta", eta),
+					)
+				} else {
+					chainCtx.Log.Debug("executing operations",
+						zap.Int("numExecuted", numExecuted),
+						zap.Uint64("numToExecute", numToExecute),
+						zap.Duration("eta", eta),
+					)
+				}
+
+				lastProgressUpdate = time.Now()
 			}
+		}
 
-			lastProgressUpdate = time.Now()
+		// The whole batch is removed from the runnable queue when it is
+		// fetched, so it must be committed atomically to ensure that no job is
+		// dropped if the node stops.
+		if err := j.Commit(); err != nil {
+			return 0, err
 		}
 	}
 
 	// Now that executing has finished, zero out the ETA.
 	j.etaMetric.Set(0)
+	reportProgress(chainCtx, numExecuted, timer.EstimateRate(startTime, uint64(numExecuted)), 0)
 
 	if !restarted {
 		chainCtx.Log.Info("executed operations",
@@ -232,6 +252,51 @@ func (j *Jobs) ExecuteAll(
 	return numExecuted, nil
 }
 
+// removeRunnableBatch removes the next jobs to execute from the runnable queue.
+//
+// The batch contains up to [maxBatchSize] ParallelJobs, optionally followed by
+// a single job that isn't a ParallelJob. Every job in the batch is runnable,
+// so no job in the batch depends on another job in the batch.
+func (j *Jobs) removeRunnableBatch(ctx context.Context) ([]Job, error) {
+	var batch []Job
+	for len(batch) < maxBatchSize {
+		job, err := j.state.RemoveRunnableJob(ctx)
+		if err == database.ErrNotFound {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to removing runnable job with %w", err)
+		}
+
+		batch = append(batch, job)
+		if _, ok := job.(ParallelJob); !ok {
+			break
+		}
+	}
+	return batch, nil
+}
+
+// verifyJob verifies [job] if it is a ParallelJob. Other jobs are verified
+// when they are executed.
+func verifyJob(ctx context.Context, job Job) error {
+	parallelJob, ok := job.(ParallelJob)
+	if !ok {
+		return nil
+	}
+	return parallelJob.Verify(ctx)
+}
+
+// reportProgress updates the bootstrapping progress of [chainCtx] to report
+// that it is executing.
+func reportProgress(chainCtx *snow.ConsensusContext, numExecuted int, rate float64, eta time.Duration) {
+	progress := chainCtx.BootstrapProgress.Get()
+	progress.Phase = snow.PhaseExecuting
+	progress.Executed = uint64(numExecuted)
+	progress.Rate = rate
+	progress.ETA = eta
+	chainCtx.BootstrapProgress.Set(progress)
+}
+
 func (j *Jobs) Clear() error {
 	return j.state.Clear()
 }
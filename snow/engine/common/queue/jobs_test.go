@@ -6,7 +6,10 @@ package queue
 import (
 	"bytes"
 	"context"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -143,6 +146,61 @@ func TestPushAndExecute(t *testing.T) {
 	require.Equal(bootstrapProgressCheckpointSize, dbSize)
 }
 
+// Test that executing jobs reports the bootstrapping progress of the chain
+func TestExecuteAllReportsProgress(t *testing.T) {
+	require := require.New(t)
+
+	parser := &TestParser{T: t}
+	jobs, err := New(memdb.New(), "", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(jobs.SetParser(parser))
+
+	job0ID, job1ID := ids.GenerateTestID(), ids.GenerateTestID()
+	job0 := testJob(t, job0ID, nil, ids.Empty, nil)
+	job1 := testJob(t, job1ID, nil, ids.Empty, nil)
+	job1.BytesF = func() []byte {
+		return []byte{1}
+	}
+
+	pushed, err := jobs.Push(context.Background(), job0)
+	require.NoError(err)
+	require.True(pushed)
+	pushed, err = jobs.Push(context.Background(), job1)
+	require.NoError(err)
+	require.True(pushed)
+
+	parser.ParseF = func(_ context.Context, b []byte) (Job, error) {
+		switch {
+		case bytes.Equal(b, []byte{0}):
+			return job0, nil
+		case bytes.Equal(b, []byte{1}):
+			return job1, nil
+		default:
+			require.FailNow("asked to parse unexpected job")
+			return nil, nil
+		}
+	}
+
+	chainCtx := snow.DefaultConsensusContextTest()
+	chainCtx.BootstrapProgress.Set(snow.BootstrapProgress{
+		Phase:        snow.PhaseFetching,
+		Fetched:      2,
+		TargetHeight: 2,
+		ETA:          time.Minute,
+	})
+
+	count, err := jobs.ExecuteAll(context.Background(), chainCtx, &common.Halter{}, false)
+	require.NoError(err)
+	require.Equal(2, count)
+
+	progress := chainCtx.BootstrapProgress.Get()
+	require.Equal(snow.PhaseExecuting, progress.Phase)
+	require.Equal(uint64(2), progress.Fetched)
+	require.Equal(uint64(2), progress.Executed)
+	require.Equal(uint64(2), progress.TargetHeight)
+	require.Zero(progress.ETA)
+}
+
 // Test that executing a job will cause a dependent job to be placed on to the
 // ready queue
 func TestRemoveDependency(t *testing.T) {
@@ -554,3 +612,116 @@ func TestClearAll(t *testing.T) {
 	require.NoError(err)
 	require.False(hasJob1)
 }
+
+type testParallelJob struct {
+	*TestJob
+	VerifyF func(context.Context) error
+}
+
+func (j *testParallelJob) Verify(ctx context.Context) error {
+	return j.VerifyF(ctx)
+}
+
+// Test that independent parallel jobs are all verified before any of them are
+// executed, and that they are executed in the order they were queued.
+func TestExecuteAllVerifiesParallelJobsBeforeExecuting(t *testing.T) {
+	require := require.New(t)
+
+	parser := &TestParser{T: t}
+	jobs, err := New(memdb.New(), "", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(jobs.SetParser(parser))
+
+	const numJobs = 10
+	var (
+		lock          sync.Mutex
+		numVerified   int
+		executedOrder []ids.ID
+		pushedOrder   []ids.ID
+		jobsByBytes   = make(map[byte]Job, numJobs)
+	)
+	for i := 0; i < numJobs; i++ {
+		i := i
+		jobID := ids.GenerateTestID()
+		job := &testParallelJob{
+			TestJob: testJob(t, jobID, nil, ids.Empty, nil),
+			VerifyF: func(context.Context) error {
+				lock.Lock()
+				defer lock.Unlock()
+
+				numVerified++
+				return nil
+			},
+		}
+		job.BytesF = func() []byte {
+			return []byte{byte(i)}
+		}
+		job.ExecuteF = func(context.Context) error {
+			lock.Lock()
+			defer lock.Unlock()
+
+			require.Equal(numJobs, numVerified)
+			executedOrder = append(executedOrder, jobID)
+			return nil
+		}
+		jobsByBytes[byte(i)] = job
+
+		pushed, err := jobs.Push(context.Background(), job)
+		require.NoError(err)
+		require.True(pushed)
+		pushedOrder = append(pushedOrder, jobID)
+	}
+
+	parser.ParseF = func(_ context.Context, b []byte) (Job, error) {
+		job, ok := jobsByBytes[b[0]]
+		require.True(ok)
+		return job, nil
+	}
+
+	count, err := jobs.ExecuteAll(context.Background(), snow.DefaultConsensusContextTest(), &common.Halter{}, false)
+	require.NoError(err)
+	require.Equal(numJobs, count)
+	require.Len(executedOrder, numJobs)
+
+	// Runnable jobs are popped off of a stack, so they are executed in the
+	// reverse order of being pushed.
+	for i, jobID := range executedOrder {
+		require.Equal(pushedOrder[numJobs-1-i], jobID)
+	}
+
+	require.Zero(jobs.PendingJobs())
+}
+
+// Test that a parallel job failing verification causes execution to fail
+// without executing the job.
+func TestExecuteAllParallelJobVerifyFails(t *testing.T) {
+	require := require.New(t)
+
+	parser := &TestParser{T: t}
+	jobs, err := New(memdb.New(), "", prometheus.NewRegistry())
+	require.NoError(err)
+	require.NoError(jobs.SetParser(parser))
+
+	errVerify := errors.New("failed to verify")
+	job := &testParallelJob{
+		TestJob: testJob(t, ids.GenerateTestID(), nil, ids.Empty, nil),
+		VerifyF: func(context.Context) error {
+			return errVerify
+		},
+	}
+	job.ExecuteF = func(context.Context) error {
+		require.FailNow("executed a job that failed verification")
+		return nil
+	}
+
+	pushed, err := jobs.Push(context.Background(), job)
+	require.NoError(err)
+	require.True(pushed)
+
+	parser.ParseF = func(context.Context, []byte) (Job, error) {
+		return job, nil
+	}
+
+	_, err = jobs.ExecuteAll(context.Background(), snow.DefaultConsensusContextTest(), &common.Halter{}, false)
+	require.ErrorIs(err, errVerify)
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"context"
+	"errors"
+
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
+)
+
+var ErrNotReconfigurable = errors.New("engine doesn't support reconfiguration")
+
+// Reconfigurer is implemented by engines whose consensus parameters can be
+// replaced while they are running.
+type Reconfigurer interface {
+	// Reconfigure verifies [params] and schedules them to replace the
+	// engine's consensus parameters. The parameters are applied once the
+	// engine reaches a safe point between polls.
+	Reconfigure(ctx context.Context, params avalanche.Parameters) error
+}
+
+// Reconfigure replaces the consensus parameters of [engine] if it implements
+// Reconfigurer.
+func Reconfigure(ctx context.Context, engine Engine, params avalanche.Parameters) error {
+	reconfigurer, ok := engine.(Reconfigurer)
+	if !ok {
+		return ErrNotReconfigurable
+	}
+	return reconfigurer.Reconfigure(ctx, params)
+}
@@ -32,6 +32,7 @@ var (
 	errAccepted                      = errors.New("unexpectedly called Accepted")
 	errGet                           = errors.New("unexpectedly called Get")
 	errGetAncestors                  = errors.New("unexpectedly called GetAncestors")
+	errGetAncestorsAtHeight          = errors.New("unexpectedly called GetAncestorsAtHeight")
 	errGetFailed                     = errors.New("unexpectedly called GetFailed")
 	errGetAncestorsFailed            = errors.New("unexpectedly called GetAncestorsFailed")
 	errPut                           = errors.New("unexpectedly called Put")
@@ -79,6 +80,7 @@ type EngineTest struct {
 
 	CantGet,
 	CantGetAncestors,
+	CantGetAncestorsAtHeight,
 	CantGetFailed,
 	CantGetAncestorsFailed,
 	CantPut,
@@ -122,6 +124,7 @@ type EngineTest struct {
 	AppRequestFailedF           func(ctx context.Context, nodeID ids.NodeID, requestID uint32) error
 	StateSummaryFrontierF       func(ctx context.Context, nodeID ids.NodeID, requestID uint32, summary []byte) error
 	GetAcceptedStateSummaryF    func(ctx context.Context, nodeID ids.NodeID, requestID uint32, keys []uint64) error
+	GetAncestorsAtHeightF       func(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) error
 	AcceptedStateSummaryF       func(ctx context.Context, nodeID ids.NodeID, requestID uint32, summaryIDs []ids.ID) error
 	ConnectedF                  func(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error
 	DisconnectedF               func(ctx context.Context, nodeID ids.NodeID) error
@@ -158,6 +161,7 @@ func (e *EngineTest) Default(cant bool) {
 	e.CantAccepted = cant
 	e.CantGet = cant
 	e.CantGetAncestors = cant
+	e.CantGetAncestorsAtHeight = cant
 	e.CantGetAncestorsFailed = cant
 	e.CantGetFailed = cant
 	e.CantPut = cant
@@ -449,6 +453,19 @@ func (e *EngineTest) GetAncestors(ctx context.Context, nodeID ids.NodeID, reques
 	return errGetAncestors
 }
 
+func (e *EngineTest) GetAncestorsAtHeight(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) error {
+	if e.GetAncestorsAtHeightF != nil {
+		return e.GetAncestorsAtHeightF(ctx, nodeID, requestID, height)
+	}
+	if !e.CantGetAncestorsAtHeight {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatal(errGetAncestorsAtHeight)
+	}
+	return errGetAncestorsAtHeight
+}
+
 func (e *EngineTest) GetFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
 	if e.GetFailedF != nil {
 		return e.GetFailedF(ctx, nodeID, requestID)
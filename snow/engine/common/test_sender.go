@@ -32,7 +32,7 @@ type SenderTest struct {
 	CantSendGetAcceptedStateSummary, CantSendAcceptedStateSummary,
 	CantSendGetAcceptedFrontier, CantSendAcceptedFrontier,
 	CantSendGetAccepted, CantSendAccepted,
-	CantSendGet, CantSendGetAncestors, CantSendPut, CantSendAncestors,
+	CantSendGet, CantSendGetAncestors, CantSendGetAncestorsAtHeight, CantSendPut, CantSendAncestors,
 	CantSendPullQuery, CantSendPushQuery, CantSendChits,
 	CantSendGossip,
 	CantSendAppRequest, CantSendAppResponse, CantSendAppGossip, CantSendAppGossipSpecific,
@@ -49,6 +49,7 @@ type SenderTest struct {
 	SendAcceptedF                func(context.Context, ids.NodeID, uint32, []ids.ID)
 	SendGetF                     func(context.Context, ids.NodeID, uint32, ids.ID)
 	SendGetAncestorsF            func(context.Context, ids.NodeID, uint32, ids.ID)
+	SendGetAncestorsAtHeightF    func(context.Context, ids.NodeID, uint32, uint64)
 	SendPutF                     func(context.Context, ids.NodeID, uint32, []byte)
 	SendAncestorsF               func(context.Context, ids.NodeID, uint32, [][]byte)
 	SendPushQueryF               func(context.Context, set.Set[ids.NodeID], uint32, []byte)
@@ -216,6 +217,17 @@ func (s *SenderTest) SendGetAncestors(ctx context.Context, validatorID ids.NodeI
 	}
 }
 
+// SendGetAncestorsAtHeight calls SendGetAncestorsAtHeightF if it was
+// initialized. If it wasn't initialized and this function shouldn't be called
+// and testing was initialized, then testing will fail.
+func (s *SenderTest) SendGetAncestorsAtHeight(ctx context.Context, validatorID ids.NodeID, requestID uint32, height uint64) {
+	if s.SendGetAncestorsAtHeightF != nil {
+		s.SendGetAncestorsAtHeightF(ctx, validatorID, requestID, height)
+	} else if s.CantSendGetAncestorsAtHeight && s.T != nil {
+		s.T.Fatalf("Unexpectedly called SendGetAncestorsAtHeight")
+	}
+}
+
 // SendPut calls SendPutF if it was initialized. If it wasn't initialized and
 // this function shouldn't be called and testing was initialized, then testing
 // will fail.
@@ -13,11 +13,16 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
 	"github.com/MetalBlockchain/metalgo/trace"
 	"github.com/MetalBlockchain/metalgo/version"
 )
 
-var _ Engine = (*tracedEngine)(nil)
+var (
+	_ Engine       = (*tracedEngine)(nil)
+	_ Inspector    = (*tracedEngine)(nil)
+	_ Reconfigurer = (*tracedEngine)(nil)
+)
 
 type tracedEngine struct {
 	engine Engine
@@ -168,6 +173,17 @@ func (e *tracedEngine) GetAncestors(ctx context.Context, nodeID ids.NodeID, requ
 	return e.engine.GetAncestors(ctx, nodeID, requestID, containerID)
 }
 
+func (e *tracedEngine) GetAncestorsAtHeight(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) error {
+	ctx, span := e.tracer.Start(ctx, "tracedEngine.GetAncestorsAtHeight", oteltrace.WithAttributes(
+		attribute.Stringer("nodeID", nodeID),
+		attribute.Int64("requestID", int64(requestID)),
+		attribute.Int64("height", int64(height)),
+	))
+	defer span.End()
+
+	return e.engine.GetAncestorsAtHeight(ctx, nodeID, requestID, height)
+}
+
 func (e *tracedEngine) Ancestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, containers [][]byte) error {
 	ctx, span := e.tracer.Start(ctx, "tracedEngine.Ancestors", oteltrace.WithAttributes(
 		attribute.Stringer("nodeID", nodeID),
@@ -415,6 +431,20 @@ func (e *tracedEngine) HealthCheck(ctx context.Context) (interface{}, error) {
 	return e.engine.HealthCheck(ctx)
 }
 
+func (e *tracedEngine) Inspect(ctx context.Context, graphviz bool) (interface{}, error) {
+	ctx, span := e.tracer.Start(ctx, "tracedEngine.Inspect")
+	defer span.End()
+
+	return Inspect(ctx, e.engine, graphviz)
+}
+
+func (e *tracedEngine) Reconfigure(ctx context.Context, params avalanche.Parameters) error {
+	ctx, span := e.tracer.Start(ctx, "tracedEngine.Reconfigure")
+	defer span.End()
+
+	return Reconfigure(ctx, e.engine, params)
+}
+
 func (e *tracedEngine) GetVM() VM {
 	return e.engine.GetVM()
 }
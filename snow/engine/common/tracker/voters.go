@@ -0,0 +1,293 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/json"
+	"github.com/MetalBlockchain/metalgo/utils/linkedhashmap"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+// maxVotedContainers is the maximum number of undecided containers whose votes
+// are tracked. If more containers are voted for, the votes for the oldest
+// container are forgotten.
+const maxVotedContainers = 1024
+
+var _ Voters = (*voters)(nil)
+
+// VoterStats summarizes the responses a validator gave to polls.
+type VoterStats struct {
+	NodeID ids.NodeID `json:"nodeID"`
+
+	// Queries is the number of polls the validator was queried in.
+	Queries json.Uint64 `json:"queries"`
+
+	// Responses is the number of queries the validator responded to.
+	Responses json.Uint64 `json:"responses"`
+
+	// Timeouts is the number of queries the validator failed to respond to.
+	Timeouts json.Uint64 `json:"timeouts"`
+
+	// InvalidResponses is the number of queries the validator responded to
+	// with a malformed response.
+	InvalidResponses json.Uint64 `json:"invalidResponses"`
+
+	// AverageLatency is the average amount of time the validator took to
+	// respond to a query.
+	AverageLatency time.Duration `json:"averageLatency"`
+
+	// Agreements is the number of decided containers the validator voted for
+	// that were accepted.
+	Agreements json.Uint64 `json:"agreements"`
+
+	// Disagreements is the number of decided containers the validator voted
+	// for that were rejected.
+	Disagreements json.Uint64 `json:"disagreements"`
+}
+
+// Voters tracks the quality of the responses that validators give to polls.
+//
+// Voters isn't thread safe; it's expected to be used by a single engine.
+type Voters interface {
+	// Queried marks that [nodeID] was sent the query [requestID].
+	Queried(nodeID ids.NodeID, requestID uint32)
+
+	// Responded marks that [nodeID] responded to the query [requestID] with
+	// votes for the undecided containers [votes]. Responses to queries that
+	// aren't outstanding are ignored.
+	Responded(nodeID ids.NodeID, requestID uint32, votes ...ids.ID)
+
+	// Invalid marks that [nodeID] responded to the query [requestID] with a
+	// malformed response. Responses to queries that aren't outstanding are
+	// ignored.
+	Invalid(nodeID ids.NodeID, requestID uint32)
+
+	// Failed marks that the query [requestID] to [nodeID] failed. Failures of
+	// queries that aren't outstanding are ignored.
+	Failed(nodeID ids.NodeID, requestID uint32)
+
+	// Decided marks that [containerID] was accepted if [accepted] is true, or
+	// rejected otherwise. The validators that voted for it are credited with an
+	// agreement or a disagreement.
+	//
+	// Decided is expected to be called when consensus accepts or rejects
+	// [containerID]. Containers that were never voted for are ignored.
+	Decided(containerID ids.ID, accepted bool)
+
+	// Stats returns the stats of every validator that has been queried, sorted
+	// by node ID.
+	Stats() []VoterStats
+}
+
+type query struct {
+	nodeID    ids.NodeID
+	requestID uint32
+}
+
+type voterStats struct {
+	queries, responses, timeouts, invalidResponses uint64
+	agreements, disagreements                      uint64
+	totalLatency                                   time.Duration
+}
+
+type voters struct {
+	// outstanding maps each outstanding query to the time it was sent
+	outstanding map[query]time.Time
+	// votes maps each undecided container to the validators that voted for
+	// it, from the oldest to the newest container
+	votes linkedhashmap.LinkedHashmap[ids.ID, set.Set[ids.NodeID]]
+	stats map[ids.NodeID]*voterStats
+
+	// Per validator stats are only exposed through [Stats], to avoid
+	// reporting a metric series for every validator that has been queried.
+	numQueries, numResponses, numTimeouts, numInvalidResponses prometheus.Counter
+	numAgreements, numDisagreements                            prometheus.Counter
+	responseLatency                                            prometheus.Counter
+}
+
+// NewVoters returns a new Voters that reports metrics aggregated over all
+// validators to [reg].
+func NewVoters(namespace string, reg prometheus.Registerer) (Voters, error) {
+	v := &voters{
+		outstanding: make(map[query]time.Time),
+		votes:       linkedhashmap.New[ids.ID, set.Set[ids.NodeID]](),
+		stats:       make(map[ids.NodeID]*voterStats),
+		numQueries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "voter_queries",
+			Help:      "Number of queries sent to validators",
+		}),
+		numResponses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "voter_responses",
+			Help:      "Number of queries validators responded to",
+		}),
+		numTimeouts: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "voter_timeouts",
+			Help:      "Number of queries validators failed to respond to",
+		}),
+		numInvalidResponses: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "voter_invalid_responses",
+			Help:      "Number of queries validators responded to with a malformed response",
+		}),
+		numAgreements: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "voter_agreements",
+			Help:      "Number of votes for decided containers that were accepted",
+		}),
+		numDisagreements: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "voter_disagreements",
+			Help:      "Number of votes for decided containers that were rejected",
+		}),
+		responseLatency: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "voter_response_latency",
+			Help:      "Cumulative time (in ns) validators took to respond to queries",
+		}),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(v.numQueries),
+		reg.Register(v.numResponses),
+		reg.Register(v.numTimeouts),
+		reg.Register(v.numInvalidResponses),
+		reg.Register(v.numAgreements),
+		reg.Register(v.numDisagreements),
+		reg.Register(v.responseLatency),
+	)
+	return v, errs.Err
+}
+
+func (v *voters) Queried(nodeID ids.NodeID, requestID uint32) {
+	v.outstanding[query{
+		nodeID:    nodeID,
+		requestID: requestID,
+	}] = time.Now()
+	v.getStats(nodeID).queries++
+	v.numQueries.Inc()
+}
+
+func (v *voters) Responded(nodeID ids.NodeID, requestID uint32, votes ...ids.ID) {
+	sent, ok := v.removeOutstanding(nodeID, requestID)
+	if !ok {
+		return
+	}
+
+	latency := time.Since(sent)
+	stats := v.getStats(nodeID)
+	stats.responses++
+	stats.totalLatency += latency
+	v.numResponses.Inc()
+	v.responseLatency.Add(float64(latency))
+
+	for _, containerID := range votes {
+		voters, ok := v.votes.Get(containerID)
+		if !ok {
+			if v.votes.Len() >= maxVotedContainers {
+				oldestID, _, _ := v.votes.Oldest()
+				v.votes.Delete(oldestID)
+			}
+			voters = set.Set[ids.NodeID]{}
+		}
+		voters.Add(nodeID)
+		v.votes.Put(containerID, voters)
+	}
+}
+
+func (v *voters) Invalid(nodeID ids.NodeID, requestID uint32) {
+	if _, ok := v.removeOutstanding(nodeID, requestID); !ok {
+		return
+	}
+
+	v.getStats(nodeID).invalidResponses++
+	v.numInvalidResponses.Inc()
+}
+
+func (v *voters) Failed(nodeID ids.NodeID, requestID uint32) {
+	if _, ok := v.removeOutstanding(nodeID, requestID); !ok {
+		return
+	}
+
+	v.getStats(nodeID).timeouts++
+	v.numTimeouts.Inc()
+}
+
+func (v *voters) Decided(containerID ids.ID, accepted bool) {
+	voters, ok := v.votes.Get(containerID)
+	if !ok {
+		return
+	}
+	v.votes.Delete(containerID)
+
+	for nodeID := range voters {
+		stats := v.getStats(nodeID)
+		if accepted {
+			stats.agreements++
+			v.numAgreements.Inc()
+		} else {
+			stats.disagreements++
+			v.numDisagreements.Inc()
+		}
+	}
+}
+
+func (v *voters) Stats() []VoterStats {
+	nodeIDs := make([]ids.NodeID, 0, len(v.stats))
+	for nodeID := range v.stats {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	utils.Sort(nodeIDs)
+
+	stats := make([]VoterStats, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		s := v.stats[nodeID]
+		stats[i] = VoterStats{
+			NodeID:           nodeID,
+			Queries:          json.Uint64(s.queries),
+			Responses:        json.Uint64(s.responses),
+			Timeouts:         json.Uint64(s.timeouts),
+			InvalidResponses: json.Uint64(s.invalidResponses),
+			Agreements:       json.Uint64(s.agreements),
+			Disagreements:    json.Uint64(s.disagreements),
+		}
+		if s.responses > 0 {
+			stats[i].AverageLatency = s.totalLatency / time.Duration(s.responses)
+		}
+	}
+	return stats
+}
+
+// removeOutstanding removes the query [requestID] to [nodeID] and returns the
+// time it was sent, if it was outstanding.
+func (v *voters) removeOutstanding(nodeID ids.NodeID, requestID uint32) (time.Time, bool) {
+	q := query{
+		nodeID:    nodeID,
+		requestID: requestID,
+	}
+	sent, ok := v.outstanding[q]
+	if ok {
+		delete(v.outstanding, q)
+	}
+	return sent, ok
+}
+
+func (v *voters) getStats(nodeID ids.NodeID) *voterStats {
+	stats, ok := v.stats[nodeID]
+	if !ok {
+		stats = &voterStats{}
+		v.stats[nodeID] = stats
+	}
+	return stats
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils"
+)
+
+func TestVoters(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs := []ids.NodeID{
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+	}
+	utils.Sort(nodeIDs)
+	acceptedID := ids.GenerateTestID()
+	rejectedID := ids.GenerateTestID()
+
+	v, err := NewVoters("", prometheus.NewRegistry())
+	require.NoError(err)
+	require.Empty(v.Stats())
+
+	for _, nodeID := range nodeIDs {
+		v.Queried(nodeID, 1)
+	}
+
+	v.Responded(nodeIDs[0], 1, acceptedID)
+	v.Responded(nodeIDs[1], 1, rejectedID)
+	v.Failed(nodeIDs[2], 1)
+	v.Invalid(nodeIDs[3], 1)
+
+	// Responses and failures of queries that aren't outstanding are ignored.
+	v.Responded(nodeIDs[0], 1, rejectedID)
+	v.Responded(nodeIDs[2], 1, acceptedID)
+	v.Responded(nodeIDs[3], 1, acceptedID)
+	v.Failed(nodeIDs[1], 1)
+	v.Failed(nodeIDs[3], 1)
+	v.Failed(nodeIDs[0], 2)
+	v.Invalid(nodeIDs[0], 1)
+
+	v.Decided(acceptedID, true)
+	v.Decided(rejectedID, false)
+
+	// Containers are only decided once.
+	v.Decided(acceptedID, false)
+
+	stats := v.Stats()
+	require.Len(stats, 4)
+
+	require.Equal(nodeIDs[0], stats[0].NodeID)
+	require.EqualValues(1, stats[0].Queries)
+	require.EqualValues(1, stats[0].Responses)
+	require.Zero(stats[0].Timeouts)
+	require.EqualValues(1, stats[0].Agreements)
+	require.Zero(stats[0].Disagreements)
+
+	require.Equal(nodeIDs[1], stats[1].NodeID)
+	require.EqualValues(1, stats[1].Queries)
+	require.EqualValues(1, stats[1].Responses)
+	require.Zero(stats[1].Timeouts)
+	require.Zero(stats[1].Agreements)
+	require.EqualValues(1, stats[1].Disagreements)
+
+	require.Equal(nodeIDs[2], stats[2].NodeID)
+	require.EqualValues(1, stats[2].Queries)
+	require.Zero(stats[2].Responses)
+	require.EqualValues(1, stats[2].Timeouts)
+	require.Zero(stats[2].AverageLatency)
+	require.Zero(stats[2].Agreements)
+	require.Zero(stats[2].Disagreements)
+
+	require.Equal(nodeIDs[3], stats[3].NodeID)
+	require.EqualValues(1, stats[3].Queries)
+	require.Zero(stats[3].Responses)
+	require.Zero(stats[3].Timeouts)
+	require.EqualValues(1, stats[3].InvalidResponses)
+	require.Zero(stats[3].Agreements)
+	require.Zero(stats[3].Disagreements)
+
+	// Metrics are aggregated over all validators.
+	metrics := v.(*voters)
+	require.Equal(float64(4), testutil.ToFloat64(metrics.numQueries))
+	require.Equal(float64(2), testutil.ToFloat64(metrics.numResponses))
+	require.Equal(float64(1), testutil.ToFloat64(metrics.numTimeouts))
+	require.Equal(float64(1), testutil.ToFloat64(metrics.numInvalidResponses))
+	require.Equal(float64(1), testutil.ToFloat64(metrics.numAgreements))
+	require.Equal(float64(1), testutil.ToFloat64(metrics.numDisagreements))
+}
+
+func TestVotersEvictsOldestContainer(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+
+	v, err := NewVoters("", prometheus.NewRegistry())
+	require.NoError(err)
+
+	containerIDs := make([]ids.ID, maxVotedContainers+1)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+	}
+
+	v.Queried(nodeID, 1)
+	v.Responded(nodeID, 1, containerIDs...)
+
+	// The votes for the oldest container were forgotten.
+	v.Decided(containerIDs[0], true)
+	require.Zero(v.Stats()[0].Agreements)
+
+	for _, containerID := range containerIDs[1:] {
+		v.Decided(containerID, true)
+	}
+	require.EqualValues(maxVotedContainers, v.Stats()[0].Agreements)
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// VerifyAll calls [verify] on every element of [elts], using up to GOMAXPROCS
+// goroutines, and returns the result of verifying each element in the order of
+// [elts].
+func VerifyAll[T any](ctx context.Context, elts []T, verify func(context.Context, T) error) []error {
+	errs := make([]error, len(elts))
+	numWorkers := runtime.GOMAXPROCS(0)
+	if numWorkers > len(elts) {
+		numWorkers = len(elts)
+	}
+	if numWorkers <= 1 {
+		for i, elt := range elts {
+			errs[i] = verify(ctx, elt)
+		}
+		return errs
+	}
+
+	var (
+		wg      sync.WaitGroup
+		indices = make(chan int, len(elts))
+	)
+	for i := range elts {
+		indices <- i
+	}
+	close(indices)
+
+	wg.Add(numWorkers)
+	for w := 0; w < numWorkers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				errs[i] = verify(ctx, elts[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
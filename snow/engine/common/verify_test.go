@@ -0,0 +1,47 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errInvalid = errors.New("invalid")
+
+func TestVerifyAll(t *testing.T) {
+	require := require.New(t)
+
+	elts := make([]int, 100)
+	expectedErrs := make([]error, len(elts))
+	for i := range elts {
+		elts[i] = i
+		if i%3 == 0 {
+			expectedErrs[i] = fmt.Errorf("%w: %d", errInvalid, i)
+		}
+	}
+
+	var numVerified int64
+	errs := VerifyAll(context.Background(), elts, func(_ context.Context, i int) error {
+		atomic.AddInt64(&numVerified, 1)
+		return expectedErrs[i]
+	})
+	require.Equal(expectedErrs, errs)
+	require.Equal(int64(len(elts)), numVerified)
+}
+
+func TestVerifyAllEmpty(t *testing.T) {
+	require := require.New(t)
+
+	errs := VerifyAll(context.Background(), nil, func(context.Context, int) error {
+		require.FailNow("nothing should be verified")
+		return nil
+	})
+	require.Empty(errs)
+}
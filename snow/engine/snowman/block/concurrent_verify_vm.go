@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import "context"
+
+// ConcurrentVerifyChainVM defines the interface a ChainVM can optionally
+// implement to allow the consensus engine to verify blocks concurrently.
+type ConcurrentVerifyChainVM interface {
+	// ConcurrentVerifyEnabled returns true if Verify may be called
+	// concurrently on different blocks.
+	//
+	// As with all Blocks, it is still guaranteed that the parent of a block
+	// has been successfully verified before Verify is called on the block.
+	// While blocks are being verified concurrently, the only calls made into
+	// the VM are Verify, and any GetBlock or ParseBlock calls made by Verify
+	// itself. The results of verification are applied to consensus in order.
+	ConcurrentVerifyEnabled(context.Context) (bool, error)
+}
@@ -76,6 +76,34 @@ type bootstrapper struct {
 	// again.
 	fetchFrom set.Set[ids.NodeID]
 
+	// peers tracks the throughput of the peers in [fetchFrom] so that blocks
+	// are fetched from the fastest available peer.
+	peers *peerTracker
+
+	// failedFetches tracks, for each block that is being fetched, the peers
+	// that failed to provide it. Retries are sent to other peers when
+	// possible.
+	failedFetches map[ids.ID]set.Set[ids.NodeID]
+
+	// While the blocks are traversed from the accepted frontier, the ranges of
+	// heights below the traversal are fetched concurrently from other peers,
+	// using their height index.
+	//
+	// rangeSize is the number of blocks that a range is expected to contain.
+	rangeSize uint64
+	// nextRangeHeight is the height of the highest block of the next range to
+	// request.
+	nextRangeHeight uint64
+	// rangeRequests maps the outstanding requests for ranges to the requested
+	// range.
+	rangeRequests map[request]*blockRange
+	// fetchedBlocks holds the blocks of the received ranges until they are
+	// reached by the traversal.
+	fetchedBlocks map[ids.ID]snowman.Block
+	// noHeightIndex is the set of peers that failed to provide a range, and
+	// that are therefore only used for the traversal.
+	noHeightIndex set.Set[ids.NodeID]
+
 	// bootstrappedOnce ensures that the [Bootstrapped] callback is only invoked
 	// once, even if bootstrapping is retried.
 	bootstrappedOnce sync.Once
@@ -101,7 +129,10 @@ func New(ctx context.Context, config Config, onFinished func(ctx context.Context
 			OnFinished: onFinished,
 		},
 		executedStateTransitions: math.MaxInt32,
+		peers:                    newPeerTracker(),
+		failedFetches:            make(map[ids.ID]set.Set[ids.NodeID]),
 	}
+	b.resetRanges()
 
 	b.parser = &parser{
 		log:         config.Ctx.Log,
@@ -156,7 +187,17 @@ func (b *bootstrapper) Start(ctx context.Context, startReqID uint32) error {
 func (b *bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, blks [][]byte) error {
 	// Make sure this is in response to a request we made
 	wantedBlkID, ok := b.OutstandingRequests.Remove(nodeID, requestID)
-	if !ok { // this message isn't in response to a request we made
+	if !ok {
+		req := request{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}
+		if r, ok := b.rangeRequests[req]; ok {
+			delete(b.rangeRequests, req)
+			return b.rangeReceived(ctx, nodeID, requestID, r, blks)
+		}
+
+		// this message isn't in response to a request we made
 		b.Ctx.Log.Debug("received unexpected Ancestors",
 			zap.Stringer("nodeID", nodeID),
 			zap.Uint32("requestID", requestID),
@@ -172,6 +213,7 @@ func (b *bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 		)
 
 		b.markUnavailable(nodeID)
+		b.fetchFailed(nodeID, requestID, wantedBlkID)
 
 		// Send another request for this
 		return b.fetch(ctx, wantedBlkID)
@@ -196,6 +238,7 @@ func (b *bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 			zap.Uint32("requestID", requestID),
 			zap.Error(err),
 		)
+		b.fetchFailed(nodeID, requestID, wantedBlkID)
 		return b.fetch(ctx, wantedBlkID)
 	}
 
@@ -204,6 +247,7 @@ func (b *bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 			zap.Stringer("nodeID", nodeID),
 			zap.Uint32("requestID", requestID),
 		)
+		b.fetchFailed(nodeID, requestID, wantedBlkID)
 		return b.fetch(ctx, wantedBlkID)
 	}
 
@@ -213,9 +257,26 @@ func (b *bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 			zap.Stringer("expectedBlkID", wantedBlkID),
 			zap.Stringer("blkID", actualID),
 		)
+		b.fetchFailed(nodeID, requestID, wantedBlkID)
 		return b.fetch(ctx, wantedBlkID)
 	}
 
+	b.peers.Received(nodeID, requestID, len(blocks), time.Now())
+	delete(b.failedFetches, wantedBlkID)
+
+	// Unless the response reached genesis, peers are expected to send as many
+	// blocks in response to the following requests.
+	if blocks[len(blocks)-1].Height() > 0 {
+		b.setRangeSize(len(blocks))
+	}
+
+	// Request the blocks preceding this response before processing it, so
+	// that the next range is downloaded, from another peer if one is
+	// available, while this range is being processed.
+	if err := b.prefetch(ctx, blocks[len(blocks)-1]); err != nil {
+		return err
+	}
+
 	blockSet := make(map[ids.ID]snowman.Block, len(blocks))
 	for _, block := range blocks[1:] {
 		blockSet[block.ID()] = block
@@ -226,6 +287,16 @@ func (b *bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 func (b *bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
 	blkID, ok := b.OutstandingRequests.Remove(nodeID, requestID)
 	if !ok {
+		req := request{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}
+		if r, ok := b.rangeRequests[req]; ok {
+			delete(b.rangeRequests, req)
+			b.fetchFrom.Add(nodeID)
+			return b.rangeFailed(ctx, nodeID, requestID, r)
+		}
+
 		b.Ctx.Log.Debug("unexpectedly called GetAncestorsFailed",
 			zap.Stringer("nodeID", nodeID),
 			zap.Uint32("requestID", requestID),
@@ -235,6 +306,7 @@ func (b *bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID
 
 	// This node timed out their request, so we can add them back to [fetchFrom]
 	b.fetchFrom.Add(nodeID)
+	b.fetchFailed(nodeID, requestID, blkID)
 
 	// Send another request for this
 	return b.fetch(ctx, blkID)
@@ -311,8 +383,9 @@ func (b *bootstrapper) Notify(_ context.Context, msg common.Message) error {
 func (b *bootstrapper) HealthCheck(ctx context.Context) (interface{}, error) {
 	vmIntf, vmErr := b.VM.HealthCheck(ctx)
 	intf := map[string]interface{}{
-		"consensus": struct{}{},
-		"vm":        vmIntf,
+		"consensus":     struct{}{},
+		"vm":            vmIntf,
+		"bootstrapping": b.Ctx.BootstrapProgress.Get(),
 	}
 	return intf, vmErr
 }
@@ -326,6 +399,9 @@ func (b *bootstrapper) ForceAccepted(ctx context.Context, acceptedContainerIDs [
 
 	// Initialize the fetch from set to the currently preferred peers
 	b.fetchFrom = b.StartupTracker.PreferredPeers()
+	b.failedFetches = make(map[ids.ID]set.Set[ids.NodeID])
+	b.noHeightIndex.Clear()
+	b.resetRanges()
 
 	// Append the list of accepted container IDs to pendingContainerIDs to ensure
 	// we iterate over every container that must be traversed.
@@ -352,6 +428,7 @@ func (b *bootstrapper) ForceAccepted(ctx context.Context, acceptedContainerIDs [
 
 	b.initiallyFetched = b.Blocked.PendingJobs()
 	b.startTime = time.Now()
+	b.reportFetchingProgress(0)
 
 	// Process received blocks
 	for _, blk := range toProcess {
@@ -372,10 +449,11 @@ func (b *bootstrapper) fetch(ctx context.Context, blkID ids.ID) error {
 
 	// Make sure we don't already have this block
 	if _, err := b.VM.GetBlock(ctx, blkID); err == nil {
+		delete(b.failedFetches, blkID)
 		return b.checkFinish(ctx)
 	}
 
-	validatorID, ok := b.fetchFrom.Peek()
+	validatorID, ok := b.peers.Select(b.fetchFrom, b.failedFetches[blkID])
 	if !ok {
 		return fmt.Errorf("dropping request for %s as there are no validators", blkID)
 	}
@@ -386,10 +464,45 @@ func (b *bootstrapper) fetch(ctx context.Context, blkID ids.ID) error {
 	b.Config.SharedCfg.RequestID++
 
 	b.OutstandingRequests.Add(validatorID, b.Config.SharedCfg.RequestID, blkID)
+	b.peers.Sent(validatorID, b.Config.SharedCfg.RequestID, time.Now())
 	b.Config.Sender.SendGetAncestors(ctx, validatorID, b.Config.SharedCfg.RequestID, blkID) // request block and ancestors
 	return nil
 }
 
+// prefetch requests the ancestors of [blk] if they are going to be needed and
+// haven't been requested yet.
+func (b *bootstrapper) prefetch(ctx context.Context, blk snowman.Block) error {
+	// The parent of [blk] is only needed if it is above the last accepted
+	// height.
+	if blk.Height() <= b.startingHeight+1 {
+		return nil
+	}
+
+	parentID := blk.Parent()
+	if b.OutstandingRequests.Contains(parentID) {
+		return nil
+	}
+	if _, err := b.VM.GetBlock(ctx, parentID); err == nil {
+		return nil
+	}
+	if pushed, err := b.Blocked.Has(parentID); err != nil || pushed {
+		return err
+	}
+	return b.fetchParent(ctx, blk)
+}
+
+// fetchFailed records that [nodeID] didn't provide [blkID] in response to
+// request [requestID], so that the next request for [blkID] is sent to a
+// different peer.
+func (b *bootstrapper) fetchFailed(nodeID ids.NodeID, requestID uint32, blkID ids.ID) {
+	b.numFailedFetches.Inc()
+	b.peers.Received(nodeID, requestID, 0, time.Now())
+
+	failed := b.failedFetches[blkID]
+	failed.Add(nodeID)
+	b.failedFetches[blkID] = failed
+}
+
 // markUnavailable removes [nodeID] from the set of peers used to fetch
 // ancestors. If the set becomes empty, it is reset to the currently preferred
 // peers so bootstrapping can continue.
@@ -432,6 +545,9 @@ func (b *bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 		}
 
 		b.Blocked.RemoveMissingID(blkID)
+		// The block may have been provided by a different request than the one
+		// that failed.
+		delete(b.failedFetches, blkID)
 
 		status := blk.Status()
 		// The status should never be rejected here - but we check to fail as
@@ -451,7 +567,8 @@ func (b *bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 
 		// If this block is going to be accepted, make sure to update the
 		// tipHeight for logging
-		if blkHeight > b.tipHeight {
+		tipHeightIncreased := blkHeight > b.tipHeight
+		if tipHeightIncreased {
 			b.tipHeight = blkHeight
 		}
 
@@ -478,6 +595,9 @@ func (b *bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 
 		// We added a new block to the queue, so track that it was fetched
 		b.numFetched.Inc()
+		if tipHeightIncreased {
+			b.reportFetchingProgress(0)
+		}
 
 		// Periodically log progress
 		blocksFetchedSoFar := b.Blocked.Jobs.PendingJobs()
@@ -489,6 +609,7 @@ func (b *bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 				totalBlocksToFetch-b.initiallyFetched, // Number of blocks we expect to fetch during this run
 			)
 			b.fetchETA.Set(float64(eta))
+			b.reportFetchingProgress(eta)
 
 			if !b.Config.SharedCfg.Restarted {
 				b.Ctx.Log.Info("fetching blocks",
@@ -524,10 +645,18 @@ func (b *bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 		}
 		// TODO: report errors that aren't `database.ErrNotFound`
 
+		// Finally, check if the parent was fetched as part of a range
+		parent, ok = b.fetchedBlocks[parentID]
+		if ok {
+			delete(b.fetchedBlocks, parentID)
+			blk = parent
+			continue
+		}
+
 		// If the block wasn't able to be acquired immediately, attempt to fetch
 		// it
 		b.Blocked.AddMissingID(parentID)
-		if err := b.fetch(ctx, parentID); err != nil {
+		if err := b.fetchParent(ctx, blk); err != nil {
 			return err
 		}
 
@@ -538,6 +667,19 @@ func (b *bootstrapper) process(ctx context.Context, blk snowman.Block, processin
 	}
 }
 
+// reportFetchingProgress updates the bootstrapping progress of this chain to
+// report that it is fetching blocks.
+func (b *bootstrapper) reportFetchingProgress(eta time.Duration) {
+	fetched := b.Blocked.PendingJobs()
+	b.Ctx.BootstrapProgress.Set(snow.BootstrapProgress{
+		Phase:        snow.PhaseFetching,
+		Fetched:      fetched,
+		TargetHeight: b.tipHeight,
+		Rate:         timer.EstimateRate(b.startTime, fetched-b.initiallyFetched),
+		ETA:          eta,
+	})
+}
+
 // checkFinish repeatedly executes pending transactions and requests new frontier vertices until there aren't any new ones
 // after which it finishes the bootstrap process
 func (b *bootstrapper) checkFinish(ctx context.Context) error {
@@ -545,6 +687,10 @@ func (b *bootstrapper) checkFinish(ctx context.Context) error {
 		return nil
 	}
 
+	// All the blocks have been fetched, so the remaining fetched ranges aren't
+	// needed.
+	b.fetchedBlocks = make(map[ids.ID]snowman.Block)
+
 	if b.IsBootstrapped() || b.awaitingTimeout {
 		return nil
 	}
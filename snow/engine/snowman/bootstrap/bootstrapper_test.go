@@ -1510,3 +1510,334 @@ func TestBootstrapContinueAfterHalt(t *testing.T) {
 		t.Fatal("Should have left blk1 as missing")
 	}
 }
+
+// A block that a peer failed to provide should be requested from another peer
+func TestBootstrapperRetryFailedFetchFromOtherPeer(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm := newConfig(t)
+
+	blks := make([]*snowman.TestBlock, 3)
+	for i := range blks {
+		blks[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.Empty.Prefix(uint64(i)),
+				StatusV: choices.Unknown,
+			},
+			HeightV: uint64(i),
+			BytesV:  []byte{byte(i)},
+		}
+		if i > 0 {
+			blks[i].ParentV = blks[i-1].IDV
+		}
+	}
+	blks[0].StatusV = choices.Accepted
+	blks[2].StatusV = choices.Processing
+
+	vm.CantLastAccepted = false
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return blks[0].ID(), nil
+	}
+	parsedBlk1 := false
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case blks[0].ID():
+			return blks[0], nil
+		case blks[1].ID():
+			if parsedBlk1 {
+				return blks[1], nil
+			}
+			return nil, database.ErrNotFound
+		case blks[2].ID():
+			return blks[2], nil
+		default:
+			require.FailNow(database.ErrNotFound.Error())
+			return nil, database.ErrNotFound
+		}
+	}
+	vm.ParseBlockF = func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+		for _, blk := range blks {
+			if bytes.Equal(blk.Bytes(), blkBytes) {
+				if blk == blks[1] {
+					blk.StatusV = choices.Processing
+					parsedBlk1 = true
+				}
+				return blk, nil
+			}
+		}
+		require.FailNow(errUnknownBlock.Error())
+		return nil, errUnknownBlock
+	}
+
+	bs, err := New(
+		context.Background(),
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+
+	vm.CantSetState = false
+	require.NoError(bs.Start(context.Background(), 0))
+
+	var (
+		requestedVdr   ids.NodeID
+		requestID      uint32
+		requestedBlkID ids.ID
+	)
+	sender.SendGetAncestorsF = func(_ context.Context, vdr ids.NodeID, reqID uint32, blkID ids.ID) {
+		requestedVdr = vdr
+		requestID = reqID
+		requestedBlkID = blkID
+	}
+
+	require.NoError(bs.ForceAccepted(context.Background(), []ids.ID{blks[2].ID()}))
+	require.Equal(peerID, requestedVdr)
+	require.Equal(blks[1].ID(), requestedBlkID)
+
+	otherPeerID := ids.GenerateTestNodeID()
+	bs.(*bootstrapper).fetchFrom.Add(otherPeerID)
+
+	// The failed peer is added back to the fetch set, but the retry should be
+	// sent to the other peer.
+	require.NoError(bs.GetAncestorsFailed(context.Background(), peerID, requestID))
+	require.True(bs.(*bootstrapper).fetchFrom.Contains(peerID))
+	require.Equal(otherPeerID, requestedVdr)
+	require.Equal(blks[1].ID(), requestedBlkID)
+
+	require.NoError(bs.Ancestors(context.Background(), otherPeerID, requestID, [][]byte{blks[1].Bytes()}))
+	require.Equal(snow.State(snow.NormalOp), config.Ctx.State.Get().State)
+	require.Equal(choices.Accepted, blks[1].Status())
+	require.Equal(choices.Accepted, blks[2].Status())
+	require.Empty(bs.(*bootstrapper).failedFetches)
+
+	_, ok := bs.(*bootstrapper).peers.Throughput(otherPeerID)
+	require.True(ok)
+	throughput, ok := bs.(*bootstrapper).peers.Throughput(peerID)
+	require.True(ok)
+	require.Zero(throughput)
+}
+
+// The ancestors of a response should be requested before the response is
+// processed
+func TestBootstrapperPrefetchAncestors(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm := newConfig(t)
+
+	blks := make([]*snowman.TestBlock, 4)
+	for i := range blks {
+		blks[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.Empty.Prefix(uint64(i)),
+				StatusV: choices.Unknown,
+			},
+			HeightV: uint64(i),
+			BytesV:  []byte{byte(i)},
+		}
+		if i > 0 {
+			blks[i].ParentV = blks[i-1].IDV
+		}
+	}
+	blks[0].StatusV = choices.Accepted
+	blks[3].StatusV = choices.Processing
+
+	vm.CantLastAccepted = false
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return blks[0].ID(), nil
+	}
+	parsed := make(map[ids.ID]bool)
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range blks {
+			if blk.ID() != blkID {
+				continue
+			}
+			if blk.Status() == choices.Unknown && !parsed[blkID] {
+				return nil, database.ErrNotFound
+			}
+			return blk, nil
+		}
+		require.FailNow(database.ErrNotFound.Error())
+		return nil, database.ErrNotFound
+	}
+	vm.ParseBlockF = func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+		for _, blk := range blks {
+			if bytes.Equal(blk.Bytes(), blkBytes) {
+				blk.StatusV = choices.Processing
+				parsed[blk.ID()] = true
+				return blk, nil
+			}
+		}
+		require.FailNow(errUnknownBlock.Error())
+		return nil, errUnknownBlock
+	}
+
+	bs, err := New(
+		context.Background(),
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+
+	vm.CantSetState = false
+	require.NoError(bs.Start(context.Background(), 0))
+
+	requests := make(map[ids.ID]request)
+	sender.SendGetAncestorsF = func(_ context.Context, vdr ids.NodeID, reqID uint32, blkID ids.ID) {
+		// blk1 must be requested before blk2 is pushed onto the job queue.
+		if blkID == blks[1].ID() {
+			pushed, err := bs.(*bootstrapper).Blocked.Has(blks[2].ID())
+			require.NoError(err)
+			require.False(pushed)
+		}
+		requests[blkID] = request{
+			nodeID:    vdr,
+			requestID: reqID,
+		}
+	}
+
+	require.NoError(bs.ForceAccepted(context.Background(), []ids.ID{blks[3].ID()}))
+	require.Contains(requests, blks[2].ID())
+
+	progress := config.Ctx.BootstrapProgress.Get()
+	require.Equal(snow.PhaseFetching, progress.Phase)
+	require.Equal(uint64(1), progress.Fetched)
+	require.Equal(uint64(3), progress.TargetHeight)
+
+	otherPeerID := ids.GenerateTestNodeID()
+	bs.(*bootstrapper).fetchFrom.Add(otherPeerID)
+
+	req := requests[blks[2].ID()]
+	require.Equal(peerID, req.nodeID)
+	require.NoError(bs.Ancestors(context.Background(), req.nodeID, req.requestID, [][]byte{blks[2].Bytes()}))
+
+	// The remaining range should be fetched from the peer whose throughput
+	// hasn't been measured yet.
+	require.Contains(requests, blks[1].ID())
+	req = requests[blks[1].ID()]
+	require.Equal(otherPeerID, req.nodeID)
+	require.NoError(bs.Ancestors(context.Background(), req.nodeID, req.requestID, [][]byte{blks[1].Bytes()}))
+
+	require.Len(requests, 2)
+	require.Equal(snow.State(snow.NormalOp), config.Ctx.State.Get().State)
+	for _, blk := range blks {
+		require.Equal(choices.Accepted, blk.Status())
+	}
+
+	progress = config.Ctx.BootstrapProgress.Get()
+	require.Equal(snow.PhaseExecuting, progress.Phase)
+	require.Equal(uint64(3), progress.Executed)
+	require.Equal(uint64(3), progress.TargetHeight)
+}
+
+// A block that a peer failed to provide shouldn't be tracked as failed once it
+// is received in response to another request
+func TestBootstrapperClearFailedFetchProvidedByOtherRequest(t *testing.T) {
+	require := require.New(t)
+
+	config, _, sender, vm := newConfig(t)
+	for i := 0; i < 2; i++ {
+		nodeID := ids.GenerateTestNodeID()
+		require.NoError(config.Beacons.Add(nodeID, nil, ids.Empty, 1))
+		require.NoError(config.StartupTracker.Connected(context.Background(), nodeID, version.CurrentApp))
+	}
+
+	blks := make([]*snowman.TestBlock, 4)
+	for i := range blks {
+		blks[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.Empty.Prefix(uint64(i)),
+				StatusV: choices.Unknown,
+			},
+			HeightV: uint64(i),
+			BytesV:  []byte{byte(i)},
+		}
+		if i > 0 {
+			blks[i].ParentV = blks[i-1].IDV
+		}
+	}
+	blks[0].StatusV = choices.Accepted
+	blks[3].StatusV = choices.Processing
+
+	vm.CantLastAccepted = false
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return blks[0].ID(), nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range blks {
+			if blk.ID() != blkID {
+				continue
+			}
+			if blk.Status() == choices.Unknown {
+				return nil, database.ErrNotFound
+			}
+			return blk, nil
+		}
+		require.FailNow(database.ErrNotFound.Error())
+		return nil, database.ErrNotFound
+	}
+	vm.ParseBlockF = func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+		for _, blk := range blks {
+			if bytes.Equal(blk.Bytes(), blkBytes) {
+				if blk.Status() == choices.Unknown {
+					blk.StatusV = choices.Processing
+				}
+				return blk, nil
+			}
+		}
+		require.FailNow(errUnknownBlock.Error())
+		return nil, errUnknownBlock
+	}
+
+	bs, err := New(
+		context.Background(),
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+
+	vm.CantSetState = false
+	require.NoError(bs.Start(context.Background(), 0))
+
+	requests := make(map[ids.ID]request)
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) {
+		requests[blkID] = request{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}
+	}
+
+	// blk1 is requested directly, and as an ancestor of blk2.
+	require.NoError(bs.ForceAccepted(context.Background(), []ids.ID{blks[3].ID(), blks[1].ID()}))
+	require.Contains(requests, blks[1].ID())
+	require.Contains(requests, blks[2].ID())
+
+	req := requests[blks[1].ID()]
+	require.NoError(bs.GetAncestorsFailed(context.Background(), req.nodeID, req.requestID))
+	require.Contains(bs.(*bootstrapper).failedFetches, blks[1].ID())
+
+	req = requests[blks[2].ID()]
+	require.NoError(bs.Ancestors(context.Background(), req.nodeID, req.requestID, [][]byte{blks[2].Bytes(), blks[1].Bytes()}))
+	require.Empty(bs.(*bootstrapper).failedFetches)
+	require.Equal(snow.State(snow.NormalOp), config.Ctx.State.Get().State)
+	for _, blk := range blks {
+		require.Equal(choices.Accepted, blk.Status())
+	}
+}
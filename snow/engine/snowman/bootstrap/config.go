@@ -4,9 +4,11 @@
 package bootstrap
 
 import (
+	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common/queue"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
+	"github.com/MetalBlockchain/metalgo/utils/set"
 )
 
 type Config struct {
@@ -22,5 +24,11 @@ type Config struct {
 
 	VM block.ChainVM
 
+	// PeerCapabilities returns the p2p capabilities that were negotiated with
+	// a peer. Blocks are only requested by height from peers that support
+	// [message.CapabilityAncestorsAtHeight]. If nil, blocks are only fetched
+	// by ID.
+	PeerCapabilities func(nodeID ids.NodeID) set.Set[string]
+
 	Bootstrapped func()
 }
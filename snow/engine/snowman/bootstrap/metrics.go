@@ -10,8 +10,8 @@ import (
 )
 
 type metrics struct {
-	numFetched, numDropped, numAccepted prometheus.Counter
-	fetchETA                            prometheus.Gauge
+	numFetched, numDropped, numAccepted, numFailedFetches, numRangeRequests prometheus.Counter
+	fetchETA                                                                prometheus.Gauge
 }
 
 func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, error) {
@@ -31,6 +31,16 @@ func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, e
 			Name:      "accepted",
 			Help:      "Number of blocks accepted during bootstrapping",
 		}),
+		numFailedFetches: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "failed_fetches",
+			Help:      "Number of ancestors requests that failed or returned no usable blocks during bootstrapping",
+		}),
+		numRangeRequests: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "range_requests",
+			Help:      "Number of ranges of blocks requested by height during bootstrapping",
+		}),
 		fetchETA: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Name:      "eta_fetching_complete",
@@ -43,6 +53,8 @@ func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, e
 		registerer.Register(m.numFetched),
 		registerer.Register(m.numDropped),
 		registerer.Register(m.numAccepted),
+		registerer.Register(m.numFailedFetches),
+		registerer.Register(m.numRangeRequests),
 		registerer.Register(m.fetchETA),
 	)
 	return m, errs.Err
@@ -0,0 +1,131 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrap
+
+import (
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/math"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+const (
+	// throughputHalflife is the halflife of the average number of blocks per
+	// second that a peer has served.
+	throughputHalflife = 5 * time.Minute
+
+	// minResponseTime bounds the time a response is assumed to have taken, so
+	// that a response received immediately doesn't report an unbounded
+	// throughput.
+	minResponseTime = time.Millisecond
+)
+
+type request struct {
+	nodeID    ids.NodeID
+	requestID uint32
+}
+
+// peerTracker tracks the rate at which peers have served ancestors during
+// bootstrapping, so that blocks can be fetched from the fastest peers.
+//
+// Note: not thread safe. Caller must handle synchronization.
+type peerTracker struct {
+	// nodeID -> average number of blocks per second served by the peer
+	throughput map[ids.NodeID]math.Averager
+	// outstanding request -> time the request was sent
+	sentTimes map[request]time.Time
+}
+
+func newPeerTracker() *peerTracker {
+	return &peerTracker{
+		throughput: make(map[ids.NodeID]math.Averager),
+		sentTimes:  make(map[request]time.Time),
+	}
+}
+
+// Sent records that request [requestID] was sent to [nodeID] at [now].
+func (p *peerTracker) Sent(nodeID ids.NodeID, requestID uint32, now time.Time) {
+	p.sentTimes[request{
+		nodeID:    nodeID,
+		requestID: requestID,
+	}] = now
+}
+
+// Received records that [nodeID] responded to request [requestID] with
+// [numBlocks] usable blocks at [now]. Failed requests, and responses that
+// didn't contain any usable blocks, should be reported with [numBlocks] = 0.
+func (p *peerTracker) Received(nodeID ids.NodeID, requestID uint32, numBlocks int, now time.Time) {
+	req := request{
+		nodeID:    nodeID,
+		requestID: requestID,
+	}
+	sentTime, ok := p.sentTimes[req]
+	if !ok {
+		return
+	}
+	delete(p.sentTimes, req)
+
+	responseTime := now.Sub(sentTime)
+	if responseTime < minResponseTime {
+		responseTime = minResponseTime
+	}
+	throughput := float64(numBlocks) / responseTime.Seconds()
+
+	averager, ok := p.throughput[nodeID]
+	if !ok {
+		p.throughput[nodeID] = math.NewAverager(throughput, throughputHalflife, now)
+		return
+	}
+	averager.Observe(throughput, now)
+}
+
+// Throughput returns the average number of blocks per second that [nodeID]
+// has served. False is returned if no response from [nodeID] has been
+// recorded.
+func (p *peerTracker) Throughput(nodeID ids.NodeID) (float64, bool) {
+	averager, ok := p.throughput[nodeID]
+	if !ok {
+		return 0, false
+	}
+	return averager.Read(), true
+}
+
+// Select returns the peer in [peers], that isn't in [exclude], that blocks
+// should be fetched from.
+//
+// Peers whose throughput hasn't been measured yet are preferred so that every
+// peer is measured. Otherwise, the peer with the highest throughput is
+// returned. If every peer is excluded, [exclude] is ignored so that fetching
+// can continue.
+func (p *peerTracker) Select(peers set.Set[ids.NodeID], exclude set.Set[ids.NodeID]) (ids.NodeID, bool) {
+	if nodeID, ok := p.selectFrom(peers, exclude); ok {
+		return nodeID, true
+	}
+	return p.selectFrom(peers, nil)
+}
+
+func (p *peerTracker) selectFrom(peers set.Set[ids.NodeID], exclude set.Set[ids.NodeID]) (ids.NodeID, bool) {
+	var (
+		bestNodeID     ids.NodeID
+		bestThroughput float64
+		found          bool
+	)
+	for nodeID := range peers {
+		if exclude.Contains(nodeID) {
+			continue
+		}
+
+		throughput, ok := p.Throughput(nodeID)
+		if !ok {
+			return nodeID, true
+		}
+		if !found || throughput > bestThroughput {
+			bestNodeID = nodeID
+			bestThroughput = throughput
+			found = true
+		}
+	}
+	return bestNodeID, found
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+func TestPeerTrackerReceived(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		p      = newPeerTracker()
+		nodeID = ids.GenerateTestNodeID()
+		now    = time.Now()
+	)
+
+	// Responses to untracked requests are ignored.
+	p.Received(nodeID, 0, 10, now)
+	_, ok := p.Throughput(nodeID)
+	require.False(ok)
+
+	p.Sent(nodeID, 1, now)
+	p.Received(nodeID, 1, 10, now.Add(2*time.Second))
+	throughput, ok := p.Throughput(nodeID)
+	require.True(ok)
+	require.InDelta(5, throughput, 0.001)
+	require.Empty(p.sentTimes)
+
+	// A duplicate response isn't recorded twice.
+	p.Received(nodeID, 1, 0, now.Add(2*time.Second))
+	throughput, ok = p.Throughput(nodeID)
+	require.True(ok)
+	require.InDelta(5, throughput, 0.001)
+
+	p.Sent(nodeID, 2, now)
+	p.Received(nodeID, 2, 0, now.Add(2*time.Second))
+	throughput, ok = p.Throughput(nodeID)
+	require.True(ok)
+	require.InDelta(2.5, throughput, 0.001)
+}
+
+func TestPeerTrackerSelect(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		p          = newPeerTracker()
+		slowNodeID = ids.GenerateTestNodeID()
+		fastNodeID = ids.GenerateTestNodeID()
+		newNodeID  = ids.GenerateTestNodeID()
+		now        = time.Now()
+	)
+
+	_, ok := p.Select(nil, nil)
+	require.False(ok)
+
+	p.Sent(slowNodeID, 0, now)
+	p.Received(slowNodeID, 0, 1, now.Add(time.Second))
+	p.Sent(fastNodeID, 1, now)
+	p.Received(fastNodeID, 1, 100, now.Add(time.Second))
+
+	peers := set.Set[ids.NodeID]{}
+	peers.Add(slowNodeID, fastNodeID)
+	nodeID, ok := p.Select(peers, nil)
+	require.True(ok)
+	require.Equal(fastNodeID, nodeID)
+
+	// Excluded peers are skipped.
+	exclude := set.Set[ids.NodeID]{}
+	exclude.Add(fastNodeID)
+	nodeID, ok = p.Select(peers, exclude)
+	require.True(ok)
+	require.Equal(slowNodeID, nodeID)
+
+	// If every peer is excluded, the exclusion is ignored.
+	exclude.Add(slowNodeID)
+	nodeID, ok = p.Select(peers, exclude)
+	require.True(ok)
+	require.Equal(fastNodeID, nodeID)
+
+	// Peers that haven't been measured are preferred.
+	peers.Add(newNodeID)
+	nodeID, ok = p.Select(peers, nil)
+	require.True(ok)
+	require.Equal(newNodeID, nodeID)
+}
@@ -0,0 +1,281 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrap
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+// maxRanges is the maximum number of ranges of blocks that are being fetched,
+// or that were fetched but not yet reached by the traversal from the accepted
+// frontier. It bounds the number of blocks held in memory.
+const maxRanges = 8
+
+// blockRange is a range of heights requested from a peer by height, rather than
+// by block ID, so that it can be fetched before the traversal from the accepted
+// frontier reaches it.
+type blockRange struct {
+	// height of the highest block in the range
+	top uint64
+	// height of the lowest block the range is expected to contain
+	bottom uint64
+	// IDs of the blocks that the traversal needs and that are expected to be
+	// in this range
+	awaiting set.Set[ids.ID]
+}
+
+func (r *blockRange) contains(height uint64) bool {
+	return r.bottom <= height && height <= r.top
+}
+
+// fetchParent requests the parent of [blk] and the ranges of blocks below it.
+// If the parent is expected to be in a range that is already being fetched,
+// the parent is processed once the range is received instead.
+func (b *bootstrapper) fetchParent(ctx context.Context, blk snowman.Block) error {
+	parentID := blk.Parent()
+	parentHeight := blk.Height() - 1
+
+	// Blocks above the parent have either been reached by the traversal or
+	// aren't part of the chain being fetched.
+	b.pruneFetchedBlocks(parentHeight)
+
+	if _, ok := b.fetchedBlocks[parentID]; !ok {
+		if r, ok := b.rangeContaining(parentHeight); ok {
+			r.awaiting.Add(parentID)
+		} else if err := b.fetch(ctx, parentID); err != nil {
+			return err
+		}
+	}
+
+	// The request for the parent is expected to return the blocks down to
+	// [parentHeight] - [rangeSize] + 1, so the ranges below it are fetched
+	// concurrently.
+	nextRangeHeight := b.startingHeight
+	if parentHeight > b.startingHeight+b.rangeSize {
+		nextRangeHeight = parentHeight - b.rangeSize
+	}
+	if nextRangeHeight < b.nextRangeHeight {
+		b.nextRangeHeight = nextRangeHeight
+	}
+	return b.fetchRanges(ctx)
+}
+
+// fetchRanges requests ranges of blocks by height from peers until [maxRanges]
+// ranges are outstanding or held, or until there are no more peers that can
+// serve them.
+func (b *bootstrapper) fetchRanges(ctx context.Context) error {
+	for b.nextRangeHeight > b.startingHeight && b.numRanges() < maxRanges {
+		top := b.nextRangeHeight
+		bottom := b.startingHeight + 1
+		if top > b.startingHeight+b.rangeSize {
+			bottom = top - b.rangeSize + 1
+		}
+		if !b.requestRange(ctx, &blockRange{
+			top:    top,
+			bottom: bottom,
+		}) {
+			return nil
+		}
+		b.nextRangeHeight = bottom - 1
+	}
+	return nil
+}
+
+// requestRange sends a request for [r] to the fastest peer that may be able to
+// serve it. Returns false if there is no such peer.
+func (b *bootstrapper) requestRange(ctx context.Context, r *blockRange) bool {
+	// The traversal from the accepted frontier must always be able to make
+	// progress, so at least one peer is left for it.
+	if b.fetchFrom.Len() <= 1 {
+		return false
+	}
+	// Unlike the traversal, ranges are never requested from peers that failed
+	// to provide one, as the blocks can always be fetched by the traversal.
+	nodeID, ok := b.peers.selectFrom(b.rangePeers(), b.noHeightIndex)
+	if !ok {
+		return false
+	}
+
+	b.markUnavailable(nodeID)
+
+	b.Config.SharedCfg.RequestID++
+	requestID := b.Config.SharedCfg.RequestID
+
+	b.rangeRequests[request{
+		nodeID:    nodeID,
+		requestID: requestID,
+	}] = r
+	b.numRangeRequests.Inc()
+	b.peers.Sent(nodeID, requestID, time.Now())
+	b.Config.Sender.SendGetAncestorsAtHeight(ctx, nodeID, requestID, r.top)
+	return true
+}
+
+// rangePeers returns the peers in [fetchFrom] that advertised that they answer
+// requests for ancestors by height.
+func (b *bootstrapper) rangePeers() set.Set[ids.NodeID] {
+	peers := set.Set[ids.NodeID]{}
+	if b.Config.PeerCapabilities == nil {
+		return peers
+	}
+	for nodeID := range b.fetchFrom {
+		capabilities := b.Config.PeerCapabilities(nodeID)
+		if capabilities.Contains(message.CapabilityAncestorsAtHeight) {
+			peers.Add(nodeID)
+		}
+	}
+	return peers
+}
+
+// rangeReceived handles the response of [nodeID] to the request [requestID]
+// for [r].
+func (b *bootstrapper) rangeReceived(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	r *blockRange,
+	blks [][]byte,
+) error {
+	b.fetchFrom.Add(nodeID)
+
+	if len(blks) > b.Config.AncestorsMaxContainersReceived {
+		blks = blks[:b.Config.AncestorsMaxContainersReceived]
+	}
+
+	blocks, err := block.BatchedParseBlock(ctx, b.VM, blks)
+	if err != nil {
+		b.Ctx.Log.Debug("failed to parse blocks in Ancestors",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Error(err),
+		)
+		return b.rangeFailed(ctx, nodeID, requestID, r)
+	}
+	if len(blocks) == 0 || blocks[0].Height() != r.top {
+		b.Ctx.Log.Debug("received Ancestors without the requested height",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Uint64("height", r.top),
+		)
+		return b.rangeFailed(ctx, nodeID, requestID, r)
+	}
+
+	// Only the blocks that form a chain are kept. The blocks aren't trusted
+	// until the traversal from the accepted frontier reaches them by their IDs.
+	numBlocks := 1
+	for ; numBlocks < len(blocks); numBlocks++ {
+		if blocks[numBlocks].ID() != blocks[numBlocks-1].Parent() {
+			break
+		}
+	}
+	b.peers.Received(nodeID, requestID, numBlocks, time.Now())
+	for _, blk := range blocks[:numBlocks] {
+		if blk.Height() > b.startingHeight {
+			b.fetchedBlocks[blk.ID()] = blk
+		}
+	}
+
+	if err := b.resumeAwaiting(ctx, r); err != nil {
+		return err
+	}
+	return b.fetchRanges(ctx)
+}
+
+// rangeFailed handles [nodeID] not providing [r] in response to request
+// [requestID] by requesting [r] from another peer.
+func (b *bootstrapper) rangeFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32, r *blockRange) error {
+	b.numFailedFetches.Inc()
+	b.peers.Received(nodeID, requestID, 0, time.Now())
+
+	// The peer may not support fetching blocks by height, so it is only used
+	// for the traversal from now on.
+	b.noHeightIndex.Add(nodeID)
+
+	if b.requestRange(ctx, r) {
+		return nil
+	}
+
+	// There are no other peers to fetch the range from, so the traversal
+	// fetches the blocks it is waiting for itself.
+	if err := b.resumeAwaiting(ctx, r); err != nil {
+		return err
+	}
+	return b.checkFinish(ctx)
+}
+
+// resumeAwaiting continues the traversal from the blocks that were expected in
+// [r].
+func (b *bootstrapper) resumeAwaiting(ctx context.Context, r *blockRange) error {
+	awaiting := r.awaiting
+	r.awaiting = nil
+	for blkID := range awaiting {
+		blk, ok := b.fetchedBlocks[blkID]
+		if !ok {
+			if err := b.fetch(ctx, blkID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		delete(b.fetchedBlocks, blkID)
+		if err := b.process(ctx, blk, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rangeContaining returns the outstanding range that is expected to contain
+// the block at [height].
+func (b *bootstrapper) rangeContaining(height uint64) (*blockRange, bool) {
+	for _, r := range b.rangeRequests {
+		if r.contains(height) {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// numRanges returns the number of ranges that are outstanding or held in
+// [fetchedBlocks].
+func (b *bootstrapper) numRanges() int {
+	numHeld := (uint64(len(b.fetchedBlocks)) + b.rangeSize - 1) / b.rangeSize
+	return len(b.rangeRequests) + int(numHeld)
+}
+
+// setRangeSize sets the number of blocks expected in a range to [numBlocks],
+// the number of blocks peers are expected to send in an Ancestors message.
+func (b *bootstrapper) setRangeSize(numBlocks int) {
+	if numBlocks < 1 {
+		numBlocks = 1
+	}
+	b.rangeSize = uint64(numBlocks)
+}
+
+// pruneFetchedBlocks removes the fetched blocks above [height].
+func (b *bootstrapper) pruneFetchedBlocks(height uint64) {
+	for blkID, blk := range b.fetchedBlocks {
+		if blk.Height() > height {
+			delete(b.fetchedBlocks, blkID)
+		}
+	}
+}
+
+// resetRanges forgets the ranges that were requested and fetched.
+func (b *bootstrapper) resetRanges() {
+	b.setRangeSize(b.Config.AncestorsMaxContainersReceived)
+	b.nextRangeHeight = math.MaxUint64
+	b.rangeRequests = make(map[request]*blockRange)
+	b.fetchedBlocks = make(map[ids.ID]snowman.Block)
+}
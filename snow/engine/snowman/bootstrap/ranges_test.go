@@ -0,0 +1,297 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/choices"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/version"
+)
+
+// newRangeTest returns a bootstrapper that fetches a chain of [numBlocks]
+// blocks, whose tip is known, from [numPeers] peers. Peers are expected to send
+// at most 2 blocks per Ancestors message.
+func newRangeTest(t *testing.T, numBlocks int, numPeers int) (*bootstrapper, Config, *common.SenderTest, []*snowman.TestBlock) {
+	require := require.New(t)
+
+	config, _, sender, vm := newConfig(t)
+	config.AncestorsMaxContainersReceived = 2
+	config.PeerCapabilities = func(ids.NodeID) set.Set[string] {
+		return message.SupportedCapabilities()
+	}
+	for i := 1; i < numPeers; i++ {
+		nodeID := ids.GenerateTestNodeID()
+		require.NoError(config.Beacons.Add(nodeID, nil, ids.Empty, 1))
+		require.NoError(config.StartupTracker.Connected(context.Background(), nodeID, version.CurrentApp))
+	}
+
+	blks := make([]*snowman.TestBlock, numBlocks)
+	for i := range blks {
+		blks[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.Empty.Prefix(uint64(i)),
+				StatusV: choices.Unknown,
+			},
+			HeightV: uint64(i),
+			BytesV:  []byte{byte(i)},
+		}
+		if i > 0 {
+			blks[i].ParentV = blks[i-1].IDV
+		}
+	}
+	blks[0].StatusV = choices.Accepted
+	blks[numBlocks-1].StatusV = choices.Processing
+
+	vm.CantLastAccepted = false
+	vm.LastAcceptedF = func(context.Context) (ids.ID, error) {
+		return blks[0].ID(), nil
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range blks {
+			if blk.ID() != blkID {
+				continue
+			}
+			if blk.Status() == choices.Unknown {
+				return nil, database.ErrNotFound
+			}
+			return blk, nil
+		}
+		require.FailNow(database.ErrNotFound.Error())
+		return nil, database.ErrNotFound
+	}
+	vm.ParseBlockF = func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+		for _, blk := range blks {
+			if bytes.Equal(blk.Bytes(), blkBytes) {
+				if blk.Status() == choices.Unknown {
+					blk.StatusV = choices.Processing
+				}
+				return blk, nil
+			}
+		}
+		require.FailNow(errUnknownBlock.Error())
+		return nil, errUnknownBlock
+	}
+
+	bs, err := New(
+		context.Background(),
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+
+	vm.CantSetState = false
+	require.NoError(bs.Start(context.Background(), 0))
+	return bs.(*bootstrapper), config, sender, blks
+}
+
+// Ranges below the traversal should be fetched by height from other peers and
+// consumed once the traversal reaches them
+func TestBootstrapperFetchRanges(t *testing.T) {
+	require := require.New(t)
+
+	bs, config, sender, blks := newRangeTest(t, 8, 4)
+
+	requests := make(map[ids.ID]request)
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) {
+		require.NotContains(requests, blkID)
+		requests[blkID] = request{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}
+	}
+	rangeRequests := make(map[uint64]request)
+	sender.SendGetAncestorsAtHeightF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, height uint64) {
+		require.NotContains(rangeRequests, height)
+		rangeRequests[height] = request{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}
+	}
+
+	require.NoError(bs.ForceAccepted(context.Background(), []ids.ID{blks[7].ID()}))
+
+	// The parent of the tip is fetched by ID while the ranges below it are
+	// fetched by height, each from a different peer.
+	require.Len(requests, 1)
+	require.Contains(requests, blks[6].ID())
+	require.Len(rangeRequests, 2)
+	require.Contains(rangeRequests, uint64(4))
+	require.Contains(rangeRequests, uint64(2))
+
+	nodeIDs := map[ids.NodeID]struct{}{
+		requests[blks[6].ID()].nodeID: {},
+		rangeRequests[4].nodeID:       {},
+		rangeRequests[2].nodeID:       {},
+	}
+	require.Len(nodeIDs, 3)
+
+	// The lowest range is held until the traversal reaches it.
+	req := rangeRequests[2]
+	require.NoError(bs.Ancestors(context.Background(), req.nodeID, req.requestID, [][]byte{blks[2].Bytes(), blks[1].Bytes()}))
+	require.Len(bs.fetchedBlocks, 2)
+	require.Equal(snow.State(snow.Bootstrapping), config.Ctx.State.Get().State)
+
+	// The parent of this response is expected to be in the outstanding range.
+	req = requests[blks[6].ID()]
+	require.NoError(bs.Ancestors(context.Background(), req.nodeID, req.requestID, [][]byte{blks[6].Bytes(), blks[5].Bytes()}))
+	require.Len(requests, 1)
+	require.Equal(snow.State(snow.Bootstrapping), config.Ctx.State.Get().State)
+
+	req = rangeRequests[4]
+	require.NoError(bs.Ancestors(context.Background(), req.nodeID, req.requestID, [][]byte{blks[4].Bytes(), blks[3].Bytes()}))
+
+	require.Len(requests, 1)
+	require.Len(rangeRequests, 2)
+	require.Empty(bs.fetchedBlocks)
+	require.Equal(snow.State(snow.NormalOp), config.Ctx.State.Get().State)
+	for _, blk := range blks {
+		require.Equal(choices.Accepted, blk.Status())
+	}
+}
+
+// A range that a peer failed to provide should be requested from a peer that
+// hasn't failed to provide one, and otherwise be fetched by the traversal
+func TestBootstrapperRetryFailedRange(t *testing.T) {
+	require := require.New(t)
+
+	bs, config, sender, blks := newRangeTest(t, 8, 4)
+
+	requests := make(map[ids.ID]request)
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) {
+		requests[blkID] = request{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}
+	}
+	rangeRequests := make(map[uint64]request)
+	sender.SendGetAncestorsAtHeightF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, height uint64) {
+		rangeRequests[height] = request{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}
+	}
+
+	require.NoError(bs.ForceAccepted(context.Background(), []ids.ID{blks[7].ID()}))
+	require.Contains(rangeRequests, uint64(4))
+
+	failedReq := rangeRequests[4]
+	require.NoError(bs.GetAncestorsFailed(context.Background(), failedReq.nodeID, failedReq.requestID))
+	require.True(bs.noHeightIndex.Contains(failedReq.nodeID))
+
+	retryReq := rangeRequests[4]
+	require.NotEqual(failedReq.nodeID, retryReq.nodeID)
+	require.NotEqual(failedReq.requestID, retryReq.requestID)
+
+	// Every available peer failed to provide the range, so it isn't requested
+	// again.
+	require.NoError(bs.GetAncestorsFailed(context.Background(), retryReq.nodeID, retryReq.requestID))
+	require.True(bs.noHeightIndex.Contains(retryReq.nodeID))
+	require.Equal(retryReq, rangeRequests[4])
+	require.NotContains(bs.rangeRequests, retryReq)
+
+	// The traversal fetches the blocks of the failed range by ID.
+	req := requests[blks[6].ID()]
+	require.NoError(bs.Ancestors(context.Background(), req.nodeID, req.requestID, [][]byte{blks[6].Bytes(), blks[5].Bytes()}))
+	require.Contains(requests, blks[4].ID())
+
+	req = requests[blks[4].ID()]
+	require.NoError(bs.Ancestors(context.Background(), req.nodeID, req.requestID, [][]byte{blks[4].Bytes(), blks[3].Bytes()}))
+	require.NotContains(requests, blks[2].ID())
+
+	req = rangeRequests[2]
+	require.NoError(bs.Ancestors(context.Background(), req.nodeID, req.requestID, [][]byte{blks[2].Bytes(), blks[1].Bytes()}))
+
+	require.Equal(snow.State(snow.NormalOp), config.Ctx.State.Get().State)
+	for _, blk := range blks {
+		require.Equal(choices.Accepted, blk.Status())
+	}
+}
+
+// Ranges should only be requested from peers that advertised that they answer
+// requests for ancestors by height
+func TestBootstrapperRangesRequireCapability(t *testing.T) {
+	require := require.New(t)
+
+	bs, config, sender, blks := newRangeTest(t, 8, 4)
+
+	capable := set.Set[ids.NodeID]{}
+	for nodeID := range config.StartupTracker.PreferredPeers() {
+		if capable.Len() == 2 {
+			break
+		}
+		capable.Add(nodeID)
+	}
+	bs.Config.PeerCapabilities = func(nodeID ids.NodeID) set.Set[string] {
+		if capable.Contains(nodeID) {
+			return message.SupportedCapabilities()
+		}
+		return nil
+	}
+
+	requests := make(map[ids.ID]request)
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) {
+		requests[blkID] = request{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}
+	}
+	rangeRequests := make(map[uint64]request)
+	sender.SendGetAncestorsAtHeightF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, height uint64) {
+		require.True(capable.Contains(nodeID))
+		rangeRequests[height] = request{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}
+	}
+
+	require.NoError(bs.ForceAccepted(context.Background(), []ids.ID{blks[7].ID()}))
+	require.NotEmpty(rangeRequests)
+
+	// Without any capable peer, the blocks are only fetched by the traversal.
+	bs, config, sender, blks = newRangeTest(t, 8, 4)
+	bs.Config.PeerCapabilities = func(ids.NodeID) set.Set[string] {
+		return nil
+	}
+
+	requests = make(map[ids.ID]request)
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) {
+		requests[blkID] = request{
+			nodeID:    nodeID,
+			requestID: requestID,
+		}
+	}
+	sender.SendGetAncestorsAtHeightF = func(context.Context, ids.NodeID, uint32, uint64) {
+		require.FailNow("unexpected range request")
+	}
+
+	require.NoError(bs.ForceAccepted(context.Background(), []ids.ID{blks[7].ID()}))
+	for _, height := range []int{6, 4, 2} {
+		req := requests[blks[height].ID()]
+		require.NoError(bs.Ancestors(context.Background(), req.nodeID, req.requestID, [][]byte{blks[height].Bytes(), blks[height-1].Bytes()}))
+	}
+
+	require.Equal(snow.State(snow.NormalOp), config.Ctx.State.Get().State)
+	for _, blk := range blks {
+		require.Equal(choices.Accepted, blk.Status())
+	}
+}
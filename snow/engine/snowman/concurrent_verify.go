@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"context"
+
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+)
+
+// deliverConcurrently queues [blk] to be verified and issued to consensus.
+//
+// Blocks that become ready to be verified while a batch is being delivered,
+// such as the children of a block in the batch, are verified concurrently in
+// the next batch. The results of verification are applied to consensus in the
+// order the blocks became ready.
+func (t *Transitive) deliverConcurrently(ctx context.Context, blk snowman.Block) error {
+	t.toVerify = append(t.toVerify, blk)
+	if t.verifying {
+		// The block will be delivered in the next batch.
+		return nil
+	}
+
+	t.verifying = true
+	defer func() {
+		t.verifying = false
+	}()
+
+	for len(t.toVerify) > 0 {
+		blks := t.toVerify
+		t.toVerify = nil
+		if err := t.deliverBatch(ctx, blks); err != nil {
+			t.toVerify = nil
+			return err
+		}
+	}
+	return t.errs.Err
+}
+
+// deliverBatch verifies [blks] concurrently and then issues them to consensus
+// in order.
+func (t *Transitive) deliverBatch(ctx context.Context, blks []snowman.Block) error {
+	ready := make([]snowman.Block, 0, len(blks))
+	for _, blk := range blks {
+		if t.readyToVerify(ctx, blk) {
+			ready = append(ready, blk)
+		}
+	}
+	if len(ready) == 0 {
+		return t.errs.Err
+	}
+
+	t.metrics.verifyBatchSize.Observe(float64(len(ready)))
+	verifyErrs := common.VerifyAll(ctx, ready, verifyBlock)
+	for i, blk := range ready {
+		if err := t.deliverVerified(ctx, blk, verifyErrs[i]); err != nil {
+			return err
+		}
+	}
+	return t.errs.Err
+}
+
+// verifyBlock verifies [blk]. It is called concurrently on every block in a
+// batch.
+func verifyBlock(ctx context.Context, blk snowman.Block) error {
+	return blk.Verify(ctx)
+}
@@ -25,9 +25,11 @@ func New(
 	commonCfg common.Config,
 ) (common.AllGetsServer, error) {
 	ssVM, _ := vm.(block.StateSyncableVM)
+	hVM, _ := vm.(block.HeightIndexedChainVM)
 	gh := &getter{
 		vm:     vm,
 		ssVM:   ssVM,
+		hVM:    hVM,
 		sender: commonCfg.Sender,
 		cfg:    commonCfg,
 		log:    commonCfg.Ctx.Log,
@@ -45,7 +47,8 @@ func New(
 
 type getter struct {
 	vm     block.ChainVM
-	ssVM   block.StateSyncableVM // can be nil
+	ssVM   block.StateSyncableVM      // can be nil
+	hVM    block.HeightIndexedChainVM // can be nil
 	sender common.Sender
 	cfg    common.Config
 
@@ -172,6 +175,34 @@ func (gh *getter) GetAncestors(ctx context.Context, nodeID ids.NodeID, requestID
 	return nil
 }
 
+func (gh *getter) GetAncestorsAtHeight(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) error {
+	blkID, err := gh.getBlockIDAtHeight(ctx, height)
+	if err != nil {
+		// Reply with an empty response so that the requester doesn't wait for
+		// the request to time out before asking another peer.
+		gh.log.Verbo("replying to GetAncestorsAtHeight with no blocks",
+			zap.String("reason", "couldn't get accepted block"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Uint64("height", height),
+			zap.Error(err),
+		)
+		gh.sender.SendAncestors(ctx, nodeID, requestID, nil)
+		return nil
+	}
+	return gh.GetAncestors(ctx, nodeID, requestID, blkID)
+}
+
+func (gh *getter) getBlockIDAtHeight(ctx context.Context, height uint64) (ids.ID, error) {
+	if gh.hVM == nil {
+		return ids.Empty, block.ErrHeightIndexedVMNotImplemented
+	}
+	if err := gh.hVM.VerifyHeightIndex(ctx); err != nil {
+		return ids.Empty, err
+	}
+	return gh.hVM.GetBlockIDAtHeight(ctx, height)
+}
+
 func (gh *getter) Get(ctx context.Context, nodeID ids.NodeID, requestID uint32, blkID ids.ID) error {
 	blk, err := gh.vm.GetBlock(ctx, blkID)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
@@ -208,3 +209,88 @@ func TestFilterAccepted(t *testing.T) {
 		t.Fatalf("Blk shouldn't be accepted")
 	}
 }
+
+type HeightIndexEnabledVM struct {
+	*block.TestVM
+	*block.TestHeightIndexedVM
+}
+
+func TestGetAncestorsAtHeight(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testVM, sender, config := testSetup(t, ctrl)
+	config.MaxTimeGetAncestors = time.Second
+
+	blks := make([]*snowman.TestBlock, 3)
+	for i := range blks {
+		blks[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Accepted,
+			},
+			HeightV: uint64(i),
+			BytesV:  []byte{byte(i)},
+		}
+		if i > 0 {
+			blks[i].ParentV = blks[i-1].IDV
+		}
+	}
+
+	vm := HeightIndexEnabledVM{
+		TestVM: testVM.TestVM,
+		TestHeightIndexedVM: &block.TestHeightIndexedVM{
+			T: t,
+			VerifyHeightIndexF: func(context.Context) error {
+				return nil
+			},
+			GetBlockIDAtHeightF: func(_ context.Context, height uint64) (ids.ID, error) {
+				require.Less(height, uint64(len(blks)))
+				return blks[height].ID(), nil
+			},
+		},
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range blks {
+			if blk.ID() == blkID {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+
+	bs, err := New(vm, config)
+	require.NoError(err)
+
+	var ancestors [][]byte
+	sender.SendAncestorsF = func(_ context.Context, _ ids.NodeID, _ uint32, containers [][]byte) {
+		ancestors = containers
+	}
+
+	require.NoError(bs.GetAncestorsAtHeight(context.Background(), ids.EmptyNodeID, 0, 1))
+	require.Equal([][]byte{blks[1].Bytes(), blks[0].Bytes()}, ancestors)
+}
+
+func TestGetAncestorsAtHeightNotImplemented(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vm, sender, config := testSetup(t, ctrl)
+
+	bs, err := New(vm, config)
+	require.NoError(err)
+
+	sentAncestors := false
+	sender.SendAncestorsF = func(_ context.Context, _ ids.NodeID, _ uint32, containers [][]byte) {
+		sentAncestors = true
+		require.Empty(containers)
+	}
+
+	// The VM doesn't index blocks by height, so an empty response is sent.
+	require.NoError(bs.GetAncestorsAtHeight(context.Background(), ids.EmptyNodeID, 0, 1))
+	require.True(sentAncestors)
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/choices"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman/poll"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common/tracker"
+	"github.com/MetalBlockchain/metalgo/snow/events"
+	"github.com/MetalBlockchain/metalgo/utils"
+)
+
+var _ common.Inspector = (*Transitive)(nil)
+
+// Inspection is a snapshot of the state of the Transitive engine.
+type Inspection struct {
+	LastAccepted ids.ID `json:"lastAccepted"`
+	Preference   ids.ID `json:"preference"`
+
+	// Blocks are the last accepted block followed by the processing blocks.
+	Blocks []snowman.BlockState `json:"blocks"`
+
+	// Polls are the outstanding polls, from oldest to newest.
+	Polls []poll.Info `json:"polls"`
+
+	// Pending are the blocks waiting on their ancestors to be issued.
+	Pending []ids.ID `json:"pending"`
+
+	// Blocked are the operations waiting on blocks to be issued.
+	Blocked []events.Job `json:"blocked"`
+
+	// Voters are the response stats of the validators that have been polled.
+	Voters []tracker.VoterStats `json:"voters"`
+
+	// Graphviz describes the processing blocks in the DOT language, if it was
+	// requested.
+	Graphviz string `json:"graphviz,omitempty"`
+}
+
+func (t *Transitive) Inspect(_ context.Context, graphviz bool) (interface{}, error) {
+	pending := maps.Keys(t.pending)
+	utils.Sort(pending)
+
+	inspection := &Inspection{
+		LastAccepted: t.Consensus.LastAccepted(),
+		Preference:   t.Consensus.Preference(),
+		Blocks:       t.Consensus.Inspect(),
+		Polls:        t.polls.Inspect(),
+		Pending:      pending,
+		Blocked:      t.blocked.Jobs(),
+		Voters:       t.voters.Stats(),
+	}
+	if graphviz {
+		inspection.Graphviz = blocksGraphviz(inspection.Blocks)
+	}
+	return inspection, nil
+}
+
+// blocksGraphviz describes [blocks] as a tree in the DOT language. Accepted
+// blocks are boxed and blocks on the preferred chain are bold.
+func blocksGraphviz(blocks []snowman.BlockState) string {
+	sb := strings.Builder{}
+	sb.WriteString("digraph snowman {\n")
+	for _, blk := range blocks {
+		attributes := fmt.Sprintf("label=\"%s\\nheight %d\"", blk.ID, blk.Height)
+		if blk.Status == choices.Accepted {
+			attributes += ", shape=box"
+		}
+		if blk.Preferred {
+			attributes += ", style=bold"
+		}
+		sb.WriteString(fmt.Sprintf("    %q [%s];\n", blk.ID, attributes))
+		if blk.Status == choices.Processing {
+			sb.WriteString(fmt.Sprintf("    %q -> %q;\n", blk.ParentID, blk.ID))
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
@@ -5,6 +5,7 @@ package snowman
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
@@ -19,6 +20,10 @@ type issuer struct {
 	deps      set.Set[ids.ID]
 }
 
+func (i *issuer) String() string {
+	return fmt.Sprintf("issue block %s at height %d", i.blk.ID(), i.blk.Height())
+}
+
 func (i *issuer) Dependencies() set.Set[ids.ID] {
 	return i.deps
 }
@@ -50,5 +55,9 @@ func (i *issuer) Update(ctx context.Context) {
 		return
 	}
 	// Issue the block into consensus
+	if i.t.verifyConcurrently {
+		i.t.errs.Add(i.t.deliverConcurrently(ctx, i.blk))
+		return
+	}
 	i.t.errs.Add(i.t.deliver(ctx, i.blk))
 }
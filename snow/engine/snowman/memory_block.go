@@ -7,22 +7,26 @@ import (
 	"context"
 
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common/tracker"
 )
 
 var _ snowman.Block = (*memoryBlock)(nil)
 
-// memoryBlock wraps a snowman Block to manage non-verified blocks
+// memoryBlock wraps a snowman Block to manage non-verified blocks and to
+// report the decision of the block to the voter tracker
 type memoryBlock struct {
 	snowman.Block
 
 	tree    AncestorTree
 	metrics *metrics
+	voters  tracker.Voters
 }
 
 // Accept accepts the underlying block & removes sibling subtrees
 func (mb *memoryBlock) Accept(ctx context.Context) error {
 	mb.tree.RemoveSubtree(mb.Parent())
 	mb.metrics.numNonVerifieds.Set(float64(mb.tree.Len()))
+	mb.voters.Decided(mb.ID(), true)
 	return mb.Block.Accept(ctx)
 }
 
@@ -30,5 +34,6 @@ func (mb *memoryBlock) Accept(ctx context.Context) error {
 func (mb *memoryBlock) Reject(ctx context.Context) error {
 	mb.tree.RemoveSubtree(mb.ID())
 	mb.metrics.numNonVerifieds.Set(float64(mb.tree.Len()))
+	mb.voters.Decided(mb.ID(), false)
 	return mb.Block.Reject(ctx)
 }
@@ -13,7 +13,7 @@ import (
 type metrics struct {
 	bootstrapFinished, numRequests, numBlocked, numBlockers, numNonVerifieds prometheus.Gauge
 	numBuilt, numBuildsFailed, numUselessPutBytes, numUselessPushQueryBytes  prometheus.Counter
-	getAncestorsBlks                                                         metric.Averager
+	getAncestorsBlks, verifyBatchSize                                        metric.Averager
 }
 
 // Initialize the metrics
@@ -66,6 +66,13 @@ func (m *metrics) Initialize(namespace string, reg prometheus.Registerer) error
 		reg,
 		&errs,
 	)
+	m.verifyBatchSize = metric.NewAveragerWithErrs(
+		namespace,
+		"verify_batch_size",
+		"blocks verified concurrently",
+		reg,
+		&errs,
+	)
 	m.numNonVerifieds = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Name:      "non_verified_blks",
@@ -388,6 +388,9 @@ func (ss *stateSyncer) Start(ctx context.Context, startReqID uint32) error {
 		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
 		State: snow.StateSyncing,
 	})
+	ss.Ctx.BootstrapProgress.Set(snow.BootstrapProgress{
+		Phase: snow.PhaseStateSyncing,
+	})
 	if err := ss.VM.SetState(ctx, snow.StateSyncing); err != nil {
 		return fmt.Errorf("failed to notify VM that state syncing has started: %w", err)
 	}
@@ -580,8 +583,9 @@ func (*stateSyncer) Timeout(context.Context) error {
 func (ss *stateSyncer) HealthCheck(ctx context.Context) (interface{}, error) {
 	vmIntf, vmErr := ss.VM.HealthCheck(ctx)
 	intf := map[string]interface{}{
-		"consensus": struct{}{},
-		"vm":        vmIntf,
+		"consensus":     struct{}{},
+		"vm":            vmIntf,
+		"bootstrapping": ss.Ctx.BootstrapProgress.Get(),
 	}
 	return intf, vmErr
 }
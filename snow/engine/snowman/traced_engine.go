@@ -11,12 +11,17 @@ import (
 	oteltrace "go.opentelemetry.io/otel/trace"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/trace"
 )
 
-var _ Engine = (*tracedEngine)(nil)
+var (
+	_ Engine              = (*tracedEngine)(nil)
+	_ common.Inspector    = (*tracedEngine)(nil)
+	_ common.Reconfigurer = (*tracedEngine)(nil)
+)
 
 type tracedEngine struct {
 	common.Engine
@@ -40,3 +45,17 @@ func (e *tracedEngine) GetBlock(ctx context.Context, blkID ids.ID) (snowman.Bloc
 
 	return e.engine.GetBlock(ctx, blkID)
 }
+
+func (e *tracedEngine) Inspect(ctx context.Context, graphviz bool) (interface{}, error) {
+	ctx, span := e.tracer.Start(ctx, "tracedEngine.Inspect")
+	defer span.End()
+
+	return common.Inspect(ctx, e.engine, graphviz)
+}
+
+func (e *tracedEngine) Reconfigure(ctx context.Context, params avalanche.Parameters) error {
+	ctx, span := e.tracer.Start(ctx, "tracedEngine.Reconfigure")
+	defer span.End()
+
+	return common.Reconfigure(ctx, e.engine, params)
+}
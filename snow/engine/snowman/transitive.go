@@ -15,10 +15,12 @@ import (
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/choices"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman/poll"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common/tracker"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
 	"github.com/MetalBlockchain/metalgo/snow/events"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/bag"
@@ -54,6 +56,13 @@ type Transitive struct {
 	// track outstanding preference requests
 	polls poll.Set
 
+	// consensus parameters that will be applied once the outstanding polls
+	// have finished
+	pendingParams *snowball.Parameters
+
+	// tracks the quality of the responses of the validators we poll
+	voters tracker.Voters
+
 	// blocks that have we have sent get requests for but haven't yet received
 	blkReqs common.Requests
 
@@ -77,6 +86,14 @@ type Transitive struct {
 	// issuing another block, responding to a query, or applying votes to consensus
 	blocked events.Blocker
 
+	// true if the VM allows blocks to be verified concurrently
+	verifyConcurrently bool
+	// blocks whose dependencies have been met, in the order they became ready
+	// to be verified. Only used if [verifyConcurrently] is true.
+	toVerify []snowman.Block
+	// true while a batch of blocks is being verified and delivered
+	verifying bool
+
 	// number of times build block needs to be called once the number of
 	// processing blocks has gone below the optimal number.
 	pendingBuildBlocks int
@@ -100,7 +117,15 @@ func newTransitive(config Config) (*Transitive, error) {
 	acceptedFrontiers := tracker.NewAccepted()
 	config.Validators.RegisterCallbackListener(acceptedFrontiers)
 
-	factory := poll.NewEarlyTermNoTraversalFactory(config.Params.Alpha)
+	voters, err := tracker.NewVoters("", config.Ctx.Registerer)
+	if err != nil {
+		return nil, err
+	}
+
+	factory := poll.NewEarlyTermNoTraversalFactory(
+		config.Params.AlphaPreference,
+		config.Params.AlphaConfidence,
+	)
 	t := &Transitive{
 		Config:                      config,
 		StateSummaryFrontierHandler: common.NewNoOpStateSummaryFrontierHandler(config.Ctx.Log),
@@ -114,6 +139,7 @@ func newTransitive(config Config) (*Transitive, error) {
 		nonVerifieds:                NewAncestorTree(),
 		nonVerifiedCache:            nonVerifiedCache,
 		acceptedFrontiers:           acceptedFrontiers,
+		voters:                      voters,
 		polls: poll.NewSet(factory,
 			config.Ctx.Log,
 			"",
@@ -253,12 +279,14 @@ func (t *Transitive) Chits(ctx context.Context, nodeID ids.NodeID, requestID uin
 			zap.Stringer("nodeID", nodeID),
 			zap.Uint32("requestID", requestID),
 		)
+		t.voters.Invalid(nodeID, requestID)
 		// because QueryFailed doesn't utilize the assumption that we actually
 		// sent a Query message, we can safely call QueryFailed here to
 		// potentially abandon the request.
 		return t.QueryFailed(ctx, nodeID, requestID)
 	}
 	blkID := votes[0]
+	t.voters.Responded(nodeID, requestID, blkID)
 
 	t.Ctx.Log.Verbo("called Chits for the block",
 		zap.Stringer("blkID", blkID),
@@ -288,6 +316,8 @@ func (t *Transitive) Chits(ctx context.Context, nodeID ids.NodeID, requestID uin
 }
 
 func (t *Transitive) QueryFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	t.voters.Failed(nodeID, requestID)
+
 	lastAccepted := t.acceptedFrontiers.AcceptedFrontier(nodeID)
 	if len(lastAccepted) == 1 {
 		// Chits calls QueryFailed if [votes] doesn't have length 1, so this
@@ -376,6 +406,13 @@ func (t *Transitive) Start(ctx context.Context, startReqID uint32) error {
 		return err
 	}
 
+	if vm, ok := t.VM.(block.ConcurrentVerifyChainVM); ok {
+		t.verifyConcurrently, err = vm.ConcurrentVerifyEnabled(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
 	// initialize consensus to the last accepted blockID
 	if err := t.Consensus.Initialize(t.Ctx, t.Params, lastAcceptedID, lastAccepted.Height(), lastAccepted.Timestamp()); err != nil {
 		return err
@@ -408,6 +445,7 @@ func (t *Transitive) Start(ctx context.Context, startReqID uint32) error {
 
 	t.Ctx.Log.Info("consensus starting",
 		zap.Stringer("lastAcceptedBlock", lastAcceptedID),
+		zap.Bool("verifyConcurrently", t.verifyConcurrently),
 	)
 	t.metrics.bootstrapFinished.Set(1)
 
@@ -415,6 +453,12 @@ func (t *Transitive) Start(ctx context.Context, startReqID uint32) error {
 		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
 		State: snow.NormalOp,
 	})
+
+	progress := t.Ctx.BootstrapProgress.Get()
+	progress.Phase = snow.PhaseDone
+	progress.ETA = 0
+	t.Ctx.BootstrapProgress.Set(progress)
+
 	if err := t.VM.SetState(ctx, snow.NormalOp); err != nil {
 		return fmt.Errorf("failed to notify VM that consensus is starting: %w",
 			err)
@@ -682,6 +726,11 @@ func (t *Transitive) sendRequest(ctx context.Context, nodeID ids.NodeID, blkID i
 
 // send a pull query for this block ID
 func (t *Transitive) pullQuery(ctx context.Context, blkID ids.ID) {
+	// New polls are held back until the pending parameters are applied.
+	if t.pendingParams != nil {
+		return
+	}
+
 	t.Ctx.Log.Verbo("sampling from validators",
 		zap.Stringer("validators", t.Validators),
 	)
@@ -700,6 +749,9 @@ func (t *Transitive) pullQuery(ctx context.Context, blkID ids.ID) {
 
 	t.RequestID++
 	if t.polls.Add(t.RequestID, vdrBag) {
+		for _, vdrID := range vdrBag.List() {
+			t.voters.Queried(vdrID, t.RequestID)
+		}
 		vdrList := vdrBag.List()
 		vdrSet := set.NewSet[ids.NodeID](len(vdrList))
 		vdrSet.Add(vdrList...)
@@ -710,6 +762,11 @@ func (t *Transitive) pullQuery(ctx context.Context, blkID ids.ID) {
 // Send a query for this block. Some validators will be sent
 // a Push Query and some will be sent a Pull Query.
 func (t *Transitive) sendMixedQuery(ctx context.Context, blk snowman.Block) {
+	// New polls are held back until the pending parameters are applied.
+	if t.pendingParams != nil {
+		return
+	}
+
 	t.Ctx.Log.Verbo("sampling from validators",
 		zap.Stringer("validators", t.Validators),
 	)
@@ -729,6 +786,9 @@ func (t *Transitive) sendMixedQuery(ctx context.Context, blk snowman.Block) {
 
 	t.RequestID++
 	if t.polls.Add(t.RequestID, vdrBag) {
+		for _, vdrID := range vdrBag.List() {
+			t.voters.Queried(vdrID, t.RequestID)
+		}
 		// Send a push query to some of the validators, and a pull query to the rest.
 		numPushTo := t.Params.MixedQueryNumPushVdr
 		if !t.Validators.Contains(t.Ctx.NodeID) {
@@ -748,9 +808,19 @@ func (t *Transitive) sendMixedQuery(ctx context.Context, blk snowman.Block) {
 
 // issue [blk] to consensus
 func (t *Transitive) deliver(ctx context.Context, blk snowman.Block) error {
+	if !t.readyToVerify(ctx, blk) {
+		return t.errs.Err
+	}
+	return t.deliverVerified(ctx, blk, blk.Verify(ctx))
+}
+
+// readyToVerify returns true if [blk] should be verified and issued to
+// consensus. If the parent of [blk] isn't processing or accepted, [blk] is
+// abandoned.
+func (t *Transitive) readyToVerify(ctx context.Context, blk snowman.Block) bool {
 	blkID := blk.ID()
 	if t.Consensus.Decided(blk) || t.Consensus.Processing(blkID) {
-		return nil
+		return false
 	}
 
 	// we are no longer waiting on adding the block to consensus, so it is no
@@ -767,13 +837,20 @@ func (t *Transitive) deliver(ctx context.Context, blk snowman.Block) error {
 		t.blocked.Abandon(ctx, blkID)
 		t.metrics.numBlocked.Set(float64(len(t.pending))) // Tracks performance statistics
 		t.metrics.numBlockers.Set(float64(t.blocked.Len()))
-		return t.errs.Err
+		return false
 	}
 
 	// By ensuring that the parent is either processing or accepted, it is
 	// guaranteed that the parent was successfully verified. This means that
 	// calling Verify on this block is allowed.
-	blkAdded, err := t.addUnverifiedBlockToConsensus(ctx, blk)
+	return true
+}
+
+// deliverVerified issues [blk] to consensus, where [verifyErr] is the result
+// of verifying [blk].
+func (t *Transitive) deliverVerified(ctx context.Context, blk snowman.Block, verifyErr error) error {
+	blkID := blk.ID()
+	blkAdded, err := t.addVerifiedBlockToConsensus(ctx, blk, verifyErr)
 	if err != nil {
 		return err
 	}
@@ -878,10 +955,17 @@ func (t *Transitive) addToNonVerifieds(blk snowman.Block) {
 // addUnverifiedBlockToConsensus returns whether the block was added and an
 // error if one occurred while adding it to consensus.
 func (t *Transitive) addUnverifiedBlockToConsensus(ctx context.Context, blk snowman.Block) (bool, error) {
+	return t.addVerifiedBlockToConsensus(ctx, blk, blk.Verify(ctx))
+}
+
+// addVerifiedBlockToConsensus returns whether the block was added and an error
+// if one occurred while adding it to consensus. [verifyErr] is the result of
+// verifying [blk].
+func (t *Transitive) addVerifiedBlockToConsensus(ctx context.Context, blk snowman.Block, verifyErr error) (bool, error) {
 	// make sure this block is valid
-	if err := blk.Verify(ctx); err != nil {
+	if verifyErr != nil {
 		t.Ctx.Log.Debug("block verification failed",
-			zap.Error(err),
+			zap.Error(verifyErr),
 		)
 
 		// if verify fails, then all descendants are also invalid
@@ -900,5 +984,6 @@ func (t *Transitive) addUnverifiedBlockToConsensus(ctx context.Context, blk snow
 		Block:   blk,
 		metrics: &t.metrics,
 		tree:    t.nonVerifieds,
+		voters:  t.voters,
 	})
 }
@@ -14,6 +14,7 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow/choices"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
@@ -409,7 +410,8 @@ func TestEngineMultipleQuery(t *testing.T) {
 	engCfg := DefaultConfigs()
 	engCfg.Params = snowball.Parameters{
 		K:                       3,
-		Alpha:                   2,
+		AlphaPreference:         2,
+		AlphaConfidence:         2,
 		BetaVirtuous:            1,
 		BetaRogue:               2,
 		ConcurrentRepolls:       1,
@@ -901,7 +903,8 @@ func TestVoteCanceling(t *testing.T) {
 	engCfg := DefaultConfigs()
 	engCfg.Params = snowball.Parameters{
 		K:                       3,
-		Alpha:                   2,
+		AlphaPreference:         2,
+		AlphaConfidence:         2,
 		BetaVirtuous:            1,
 		BetaRogue:               2,
 		ConcurrentRepolls:       1,
@@ -1912,7 +1915,8 @@ func TestEngineDoubleChit(t *testing.T) {
 	engCfg := DefaultConfigs()
 	engCfg.Params = snowball.Parameters{
 		K:                       2,
-		Alpha:                   2,
+		AlphaPreference:         2,
+		AlphaConfidence:         2,
 		BetaVirtuous:            1,
 		BetaRogue:               2,
 		ConcurrentRepolls:       1,
@@ -2052,7 +2056,8 @@ func TestEngineDoubleChit(t *testing.T) {
 func TestEngineBuildBlockLimit(t *testing.T) {
 	engCfg := DefaultConfigs()
 	engCfg.Params.K = 1
-	engCfg.Params.Alpha = 1
+	engCfg.Params.AlphaPreference = 1
+	engCfg.Params.AlphaConfidence = 1
 	engCfg.Params.OptimalProcessing = 1
 
 	vals := validators.NewSet()
@@ -3141,7 +3146,8 @@ func TestSendMixedQuery(t *testing.T) {
 				commonCfg := common.DefaultConfigTest()
 				// Override the parameters k and MixedQueryNumPushNonVdr,
 				// and update the validator set to have k validators.
-				engConfig.Params.Alpha = 12
+				engConfig.Params.AlphaPreference = 12
+				engConfig.Params.AlphaConfidence = 12
 				engConfig.Params.MixedQueryNumPushNonVdr = 12
 				engConfig.Params.MixedQueryNumPushVdr = 14
 				engConfig.Params.K = 20
@@ -3411,7 +3417,8 @@ func TestEngineApplyAcceptedFrontierInQueryFailed(t *testing.T) {
 	engCfg := DefaultConfigs()
 	engCfg.Params = snowball.Parameters{
 		K:                       1,
-		Alpha:                   1,
+		AlphaPreference:         1,
+		AlphaConfidence:         1,
 		BetaVirtuous:            2,
 		BetaRogue:               2,
 		ConcurrentRepolls:       1,
@@ -3506,3 +3513,319 @@ func TestEngineApplyAcceptedFrontierInQueryFailed(t *testing.T) {
 
 	require.Equal(choices.Accepted, blk.Status())
 }
+
+func TestEngineInspect(t *testing.T) {
+	require := require.New(t)
+
+	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
+
+	sender.Default(false)
+
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Unknown,
+		},
+		ParentV: gBlk.ID(),
+		HeightV: 1,
+		BytesV:  []byte{1},
+	}
+	blk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: blk0.IDV,
+		HeightV: 2,
+		BytesV:  []byte{2},
+	}
+
+	sender.SendGetF = func(context.Context, ids.NodeID, uint32, ids.ID) {}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case gBlk.ID():
+			return gBlk, nil
+		case blk0.ID():
+			return blk0, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	require.NoError(te.issue(context.Background(), blk1))
+
+	intf, err := te.Inspect(context.Background(), false)
+	require.NoError(err)
+	inspection := intf.(*Inspection)
+	require.Equal(gBlk.ID(), inspection.LastAccepted)
+	require.Len(inspection.Blocks, 1)
+	require.Empty(inspection.Polls)
+	require.Equal([]ids.ID{blk1.ID()}, inspection.Pending)
+	require.Len(inspection.Blocked, 1)
+	require.Equal([]ids.ID{blk0.ID()}, inspection.Blocked[0].Dependencies)
+	require.Empty(inspection.Graphviz)
+
+	blk0.StatusV = choices.Processing
+	require.NoError(te.issue(context.Background(), blk0))
+
+	intf, err = te.Inspect(context.Background(), true)
+	require.NoError(err)
+	inspection = intf.(*Inspection)
+	require.Equal(blk1.ID(), inspection.Preference)
+	require.Len(inspection.Blocks, 3)
+	require.Equal(blk0.ID(), inspection.Blocks[1].ID)
+	require.Equal(blk1.ID(), inspection.Blocks[2].ID)
+	require.NotEmpty(inspection.Polls)
+	require.Equal([]ids.NodeID{vdr}, inspection.Polls[0].Pending)
+	require.Empty(inspection.Pending)
+	require.Empty(inspection.Blocked)
+	require.Contains(inspection.Graphviz, fmt.Sprintf("%q -> %q", blk0.ID(), blk1.ID()))
+}
+
+func TestEngineVoterStats(t *testing.T) {
+	require := require.New(t)
+
+	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
+
+	sender.Default(false)
+
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: gBlk.ID(),
+		HeightV: 1,
+		BytesV:  []byte{1},
+	}
+	blk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: blk0.IDV,
+		HeightV: 2,
+		BytesV:  []byte{2},
+	}
+
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case gBlk.ID():
+			return gBlk, nil
+		case blk0.ID():
+			return blk0, nil
+		case blk1.ID():
+			return blk1, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	var (
+		numQueries     int
+		queryRequestID uint32
+	)
+	sender.SendPushQueryF = func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ []byte) {
+		numQueries++
+		queryRequestID = requestID
+	}
+	sender.SendPullQueryF = func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ ids.ID) {
+		numQueries++
+		queryRequestID = requestID
+	}
+
+	require.NoError(te.issue(context.Background(), blk0))
+	require.NoError(te.Chits(context.Background(), vdr, queryRequestID, []ids.ID{blk0.ID()}, nil))
+	require.Equal(choices.Accepted, blk0.Status())
+
+	require.NoError(te.issue(context.Background(), blk1))
+	require.NoError(te.QueryFailed(context.Background(), vdr, queryRequestID))
+
+	// Chits must contain exactly one vote.
+	require.NoError(te.Chits(context.Background(), vdr, queryRequestID, []ids.ID{blk0.ID(), blk1.ID()}, nil))
+
+	intf, err := te.Inspect(context.Background(), false)
+	require.NoError(err)
+	inspection := intf.(*Inspection)
+	require.Len(inspection.Voters, 1)
+
+	stats := inspection.Voters[0]
+	require.Equal(vdr, stats.NodeID)
+	require.EqualValues(numQueries, stats.Queries)
+	require.EqualValues(1, stats.Responses)
+	require.EqualValues(1, stats.Timeouts)
+	require.EqualValues(1, stats.InvalidResponses)
+	require.EqualValues(1, stats.Agreements)
+	require.Zero(stats.Disagreements)
+}
+
+func TestEngineReconfigure(t *testing.T) {
+	require := require.New(t)
+
+	vdr, _, sender, vm, te, gBlk := setupDefaultConfig(t)
+
+	sender.Default(false)
+
+	blks := make([]*snowman.TestBlock, 3)
+	parentID := gBlk.ID()
+	for i := range blks {
+		blks[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			ParentV: parentID,
+			HeightV: uint64(i + 1),
+			BytesV:  []byte{byte(i)},
+		}
+		parentID = blks[i].ID()
+	}
+
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		if blkID == gBlk.ID() {
+			return gBlk, nil
+		}
+		for _, blk := range blks {
+			if blkID == blk.ID() {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+
+	var (
+		numQueries     int
+		queryRequestID uint32
+	)
+	sender.SendPushQueryF = func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ []byte) {
+		numQueries++
+		queryRequestID = requestID
+	}
+	sender.SendPullQueryF = func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ ids.ID) {
+		numQueries++
+		queryRequestID = requestID
+	}
+
+	require.NoError(te.issue(context.Background(), blks[0]))
+	require.Equal(1, numQueries)
+
+	params := avalanche.Parameters{
+		Parameters: te.Params,
+		Parents:    2,
+		BatchSize:  1,
+	}
+	params.BetaVirtuous = 2
+	params.BetaRogue = 2
+
+	invalidParams := params
+	invalidParams.BetaVirtuous = 0
+	require.Error(te.Reconfigure(context.Background(), invalidParams))
+
+	// The parameters can't be applied while a poll is outstanding, so no new
+	// polls are issued.
+	require.NoError(te.Reconfigure(context.Background(), params))
+	require.Equal(1, te.Params.BetaVirtuous)
+	require.NoError(te.issue(context.Background(), blks[1]))
+	require.Equal(1, numQueries)
+
+	// Finishing the outstanding poll applies the parameters.
+	require.NoError(te.Chits(context.Background(), vdr, queryRequestID, []ids.ID{blks[1].ID()}, nil))
+	require.Equal(choices.Accepted, blks[1].Status())
+	require.Equal(params.Parameters, te.Params)
+
+	// New blocks are decided with the new parameters.
+	require.NoError(te.issue(context.Background(), blks[2]))
+	require.Equal(2, numQueries)
+	require.NoError(te.Chits(context.Background(), vdr, queryRequestID, []ids.ID{blks[2].ID()}, nil))
+	require.Equal(choices.Processing, blks[2].Status())
+	require.Equal(3, numQueries)
+	require.NoError(te.Chits(context.Background(), vdr, queryRequestID, []ids.ID{blks[2].ID()}, nil))
+	require.Equal(choices.Accepted, blks[2].Status())
+}
+
+func TestEngineVerifyConcurrently(t *testing.T) {
+	require := require.New(t)
+
+	_, _, sender, vm, te, gBlk := setupDefaultConfig(t)
+	sender.Default(false)
+	sender.SendGetF = func(context.Context, ids.NodeID, uint32, ids.ID) {}
+	sender.SendPushQueryF = func(context.Context, set.Set[ids.NodeID], uint32, []byte) {}
+	sender.SendPullQueryF = func(context.Context, set.Set[ids.NodeID], uint32, ids.ID) {}
+
+	te.verifyConcurrently = true
+
+	parent := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: gBlk.ID(),
+		HeightV: 1,
+		BytesV:  []byte{1},
+	}
+	children := make([]*snowman.TestBlock, 3)
+	for i := range children {
+		children[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			ParentV: parent.ID(),
+			HeightV: 2,
+			BytesV:  []byte{2, byte(i)},
+		}
+	}
+	children[1].VerifyV = errInvalid
+
+	parentIssued := false
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch {
+		case blkID == gBlk.ID():
+			return gBlk, nil
+		case blkID == parent.ID() && parentIssued:
+			return parent, nil
+		}
+		for _, blk := range children {
+			if blkID == blk.ID() {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+
+	// The children are blocked on their parent being issued.
+	for _, blk := range children {
+		require.NoError(te.issue(context.Background(), blk))
+	}
+	require.Zero(te.Consensus.NumProcessing())
+
+	// Issuing the parent makes all the children ready to be verified at once.
+	parentIssued = true
+	require.NoError(te.issue(context.Background(), parent))
+
+	require.True(te.Consensus.Processing(parent.ID()))
+	require.True(te.Consensus.Processing(children[0].ID()))
+	require.False(te.Consensus.Processing(children[1].ID()))
+	require.True(te.nonVerifieds.Has(children[1].ID()))
+	require.True(te.Consensus.Processing(children[2].ID()))
+	require.Empty(te.pending)
+	require.Empty(te.toVerify)
+
+	// The parent was verified on its own, followed by a batch of the
+	// children.
+	metrics, err := te.Ctx.Registerer.Gather()
+	require.NoError(err)
+	batchSizes := map[string]float64{}
+	for _, metric := range metrics {
+		switch name := metric.GetName(); name {
+		case "verify_batch_size_count":
+			batchSizes[name] = metric.Metric[0].GetCounter().GetValue()
+		case "verify_batch_size_sum":
+			batchSizes[name] = metric.Metric[0].GetGauge().GetValue()
+		}
+	}
+	require.Equal(map[string]float64{
+		"verify_batch_size_count": 2,
+		"verify_batch_size_sum":   4,
+	}, batchSizes)
+}
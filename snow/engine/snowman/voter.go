@@ -5,6 +5,7 @@ package snowman
 
 import (
 	"context"
+	"fmt"
 
 	"go.uber.org/zap"
 
@@ -22,6 +23,10 @@ type voter struct {
 	deps      set.Set[ids.ID]
 }
 
+func (v *voter) String() string {
+	return fmt.Sprintf("record vote from %s for request %d", v.vdr, v.requestID)
+}
+
 func (v *voter) Dependencies() set.Set[ids.ID] {
 	return v.deps
 }
@@ -74,6 +79,12 @@ func (v *voter) Update(ctx context.Context) {
 		return
 	}
 
+	// The finished polls may have been the last ones outstanding.
+	if err := v.t.applyPendingParams(); err != nil {
+		v.t.errs.Add(err)
+		return
+	}
+
 	if err := v.t.VM.SetPreference(ctx, v.t.Consensus.Preference()); err != nil {
 		v.t.errs.Add(err)
 		return
@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"strings"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils"
 )
 
 const (
@@ -70,6 +73,45 @@ func (b *Blocker) Register(ctx context.Context, pending Blockable) {
 	pending.Update(ctx)
 }
 
+// Job is a snapshot of a registered Blockable.
+type Job struct {
+	// Description is the String of the Blockable, if it implements
+	// fmt.Stringer.
+	Description  string   `json:"description"`
+	Dependencies []ids.ID `json:"dependencies"`
+}
+
+// Jobs returns the registered Blockables that are still waiting on at least
+// one of their dependencies, sorted by their description.
+func (b *Blocker) Jobs() []Job {
+	var (
+		seen = make(map[Blockable]struct{})
+		jobs []Job
+	)
+	for _, blocking := range *b {
+		for _, pending := range blocking {
+			if _, ok := seen[pending]; ok {
+				continue
+			}
+			seen[pending] = struct{}{}
+
+			deps := pending.Dependencies().List()
+			utils.Sort(deps)
+			job := Job{
+				Dependencies: deps,
+			}
+			if stringer, ok := pending.(fmt.Stringer); ok {
+				job.Description = stringer.String()
+			}
+			jobs = append(jobs, job)
+		}
+	}
+	slices.SortFunc(jobs, func(i, j Job) bool {
+		return i.Description < j.Description
+	})
+	return jobs
+}
+
 // PrefixedString returns the same value as the String function, with all the
 // new lines prefixed by [prefix]
 func (b *Blocker) PrefixedString(prefix string) string {
@@ -7,7 +7,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 )
 
@@ -111,3 +114,30 @@ func (b *testBlockable) Abandon(ctx context.Context, id ids.ID) {
 func (b *testBlockable) Update(ctx context.Context) {
 	b.update(ctx)
 }
+
+func TestBlockerJobs(t *testing.T) {
+	require := require.New(t)
+
+	b := Blocker(nil)
+	require.Empty(b.Jobs())
+
+	id0 := ids.GenerateTestID()
+	id1 := ids.GenerateTestID()
+	deps := []ids.ID{id0, id1}
+	utils.Sort(deps)
+
+	a := newTestBlockable()
+	a.dependencies = func() set.Set[ids.ID] {
+		s := set.Set[ids.ID]{}
+		s.Add(id0, id1)
+		return s
+	}
+	b.Register(context.Background(), a)
+
+	// A Blockable waiting on multiple dependencies is only reported once.
+	require.Equal([]Job{{Dependencies: deps}}, b.Jobs())
+
+	b.Fulfill(context.Background(), id0)
+	b.Fulfill(context.Background(), id1)
+	require.Empty(b.Jobs())
+}
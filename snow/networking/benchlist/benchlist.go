@@ -5,6 +5,7 @@ package benchlist
 
 import (
 	"container/heap"
+	"errors"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -14,6 +15,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
@@ -24,7 +26,12 @@ import (
 	safemath "github.com/MetalBlockchain/metalgo/utils/math"
 )
 
-var _ heap.Interface = (*benchedQueue)(nil)
+var (
+	errNonPositiveDuration = errors.New("bench duration must be positive")
+	errInvalidMaxPortion   = errors.New("max portion of benched stake must be in [0,1)")
+
+	_ heap.Interface = (*benchedQueue)(nil)
+)
 
 // If a peer consistently does not respond to queries, it will
 // increase latencies on the network whenever that peer is polled.
@@ -43,6 +50,20 @@ type Benchlist interface {
 	// IsBenched returns true if messages to [validatorID]
 	// should not be sent over the network and should immediately fail.
 	IsBenched(nodeID ids.NodeID) bool
+	// Benched returns the nodes that are currently benched and the time
+	// that each of them will leave the bench.
+	Benched() map[ids.NodeID]time.Time
+	// Bench benches [nodeID] for [duration], regardless of its recent failures
+	// or the portion of stake that is already benched. If [nodeID] is already
+	// benched, it is instead benched for [duration] from now.
+	Bench(nodeID ids.NodeID, duration time.Duration) error
+	// Unbench removes [nodeID] from the bench. It is a no-op if [nodeID]
+	// isn't benched.
+	Unbench(nodeID ids.NodeID) error
+	// SetMaxPortion replaces the maximum portion of stake that may be benched.
+	// Validators that are already benched stay benched until their bench
+	// expires.
+	SetMaxPortion(maxPortion float64) error
 }
 
 // Data about a validator who is benched
@@ -112,6 +133,11 @@ type benchlist struct {
 	// Validator set of the network
 	vdrs validators.Set
 
+	// Benched validators and the time they leave the bench, so that they
+	// remain benched across restarts.
+	// Node ID --> benched until
+	db database.Database
+
 	// Validator ID --> Consecutive failure information
 	// [streaklock] must be held when touching [failureStreaks]
 	streaklock     sync.Mutex
@@ -138,20 +164,23 @@ type benchlist struct {
 	maxPortion float64
 }
 
-// NewBenchlist returns a new Benchlist
+// NewBenchlist returns a new Benchlist. The validators that were benched in
+// [db], and whose bench hasn't expired yet, are benched again.
 func NewBenchlist(
 	chainID ids.ID,
 	log logging.Logger,
 	benchable Benchable,
 	validators validators.Set,
+	db database.Database,
 	threshold int,
 	minimumFailingDuration,
 	duration time.Duration,
 	maxPortion float64,
 	registerer prometheus.Registerer,
+	clock mockable.Clock,
 ) (Benchlist, error) {
-	if maxPortion < 0 || maxPortion >= 1 {
-		return nil, fmt.Errorf("max portion of benched stake must be in [0,1) but got %f", maxPortion)
+	if err := verifyMaxPortion(maxPortion); err != nil {
+		return nil, err
 	}
 	benchlist := &benchlist{
 		chainID:                chainID,
@@ -160,14 +189,70 @@ func NewBenchlist(
 		benchlistSet:           set.Set[ids.NodeID]{},
 		benchable:              benchable,
 		vdrs:                   validators,
+		db:                     db,
 		threshold:              threshold,
 		minimumFailingDuration: minimumFailingDuration,
 		duration:               duration,
 		maxPortion:             maxPortion,
+		clock:                  clock,
 	}
-	benchlist.timer = timer.NewTimer(benchlist.update)
+	if err := benchlist.metrics.Initialize(registerer); err != nil {
+		return nil, err
+	}
+	benchlist.timer = timer.NewTimerWithClock(clock, benchlist.update)
 	go benchlist.timer.Dispatch()
-	return benchlist, benchlist.metrics.Initialize(registerer)
+	if err := benchlist.load(); err != nil {
+		benchlist.timer.Stop()
+		return nil, err
+	}
+	return benchlist, nil
+}
+
+// load benches the validators in [b.db] whose bench hasn't expired yet, and
+// removes the expired ones from [b.db].
+func (b *benchlist) load() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var (
+		now     = b.clock.Time()
+		expired []ids.NodeID
+		it      = b.db.NewIterator()
+	)
+	defer it.Release()
+
+	for it.Next() {
+		nodeID, err := ids.ToNodeID(it.Key())
+		if err != nil {
+			return err
+		}
+		benchedUntil, err := database.ParseTimestamp(it.Value())
+		if err != nil {
+			return err
+		}
+		if !now.Before(benchedUntil) {
+			expired = append(expired, nodeID)
+			continue
+		}
+
+		b.log.Debug("restoring node to benchlist",
+			zap.Stringer("nodeID", nodeID),
+			zap.Duration("benchDuration", benchedUntil.Sub(now)),
+		)
+		b.add(nodeID, benchedUntil)
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	for _, nodeID := range expired {
+		if err := b.db.Delete(nodeID[:]); err != nil {
+			return err
+		}
+	}
+
+	b.setNextLeaveTime()
+	return nil
 }
 
 // Update removes benched validators whose time on the bench is over
@@ -183,7 +268,12 @@ func (b *benchlist) update() {
 		if next == nil {
 			break
 		}
-		b.remove(next)
+		if err := b.remove(next); err != nil {
+			b.log.Error("failed to remove node from persisted benchlist",
+				zap.Stringer("nodeID", next.nodeID),
+				zap.Error(err),
+			)
+		}
 	}
 	// Set next time update will be called
 	b.setNextLeaveTime()
@@ -191,7 +281,7 @@ func (b *benchlist) update() {
 
 // Remove [validator] from the benchlist
 // Assumes [b.lock] is held
-func (b *benchlist) remove(node *benchData) {
+func (b *benchlist) remove(node *benchData) error {
 	// Update state
 	id := node.nodeID
 	b.log.Debug("removing node from benchlist",
@@ -201,7 +291,30 @@ func (b *benchlist) remove(node *benchData) {
 	b.benchlistSet.Remove(id)
 	b.benchable.Unbenched(b.chainID, id)
 
-	// Update metrics
+	b.updateMetrics()
+	return b.db.Delete(id[:])
+}
+
+// add benches [nodeID] until [benchedUntil] without persisting it.
+// Assumes [b.lock] is held
+// Assumes [nodeID] is not already benched
+func (b *benchlist) add(nodeID ids.NodeID, benchedUntil time.Time) {
+	b.benchlistSet.Add(nodeID)
+	b.benchable.Benched(b.chainID, nodeID)
+
+	b.streaklock.Lock()
+	delete(b.failureStreaks, nodeID)
+	b.streaklock.Unlock()
+
+	heap.Push(
+		&b.benchedQueue,
+		&benchData{nodeID: nodeID, benchedUntil: benchedUntil},
+	)
+	b.updateMetrics()
+}
+
+// Assumes [b.lock] is held
+func (b *benchlist) updateMetrics() {
 	b.metrics.numBenched.Set(float64(b.benchedQueue.Len()))
 	benchedStake := b.vdrs.SubsetWeight(b.benchlistSet)
 	b.metrics.weightBenched.Set(float64(benchedStake))
@@ -252,6 +365,88 @@ func (b *benchlist) isBenched(nodeID ids.NodeID) bool {
 	return false
 }
 
+func (b *benchlist) Benched() map[ids.NodeID]time.Time {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	benched := make(map[ids.NodeID]time.Time, b.benchedQueue.Len())
+	for _, node := range b.benchedQueue {
+		benched[node.nodeID] = node.benchedUntil
+	}
+	return benched
+}
+
+func (b *benchlist) Bench(nodeID ids.NodeID, duration time.Duration) error {
+	if duration <= 0 {
+		return errNonPositiveDuration
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	benchedUntil := b.clock.Time().Add(duration)
+	if err := database.PutTimestamp(b.db, nodeID[:], benchedUntil); err != nil {
+		return err
+	}
+
+	if node, ok := b.get(nodeID); ok {
+		node.benchedUntil = benchedUntil
+		heap.Fix(&b.benchedQueue, node.index)
+	} else {
+		b.add(nodeID, benchedUntil)
+		b.metrics.numBenchings.Inc()
+	}
+	b.log.Info("benching node by request",
+		zap.Stringer("nodeID", nodeID),
+		zap.Duration("benchDuration", duration),
+	)
+
+	b.setNextLeaveTime()
+	return nil
+}
+
+func (b *benchlist) Unbench(nodeID ids.NodeID) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	node, ok := b.get(nodeID)
+	if !ok {
+		return nil
+	}
+	b.log.Info("unbenching node by request",
+		zap.Stringer("nodeID", nodeID),
+	)
+	err := b.remove(node)
+	b.setNextLeaveTime()
+	return err
+}
+
+func (b *benchlist) SetMaxPortion(maxPortion float64) error {
+	if err := verifyMaxPortion(maxPortion); err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.maxPortion = maxPortion
+	return nil
+}
+
+// get returns the bench data of [nodeID], if it is benched.
+// Assumes [b.lock] is held
+func (b *benchlist) get(nodeID ids.NodeID) (*benchData, bool) {
+	if !b.benchlistSet.Contains(nodeID) {
+		return nil, false
+	}
+	for _, node := range b.benchedQueue {
+		if node.nodeID == nodeID {
+			return node, true
+		}
+	}
+	return nil, false
+}
+
 // RegisterResponse notes that we received a response from validator [validatorID]
 func (b *benchlist) RegisterResponse(nodeID ids.NodeID) {
 	b.streaklock.Lock()
@@ -328,19 +523,19 @@ func (b *benchlist) bench(nodeID ids.NodeID) {
 	diff := maxBenchedUntil.Sub(minBenchedUntil)
 	benchedUntil := minBenchedUntil.Add(time.Duration(rand.Float64() * float64(diff))) // #nosec G404
 
-	// Add to benchlist times with randomized delay
-	b.benchlistSet.Add(nodeID)
-	b.benchable.Benched(b.chainID, nodeID)
-
-	b.streaklock.Lock()
-	delete(b.failureStreaks, nodeID)
-	b.streaklock.Unlock()
+	// The validator is benched even if it can't be persisted, so that
+	// queries to it keep failing fast until the node restarts.
+	if err := database.PutTimestamp(b.db, nodeID[:], benchedUntil); err != nil {
+		b.log.Error("failed to persist benched node",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
 
-	heap.Push(
-		&b.benchedQueue,
-		&benchData{nodeID: nodeID, benchedUntil: benchedUntil},
-	)
-	b.log.Debug("benching validator after consecutive failed queries",
+	// Add to benchlist times with randomized delay
+	b.add(nodeID, benchedUntil)
+	b.metrics.numBenchings.Inc()
+	b.log.Info("benching validator after consecutive failed queries",
 		zap.Stringer("nodeID", nodeID),
 		zap.Duration("benchDuration", benchedUntil.Sub(now)),
 		zap.Int("numFailedQueries", b.threshold),
@@ -348,8 +543,11 @@ func (b *benchlist) bench(nodeID ids.NodeID) {
 
 	// Set [b.timer] to fire when next validator should leave bench
 	b.setNextLeaveTime()
+}
 
-	// Update metrics
-	b.metrics.numBenched.Set(float64(b.benchedQueue.Len()))
-	b.metrics.weightBenched.Set(float64(newBenchedStake))
+func verifyMaxPortion(maxPortion float64) error {
+	if maxPortion < 0 || maxPortion >= 1 {
+		return fmt.Errorf("%w but got %f", errInvalidMaxPortion, maxPortion)
+	}
+	return nil
 }
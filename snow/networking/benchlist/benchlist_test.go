@@ -8,12 +8,17 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 	"github.com/MetalBlockchain/metalgo/utils/wrappers"
 )
 
@@ -51,11 +56,13 @@ func TestBenchlistAdd(t *testing.T) {
 		logging.NoLog{},
 		benchable,
 		vdrs,
+		memdb.New(),
 		threshold,
 		minimumFailingDuration,
 		duration,
 		maxPortion,
 		prometheus.NewRegistry(),
+		mockable.Clock{},
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -189,11 +196,13 @@ func TestBenchlistMaxStake(t *testing.T) {
 		logging.NoLog{},
 		&TestBenchable{T: t},
 		vdrs,
+		memdb.New(),
 		threshold,
 		minimumFailingDuration,
 		duration,
 		maxPortion,
 		prometheus.NewRegistry(),
+		mockable.Clock{},
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -329,11 +338,13 @@ func TestBenchlistRemove(t *testing.T) {
 		logging.NoLog{},
 		benchable,
 		vdrs,
+		memdb.New(),
 		threshold,
 		minimumFailingDuration,
 		duration,
 		maxPortion,
 		prometheus.NewRegistry(),
+		mockable.Clock{},
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -414,3 +425,178 @@ func TestBenchlistRemove(t *testing.T) {
 
 	require.Equal(t, 3, count)
 }
+
+// Test that nodes can be benched and unbenched on request, and that the bench
+// is kept across restarts.
+func TestBenchlistBenchPersisted(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := validators.NewSet()
+	vdrID0 := ids.GenerateTestNodeID()
+	vdrID1 := ids.GenerateTestNodeID()
+	require.NoError(vdrs.Add(vdrID0, nil, ids.Empty, 50))
+	require.NoError(vdrs.Add(vdrID1, nil, ids.Empty, 50))
+
+	benched := set.Set[ids.NodeID]{}
+	benchable := &TestBenchable{
+		T: t,
+		BenchedF: func(_ ids.ID, nodeID ids.NodeID) {
+			benched.Add(nodeID)
+		},
+		UnbenchedF: func(_ ids.ID, nodeID ids.NodeID) {
+			benched.Remove(nodeID)
+		},
+	}
+
+	db := memdb.New()
+	newBenchlist := func() *benchlist {
+		benchIntf, err := NewBenchlist(
+			ids.Empty,
+			logging.NoLog{},
+			benchable,
+			vdrs,
+			db,
+			3,
+			minimumFailingDuration,
+			time.Minute,
+			0.5,
+			prometheus.NewRegistry(),
+			mockable.Clock{},
+		)
+		require.NoError(err)
+		return benchIntf.(*benchlist)
+	}
+
+	b := newBenchlist()
+	require.ErrorIs(b.Bench(vdrID0, 0), errNonPositiveDuration)
+
+	// Benching on request ignores the maximum portion of benched stake
+	require.NoError(b.Bench(vdrID0, time.Hour))
+	require.NoError(b.Bench(vdrID1, 2*time.Hour))
+	require.True(b.IsBenched(vdrID0))
+	require.True(b.IsBenched(vdrID1))
+	require.Equal(set.Set[ids.NodeID]{vdrID0: struct{}{}, vdrID1: struct{}{}}, benched)
+	require.Equal(float64(2), testutil.ToFloat64(b.metrics.numBenchings))
+
+	// Re-benching a node moves the time it leaves the bench
+	require.NoError(b.Bench(vdrID1, 30*time.Minute))
+	benchedUntil := b.Benched()
+	require.Len(benchedUntil, 2)
+	require.True(benchedUntil[vdrID1].Before(benchedUntil[vdrID0]))
+	require.Equal(float64(2), testutil.ToFloat64(b.metrics.numBenchings))
+
+	require.NoError(b.Unbench(vdrID1))
+	require.False(b.IsBenched(vdrID1))
+	require.NoError(b.Unbench(vdrID1))
+	require.Equal(set.Set[ids.NodeID]{vdrID0: struct{}{}}, benched)
+	b.timer.Stop()
+
+	// After a restart, only [vdrID0] should still be benched
+	benched.Clear()
+	b = newBenchlist()
+	defer b.timer.Stop()
+	require.True(b.IsBenched(vdrID0))
+	require.False(b.IsBenched(vdrID1))
+	require.Equal(set.Set[ids.NodeID]{vdrID0: struct{}{}}, benched)
+	loaded := b.Benched()
+	require.Len(loaded, 1)
+	require.True(benchedUntil[vdrID0].Equal(loaded[vdrID0]))
+	require.Equal(float64(1), testutil.ToFloat64(b.metrics.numBenched))
+}
+
+// Test that benches that expired while the node was stopped are removed when
+// the benchlist is loaded.
+func TestBenchlistLoadRemovesExpired(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := validators.NewSet()
+	vdrID0 := ids.GenerateTestNodeID()
+	vdrID1 := ids.GenerateTestNodeID()
+	require.NoError(vdrs.Add(vdrID0, nil, ids.Empty, 50))
+	require.NoError(vdrs.Add(vdrID1, nil, ids.Empty, 50))
+
+	db := memdb.New()
+	now := time.Now()
+	require.NoError(database.PutTimestamp(db, vdrID0[:], now.Add(-time.Minute)))
+	require.NoError(database.PutTimestamp(db, vdrID1[:], now.Add(time.Hour)))
+
+	benchable := &TestBenchable{T: t}
+	benchable.Default(true)
+	benchable.BenchedF = func(_ ids.ID, nodeID ids.NodeID) {
+		require.Equal(vdrID1, nodeID)
+	}
+
+	benchIntf, err := NewBenchlist(
+		ids.Empty,
+		logging.NoLog{},
+		benchable,
+		vdrs,
+		db,
+		3,
+		minimumFailingDuration,
+		time.Minute,
+		0.5,
+		prometheus.NewRegistry(),
+		mockable.Clock{},
+	)
+	require.NoError(err)
+	b := benchIntf.(*benchlist)
+	defer b.timer.Stop()
+
+	require.False(b.IsBenched(vdrID0))
+	require.True(b.IsBenched(vdrID1))
+
+	has, err := db.Has(vdrID0[:])
+	require.NoError(err)
+	require.False(has)
+	has, err = db.Has(vdrID1[:])
+	require.NoError(err)
+	require.True(has)
+}
+
+func TestBenchlistSetMaxPortion(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := validators.NewSet()
+	vdrID0 := ids.GenerateTestNodeID()
+	vdrID1 := ids.GenerateTestNodeID()
+	require.NoError(vdrs.Add(vdrID0, nil, ids.Empty, 50))
+	require.NoError(vdrs.Add(vdrID1, nil, ids.Empty, 50))
+
+	var benched []ids.NodeID
+	benchable := &TestBenchable{T: t}
+	benchable.Default(true)
+	benchable.BenchedF = func(_ ids.ID, nodeID ids.NodeID) {
+		benched = append(benched, nodeID)
+	}
+
+	benchIntf, err := NewBenchlist(
+		ids.Empty,
+		logging.NoLog{},
+		benchable,
+		vdrs,
+		memdb.New(),
+		1,
+		0,
+		time.Hour,
+		0,
+		prometheus.NewRegistry(),
+		mockable.Clock{},
+	)
+	require.NoError(err)
+	b := benchIntf.(*benchlist)
+	defer b.timer.Stop()
+
+	// No stake may be benched.
+	b.RegisterFailure(vdrID0)
+	require.False(b.IsBenched(vdrID0))
+	require.Empty(benched)
+
+	err = b.SetMaxPortion(1)
+	require.ErrorIs(err, errInvalidMaxPortion)
+
+	require.NoError(b.SetMaxPortion(0.5))
+	b.RegisterFailure(vdrID0)
+	require.True(b.IsBenched(vdrID0))
+	require.Equal([]ids.NodeID{vdrID0}, benched)
+}
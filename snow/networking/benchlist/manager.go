@@ -5,9 +5,12 @@ package benchlist
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/prefixdb"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
@@ -16,6 +19,8 @@ import (
 
 var (
 	errUnknownValidators = errors.New("unknown validator set for provided chain")
+	errUnknownChain      = errors.New("unknown chain")
+	errBenchlistDisabled = errors.New("benchlist is disabled")
 
 	_ Manager = (*manager)(nil)
 )
@@ -41,6 +46,16 @@ type Manager interface {
 	// [nodeID] is benched. If called on an id.ShortID that does
 	// not map to a validator, it will return an empty array.
 	GetBenched(nodeID ids.NodeID) []ids.ID
+	// Benched returns the nodes that are benched on [chainID] and the time
+	// that each of them will leave the bench.
+	Benched(chainID ids.ID) (map[ids.NodeID]time.Time, error)
+	// Bench benches [nodeID] on [chainID] for [duration].
+	Bench(chainID ids.ID, nodeID ids.NodeID, duration time.Duration) error
+	// Unbench removes [nodeID] from the bench of [chainID].
+	Unbench(chainID ids.ID, nodeID ids.NodeID) error
+	// SetMaxPortion replaces the maximum portion of stake that may be benched
+	// on every chain, including the chains that are registered later.
+	SetMaxPortion(maxPortion float64) error
 }
 
 // Config defines the configuration for a benchlist
@@ -48,6 +63,7 @@ type Config struct {
 	Benchable              Benchable          `json:"-"`
 	Validators             validators.Manager `json:"-"`
 	StakingEnabled         bool               `json:"-"`
+	DB                     database.Database  `json:"-"`
 	Threshold              int                `json:"threshold"`
 	MinimumFailingDuration time.Duration      `json:"minimumFailingDuration"`
 	Duration               time.Duration      `json:"duration"`
@@ -56,6 +72,9 @@ type Config struct {
 
 type manager struct {
 	config *Config
+	// maxPortion is the maximum portion of stake that may be benched on each
+	// chain. It starts out as [config.MaxPortion].
+	maxPortion float64
 	// Chain ID --> benchlist for that chain.
 	// Each benchlist is safe for concurrent access.
 	chainBenchlists map[ids.ID]Benchlist
@@ -72,6 +91,7 @@ func NewManager(config *Config) Manager {
 	}
 	return &manager{
 		config:          config,
+		maxPortion:      config.MaxPortion,
 		chainBenchlists: make(map[ids.ID]Benchlist),
 	}
 }
@@ -134,11 +154,13 @@ func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 		ctx.Log,
 		m.config.Benchable,
 		vdrs,
+		prefixdb.New(ctx.ChainID[:], m.config.DB),
 		m.config.Threshold,
 		m.config.MinimumFailingDuration,
 		m.config.Duration,
-		m.config.MaxPortion,
+		m.maxPortion,
 		ctx.Registerer,
+		ctx.Clock,
 	)
 	if err != nil {
 		return err
@@ -148,6 +170,58 @@ func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 	return nil
 }
 
+func (m *manager) Benched(chainID ids.ID) (map[ids.NodeID]time.Time, error) {
+	benchlist, err := m.getBenchlist(chainID)
+	if err != nil {
+		return nil, err
+	}
+	return benchlist.Benched(), nil
+}
+
+func (m *manager) Bench(chainID ids.ID, nodeID ids.NodeID, duration time.Duration) error {
+	benchlist, err := m.getBenchlist(chainID)
+	if err != nil {
+		return err
+	}
+	return benchlist.Bench(nodeID, duration)
+}
+
+func (m *manager) Unbench(chainID ids.ID, nodeID ids.NodeID) error {
+	benchlist, err := m.getBenchlist(chainID)
+	if err != nil {
+		return err
+	}
+	return benchlist.Unbench(nodeID)
+}
+
+func (m *manager) SetMaxPortion(maxPortion float64) error {
+	if err := verifyMaxPortion(maxPortion); err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.maxPortion = maxPortion
+	for _, benchlist := range m.chainBenchlists {
+		if err := benchlist.SetMaxPortion(maxPortion); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *manager) getBenchlist(chainID ids.ID) (Benchlist, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	benchlist, exists := m.chainBenchlists[chainID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+	return benchlist, nil
+}
+
 func (m *manager) RegisterResponse(chainID ids.ID, nodeID ids.NodeID) {
 	m.lock.RLock()
 	benchlist, exists := m.chainBenchlists[chainID]
@@ -192,3 +266,21 @@ func (noBenchlist) IsBenched(ids.NodeID, ids.ID) bool {
 func (noBenchlist) GetBenched(ids.NodeID) []ids.ID {
 	return []ids.ID{}
 }
+
+func (noBenchlist) Benched(ids.ID) (map[ids.NodeID]time.Time, error) {
+	return map[ids.NodeID]time.Time{}, nil
+}
+
+func (noBenchlist) Bench(ids.ID, ids.NodeID, time.Duration) error {
+	return errBenchlistDisabled
+}
+
+func (noBenchlist) Unbench(ids.ID, ids.NodeID) error {
+	return nil
+}
+
+// SetMaxPortion is a no-op. A benchlist that was disabled on startup stays
+// disabled.
+func (noBenchlist) SetMaxPortion(float64) error {
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 
 type metrics struct {
 	numBenched, weightBenched prometheus.Gauge
+	numBenchings              prometheus.Counter
 }
 
 func (m *metrics) Initialize(registerer prometheus.Registerer) error {
@@ -32,5 +33,14 @@ func (m *metrics) Initialize(registerer prometheus.Registerer) error {
 		return fmt.Errorf("failed to register weight benched statistics due to %w", err)
 	}
 
+	m.numBenchings = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "benchlist",
+		Name:      "benchings",
+		Help:      "Number of times a node has been benched",
+	})
+	if err := registerer.Register(m.numBenchings); err != nil {
+		return fmt.Errorf("failed to register benchings statistics due to %w", err)
+	}
+
 	return nil
 }
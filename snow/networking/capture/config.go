@@ -0,0 +1,35 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+// Config defines which messages are captured and where they are written.
+//
+// Every message that is pushed into a chain's handler is captured: messages
+// received from peers as well as the request timeouts and connection events
+// that the node generates itself.
+type Config struct {
+	logging.RotatingWriterConfig
+	Enabled bool `json:"enabled"`
+	// ChainIDs restricts the capture to messages sent to these chains. If
+	// empty, messages for all chains are captured.
+	ChainIDs set.Set[ids.ID] `json:"chainIDs"`
+	// NodeIDs restricts the capture to messages sent by these peers. If empty,
+	// messages from all peers are captured.
+	NodeIDs set.Set[ids.NodeID] `json:"nodeIDs"`
+}
+
+// shouldCapture returns true if a message from, or about, [nodeID] that is
+// pushed into the handler of [chainID] passes the filters of this config.
+func (c *Config) shouldCapture(nodeID ids.NodeID, chainID ids.ID) bool {
+	if c.NodeIDs.Len() > 0 && !c.NodeIDs.Contains(nodeID) {
+		return false
+	}
+	return c.ChainIDs.Len() == 0 || c.ChainIDs.Contains(chainID)
+}
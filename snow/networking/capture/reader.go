@@ -0,0 +1,30 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Reader reads records from a capture.
+type Reader struct {
+	decoder *json.Decoder
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{
+		decoder: json.NewDecoder(r),
+	}
+}
+
+// Next returns the next record of the capture. io.EOF is returned once the
+// capture has been fully read.
+func (r *Reader) Next() (*Record, error) {
+	record := &Record{}
+	if err := r.decoder.Decode(record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
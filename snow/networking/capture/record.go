@@ -0,0 +1,239 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+)
+
+var (
+	errUnknownOp   = errors.New("unknown op")
+	errNoChainID   = errors.New("record doesn't specify a chain")
+	errUnsupported = errors.New("op can't be replayed")
+
+	// opsByName maps the name of every op that can be captured to the op.
+	opsByName = func() map[string]message.Op {
+		ops := make(map[string]message.Op, len(message.ConsensusOps))
+		for _, op := range message.ConsensusOps {
+			ops[op.String()] = op
+		}
+		return ops
+	}()
+
+	internalOps = func() set.Set[message.Op] {
+		ops := set.NewSet[message.Op](len(message.ConsensusInternalOps))
+		ops.Add(message.ConsensusInternalOps...)
+		return ops
+	}()
+)
+
+// Record is a single captured message that was pushed into a chain's handler.
+//
+// Records are written as one JSON object per line. Messages received from
+// peers are stored as the protobuf JSON encoding of the decoded p2p message, so
+// a capture is readable without any tooling and independent of the compression
+// that was used on the wire. Messages generated by the node itself, such as
+// request timeouts and connection events, are stored as the JSON encoding of
+// their internal message.
+type Record struct {
+	// Time this message was pushed into the chain's handler
+	Time time.Time `json:"time"`
+	// NodeID of the peer that sent, or that this message is about
+	NodeID ids.NodeID `json:"nodeID"`
+	// Op of the message
+	Op string `json:"op"`
+	// ChainID of the handler this message was pushed into
+	ChainID *ids.ID `json:"chainID,omitempty"`
+	// EngineType this message was routed to
+	EngineType p2p.EngineType `json:"engineType,omitempty"`
+	// Deadline of an internal request, relative to [Time]
+	Deadline time.Duration `json:"deadline,omitempty"`
+	// Message is the encoding of the message
+	Message json.RawMessage `json:"message"`
+}
+
+// NewRecord captures [msg], which was pushed into the handler of [chainID] at
+// [receivedAt].
+func NewRecord(receivedAt time.Time, chainID ids.ID, msg handler.Message) (*Record, error) {
+	var (
+		msgJSON  []byte
+		deadline time.Duration
+		err      error
+	)
+	if isInternal(msg.Op()) {
+		msgJSON, err = json.Marshal(msg.Message())
+		if expiration := msg.Expiration(); expiration != mockable.MaxTime {
+			deadline = expiration.Sub(receivedAt)
+		}
+	} else {
+		var p2pMsg *p2p.Message
+		p2pMsg, err = message.Wrap(msg.Message())
+		if err != nil {
+			return nil, err
+		}
+		msgJSON, err = protojson.Marshal(p2pMsg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Record{
+		Time:       receivedAt,
+		NodeID:     msg.NodeID(),
+		Op:         msg.Op().String(),
+		ChainID:    &chainID,
+		EngineType: msg.EngineType,
+		Deadline:   deadline,
+		Message:    msgJSON,
+	}, nil
+}
+
+// Bytes returns the uncompressed wire format of a message that was received
+// from a peer.
+func (r *Record) Bytes() ([]byte, error) {
+	p2pMsg := &p2p.Message{}
+	if err := protojson.Unmarshal(r.Message, p2pMsg); err != nil {
+		return nil, err
+	}
+	return proto.Marshal(p2pMsg)
+}
+
+// Parse reconstructs the captured message. Messages that were received from
+// peers are rebuilt using [parser].
+//
+// [onFinishedHandling] is called once a message that was received from a peer
+// has been handled.
+func (r *Record) Parse(parser message.InboundMsgBuilder, onFinishedHandling func()) (message.InboundMessage, error) {
+	op, ok := opsByName[r.Op]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownOp, r.Op)
+	}
+	if isInternal(op) {
+		return r.parseInternal(op)
+	}
+
+	msgBytes, err := r.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return parser.Parse(msgBytes, r.NodeID, onFinishedHandling)
+}
+
+// isInternal returns true if this message was generated by the node rather than
+// received from a peer.
+func (r *Record) isInternal() bool {
+	op, ok := opsByName[r.Op]
+	return ok && isInternal(op)
+}
+
+func (r *Record) parseInternal(op message.Op) (message.InboundMessage, error) {
+	if r.ChainID == nil {
+		return nil, errNoChainID
+	}
+	chainID := *r.ChainID
+
+	switch op {
+	case message.GetStateSummaryFrontierFailedOp:
+		m := &message.GetStateSummaryFrontierFailed{}
+		if err := json.Unmarshal(r.Message, m); err != nil {
+			return nil, err
+		}
+		return message.InternalGetStateSummaryFrontierFailed(r.NodeID, chainID, m.RequestID), nil
+	case message.GetAcceptedStateSummaryFailedOp:
+		m := &message.GetAcceptedStateSummaryFailed{}
+		if err := json.Unmarshal(r.Message, m); err != nil {
+			return nil, err
+		}
+		return message.InternalGetAcceptedStateSummaryFailed(r.NodeID, chainID, m.RequestID), nil
+	case message.GetAcceptedFrontierFailedOp:
+		m := &message.GetAcceptedFrontierFailed{}
+		if err := json.Unmarshal(r.Message, m); err != nil {
+			return nil, err
+		}
+		return message.InternalGetAcceptedFrontierFailed(r.NodeID, chainID, m.RequestID, m.EngineType), nil
+	case message.GetAcceptedFailedOp:
+		m := &message.GetAcceptedFailed{}
+		if err := json.Unmarshal(r.Message, m); err != nil {
+			return nil, err
+		}
+		return message.InternalGetAcceptedFailed(r.NodeID, chainID, m.RequestID, m.EngineType), nil
+	case message.GetAncestorsFailedOp:
+		m := &message.GetAncestorsFailed{}
+		if err := json.Unmarshal(r.Message, m); err != nil {
+			return nil, err
+		}
+		return message.InternalGetAncestorsFailed(r.NodeID, chainID, m.RequestID, m.EngineType), nil
+	case message.GetFailedOp:
+		m := &message.GetFailed{}
+		if err := json.Unmarshal(r.Message, m); err != nil {
+			return nil, err
+		}
+		return message.InternalGetFailed(r.NodeID, chainID, m.RequestID, m.EngineType), nil
+	case message.QueryFailedOp:
+		m := &message.QueryFailed{}
+		if err := json.Unmarshal(r.Message, m); err != nil {
+			return nil, err
+		}
+		return message.InternalQueryFailed(r.NodeID, chainID, m.RequestID, m.EngineType), nil
+	case message.AppRequestFailedOp:
+		m := &message.AppRequestFailed{}
+		if err := json.Unmarshal(r.Message, m); err != nil {
+			return nil, err
+		}
+		return message.InternalAppRequestFailed(r.NodeID, chainID, m.RequestID), nil
+	case message.CrossChainAppRequestOp:
+		m := &message.CrossChainAppRequest{}
+		if err := json.Unmarshal(r.Message, m); err != nil {
+			return nil, err
+		}
+		return message.InternalCrossChainAppRequest(r.NodeID, m.SourceChainID, chainID, m.RequestID, r.Deadline, m.Message), nil
+	case message.CrossChainAppRequestFailedOp:
+		m := &message.CrossChainAppRequestFailed{}
+		if err := json.Unmarshal(r.Message, m); err != nil {
+			return nil, err
+		}
+		return message.InternalCrossChainAppRequestFailed(r.NodeID, m.SourceChainID, chainID, m.RequestID), nil
+	case message.CrossChainAppResponseOp:
+		m := &message.CrossChainAppResponse{}
+		if err := json.Unmarshal(r.Message, m); err != nil {
+			return nil, err
+		}
+		return message.InternalCrossChainAppResponse(r.NodeID, m.SourceChainID, chainID, m.RequestID, m.Message), nil
+	case message.ConnectedOp:
+		m := &message.Connected{}
+		if err := json.Unmarshal(r.Message, m); err != nil {
+			return nil, err
+		}
+		return message.InternalConnected(r.NodeID, m.NodeVersion), nil
+	case message.ConnectedSubnetOp:
+		m := &message.ConnectedSubnet{}
+		if err := json.Unmarshal(r.Message, m); err != nil {
+			return nil, err
+		}
+		return message.InternalConnectedSubnet(r.NodeID, m.SubnetID), nil
+	case message.DisconnectedOp:
+		return message.InternalDisconnected(r.NodeID), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupported, op)
+	}
+}
+
+// isInternal returns true if messages with [op] are generated by the node
+// rather than received from peers.
+func isInternal(op message.Op) bool {
+	return internalOps.Contains(op)
+}
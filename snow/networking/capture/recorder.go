@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+)
+
+// Filename is the name of the file, inside the configured directory, that
+// captured messages are written to. Rotated files are written next to it.
+const Filename = "inbound.jsonl"
+
+var _ Recorder = (*recorder)(nil)
+
+// Recorder captures the messages that are pushed into chains' handlers.
+type Recorder interface {
+	// Record captures [msg], which is being pushed into the handler of
+	// [chainID], if it passes the configured filters. Record never takes
+	// ownership of [msg], so the caller remains responsible for calling
+	// OnFinishedHandling.
+	Record(chainID ids.ID, msg handler.Message)
+
+	// Close flushes and closes the capture. Messages recorded after Close are
+	// dropped.
+	io.Closer
+}
+
+type recorder struct {
+	log    logging.Logger
+	config Config
+
+	// Useful for faking time in tests
+	clock mockable.Clock
+
+	lock    sync.Mutex
+	closed  bool
+	writer  io.WriteCloser
+	encoder *json.Encoder
+}
+
+// NewRecorder returns a recorder that writes the messages selected by
+// [config] to a rotating file in [config.Directory].
+func NewRecorder(log logging.Logger, config Config) Recorder {
+	return newRecorder(log, config, &lumberjack.Logger{
+		Filename:   filepath.Join(config.Directory, Filename),
+		MaxSize:    config.MaxSize,  // megabytes
+		MaxAge:     config.MaxAge,   // days
+		MaxBackups: config.MaxFiles, // files
+		Compress:   config.Compress,
+	})
+}
+
+func newRecorder(log logging.Logger, config Config, writer io.WriteCloser) *recorder {
+	return &recorder{
+		log:     log,
+		config:  config,
+		writer:  writer,
+		encoder: json.NewEncoder(writer),
+	}
+}
+
+func (r *recorder) Record(chainID ids.ID, msg handler.Message) {
+	if !r.config.shouldCapture(msg.NodeID(), chainID) {
+		return
+	}
+
+	record, err := NewRecord(r.clock.Time(), chainID, msg)
+	if err != nil {
+		r.log.Debug("failed to capture message",
+			zap.Stringer("nodeID", msg.NodeID()),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.closed {
+		return
+	}
+	if err := r.encoder.Encode(record); err != nil {
+		r.log.Warn("failed to write captured message",
+			zap.Stringer("nodeID", msg.NodeID()),
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Error(err),
+		)
+	}
+}
+
+func (r *recorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	return r.writer.Close()
+}
@@ -0,0 +1,226 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func newParser(t *testing.T) message.InboundMsgBuilder {
+	mc, err := message.NewCreator(
+		prometheus.NewRegistry(),
+		"",
+		compression.TypeNone,
+		10*time.Second,
+	)
+	require.NoError(t, err)
+	return mc
+}
+
+// recordInbound records [msg] as the chain router would push it into the
+// handler of the chain it is sent to.
+func recordInbound(r Recorder, msg message.InboundMessage) {
+	chainID, _ := message.GetChainID(msg.Message())
+	engineType, _ := message.GetEngineType(msg.Message())
+	r.Record(chainID, handler.Message{
+		InboundMessage: msg,
+		EngineType:     engineType,
+	})
+}
+
+func TestRecorderFilters(t *testing.T) {
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+
+	tests := []struct {
+		name           string
+		chainIDs       []ids.ID
+		nodeIDs        []ids.NodeID
+		expectedChains []ids.ID
+		expectedNodes  []ids.NodeID
+	}{
+		{
+			name:           "capture everything",
+			expectedChains: []ids.ID{chainID0, chainID0, chainID1, chainID1},
+			expectedNodes:  []ids.NodeID{nodeID0, nodeID1, nodeID0, nodeID1},
+		},
+		{
+			name:           "filter chains",
+			chainIDs:       []ids.ID{chainID1},
+			expectedChains: []ids.ID{chainID1, chainID1},
+			expectedNodes:  []ids.NodeID{nodeID0, nodeID1},
+		},
+		{
+			name:           "filter nodes",
+			nodeIDs:        []ids.NodeID{nodeID0},
+			expectedChains: []ids.ID{chainID0, chainID1},
+			expectedNodes:  []ids.NodeID{nodeID0, nodeID0},
+		},
+		{
+			name:           "filter chains and nodes",
+			chainIDs:       []ids.ID{chainID0},
+			nodeIDs:        []ids.NodeID{nodeID1},
+			expectedChains: []ids.ID{chainID0},
+			expectedNodes:  []ids.NodeID{nodeID1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config := Config{Enabled: true}
+			config.ChainIDs.Add(test.chainIDs...)
+			config.NodeIDs.Add(test.nodeIDs...)
+
+			buf := &bytes.Buffer{}
+			r := newRecorder(logging.NoLog{}, config, nopWriteCloser{Writer: buf})
+			for _, chainID := range []ids.ID{chainID0, chainID1} {
+				for _, nodeID := range []ids.NodeID{nodeID0, nodeID1} {
+					recordInbound(r, message.InboundPullQuery(
+						chainID,
+						1,
+						time.Second,
+						ids.GenerateTestID(),
+						nodeID,
+						p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+					))
+				}
+			}
+			require.NoError(r.Close())
+
+			reader := NewReader(buf)
+			for i, expectedChainID := range test.expectedChains {
+				record, err := reader.Next()
+				require.NoError(err)
+				require.Equal(message.PullQueryOp.String(), record.Op)
+				require.NotNil(record.ChainID)
+				require.Equal(expectedChainID, *record.ChainID)
+				require.Equal(test.expectedNodes[i], record.NodeID)
+			}
+			_, err := reader.Next()
+			require.ErrorIs(err, io.EOF)
+		})
+	}
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+	containerID := ids.GenerateTestID()
+	msgs := []message.InboundMessage{
+		message.InboundPushQuery(
+			chainID,
+			1,
+			time.Second,
+			[]byte("container"),
+			nodeID,
+			p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		),
+		message.InboundChits(
+			chainID,
+			2,
+			[]ids.ID{containerID},
+			[]ids.ID{containerID},
+			nodeID,
+		),
+		message.InboundAppRequest(
+			chainID,
+			3,
+			time.Second,
+			[]byte("request"),
+			nodeID,
+		),
+	}
+
+	buf := &bytes.Buffer{}
+	r := newRecorder(logging.NoLog{}, Config{Enabled: true}, nopWriteCloser{Writer: buf})
+	now := time.Unix(1_000_000, 0)
+	r.clock.Set(now)
+	for _, msg := range msgs {
+		recordInbound(r, msg)
+	}
+	require.NoError(r.Close())
+
+	// Records after Close must be dropped
+	recordInbound(r, msgs[0])
+
+	parser := newParser(t)
+	reader := NewReader(buf)
+	for _, expected := range msgs {
+		record, err := reader.Next()
+		require.NoError(err)
+		require.True(now.Equal(record.Time))
+
+		msg, err := record.Parse(parser, nil)
+		require.NoError(err)
+		require.Equal(expected.NodeID(), msg.NodeID())
+		require.Equal(expected.Op(), msg.Op())
+
+		require.True(proto.Equal(
+			expected.Message().(proto.Message),
+			msg.Message().(proto.Message),
+		))
+	}
+	_, err := reader.Next()
+	require.ErrorIs(err, io.EOF)
+}
+
+func TestNewRecorderWritesFile(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	r := NewRecorder(logging.NoLog{}, Config{
+		RotatingWriterConfig: logging.RotatingWriterConfig{
+			Directory: dir,
+			MaxSize:   1,
+		},
+		Enabled: true,
+	})
+	recordInbound(r, message.InboundAppRequest(
+		ids.GenerateTestID(),
+		1,
+		time.Second,
+		[]byte("request"),
+		ids.GenerateTestNodeID(),
+	))
+	require.NoError(r.Close())
+
+	f, err := os.Open(filepath.Join(dir, Filename))
+	require.NoError(err)
+	defer f.Close()
+
+	record, err := NewReader(f).Next()
+	require.NoError(err)
+	require.Equal(message.AppRequestOp.String(), record.Op)
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
+)
+
+// ReplayConfig defines how a capture is replayed.
+type ReplayConfig struct {
+	// Parser is used to rebuild the captured messages.
+	Parser message.InboundMsgBuilder
+	// Pace, if true, delays each message by the time that elapsed between it
+	// and the previous message when the capture was recorded. Otherwise,
+	// messages are replayed as fast as the handler accepts them.
+	Pace bool
+}
+
+// Replay feeds the captured messages sent to [h]'s chain into [h].
+//
+// Messages are pushed in the order they were recorded, including the request
+// timeouts and connection events that were generated by the node. Messages from
+// peers that [h] wouldn't handle are skipped. Returns the number of messages
+// that were pushed into [h].
+func Replay(
+	ctx context.Context,
+	reader *Reader,
+	config ReplayConfig,
+	h handler.Handler,
+) (int, error) {
+	var (
+		chainID    = h.Context().ChainID
+		lastTime   time.Time
+		numPushed  int
+		numRecords int
+	)
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return numPushed, nil
+		}
+		if err != nil {
+			return numPushed, fmt.Errorf("failed to read record %d: %w", numRecords, err)
+		}
+		numRecords++
+
+		if record.ChainID == nil || *record.ChainID != chainID {
+			continue
+		}
+		if !record.isInternal() && !h.ShouldHandle(record.NodeID) {
+			continue
+		}
+
+		if config.Pace && !lastTime.IsZero() {
+			if err := sleep(ctx, record.Time.Sub(lastTime)); err != nil {
+				return numPushed, err
+			}
+		}
+		lastTime = record.Time
+
+		msg, err := record.Parse(config.Parser, nil)
+		if err != nil {
+			return numPushed, fmt.Errorf("failed to parse record %d: %w", numRecords-1, err)
+		}
+
+		// Messages are routed to the engine type that the chain router routed
+		// them to when they were captured.
+		h.Push(ctx, handler.Message{
+			InboundMessage: msg,
+			EngineType:     record.EngineType,
+		})
+		numPushed++
+	}
+}
+
+func sleep(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
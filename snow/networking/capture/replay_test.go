@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+func TestReplay(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := snow.DefaultConsensusContextTest()
+	otherChainID := ids.GenerateTestID()
+	allowedNodeID := ids.GenerateTestNodeID()
+	droppedNodeID := ids.GenerateTestNodeID()
+
+	buf := &bytes.Buffer{}
+	r := newRecorder(logging.NoLog{}, Config{Enabled: true}, nopWriteCloser{Writer: buf})
+	recordInbound(r, message.InboundAppRequest(ctx.ChainID, 1, time.Second, []byte{1}, allowedNodeID))
+	recordInbound(r, message.InboundAppRequest(otherChainID, 2, time.Second, []byte{2}, allowedNodeID))
+	recordInbound(r, message.InboundAppRequest(ctx.ChainID, 3, time.Second, []byte{3}, droppedNodeID))
+	recordInbound(r, message.InboundAppRequest(ctx.ChainID, 4, time.Second, []byte{4}, allowedNodeID))
+	require.NoError(r.Close())
+
+	h := handler.NewMockHandler(ctrl)
+	h.EXPECT().Context().Return(ctx).AnyTimes()
+	h.EXPECT().ShouldHandle(allowedNodeID).Return(true).AnyTimes()
+	h.EXPECT().ShouldHandle(droppedNodeID).Return(false).AnyTimes()
+
+	var pushed []handler.Message
+	h.EXPECT().Push(gomock.Any(), gomock.Any()).Do(func(_ context.Context, msg handler.Message) {
+		pushed = append(pushed, msg)
+	}).Times(2)
+
+	numPushed, err := Replay(
+		context.Background(),
+		NewReader(buf),
+		ReplayConfig{
+			Parser: newParser(t),
+		},
+		h,
+	)
+	require.NoError(err)
+	require.Equal(2, numPushed)
+	require.Len(pushed, 2)
+
+	for i, expectedRequestID := range []uint32{1, 4} {
+		msg := pushed[i]
+		require.Equal(allowedNodeID, msg.NodeID())
+		require.Equal(message.AppRequestOp, msg.Op())
+		requestID, ok := message.GetRequestID(msg.Message())
+		require.True(ok)
+		require.Equal(expectedRequestID, requestID)
+		msg.OnFinishedHandling()
+	}
+}
+
+// Test that replayed messages are routed to the engine type they specify, as
+// they would be by the chain router.
+func TestReplayEngineType(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := snow.DefaultConsensusContextTest()
+	nodeID := ids.GenerateTestNodeID()
+	containerID := ids.GenerateTestID()
+
+	buf := &bytes.Buffer{}
+	r := newRecorder(logging.NoLog{}, Config{Enabled: true}, nopWriteCloser{Writer: buf})
+	recordInbound(r, message.InboundPullQuery(ctx.ChainID, 1, time.Second, containerID, nodeID, p2p.EngineType_ENGINE_TYPE_AVALANCHE))
+	recordInbound(r, message.InboundPullQuery(ctx.ChainID, 2, time.Second, containerID, nodeID, p2p.EngineType_ENGINE_TYPE_SNOWMAN))
+	recordInbound(r, message.InboundChits(ctx.ChainID, 3, []ids.ID{containerID}, []ids.ID{containerID}, nodeID))
+	require.NoError(r.Close())
+
+	h := handler.NewMockHandler(ctrl)
+	h.EXPECT().Context().Return(ctx).AnyTimes()
+	h.EXPECT().ShouldHandle(nodeID).Return(true).AnyTimes()
+
+	var pushed []handler.Message
+	h.EXPECT().Push(gomock.Any(), gomock.Any()).Do(func(_ context.Context, msg handler.Message) {
+		pushed = append(pushed, msg)
+	}).Times(3)
+
+	numPushed, err := Replay(
+		context.Background(),
+		NewReader(buf),
+		ReplayConfig{
+			Parser: newParser(t),
+		},
+		h,
+	)
+	require.NoError(err)
+	require.Equal(3, numPushed)
+
+	expectedEngineTypes := []p2p.EngineType{
+		p2p.EngineType_ENGINE_TYPE_AVALANCHE,
+		p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+	}
+	for i, expectedEngineType := range expectedEngineTypes {
+		require.Equal(expectedEngineType, pushed[i].EngineType)
+		pushed[i].OnFinishedHandling()
+	}
+}
+
+func TestReplayPaceCancelled(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := snow.DefaultConsensusContextTest()
+	nodeID := ids.GenerateTestNodeID()
+
+	buf := &bytes.Buffer{}
+	r := newRecorder(logging.NoLog{}, Config{Enabled: true}, nopWriteCloser{Writer: buf})
+	r.clock.Set(time.Unix(0, 0))
+	recordInbound(r, message.InboundAppRequest(ctx.ChainID, 1, time.Second, []byte{1}, nodeID))
+	r.clock.Set(time.Unix(0, 0).Add(time.Hour))
+	recordInbound(r, message.InboundAppRequest(ctx.ChainID, 2, time.Second, []byte{2}, nodeID))
+	require.NoError(r.Close())
+
+	h := handler.NewMockHandler(ctrl)
+	h.EXPECT().Context().Return(ctx).AnyTimes()
+	h.EXPECT().ShouldHandle(nodeID).Return(true).AnyTimes()
+
+	replayCtx, cancel := context.WithCancel(context.Background())
+	h.EXPECT().Push(gomock.Any(), gomock.Any()).Do(func(context.Context, handler.Message) {
+		// Cancel while waiting to replay the second message.
+		cancel()
+	}).Times(1)
+
+	numPushed, err := Replay(
+		replayCtx,
+		NewReader(buf),
+		ReplayConfig{
+			Parser: newParser(t),
+			Pace:   true,
+		},
+		h,
+	)
+	require.ErrorIs(err, context.Canceled)
+	require.Equal(1, numPushed)
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"context"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
+	"github.com/MetalBlockchain/metalgo/snow/networking/router"
+)
+
+var (
+	_ router.Router   = (*capturingRouter)(nil)
+	_ handler.Handler = (*capturingHandler)(nil)
+)
+
+type capturingRouter struct {
+	router.Router
+	recorder Recorder
+}
+
+// Router returns a router that records every message that [router] pushes into
+// a chain's handler with [recorder]. This includes the request timeouts and
+// connection events that [router] generates, as well as the messages received
+// from peers.
+func Router(router router.Router, recorder Recorder) router.Router {
+	return &capturingRouter{
+		Router:   router,
+		recorder: recorder,
+	}
+}
+
+func (r *capturingRouter) AddChain(ctx context.Context, chain handler.Handler) {
+	r.Router.AddChain(ctx, &capturingHandler{
+		Handler:  chain,
+		chainID:  chain.Context().ChainID,
+		recorder: r.recorder,
+	})
+}
+
+type capturingHandler struct {
+	handler.Handler
+	chainID  ids.ID
+	recorder Recorder
+}
+
+func (h *capturingHandler) Push(ctx context.Context, msg handler.Message) {
+	h.recorder.Record(h.chainID, msg)
+	h.Handler.Push(ctx, msg)
+}
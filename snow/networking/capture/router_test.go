@@ -0,0 +1,142 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
+	"github.com/MetalBlockchain/metalgo/snow/networking/router"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/version"
+)
+
+// Test that the messages the router generates itself, such as request timeouts
+// and connection events, are captured and replayed along with the messages
+// received from peers.
+func TestRouterCapturesGeneratedMessages(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := snow.DefaultConsensusContextTest()
+	nodeID := ids.GenerateTestNodeID()
+	subnetID := ids.GenerateTestID()
+	sourceChainID := ids.GenerateTestID()
+	containerID := ids.GenerateTestID()
+
+	msgs := []handler.Message{
+		{
+			InboundMessage: message.InternalConnected(nodeID, version.CurrentApp),
+			EngineType:     p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+		},
+		{
+			InboundMessage: message.InternalConnectedSubnet(nodeID, subnetID),
+			EngineType:     p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+		},
+		{
+			InboundMessage: message.InboundAppRequest(ctx.ChainID, 1, time.Second, []byte{1}, nodeID),
+			EngineType:     p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+		},
+		{
+			InboundMessage: message.InternalGetFailed(nodeID, ctx.ChainID, 2, p2p.EngineType_ENGINE_TYPE_SNOWMAN),
+			EngineType:     p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		},
+		{
+			// Responses are routed to the engine that sent the request
+			InboundMessage: message.InboundChits(ctx.ChainID, 3, []ids.ID{containerID}, []ids.ID{containerID}, nodeID),
+			EngineType:     p2p.EngineType_ENGINE_TYPE_AVALANCHE,
+		},
+		{
+			InboundMessage: message.InternalAppRequestFailed(nodeID, ctx.ChainID, 4),
+			EngineType:     p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+		},
+		{
+			InboundMessage: message.InternalCrossChainAppRequest(nodeID, sourceChainID, ctx.ChainID, 5, time.Minute, []byte{5}),
+			EngineType:     p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+		},
+		{
+			InboundMessage: message.InternalDisconnected(nodeID),
+			EngineType:     p2p.EngineType_ENGINE_TYPE_UNSPECIFIED,
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	recorder := newRecorder(logging.NoLog{}, Config{Enabled: true}, nopWriteCloser{Writer: buf})
+
+	// The router pushes messages into the handler that it was given.
+	var chain handler.Handler
+	innerRouter := router.NewMockRouter(ctrl)
+	innerRouter.EXPECT().AddChain(gomock.Any(), gomock.Any()).Do(func(_ context.Context, h handler.Handler) {
+		chain = h
+	})
+
+	innerHandler := handler.NewMockHandler(ctrl)
+	innerHandler.EXPECT().Context().Return(ctx).AnyTimes()
+	innerHandler.EXPECT().Push(gomock.Any(), gomock.Any()).Times(len(msgs))
+
+	Router(innerRouter, recorder).AddChain(context.Background(), innerHandler)
+	require.NotNil(chain)
+	for _, msg := range msgs {
+		chain.Push(context.Background(), msg)
+	}
+	require.NoError(recorder.Close())
+
+	replayed := handler.NewMockHandler(ctrl)
+	replayed.EXPECT().Context().Return(ctx).AnyTimes()
+	replayed.EXPECT().ShouldHandle(nodeID).Return(true).AnyTimes()
+
+	var pushed []handler.Message
+	replayed.EXPECT().Push(gomock.Any(), gomock.Any()).Do(func(_ context.Context, msg handler.Message) {
+		pushed = append(pushed, msg)
+	}).Times(len(msgs))
+
+	numPushed, err := Replay(
+		context.Background(),
+		NewReader(buf),
+		ReplayConfig{
+			Parser: newParser(t),
+		},
+		replayed,
+	)
+	require.NoError(err)
+	require.Equal(len(msgs), numPushed)
+
+	for i, expected := range msgs {
+		msg := pushed[i]
+		require.Equal(expected.Op(), msg.Op())
+		require.Equal(expected.NodeID(), msg.NodeID())
+		require.Equal(expected.EngineType, msg.EngineType)
+
+		requestID, ok := message.GetRequestID(expected.Message())
+		if ok {
+			replayedRequestID, ok := message.GetRequestID(msg.Message())
+			require.True(ok)
+			require.Equal(requestID, replayedRequestID)
+		}
+	}
+
+	connected := pushed[0].Message().(*message.Connected)
+	require.Zero(version.CurrentApp.Compare(connected.NodeVersion))
+
+	connectedSubnet := pushed[1].Message().(*message.ConnectedSubnet)
+	require.Equal(subnetID, connectedSubnet.SubnetID)
+
+	crossChainRequest := pushed[6].Message().(*message.CrossChainAppRequest)
+	require.Equal(sourceChainID, crossChainRequest.SourceChainID)
+	require.Equal(ctx.ChainID, crossChainRequest.DestinationChainID)
+	require.Equal([]byte{5}, crossChainRequest.Message)
+	require.True(pushed[6].Expiration().After(time.Now()))
+}
@@ -20,8 +20,10 @@ import (
 	"github.com/MetalBlockchain/metalgo/api/health"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/networking/worker"
@@ -29,6 +31,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/subnets"
 	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
 )
 
 const (
@@ -58,6 +61,16 @@ type Handler interface {
 
 	SetEngineManager(engineManager *EngineManager)
 
+	// Inspect returns a snapshot of the state of the currently running engine.
+	// Returns common.ErrNotInspectable if the engine doesn't support
+	// inspection.
+	Inspect(ctx context.Context, graphviz bool) (interface{}, error)
+
+	// Reconfigure schedules [params] to replace the parameters of the
+	// consensus engines. Returns common.ErrNotReconfigurable if an engine
+	// doesn't support reconfiguration.
+	Reconfigure(ctx context.Context, params avalanche.Parameters) error
+
 	SetOnStopped(onStopped func())
 	Start(ctx context.Context, recoverPanic bool)
 	Push(ctx context.Context, msg Message)
@@ -121,11 +134,13 @@ func New(
 	msgFromVMChan <-chan common.Message,
 	gossipFrequency time.Duration,
 	resourceTracker tracker.ResourceTracker,
+	subnetThrottler throttling.SubnetSystemThrottler,
 	subnetConnector validators.SubnetConnector,
 	subnet subnets.Subnet,
 ) (Handler, error) {
 	h := &handler{
 		ctx:              ctx,
+		clock:            ctx.Clock,
 		validators:       validators,
 		msgFromVMChan:    msgFromVMChan,
 		preemptTimeouts:  subnet.OnBootstrapCompleted(),
@@ -146,11 +161,11 @@ func New(
 		return nil, fmt.Errorf("initializing handler metrics errored with: %w", err)
 	}
 	cpuTracker := resourceTracker.CPUTracker()
-	h.syncMessageQueue, err = NewMessageQueue(h.ctx.Log, h.validators, cpuTracker, "handler", h.ctx.Registerer, message.SynchronousOps)
+	h.syncMessageQueue, err = NewMessageQueue(h.ctx.Log, h.ctx.SubnetID, h.validators, cpuTracker, subnetThrottler, "handler", h.ctx.Registerer, message.SynchronousOps)
 	if err != nil {
 		return nil, fmt.Errorf("initializing sync message queue errored with: %w", err)
 	}
-	h.asyncMessageQueue, err = NewMessageQueue(h.ctx.Log, h.validators, cpuTracker, "handler_async", h.ctx.Registerer, message.AsynchronousOps)
+	h.asyncMessageQueue, err = NewMessageQueue(h.ctx.Log, h.ctx.SubnetID, h.validators, cpuTracker, subnetThrottler, "handler_async", h.ctx.Registerer, message.AsynchronousOps)
 	if err != nil {
 		return nil, fmt.Errorf("initializing async message queue errored with: %w", err)
 	}
@@ -261,8 +276,48 @@ func (h *handler) HealthCheck(ctx context.Context) (interface{}, error) {
 	return engine.HealthCheck(ctx)
 }
 
+func (h *handler) Inspect(ctx context.Context, graphviz bool) (interface{}, error) {
+	h.ctx.Lock.Lock()
+	defer h.ctx.Lock.Unlock()
+
+	state := h.ctx.State.Get()
+	engine, ok := h.engineManager.Get(state.Type).Get(state.State)
+	if !ok {
+		return nil, fmt.Errorf(
+			"%w %s running %s",
+			errMissingEngine,
+			state.State,
+			state.Type,
+		)
+	}
+	return common.Inspect(ctx, engine, graphviz)
+}
+
+func (h *handler) Reconfigure(ctx context.Context, params avalanche.Parameters) error {
+	h.ctx.Lock.Lock()
+	defer h.ctx.Lock.Unlock()
+
+	// The consensus engines are reconfigured even if they aren't running yet,
+	// so that they start with the new parameters. A failure of one engine
+	// doesn't prevent the other from being reconfigured.
+	errs := wrappers.Errs{}
+	for _, engine := range []*Engine{h.engineManager.Avalanche, h.engineManager.Snowman} {
+		consensus, ok := engine.Get(snow.NormalOp)
+		if !ok {
+			continue
+		}
+		errs.Add(common.Reconfigure(ctx, consensus, params))
+	}
+	return errs.Err
+}
+
 // Push the message onto the handler's queue
 func (h *handler) Push(ctx context.Context, msg Message) {
+	// If the peer traced the request, handling it continues the peer's trace.
+	if spanContext := message.GetSpanContext(msg.Message()); spanContext.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, spanContext)
+	}
+
 	switch msg.Op() {
 	case message.AppRequestOp, message.AppRequestFailedOp, message.AppResponseOp, message.AppGossipOp,
 		message.CrossChainAppRequestOp, message.CrossChainAppRequestFailedOp, message.CrossChainAppResponseOp:
@@ -278,11 +333,11 @@ func (h *handler) Len() int {
 
 func (h *handler) RegisterTimeout(d time.Duration) {
 	go func() {
-		timer := time.NewTimer(d)
+		timer := h.clock.NewTimer(d)
 		defer timer.Stop()
 
 		select {
-		case <-timer.C:
+		case <-timer.C():
 		case <-h.preemptTimeouts:
 		}
 
@@ -382,7 +437,7 @@ func (h *handler) dispatchAsync(ctx context.Context) {
 }
 
 func (h *handler) dispatchChans(ctx context.Context) {
-	gossiper := time.NewTicker(h.gossipFrequency)
+	gossiper := h.clock.NewTicker(h.gossipFrequency)
 	defer func() {
 		gossiper.Stop()
 		h.closeDispatcher(ctx)
@@ -398,7 +453,7 @@ func (h *handler) dispatchChans(ctx context.Context) {
 		case vmMSG := <-h.msgFromVMChan:
 			msg = message.InternalVMMessage(h.ctx.NodeID, uint32(vmMSG))
 
-		case <-gossiper.C:
+		case <-gossiper.C():
 			msg = message.InternalGossipRequest(h.ctx.NodeID)
 
 		case <-h.timeouts:
@@ -434,6 +489,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 		zap.Any("message", body),
 	)
 	h.resourceTracker.StartProcessing(nodeID, startTime)
+	h.resourceTracker.StartProcessingSubnet(h.ctx.SubnetID, startTime)
 	h.ctx.Lock.Lock()
 	defer func() {
 		h.ctx.Lock.Unlock()
@@ -443,6 +499,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 			histogram      = h.metrics.messages[op]
 			processingTime = endTime.Sub(startTime)
 		)
+		h.resourceTracker.StopProcessingSubnet(h.ctx.SubnetID, endTime)
 		h.resourceTracker.StopProcessing(nodeID, endTime)
 		histogram.Observe(float64(processingTime))
 		msg.OnFinishedHandling()
@@ -617,6 +674,10 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 		return engine.GetAcceptedFailed(ctx, nodeID, msg.RequestID)
 
 	case *p2p.GetAncestors:
+		if len(msg.ContainerId) == 0 {
+			return engine.GetAncestorsAtHeight(ctx, nodeID, msg.RequestId, msg.Height)
+		}
+
 		containerID, err := ids.ToID(msg.ContainerId)
 		if err != nil {
 			h.ctx.Log.Debug("dropping message with invalid field",
@@ -754,11 +815,13 @@ func (h *handler) executeAsyncMsg(ctx context.Context, msg Message) error {
 		zap.Any("message", body),
 	)
 	h.resourceTracker.StartProcessing(nodeID, startTime)
+	h.resourceTracker.StartProcessingSubnet(h.ctx.SubnetID, startTime)
 	defer func() {
 		var (
 			endTime   = h.clock.Time()
 			histogram = h.metrics.messages[op]
 		)
+		h.resourceTracker.StopProcessingSubnet(h.ctx.SubnetID, endTime)
 		h.resourceTracker.StopProcessing(nodeID, endTime)
 		histogram.Observe(float64(endTime.Sub(startTime)))
 		msg.OnFinishedHandling()
@@ -16,14 +16,20 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	oteltrace "go.opentelemetry.io/otel/trace"
+
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/subnets"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/math/meter"
 	"github.com/MetalBlockchain/metalgo/utils/resource"
 )
@@ -53,6 +59,7 @@ func TestHandlerDropsTimedOutMessages(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 	)
@@ -147,6 +154,7 @@ func TestHandlerClosesOnError(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 	)
@@ -237,6 +245,7 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 		nil,
 		1,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 	)
@@ -316,6 +325,7 @@ func TestHandlerDispatchInternal(t *testing.T) {
 		msgFromVMChan,
 		time.Second,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 	)
@@ -393,6 +403,7 @@ func TestHandlerSubnetConnector(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		connector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 	)
@@ -562,6 +573,7 @@ func TestDynamicEngineTypeDispatch(t *testing.T) {
 				nil,
 				time.Second,
 				resourceTracker,
+				throttling.NewNoSubnetSystemThrottler(),
 				validators.UnhandledSubnetConnector,
 				subnets.New(ids.EmptyNodeID, subnets.Config{}),
 			)
@@ -614,3 +626,295 @@ func TestDynamicEngineTypeDispatch(t *testing.T) {
 		})
 	}
 }
+
+// Test that handling a request continues the trace of the peer that sent it
+func TestHandlerContinuesRemoteTrace(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewSet()
+	require.NoError(vdrs.Add(ids.GenerateTestNodeID(), nil, ids.Empty, 1))
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+	handler, err := New(
+		ctx,
+		vdrs,
+		nil,
+		time.Second,
+		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
+		validators.UnhandledSubnetConnector,
+		subnets.New(ids.EmptyNodeID, subnets.Config{}),
+	)
+	require.NoError(err)
+
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+	bootstrapper.StartF = func(context.Context, uint32) error {
+		return nil
+	}
+
+	remoteSpanContexts := make(chan oteltrace.SpanContext, 1)
+	engine := &common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = func() *snow.ConsensusContext {
+		return ctx
+	}
+	engine.PullQueryF = func(ctx context.Context, _ ids.NodeID, _ uint32, _ ids.ID) error {
+		remoteSpanContexts <- oteltrace.SpanContextFromContext(ctx)
+		return nil
+	}
+	handler.SetEngineManager(&EngineManager{
+		Snowman: &Engine{
+			Bootstrapper: bootstrapper,
+			Consensus:    engine,
+		},
+	})
+	ctx.State.Set(snow.EngineState{
+		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.NormalOp, // assumed bootstrap is done
+	})
+
+	handler.Start(context.Background(), false)
+
+	traceID := oteltrace.TraceID{1}
+	spanID := oteltrace.SpanID{2}
+	handler.Push(context.Background(), Message{
+		InboundMessage: message.InboundPullQuery(
+			ctx.ChainID,
+			1,
+			time.Minute,
+			ids.GenerateTestID(),
+			ids.EmptyNodeID,
+			p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		),
+		EngineType: p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+	})
+	require.False((<-remoteSpanContexts).IsValid())
+
+	mc, err := message.NewCreator(
+		prometheus.NewRegistry(),
+		"",
+		compression.TypeNone,
+		10*time.Second,
+	)
+	require.NoError(err)
+	sentCtx := oteltrace.ContextWithSpanContext(
+		context.Background(),
+		oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  spanID,
+		}),
+	)
+	outMsg, err := mc.PullQuery(sentCtx, ctx.ChainID, 1, time.Minute, ids.GenerateTestID(), p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+	require.NoError(err)
+	inMsg, err := mc.Parse(outMsg.Bytes(), ids.EmptyNodeID, func() {})
+	require.NoError(err)
+
+	handler.Push(context.Background(), Message{
+		InboundMessage: inMsg,
+		EngineType:     p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+	})
+	remoteSpanContext := <-remoteSpanContexts
+	require.True(remoteSpanContext.IsRemote())
+	require.Equal(traceID, remoteSpanContext.TraceID())
+	require.Equal(spanID, remoteSpanContext.SpanID())
+}
+
+type inspectableEngine struct {
+	*common.EngineTest
+	graphviz bool
+}
+
+func (e *inspectableEngine) Inspect(_ context.Context, graphviz bool) (interface{}, error) {
+	e.graphviz = graphviz
+	return "inspection", nil
+}
+
+func TestHandlerInspect(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewSet()
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+	handler, err := New(
+		ctx,
+		vdrs,
+		nil,
+		time.Second,
+		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
+		validators.UnhandledSubnetConnector,
+		subnets.New(ctx.NodeID, subnets.Config{}),
+	)
+	require.NoError(err)
+
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+
+	engine := &inspectableEngine{
+		EngineTest: &common.EngineTest{T: t},
+	}
+	engine.Default(false)
+
+	handler.SetEngineManager(&EngineManager{
+		Snowman: &Engine{
+			Bootstrapper: bootstrapper,
+			Consensus:    engine,
+		},
+	})
+
+	ctx.State.Set(snow.EngineState{
+		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.Bootstrapping,
+	})
+	_, err = handler.Inspect(context.Background(), false)
+	require.ErrorIs(err, common.ErrNotInspectable)
+
+	ctx.State.Set(snow.EngineState{
+		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.NormalOp,
+	})
+	inspection, err := handler.Inspect(context.Background(), true)
+	require.NoError(err)
+	require.Equal("inspection", inspection)
+	require.True(engine.graphviz)
+}
+
+type reconfigurableEngine struct {
+	*common.EngineTest
+	params avalanche.Parameters
+}
+
+func (e *reconfigurableEngine) Reconfigure(_ context.Context, params avalanche.Parameters) error {
+	e.params = params
+	return nil
+}
+
+func TestHandlerReconfigure(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewSet()
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+	handler, err := New(
+		ctx,
+		vdrs,
+		nil,
+		time.Second,
+		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
+		validators.UnhandledSubnetConnector,
+		subnets.New(ctx.NodeID, subnets.Config{}),
+	)
+	require.NoError(err)
+
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+
+	engine := &reconfigurableEngine{
+		EngineTest: &common.EngineTest{T: t},
+	}
+	engine.Default(false)
+
+	handler.SetEngineManager(&EngineManager{
+		Snowman: &Engine{
+			Bootstrapper: bootstrapper,
+			Consensus:    engine,
+		},
+	})
+
+	ctx.State.Set(snow.EngineState{
+		Type:  p2p.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.Bootstrapping,
+	})
+
+	// The consensus engine is reconfigured before it starts running.
+	params := avalanche.Parameters{
+		Parameters: snowball.Parameters{
+			K:                     1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
+			BetaVirtuous:          1,
+			BetaRogue:             2,
+			ConcurrentRepolls:     1,
+			OptimalProcessing:     1,
+			MaxOutstandingItems:   1,
+			MaxItemProcessingTime: 1,
+		},
+		Parents:   2,
+		BatchSize: 1,
+	}
+	require.NoError(handler.Reconfigure(context.Background(), params))
+	require.Equal(params, engine.params)
+
+	handler.SetEngineManager(&EngineManager{
+		Snowman: &Engine{
+			Bootstrapper: bootstrapper,
+			Consensus:    &common.EngineTest{T: t},
+		},
+	})
+	err = handler.Reconfigure(context.Background(), params)
+	require.ErrorIs(err, common.ErrNotReconfigurable)
+
+	// A failure of one engine doesn't prevent the other from being
+	// reconfigured.
+	engine = &reconfigurableEngine{
+		EngineTest: &common.EngineTest{T: t},
+	}
+	engine.Default(false)
+	handler.SetEngineManager(&EngineManager{
+		Avalanche: &Engine{
+			Bootstrapper: bootstrapper,
+			Consensus:    &common.EngineTest{T: t},
+		},
+		Snowman: &Engine{
+			Bootstrapper: bootstrapper,
+			Consensus:    engine,
+		},
+	})
+	err = handler.Reconfigure(context.Background(), params)
+	require.ErrorIs(err, common.ErrNotReconfigurable)
+	require.Equal(params, engine.params)
+}
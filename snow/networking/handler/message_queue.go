@@ -13,6 +13,7 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
@@ -42,7 +43,9 @@ type MessageQueue interface {
 	// Remove and return a message and its context.
 	//
 	// If there are no available messages, this function will block until a
-	// message becomes available or the queue is [Shutdown].
+	// message becomes available or the queue is [Shutdown]. If the subnet of
+	// this queue is using excessive resources, this function will block until
+	// the usage drops to an acceptable level or the queue is [Shutdown].
 	Pop() (context.Context, Message, bool)
 
 	// Returns the number of messages currently on the queue
@@ -61,10 +64,17 @@ type messageQueue struct {
 	metrics messageQueueMetrics
 
 	log logging.Logger
+	// Subnet of the chain associated with this
+	subnetID ids.ID
 	// Validator set for the chain associated with this
 	vdrs validators.Set
 	// Tracks CPU utilization of each node
 	cpuTracker tracker.Tracker
+	// Limits the resources used to process the messages of [subnetID]
+	subnetThrottler throttling.SubnetSystemThrottler
+	// Cancelled on [Shutdown] to stop waiting on [subnetThrottler]
+	closingCtx       context.Context
+	closingCtxCancel context.CancelFunc
 
 	cond   *sync.Cond
 	closed bool
@@ -76,16 +86,23 @@ type messageQueue struct {
 
 func NewMessageQueue(
 	log logging.Logger,
+	subnetID ids.ID,
 	vdrs validators.Set,
 	cpuTracker tracker.Tracker,
+	subnetThrottler throttling.SubnetSystemThrottler,
 	metricsNamespace string,
 	metricsRegisterer prometheus.Registerer,
 	ops []message.Op,
 ) (MessageQueue, error) {
+	closingCtx, closingCtxCancel := context.WithCancel(context.Background())
 	m := &messageQueue{
 		log:                   log,
+		subnetID:              subnetID,
 		vdrs:                  vdrs,
 		cpuTracker:            cpuTracker,
+		subnetThrottler:       subnetThrottler,
+		closingCtx:            closingCtx,
+		closingCtxCancel:      closingCtxCancel,
 		cond:                  sync.NewCond(&sync.Mutex{}),
 		nodeToUnprocessedMsgs: make(map[ids.NodeID]int),
 	}
@@ -117,9 +134,26 @@ func (m *messageQueue) Push(ctx context.Context, msg Message) {
 	m.cond.Signal()
 }
 
+func (m *messageQueue) Pop() (context.Context, Message, bool) {
+	ctx, msg, ok := m.pop()
+	if !ok || m.alwaysPop(msg) {
+		return ctx, msg, ok
+	}
+
+	// Wait until the resources used by this subnet drop to an acceptable level.
+	// The lock isn't held here so that messages can still be pushed.
+	m.subnetThrottler.Acquire(m.closingCtx, m.subnetID)
+	if m.closingCtx.Err() != nil {
+		// The queue was shutdown while waiting.
+		msg.OnFinishedHandling()
+		return nil, Message{}, false
+	}
+	return ctx, msg, true
+}
+
 // FIFO, but skip over messages whose senders whose messages have caused us to
 // use excessive CPU recently.
-func (m *messageQueue) Pop() (context.Context, Message, bool) {
+func (m *messageQueue) pop() (context.Context, Message, bool) {
 	m.cond.L.Lock()
 	defer m.cond.L.Unlock()
 
@@ -199,19 +233,13 @@ func (m *messageQueue) Shutdown() {
 
 	// Mark the queue as closed
 	m.closed = true
+	m.closingCtxCancel()
 	m.cond.Broadcast()
 }
 
 // canPop will return true for at least one message in [m.msgs]
 func (m *messageQueue) canPop(msg message.InboundMessage) bool {
-	// Always pop connected and disconnected messages.
-	if op := msg.Op(); op == message.ConnectedOp || op == message.DisconnectedOp || op == message.ConnectedSubnetOp {
-		return true
-	}
-
-	// If the deadline to handle [msg] has passed, always pop it.
-	// It will be dropped immediately.
-	if expiration := msg.Expiration(); m.clock.Time().After(expiration) {
+	if m.alwaysPop(msg) {
 		return true
 	}
 	// Every node has some allowed CPU allocation depending on
@@ -232,6 +260,19 @@ func (m *messageQueue) canPop(msg message.InboundMessage) bool {
 	return recentCPUUsage <= maxCPU
 }
 
+// alwaysPop returns true if [msg] should be popped regardless of the resources
+// that have been used recently.
+func (m *messageQueue) alwaysPop(msg message.InboundMessage) bool {
+	// Always pop connected and disconnected messages.
+	if op := msg.Op(); op == message.ConnectedOp || op == message.DisconnectedOp || op == message.ConnectedSubnetOp {
+		return true
+	}
+
+	// If the deadline to handle [msg] has passed, always pop it.
+	// It will be dropped immediately.
+	return m.clock.Time().After(msg.Expiration())
+}
+
 type msgAndContext struct {
 	msg Message
 	ctx context.Context
@@ -16,6 +16,7 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
@@ -34,7 +35,7 @@ func TestQueue(t *testing.T) {
 	vdr1ID, vdr2ID := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
 	require.NoError(vdrs.Add(vdr1ID, nil, ids.Empty, 1))
 	require.NoError(vdrs.Add(vdr2ID, nil, ids.Empty, 1))
-	mIntf, err := NewMessageQueue(logging.NoLog{}, vdrs, cpuTracker, "", prometheus.NewRegistry(), message.SynchronousOps)
+	mIntf, err := NewMessageQueue(logging.NoLog{}, ids.Empty, vdrs, cpuTracker, throttling.NewNoSubnetSystemThrottler(), "", prometheus.NewRegistry(), message.SynchronousOps)
 	require.NoError(err)
 	u := mIntf.(*messageQueue)
 	currentTime := time.Now()
@@ -166,3 +167,80 @@ func TestQueue(t *testing.T) {
 	require.EqualValues(msg3, gotMsg3)
 	require.EqualValues(0, u.Len())
 }
+
+func TestQueueSubnetThrottling(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	require := require.New(t)
+	cpuTracker := tracker.NewMockTracker(ctrl)
+	targeter := tracker.NewMockSubnetTargeter(ctrl)
+	subnetThrottler, err := throttling.NewSubnetSystemThrottler(
+		"",
+		prometheus.NewRegistry(),
+		throttling.SystemThrottlerConfig{
+			MaxRecheckDelay: time.Hour,
+		},
+		cpuTracker,
+		targeter,
+	)
+	require.NoError(err)
+
+	subnetID := ids.GenerateTestID()
+	vdrs := validators.NewSet()
+	mIntf, err := NewMessageQueue(logging.NoLog{}, subnetID, vdrs, cpuTracker, subnetThrottler, "", prometheus.NewRegistry(), message.SynchronousOps)
+	require.NoError(err)
+	u := mIntf.(*messageQueue)
+
+	nodeID := ids.GenerateTestNodeID()
+	// Connected messages are popped regardless of the subnet's usage.
+	u.Push(context.Background(), Message{
+		InboundMessage: message.InternalConnected(nodeID, nil),
+		EngineType:     engineType,
+	})
+	_, gotMsg, ok := u.Pop()
+	require.True(ok)
+	require.Equal(message.ConnectedOp, gotMsg.Op())
+
+	// The subnet's usage is below its target.
+	msg := Message{
+		InboundMessage: message.InboundPullQuery(
+			ids.Empty,
+			0,
+			time.Minute,
+			ids.GenerateTestID(),
+			nodeID,
+			engineType,
+		),
+		EngineType: engineType,
+	}
+	cpuTracker.EXPECT().Usage(nodeID, gomock.Any()).Return(0.0).Times(1)
+	targeter.EXPECT().TargetUsage(subnetID).Return(1.0).Times(1)
+	cpuTracker.EXPECT().SubnetUsage(subnetID, gomock.Any()).Return(0.5).Times(1)
+	u.Push(context.Background(), msg)
+	_, gotMsg, ok = u.Pop()
+	require.True(ok)
+	require.Equal(msg, gotMsg)
+
+	// The subnet's usage exceeds its target, so Pop blocks until the queue is
+	// shutdown.
+	cpuTracker.EXPECT().Usage(nodeID, gomock.Any()).Return(0.0).Times(1)
+	targeter.EXPECT().TargetUsage(subnetID).Return(1.0).Times(1)
+	cpuTracker.EXPECT().SubnetUsage(subnetID, gomock.Any()).Return(2.0).Times(1)
+	cpuTracker.EXPECT().TimeUntilSubnetUsage(subnetID, gomock.Any(), 1.0).Return(time.Hour).Times(1)
+	u.Push(context.Background(), msg)
+
+	popped := make(chan bool)
+	go func() {
+		_, _, ok := u.Pop()
+		popped <- ok
+	}()
+	select {
+	case <-popped:
+		require.FailNow("should have waited for the subnet's usage to drop")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	u.Shutdown()
+	require.False(<-popped)
+}
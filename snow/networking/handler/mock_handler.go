@@ -14,6 +14,7 @@ import (
 
 	ids "github.com/MetalBlockchain/metalgo/ids"
 	snow "github.com/MetalBlockchain/metalgo/snow"
+	avalanche "github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -69,6 +70,21 @@ func (mr *MockHandlerMockRecorder) HealthCheck(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockHandler)(nil).HealthCheck), arg0)
 }
 
+// Inspect mocks base method.
+func (m *MockHandler) Inspect(arg0 context.Context, arg1 bool) (interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Inspect", arg0, arg1)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Inspect indicates an expected call of Inspect.
+func (mr *MockHandlerMockRecorder) Inspect(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inspect", reflect.TypeOf((*MockHandler)(nil).Inspect), arg0, arg1)
+}
+
 // Len mocks base method.
 func (m *MockHandler) Len() int {
 	m.ctrl.T.Helper()
@@ -95,6 +111,20 @@ func (mr *MockHandlerMockRecorder) Push(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockHandler)(nil).Push), arg0, arg1)
 }
 
+// Reconfigure mocks base method.
+func (m *MockHandler) Reconfigure(arg0 context.Context, arg1 avalanche.Parameters) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconfigure", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reconfigure indicates an expected call of Reconfigure.
+func (mr *MockHandlerMockRecorder) Reconfigure(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconfigure", reflect.TypeOf((*MockHandler)(nil).Reconfigure), arg0, arg1)
+}
+
 // RegisterTimeout mocks base method.
 func (m *MockHandler) RegisterTimeout(arg0 time.Duration) {
 	m.ctrl.T.Helper()
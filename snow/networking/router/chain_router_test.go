@@ -18,6 +18,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/api/metrics"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
@@ -90,6 +91,7 @@ func TestShutdown(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 	)
@@ -225,6 +227,7 @@ func TestShutdownTimesOut(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 	)
@@ -381,6 +384,7 @@ func TestRouterTimeout(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 	)
@@ -849,6 +853,7 @@ func TestRouterClearTimeouts(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 	)
@@ -1139,6 +1144,7 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		validators.UnhandledSubnetConnector,
 		sb,
 	)
@@ -1288,6 +1294,7 @@ func TestRouterCrossChainMessages(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		validators.UnhandledSubnetConnector,
 		subnets.New(requester.NodeID, subnets.Config{}),
 	)
@@ -1305,6 +1312,7 @@ func TestRouterCrossChainMessages(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		validators.UnhandledSubnetConnector,
 		subnets.New(responder.NodeID, subnets.Config{}),
 	)
@@ -1553,6 +1561,7 @@ func TestValidatorOnlyAllowedNodeMessageDrops(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		validators.UnhandledSubnetConnector,
 		sb,
 	)
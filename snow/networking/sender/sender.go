@@ -6,6 +6,7 @@ package sender
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -637,6 +638,55 @@ func (s *sender) SendAccepted(ctx context.Context, nodeID ids.NodeID, requestID
 }
 
 func (s *sender) SendGetAncestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, containerID ids.ID) {
+	s.sendGetAncestors(
+		ctx,
+		nodeID,
+		requestID,
+		zap.Stringer("containerID", containerID),
+		func(ctx context.Context, deadline time.Duration) (message.OutboundMessage, error) {
+			return s.msgCreator.GetAncestors(
+				ctx,
+				s.ctx.ChainID,
+				requestID,
+				deadline,
+				containerID,
+				s.engineType,
+			)
+		},
+	)
+}
+
+// SendGetAncestorsAtHeight requests that node [nodeID] send the container it
+// accepted at [height] and its ancestors. The response is handled like the
+// response to a GetAncestors message.
+func (s *sender) SendGetAncestorsAtHeight(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) {
+	s.sendGetAncestors(
+		ctx,
+		nodeID,
+		requestID,
+		zap.Uint64("height", height),
+		func(ctx context.Context, deadline time.Duration) (message.OutboundMessage, error) {
+			return s.msgCreator.GetAncestorsAtHeight(
+				ctx,
+				s.ctx.ChainID,
+				requestID,
+				deadline,
+				height,
+				s.engineType,
+			)
+		},
+	)
+}
+
+// sendGetAncestors sends the GetAncestors message built by [buildMsg] to
+// [nodeID]. [requested] describes the requested containers in logs.
+func (s *sender) sendGetAncestors(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	requested zap.Field,
+	buildMsg func(ctx context.Context, deadline time.Duration) (message.OutboundMessage, error),
+) {
 	ctx = utils.Detach(ctx)
 
 	// Tell the router to expect a response message or a message notifying
@@ -677,19 +727,13 @@ func (s *sender) SendGetAncestors(ctx context.Context, nodeID ids.NodeID, reques
 	// registered. That's OK.
 	deadline := s.timeouts.TimeoutDuration()
 	// Create the outbound message.
-	outMsg, err := s.msgCreator.GetAncestors(
-		s.ctx.ChainID,
-		requestID,
-		deadline,
-		containerID,
-		s.engineType,
-	)
+	outMsg, err := buildMsg(ctx, deadline)
 	if err != nil {
 		s.ctx.Log.Error("failed to build message",
 			zap.Stringer("messageOp", message.GetAncestorsOp),
 			zap.Stringer("chainID", s.ctx.ChainID),
 			zap.Uint32("requestID", requestID),
-			zap.Stringer("containerID", containerID),
+			requested,
 			zap.Error(err),
 		)
 
@@ -712,7 +756,7 @@ func (s *sender) SendGetAncestors(ctx context.Context, nodeID ids.NodeID, reques
 			zap.Stringer("nodeID", nodeID),
 			zap.Stringer("chainID", s.ctx.ChainID),
 			zap.Uint32("requestID", requestID),
-			zap.Stringer("containerID", containerID),
+			requested,
 		)
 
 		s.timeouts.RegisterRequestToUnreachableValidator()
@@ -964,6 +1008,7 @@ func (s *sender) SendPushQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID],
 
 	// Create the outbound message.
 	outMsg, err := s.msgCreator.PushQuery(
+		ctx,
 		s.ctx.ChainID,
 		requestID,
 		deadline,
@@ -1092,6 +1137,7 @@ func (s *sender) SendPullQuery(ctx context.Context, nodeIDs set.Set[ids.NodeID],
 
 	// Create the outbound message.
 	outMsg, err := s.msgCreator.PullQuery(
+		ctx,
 		s.ctx.ChainID,
 		requestID,
 		deadline,
@@ -1309,6 +1355,7 @@ func (s *sender) SendAppRequest(ctx context.Context, nodeIDs set.Set[ids.NodeID]
 
 	// Create the outbound message.
 	outMsg, err := s.msgCreator.AppRequest(
+		ctx,
 		s.ctx.ChainID,
 		requestID,
 		deadline,
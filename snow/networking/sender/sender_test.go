@@ -18,6 +18,7 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
@@ -28,6 +29,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/subnets"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/math/meter"
 	"github.com/MetalBlockchain/metalgo/utils/resource"
@@ -72,7 +74,7 @@ func TestTimeout(t *testing.T) {
 	mc, err := message.NewCreator(
 		metrics,
 		"dummyNamespace",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	require.NoError(err)
@@ -121,6 +123,7 @@ func TestTimeout(t *testing.T) {
 		nil,
 		time.Hour,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 	)
@@ -343,7 +346,7 @@ func TestReliableMessages(t *testing.T) {
 	mc, err := message.NewCreator(
 		metrics,
 		"dummyNamespace",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -393,6 +396,7 @@ func TestReliableMessages(t *testing.T) {
 		nil,
 		1,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 	)
@@ -490,7 +494,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 	mc, err := message.NewCreator(
 		metrics,
 		"dummyNamespace",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -540,6 +544,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		validators.UnhandledSubnetConnector,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 	)
@@ -1124,6 +1129,7 @@ func TestSender_Single_Request(t *testing.T) {
 		requestID         = uint32(1337)
 		ctx               = snow.DefaultContextTest()
 		containerID       = ids.GenerateTestID()
+		height            = uint64(1337)
 		engineType        = p2p.EngineType_ENGINE_TYPE_SNOWMAN
 	)
 	ctx.ChainID = chainID
@@ -1166,6 +1172,7 @@ func TestSender_Single_Request(t *testing.T) {
 			expectedResponseOp: message.AncestorsOp,
 			setMsgCreatorExpect: func(msgCreator *message.MockOutboundMsgBuilder) {
 				msgCreator.EXPECT().GetAncestors(
+					gomock.Any(),
 					chainID,
 					requestID,
 					deadline,
@@ -1185,6 +1192,46 @@ func TestSender_Single_Request(t *testing.T) {
 				sender.SendGetAncestors(context.Background(), nodeID, requestID, containerID)
 			},
 		},
+		{
+			name: "GetAncestorsAtHeight",
+			failedMsgF: func(nodeID ids.NodeID) message.InboundMessage {
+				return message.InternalGetAncestorsFailed(
+					nodeID,
+					chainID,
+					requestID,
+					engineType,
+				)
+			},
+			assertMsgToMyself: func(require *require.Assertions, msg message.InboundMessage) {
+				innerMsg, ok := msg.Message().(*message.GetAncestorsFailed)
+				require.True(ok)
+				require.Equal(chainID, innerMsg.ChainID)
+				require.Equal(requestID, innerMsg.RequestID)
+				require.Equal(engineType, innerMsg.EngineType)
+			},
+			expectedResponseOp: message.AncestorsOp,
+			setMsgCreatorExpect: func(msgCreator *message.MockOutboundMsgBuilder) {
+				msgCreator.EXPECT().GetAncestorsAtHeight(
+					gomock.Any(),
+					chainID,
+					requestID,
+					deadline,
+					height,
+					engineType,
+				).Return(nil, nil)
+			},
+			setExternalSenderExpect: func(externalSender *MockExternalSender, sentTo set.Set[ids.NodeID]) {
+				externalSender.EXPECT().Send(
+					gomock.Any(), // Outbound message
+					set.Set[ids.NodeID]{destinationNodeID: struct{}{}}, // Node IDs
+					subnetID,
+					gomock.Any(),
+				).Return(sentTo)
+			},
+			sendF: func(_ *require.Assertions, sender common.Sender, nodeID ids.NodeID) {
+				sender.SendGetAncestorsAtHeight(context.Background(), nodeID, requestID, height)
+			},
+		},
 		{
 			name: "Get",
 			failedMsgF: func(nodeID ids.NodeID) message.InboundMessage {
@@ -124,6 +124,17 @@ func (s *tracedSender) SendGetAncestors(ctx context.Context, nodeID ids.NodeID,
 	s.sender.SendGetAncestors(ctx, nodeID, requestID, containerID)
 }
 
+func (s *tracedSender) SendGetAncestorsAtHeight(ctx context.Context, nodeID ids.NodeID, requestID uint32, height uint64) {
+	ctx, span := s.tracer.Start(ctx, "tracedSender.SendGetAncestorsAtHeight", oteltrace.WithAttributes(
+		attribute.Stringer("recipients", nodeID),
+		attribute.Int64("requestID", int64(requestID)),
+		attribute.Int64("height", int64(height)),
+	))
+	defer span.End()
+
+	s.sender.SendGetAncestorsAtHeight(ctx, nodeID, requestID, height)
+}
+
 func (s *tracedSender) SendAncestors(ctx context.Context, nodeID ids.NodeID, requestID uint32, containers [][]byte) {
 	_, span := s.tracer.Start(ctx, "tracedSender.SendAncestors", oteltrace.WithAttributes(
 		attribute.Stringer("recipients", nodeID),
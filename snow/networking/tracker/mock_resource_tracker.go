@@ -38,6 +38,34 @@ func (m *MockTracker) EXPECT() *MockTrackerMockRecorder {
 	return m.recorder
 }
 
+// SubnetUsage mocks base method.
+func (m *MockTracker) SubnetUsage(arg0 ids.ID, arg1 time.Time) float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubnetUsage", arg0, arg1)
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// SubnetUsage indicates an expected call of SubnetUsage.
+func (mr *MockTrackerMockRecorder) SubnetUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubnetUsage", reflect.TypeOf((*MockTracker)(nil).SubnetUsage), arg0, arg1)
+}
+
+// TimeUntilSubnetUsage mocks base method.
+func (m *MockTracker) TimeUntilSubnetUsage(arg0 ids.ID, arg1 time.Time, arg2 float64) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimeUntilSubnetUsage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// TimeUntilSubnetUsage indicates an expected call of TimeUntilSubnetUsage.
+func (mr *MockTrackerMockRecorder) TimeUntilSubnetUsage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimeUntilSubnetUsage", reflect.TypeOf((*MockTracker)(nil).TimeUntilSubnetUsage), arg0, arg1, arg2)
+}
+
 // TimeUntilUsage mocks base method.
 func (m *MockTracker) TimeUntilUsage(arg0 ids.NodeID, arg1 time.Time, arg2 float64) time.Duration {
 	m.ctrl.T.Helper()
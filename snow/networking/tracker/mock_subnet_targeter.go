@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/MetalBlockchain/metalgo/snow/networking/tracker (interfaces: SubnetTargeter)
+
+// Package tracker is a generated GoMock package.
+package tracker
+
+import (
+	reflect "reflect"
+
+	ids "github.com/MetalBlockchain/metalgo/ids"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSubnetTargeter is a mock of SubnetTargeter interface.
+type MockSubnetTargeter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubnetTargeterMockRecorder
+}
+
+// MockSubnetTargeterMockRecorder is the mock recorder for MockSubnetTargeter.
+type MockSubnetTargeterMockRecorder struct {
+	mock *MockSubnetTargeter
+}
+
+// NewMockSubnetTargeter creates a new mock instance.
+func NewMockSubnetTargeter(ctrl *gomock.Controller) *MockSubnetTargeter {
+	mock := &MockSubnetTargeter{ctrl: ctrl}
+	mock.recorder = &MockSubnetTargeterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubnetTargeter) EXPECT() *MockSubnetTargeterMockRecorder {
+	return m.recorder
+}
+
+// TargetUsage mocks base method.
+func (m *MockSubnetTargeter) TargetUsage(arg0 ids.ID) float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TargetUsage", arg0)
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// TargetUsage indicates an expected call of TargetUsage.
+func (mr *MockSubnetTargeterMockRecorder) TargetUsage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TargetUsage", reflect.TypeOf((*MockSubnetTargeter)(nil).TargetUsage), arg0)
+}
@@ -31,6 +31,14 @@ type Tracker interface {
 	// If the node's usage isn't known, or is already <= [value], returns the
 	// zero duration.
 	TimeUntilUsage(nodeID ids.NodeID, now time.Time, value float64) time.Duration
+	// Returns the current usage caused by processing messages of the given
+	// subnet.
+	SubnetUsage(subnetID ids.ID, now time.Time) float64
+	// Returns the duration between [now] and when the usage of [subnetID]
+	// reaches [value], assuming that the subnet uses no more resources.
+	// If the subnet's usage isn't known, or is already <= [value], returns the
+	// zero duration.
+	TimeUntilSubnetUsage(subnetID ids.ID, now time.Time, value float64) time.Duration
 }
 
 type DiskTracker interface {
@@ -46,6 +54,12 @@ type ResourceTracker interface {
 	StartProcessing(ids.NodeID, time.Time)
 	// Registers that the given node stopped processing at the given time.
 	StopProcessing(ids.NodeID, time.Time)
+	// Registers that a message of the given subnet started processing at the
+	// given time. Must be called while the sending node is processing.
+	StartProcessingSubnet(ids.ID, time.Time)
+	// Registers that a message of the given subnet stopped processing at the
+	// given time.
+	StopProcessingSubnet(ids.ID, time.Time)
 }
 
 type cpuResourceTracker struct {
@@ -110,6 +124,30 @@ func (t *cpuResourceTracker) TimeUntilUsage(nodeID ids.NodeID, now time.Time, va
 	return m.TimeUntil(now, value/scale)
 }
 
+func (t *cpuResourceTracker) SubnetUsage(subnetID ids.ID, now time.Time) float64 {
+	rt := t.t
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	realCPUUsage := rt.resources.CPUUsage()
+	rt.metrics.cpuMetric.Set(realCPUUsage)
+
+	usage := rt.subnetUsage(subnetID, now, realCPUUsage)
+	rt.metrics.subnetCPUMetric.WithLabelValues(subnetID.String()).Set(usage)
+	return usage
+}
+
+func (t *cpuResourceTracker) TimeUntilSubnetUsage(subnetID ids.ID, now time.Time, value float64) time.Duration {
+	rt := t.t
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	realCPUUsage := rt.resources.CPUUsage()
+	rt.metrics.cpuMetric.Set(realCPUUsage)
+
+	return rt.timeUntilSubnetUsage(subnetID, now, value, realCPUUsage)
+}
+
 type diskResourceTracker struct {
 	t *resourceTracker
 }
@@ -187,6 +225,34 @@ func (t *diskResourceTracker) TimeUntilUsage(nodeID ids.NodeID, now time.Time, v
 	return m.TimeUntil(now, value/scale)
 }
 
+func (t *diskResourceTracker) SubnetUsage(subnetID ids.ID, now time.Time) float64 {
+	rt := t.t
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	// [realWriteUsage] is only used for metrics.
+	realReadUsage, realWriteUsage := rt.resources.DiskUsage()
+	rt.metrics.diskReadsMetric.Set(realReadUsage)
+	rt.metrics.diskWritesMetric.Set(realWriteUsage)
+
+	usage := rt.subnetUsage(subnetID, now, realReadUsage)
+	rt.metrics.subnetDiskReadsMetric.WithLabelValues(subnetID.String()).Set(usage)
+	return usage
+}
+
+func (t *diskResourceTracker) TimeUntilSubnetUsage(subnetID ids.ID, now time.Time, value float64) time.Duration {
+	rt := t.t
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	// [realWriteUsage] is only used for metrics.
+	realReadUsage, realWriteUsage := rt.resources.DiskUsage()
+	rt.metrics.diskReadsMetric.Set(realReadUsage)
+	rt.metrics.diskWritesMetric.Set(realWriteUsage)
+
+	return rt.timeUntilSubnetUsage(subnetID, now, value, realReadUsage)
+}
+
 type resourceTracker struct {
 	lock sync.RWMutex
 
@@ -200,8 +266,13 @@ type resourceTracker struct {
 	// utilized. This doesn't necessarily result in the meters being sorted
 	// based on their usage. However, in practice the nodes that are not being
 	// utilized will move towards the oldest elements where they can be deleted.
-	meters  linkedhashmap.LinkedHashmap[ids.NodeID, meter.Meter]
-	metrics *trackerMetrics
+	meters linkedhashmap.LinkedHashmap[ids.NodeID, meter.Meter]
+	// Each element is a meter that tracks the number of current processing
+	// requests of a subnet. [subnetMeters] is ordered by the last time that a
+	// meter was utilized, so idle subnets move towards the oldest elements
+	// where they can be deleted.
+	subnetMeters linkedhashmap.LinkedHashmap[ids.ID, meter.Meter]
+	metrics      *trackerMetrics
 }
 
 func NewResourceTracker(
@@ -216,6 +287,7 @@ func NewResourceTracker(
 		processingMeter: factory.New(halflife),
 		halflife:        halflife,
 		meters:          linkedhashmap.New[ids.NodeID, meter.Meter](),
+		subnetMeters:    linkedhashmap.New[ids.ID, meter.Meter](),
 	}
 	var err error
 	t.metrics, err = newCPUTrackerMetrics("resource_tracker", reg)
@@ -251,6 +323,64 @@ func (rt *resourceTracker) StopProcessing(nodeID ids.NodeID, now time.Time) {
 	rt.processingMeter.Dec(now, 1)
 }
 
+func (rt *resourceTracker) StartProcessingSubnet(subnetID ids.ID, now time.Time) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	meter := rt.getSubnetMeter(subnetID)
+	meter.Inc(now, 1)
+}
+
+func (rt *resourceTracker) StopProcessingSubnet(subnetID ids.ID, now time.Time) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	meter := rt.getSubnetMeter(subnetID)
+	meter.Dec(now, 1)
+}
+
+// subnetUsage returns the portion of [realUsage] caused by processing messages
+// of [subnetID].
+// assumes [rt.lock] is held.
+func (rt *resourceTracker) subnetUsage(subnetID ids.ID, now time.Time, realUsage float64) float64 {
+	measuredProcessingTime := rt.processingMeter.Read(now)
+	rt.metrics.processingTimeMetric.Set(measuredProcessingTime)
+
+	if measuredProcessingTime == 0 {
+		return 0
+	}
+
+	m, exists := rt.subnetMeters.Get(subnetID)
+	if !exists {
+		return 0
+	}
+
+	portionUsageBySubnet := m.Read(now) / measuredProcessingTime
+	return realUsage * portionUsageBySubnet
+}
+
+// timeUntilSubnetUsage returns the duration between [now] and when the portion
+// of [realUsage] caused by [subnetID] reaches [value].
+// assumes [rt.lock] is held.
+func (rt *resourceTracker) timeUntilSubnetUsage(subnetID ids.ID, now time.Time, value float64, realUsage float64) time.Duration {
+	rt.pruneSubnets(now)
+
+	m, exists := rt.subnetMeters.Get(subnetID)
+	if !exists {
+		return 0
+	}
+
+	measuredProcessingTime := rt.processingMeter.Read(now)
+	rt.metrics.processingTimeMetric.Set(measuredProcessingTime)
+
+	if measuredProcessingTime == 0 || realUsage == 0 {
+		return 0
+	}
+
+	scale := realUsage / measuredProcessingTime
+	return m.TimeUntil(now, value/scale)
+}
+
 // getMeter returns the meter used to measure CPU time spent processing
 // messages from [nodeID].
 // assumes [rt.lock] is held.
@@ -265,6 +395,23 @@ func (rt *resourceTracker) getMeter(nodeID ids.NodeID) meter.Meter {
 	return newMeter
 }
 
+// getSubnetMeter returns the meter used to measure CPU time spent processing
+// messages of [subnetID].
+// assumes [rt.lock] is held.
+func (rt *resourceTracker) getSubnetMeter(subnetID ids.ID) meter.Meter {
+	m, exists := rt.subnetMeters.Get(subnetID)
+	if exists {
+		// Mark the meter as the most recently used so that subnets that are
+		// processing messages aren't pruned before idle ones.
+		rt.subnetMeters.Put(subnetID, m)
+		return m
+	}
+
+	newMeter := rt.factory.New(rt.halflife)
+	rt.subnetMeters.Put(subnetID, newMeter)
+	return newMeter
+}
+
 // prune attempts to remove meters that currently show a value less than
 // [epsilon].
 //
@@ -285,12 +432,32 @@ func (rt *resourceTracker) prune(now time.Time) {
 	}
 }
 
+// pruneSubnets attempts to remove subnet meters that currently show a value
+// less than [epsilon].
+func (rt *resourceTracker) pruneSubnets(now time.Time) {
+	for {
+		oldest, meter, exists := rt.subnetMeters.Oldest()
+		if !exists {
+			return
+		}
+
+		if meter.Read(now) > epsilon {
+			return
+		}
+
+		rt.subnetMeters.Delete(oldest)
+	}
+}
+
 type trackerMetrics struct {
 	processingTimeMetric prometheus.Gauge
 	cpuMetric            prometheus.Gauge
 	diskReadsMetric      prometheus.Gauge
 	diskWritesMetric     prometheus.Gauge
 	diskSpaceAvailable   prometheus.Gauge
+
+	subnetCPUMetric       *prometheus.GaugeVec
+	subnetDiskReadsMetric *prometheus.GaugeVec
 }
 
 func newCPUTrackerMetrics(namespace string, reg prometheus.Registerer) (*trackerMetrics, error) {
@@ -320,6 +487,22 @@ func newCPUTrackerMetrics(namespace string, reg prometheus.Registerer) (*tracker
 			Name:      "disk_available_space",
 			Help:      "Available space remaining (bytes) on the database volume",
 		}),
+		subnetCPUMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "subnet_cpu_usage",
+				Help:      "CPU usage caused by processing messages of a subnet. Value should be in [0, number of CPU cores]",
+			},
+			[]string{"subnetID"},
+		),
+		subnetDiskReadsMetric: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "subnet_disk_reads",
+				Help:      "Disk reads (bytes/sec) caused by processing messages of a subnet",
+			},
+			[]string{"subnetID"},
+		),
 	}
 	errs := wrappers.Errs{}
 	errs.Add(
@@ -328,6 +511,8 @@ func newCPUTrackerMetrics(namespace string, reg prometheus.Registerer) (*tracker
 		reg.Register(m.diskReadsMetric),
 		reg.Register(m.diskWritesMetric),
 		reg.Register(m.diskSpaceAvailable),
+		reg.Register(m.subnetCPUMetric),
+		reg.Register(m.subnetDiskReadsMetric),
 	)
 	return m, errs.Err
 }
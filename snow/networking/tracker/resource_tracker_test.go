@@ -133,3 +133,44 @@ func TestCPUTrackerTimeUntilCPUUtilization(t *testing.T) {
 	// Make sure it returns the zero duration if the node isn't known
 	require.Zero(t, cpuTracker.TimeUntilUsage(ids.GenerateTestNodeID(), now, 0.0001))
 }
+
+func TestCPUTrackerSubnetUsage(t *testing.T) {
+	require := require.New(t)
+
+	halflife := 5 * time.Second
+
+	ctrl := gomock.NewController(t)
+	mockUser := resource.NewMockUser(ctrl)
+	mockUser.EXPECT().CPUUsage().Return(1.0).AnyTimes()
+
+	tracker, err := NewResourceTracker(prometheus.NewRegistry(), mockUser, meter.ContinuousFactory{}, halflife)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	subnetID := ids.GenerateTestID()
+
+	// Half of the time spent processing messages from [nodeID] is spent
+	// processing messages of [subnetID].
+	now := time.Now()
+	tracker.StartProcessing(nodeID, now)
+	now = now.Add(halflife)
+	tracker.StartProcessingSubnet(subnetID, now)
+	now = now.Add(halflife)
+	tracker.StopProcessingSubnet(subnetID, now)
+	tracker.StopProcessing(nodeID, now)
+
+	cpuTracker := tracker.CPUTracker()
+	nodeUsage := cpuTracker.Usage(nodeID, now)
+	subnetUsage := cpuTracker.SubnetUsage(subnetID, now)
+	require.Greater(subnetUsage, 0.0)
+	require.Less(subnetUsage, nodeUsage)
+
+	// Unknown subnets don't have any usage
+	require.Zero(cpuTracker.SubnetUsage(ids.GenerateTestID(), now))
+
+	// It takes one halflife for the subnet's usage to be halved, assuming no
+	// more usage.
+	require.InDelta(halflife, cpuTracker.TimeUntilSubnetUsage(subnetID, now, subnetUsage/2), float64(time.Millisecond))
+	require.Zero(cpuTracker.TimeUntilSubnetUsage(subnetID, now, subnetUsage))
+	require.Zero(cpuTracker.TimeUntilSubnetUsage(ids.GenerateTestID(), now, 0.0001))
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"math"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+var _ SubnetTargeter = (*subnetTargeter)(nil)
+
+type SubnetTargeter interface {
+	// Returns the target usage of the given subnet.
+	TargetUsage(subnetID ids.ID) float64
+}
+
+// SubnetAllocation describes the portion of a resource that may be used to
+// process the messages of a subnet.
+type SubnetAllocation struct {
+	// Allocation is the portion of the target usage, in [0, 1], that is
+	// reserved for the subnet.
+	Allocation float64 `json:"allocation" yaml:"allocation"`
+
+	// Quota is the maximum portion of the target usage, in [0, 1], that the
+	// subnet may use. If 0, the subnet's usage is only limited by the usage of
+	// the other subnets.
+	Quota float64 `json:"quota" yaml:"quota"`
+}
+
+type SubnetTargeterConfig struct {
+	// Target is the amount of the resource to split over subnets.
+	Target float64 `json:"target"`
+
+	// Allocations of each subnet. Subnets without an allocation have no
+	// reserved portion of [Target] and no quota.
+	Allocations map[ids.ID]SubnetAllocation `json:"allocations"`
+}
+
+func NewSubnetTargeter(
+	config *SubnetTargeterConfig,
+	tracker Tracker,
+) SubnetTargeter {
+	return &subnetTargeter{
+		tracker:     tracker,
+		target:      config.Target,
+		allocations: config.Allocations,
+	}
+}
+
+type subnetTargeter struct {
+	tracker     Tracker
+	target      float64
+	allocations map[ids.ID]SubnetAllocation
+}
+
+func (t *subnetTargeter) TargetUsage(subnetID ids.ID) float64 {
+	allocation := t.allocations[subnetID]
+
+	// Every subnet may use whatever portion of the target isn't currently
+	// used, on top of its reserved allocation.
+	usage := t.tracker.TotalUsage()
+	atLargeAlloc := math.Max(0, t.target-usage)
+	target := allocation.Allocation*t.target + atLargeAlloc
+	if allocation.Quota == 0 {
+		return target
+	}
+	return math.Min(target, allocation.Quota*t.target)
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+func TestSubnetTarget(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		allocatedSubnet   = ids.GenerateTestID()
+		limitedSubnet     = ids.GenerateTestID()
+		unallocatedSubnet = ids.GenerateTestID()
+	)
+
+	tracker := NewMockTracker(ctrl)
+	config := &SubnetTargeterConfig{
+		Target: 10,
+		Allocations: map[ids.ID]SubnetAllocation{
+			allocatedSubnet: {
+				Allocation: .5,
+			},
+			limitedSubnet: {
+				Allocation: .1,
+				Quota:      .2,
+			},
+		},
+	}
+
+	targeter := NewSubnetTargeter(config, tracker)
+
+	tests := []struct {
+		name           string
+		totalUsage     float64
+		subnetID       ids.ID
+		expectedTarget float64
+	}{
+		{
+			name:           "allocation and at-large alloc",
+			totalUsage:     6,
+			subnetID:       allocatedSubnet,
+			expectedTarget: 5 + 4, // .5 * 10 + max(0, 10-6)
+		},
+		{
+			name:           "allocation and at-large alloc used",
+			totalUsage:     12,
+			subnetID:       allocatedSubnet,
+			expectedTarget: 5 + 0, // .5 * 10 + max(0, 10-12)
+		},
+		{
+			name:           "no allocation and at-large alloc",
+			totalUsage:     6,
+			subnetID:       unallocatedSubnet,
+			expectedTarget: 0 + 4, // 0 * 10 + max(0, 10-6)
+		},
+		{
+			name:           "no allocation and at-large alloc used",
+			totalUsage:     10,
+			subnetID:       unallocatedSubnet,
+			expectedTarget: 0, // 0 * 10 + max(0, 10-10)
+		},
+		{
+			name:           "quota exceeded",
+			totalUsage:     0,
+			subnetID:       limitedSubnet,
+			expectedTarget: 2, // min(.1 * 10 + max(0, 10-0), .2 * 10)
+		},
+		{
+			name:           "quota not exceeded",
+			totalUsage:     10,
+			subnetID:       limitedSubnet,
+			expectedTarget: 1, // min(.1 * 10 + max(0, 10-10), .2 * 10)
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker.EXPECT().TotalUsage().Return(tt.totalUsage).Times(1)
+			require.Equal(t, tt.expectedTarget, targeter.TargetUsage(tt.subnetID))
+		})
+	}
+}
@@ -50,7 +50,13 @@ type manager struct {
 }
 
 func NewManager(state State) Manager {
+	return NewManagerWithClock(state, mockable.Clock{})
+}
+
+// NewManagerWithClock returns a Manager that measures uptimes with [clock].
+func NewManagerWithClock(state State, clock mockable.Clock) Manager {
 	return &manager{
+		clock:       clock,
 		state:       state,
 		connections: make(map[ids.NodeID]map[ids.ID]time.Time),
 	}
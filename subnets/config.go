@@ -4,16 +4,28 @@
 package subnets
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
+	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 )
 
-var errAllowedNodesWhenNotValidatorOnly = errors.New("allowedNodes can only be set when ValidatorOnly is true")
+// allocationTolerance is the amount that the sum of the allocations of all
+// subnets may exceed 1 by, to allow for floating point rounding.
+const allocationTolerance = 1e-9
+
+var (
+	errAllowedNodesWhenNotValidatorOnly = errors.New("allowedNodes can only be set when ValidatorOnly is true")
+	errInvalidResourceAllocation        = errors.New("invalid resource allocation")
+	errOversubscribedAllocation         = errors.New("resource allocations sum to more than 1")
+)
 
 type GossipConfig struct {
 	AcceptedFrontierValidatorSize    uint `json:"gossipAcceptedFrontierValidatorSize" yaml:"gossipAcceptedFrontierValidatorSize"`
@@ -47,6 +59,60 @@ type Config struct {
 
 	// See comment on [MinPercentConnectedStakeHealthy] in platformvm.Config
 	MinPercentConnectedStakeHealthy float64 `json:"minPercentConnectedStakeHealthy" yaml:"minPercentConnectedStakeHealthy"`
+
+	// CPUAllocation is the portion of this node's CPU usage target that is
+	// reserved for processing this Subnet's messages, and the maximum portion
+	// that processing this Subnet's messages may use.
+	CPUAllocation tracker.SubnetAllocation `json:"cpuAllocation" yaml:"cpuAllocation"`
+	// DiskAllocation is the portion of this node's disk usage target that is
+	// reserved for processing this Subnet's messages, and the maximum portion
+	// that processing this Subnet's messages may use.
+	DiskAllocation tracker.SubnetAllocation `json:"diskAllocation" yaml:"diskAllocation"`
+}
+
+// UnmarshalJSON parses [b] on top of the current values of [c], so that any
+// fields missing from [b] keep their defaults.
+func (c *Config) UnmarshalJSON(b []byte) error {
+	// [config] has the fields of [Config] but not this method, which avoids
+	// infinite recursion.
+	type config Config
+	if err := json.Unmarshal(b, (*config)(c)); err != nil {
+		return err
+	}
+
+	var raw struct {
+		ConsensusParameters json.RawMessage `json:"consensusParameters"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	if raw.ConsensusParameters == nil {
+		return nil
+	}
+	return UnmarshalConsensusParameters(raw.ConsensusParameters, &c.ConsensusParameters)
+}
+
+// UnmarshalConsensusParameters parses [b] on top of the current values of
+// [params].
+//
+// The legacy [alpha] field is still accepted for backwards compatibility. If
+// set, it overrides both [AlphaPreference] and [AlphaConfidence].
+func UnmarshalConsensusParameters(b []byte, params *avalanche.Parameters) error {
+	if err := json.Unmarshal(b, params); err != nil {
+		return err
+	}
+
+	var legacy struct {
+		Alpha *int `json:"alpha"`
+	}
+	if err := json.Unmarshal(b, &legacy); err != nil {
+		return err
+	}
+	if legacy.Alpha != nil {
+		params.AlphaPreference = *legacy.Alpha
+		params.AlphaConfidence = *legacy.Alpha
+	}
+	return nil
 }
 
 func (c *Config) Valid() error {
@@ -56,5 +122,57 @@ func (c *Config) Valid() error {
 	if !c.ValidatorOnly && c.AllowedNodes.Len() > 0 {
 		return errAllowedNodesWhenNotValidatorOnly
 	}
+	if err := verifyAllocation(c.CPUAllocation); err != nil {
+		return fmt.Errorf("cpu allocation is invalid: %w", err)
+	}
+	if err := verifyAllocation(c.DiskAllocation); err != nil {
+		return fmt.Errorf("disk allocation is invalid: %w", err)
+	}
+	return nil
+}
+
+func verifyAllocation(a tracker.SubnetAllocation) error {
+	switch {
+	case a.Allocation < 0 || a.Allocation > 1:
+		return fmt.Errorf("%w: allocation (%f) not in [0, 1]", errInvalidResourceAllocation, a.Allocation)
+	case a.Quota < 0 || a.Quota > 1:
+		return fmt.Errorf("%w: quota (%f) not in [0, 1]", errInvalidResourceAllocation, a.Quota)
+	case a.Quota != 0 && a.Allocation > a.Quota:
+		return fmt.Errorf("%w: allocation (%f) > quota (%f)", errInvalidResourceAllocation, a.Allocation, a.Quota)
+	default:
+		return nil
+	}
+}
+
+// VerifyAllocations returns an error if the resources reserved by [configs]
+// sum to more than the whole target usage, in which case the reservations
+// couldn't all be honored.
+func VerifyAllocations(configs map[ids.ID]Config) error {
+	var cpu, disk float64
+	for _, config := range configs {
+		cpu += config.CPUAllocation.Allocation
+		disk += config.DiskAllocation.Allocation
+	}
+	if cpu > 1+allocationTolerance {
+		return fmt.Errorf("%w: cpu allocations sum to %f", errOversubscribedAllocation, cpu)
+	}
+	if disk > 1+allocationTolerance {
+		return fmt.Errorf("%w: disk allocations sum to %f", errOversubscribedAllocation, disk)
+	}
 	return nil
 }
+
+// MinPercentConnectedStakeHealthy returns the portion of a subnet's stake that
+// this node must be connected to for the subnet to be reported as healthy,
+// when the subnet runs consensus with [params].
+func MinPercentConnectedStakeHealthy(params snowball.Parameters) float64 {
+	r := float64(params.AlphaConfidence) / float64(params.K)
+	return r*(1-constants.MinConnectedStakeBuffer) + constants.MinConnectedStakeBuffer
+}
+
+// BenchlistMaxPortion returns the maximum portion of stake that may be benched
+// when consensus runs with [params].
+func BenchlistMaxPortion(params snowball.Parameters) float64 {
+	r := float64(params.AlphaConfidence) / float64(params.K)
+	return (1.0 - r) / 3.0
+}
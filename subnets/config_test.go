@@ -4,6 +4,7 @@
 package subnets
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -11,6 +12,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
+	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 )
 
@@ -19,7 +21,8 @@ var validParameters = avalanche.Parameters{
 	BatchSize: 1,
 	Parameters: snowball.Parameters{
 		K:                     1,
-		Alpha:                 1,
+		AlphaPreference:       1,
+		AlphaConfidence:       1,
 		BetaVirtuous:          1,
 		BetaRogue:             1,
 		ConcurrentRepolls:     1,
@@ -40,8 +43,9 @@ func TestValid(t *testing.T) {
 			s: Config{
 				ConsensusParameters: avalanche.Parameters{
 					Parameters: snowball.Parameters{
-						K:     2,
-						Alpha: 1,
+						K:               2,
+						AlphaPreference: 1,
+						AlphaConfidence: 1,
 					},
 				},
 			},
@@ -56,6 +60,27 @@ func TestValid(t *testing.T) {
 			},
 			err: errAllowedNodesWhenNotValidatorOnly.Error(),
 		},
+		{
+			name: "invalid cpu allocation",
+			s: Config{
+				ConsensusParameters: validParameters,
+				CPUAllocation: tracker.SubnetAllocation{
+					Allocation: 1.5,
+				},
+			},
+			err: errInvalidResourceAllocation.Error(),
+		},
+		{
+			name: "disk allocation exceeds quota",
+			s: Config{
+				ConsensusParameters: validParameters,
+				DiskAllocation: tracker.SubnetAllocation{
+					Allocation: .5,
+					Quota:      .25,
+				},
+			},
+			err: errInvalidResourceAllocation.Error(),
+		},
 		{
 			name: "valid",
 			s: Config{
@@ -75,3 +100,91 @@ func TestValid(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyAllocations(t *testing.T) {
+	require := require.New(t)
+
+	subnetID0 := ids.GenerateTestID()
+	subnetID1 := ids.GenerateTestID()
+	configs := map[ids.ID]Config{
+		subnetID0: {
+			CPUAllocation:  tracker.SubnetAllocation{Allocation: .5},
+			DiskAllocation: tracker.SubnetAllocation{Allocation: .7},
+		},
+		subnetID1: {
+			CPUAllocation:  tracker.SubnetAllocation{Allocation: .3},
+			DiskAllocation: tracker.SubnetAllocation{Allocation: .3},
+		},
+	}
+	require.NoError(VerifyAllocations(configs))
+
+	configs[subnetID1] = Config{
+		CPUAllocation:  tracker.SubnetAllocation{Allocation: .6},
+		DiskAllocation: tracker.SubnetAllocation{Allocation: .3},
+	}
+	err := VerifyAllocations(configs)
+	require.ErrorIs(err, errOversubscribedAllocation)
+	require.ErrorContains(err, "cpu")
+
+	configs[subnetID1] = Config{
+		CPUAllocation:  tracker.SubnetAllocation{Allocation: .5},
+		DiskAllocation: tracker.SubnetAllocation{Allocation: .4},
+	}
+	err = VerifyAllocations(configs)
+	require.ErrorIs(err, errOversubscribedAllocation)
+	require.ErrorContains(err, "disk")
+}
+
+func TestConfigUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name                    string
+		givenJSON               string
+		expectedK               int
+		expectedAlphaPreference int
+		expectedAlphaConfidence int
+		expectedValidatorOnly   bool
+	}{
+		{
+			name:                    "no consensus parameters keeps defaults",
+			givenJSON:               `{"validatorOnly": true}`,
+			expectedK:               20,
+			expectedAlphaPreference: 14,
+			expectedAlphaConfidence: 15,
+			expectedValidatorOnly:   true,
+		},
+		{
+			name:                    "split alpha",
+			givenJSON:               `{"consensusParameters": {"alphaPreference": 12, "alphaConfidence": 16}}`,
+			expectedK:               20,
+			expectedAlphaPreference: 12,
+			expectedAlphaConfidence: 16,
+		},
+		{
+			name:                    "legacy alpha",
+			givenJSON:               `{"consensusParameters": {"k": 30, "alpha": 20}}`,
+			expectedK:               30,
+			expectedAlphaPreference: 20,
+			expectedAlphaConfidence: 20,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config := Config{
+				ConsensusParameters: avalanche.Parameters{
+					Parameters: snowball.Parameters{
+						K:               20,
+						AlphaPreference: 14,
+						AlphaConfidence: 15,
+					},
+				},
+			}
+			require.NoError(json.Unmarshal([]byte(test.givenJSON), &config))
+			require.Equal(test.expectedK, config.ConsensusParameters.K)
+			require.Equal(test.expectedAlphaPreference, config.ConsensusParameters.AlphaPreference)
+			require.Equal(test.expectedAlphaConfidence, config.ConsensusParameters.AlphaConfidence)
+			require.Equal(test.expectedValidatorOnly, config.ValidatorOnly)
+		})
+	}
+}
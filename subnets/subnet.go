@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 )
@@ -30,6 +31,10 @@ type Subnet interface {
 	// Config returns config of this Subnet
 	Config() Config
 
+	// SetConsensusParameters replaces the consensus parameters in the config
+	// of this Subnet
+	SetConsensusParameters(params avalanche.Parameters)
+
 	Allower
 }
 
@@ -90,9 +95,19 @@ func (s *subnet) AddChain(chainID ids.ID) bool {
 }
 
 func (s *subnet) Config() Config {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
 	return s.config
 }
 
+func (s *subnet) SetConsensusParameters(params avalanche.Parameters) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.config.ConsensusParameters = params
+}
+
 func (s *subnet) IsAllowed(nodeID ids.NodeID, isValidator bool) bool {
 	// Case 1: NodeID is this node
 	// Case 2: This subnet is not validator-only subnet
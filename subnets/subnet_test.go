@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/avalanche"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 )
 
@@ -70,3 +71,21 @@ func TestIsAllowed(t *testing.T) {
 	require.False(s.IsAllowed(ids.GenerateTestNodeID(), false), "Non-validator should not be allowed with validator only rules and allowed nodes")
 	require.True(s.IsAllowed(allowedNodeID, true), "Non-validator allowed node should be allowed with validator only rules and allowed nodes")
 }
+
+func TestSubnetSetConsensusParameters(t *testing.T) {
+	require := require.New(t)
+
+	config := Config{
+		ValidatorOnly: true,
+	}
+	s := New(ids.GenerateTestNodeID(), config)
+
+	params := avalanche.Parameters{
+		Parents:   2,
+		BatchSize: 1,
+	}
+	s.SetConsensusParameters(params)
+
+	config.ConsensusParameters = params
+	require.Equal(config, s.Config())
+}
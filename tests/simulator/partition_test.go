@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/config"
+	"github.com/MetalBlockchain/metalgo/genesis"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/simulator"
+	"github.com/MetalBlockchain/metalgo/snow/choices"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+	"github.com/MetalBlockchain/metalgo/vms/avm"
+	"github.com/MetalBlockchain/metalgo/vms/components/avax"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/status"
+	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
+	"github.com/MetalBlockchain/metalgo/wallet/subnet/primary"
+	"github.com/MetalBlockchain/metalgo/wallet/subnet/primary/common"
+)
+
+const timeout = 2 * time.Minute
+
+// TestNetworkPartition checks that the majority side of a partition keeps
+// accepting transactions on the P-chain and X-chain, and that the minority
+// learns about them once the partition heals.
+func TestNetworkPartition(t *testing.T) {
+	require := require.New(t)
+
+	n, err := simulator.New(simulator.Config{
+		NumNodes: 5,
+		Dir:      t.TempDir(),
+		Seed:     1,
+		Link: simulator.Link{
+			Latency: 10 * time.Millisecond,
+			Jitter:  5 * time.Millisecond,
+			Loss:    .01,
+		},
+		Flags: map[string]interface{}{
+			config.SnowSampleSizeKey:              5,
+			config.SnowQuorumSizeKey:              4,
+			config.SnowVirtuousCommitThresholdKey: 5,
+			config.SnowRogueCommitThresholdKey:    10,
+			config.SnowMixedQueryNumPushVdrKey:    2,
+			config.NetworkPingFrequencyKey:        "1s",
+			config.NetworkPingTimeoutKey:          "2s",
+		},
+	})
+	require.NoError(err)
+	require.NoError(n.Start())
+	defer n.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	require.NoError(n.AwaitHealthy(ctx))
+
+	// Isolate the last node from the rest of the network.
+	require.NoError(n.Partition([]int{0, 1, 2, 3}, []int{4}))
+
+	kc := secp256k1fx.NewKeychain(genesis.EWOQKey)
+	wallet, err := primary.NewWalletFromURI(ctx, n.Nodes[0].URI, kc)
+	require.NoError(err)
+
+	owner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs: []ids.ShortID{
+			genesis.EWOQKey.PublicKey().Address(),
+		},
+	}
+	xTxID, err := wallet.X().IssueBaseTx(
+		[]*avax.TransferableOutput{{
+			Asset: avax.Asset{
+				ID: wallet.X().AVAXAssetID(),
+			},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          units.Avax,
+				OutputOwners: *owner,
+			},
+		}},
+		common.WithContext(ctx),
+	)
+	require.NoError(err)
+
+	pTxID, err := wallet.P().IssueCreateSubnetTx(
+		owner,
+		common.WithContext(ctx),
+	)
+	require.NoError(err)
+
+	for _, node := range n.Nodes[:4] {
+		requireAccepted(ctx, t, node, xTxID, pTxID)
+	}
+
+	n.Heal()
+	requireAccepted(ctx, t, n.Nodes[4], xTxID, pTxID)
+}
+
+// requireAccepted waits until [node] has accepted [xTxID] on the X-chain and
+// [pTxID] on the P-chain.
+func requireAccepted(ctx context.Context, t *testing.T, node *simulator.Node, xTxID ids.ID, pTxID ids.ID) {
+	require := require.New(t)
+
+	xStatus, err := avm.NewClient(node.URI, "X").ConfirmTx(ctx, xTxID, 100*time.Millisecond)
+	require.NoError(err)
+	require.Equal(choices.Accepted, xStatus)
+
+	pStatus, err := platformvm.NewClient(node.URI).AwaitTxDecided(ctx, pTxID, 100*time.Millisecond)
+	require.NoError(err)
+	require.Equal(status.Committed, pStatus.Status)
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bloom
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/spaolacci/murmur3"
+
+	streakKnife "github.com/holiman/bloomfilter/v2"
+
+	"github.com/MetalBlockchain/metalgo/utils/math"
+)
+
+const (
+	MinHashes = 1
+	// MaxHashes bounds the work done to check an element against a filter
+	// that was parsed from an untrusted source.
+	MaxHashes = 16
+
+	headerLen = 1 + 4 // numHashes + seed
+)
+
+var (
+	_ Filter = (*PortableFilter)(nil)
+
+	errInvalidNumHashes = errors.New("invalid number of hashes")
+	errNoBits           = errors.New("filter has no bits")
+)
+
+// PortableFilter is a bloom filter that can be serialized and checked by
+// other nodes. Unlike the other filters, its hash functions are derived from
+// [seed], so that a filter parsed from its bytes contains the same elements.
+//
+// Using a different seed for every filter built over the same elements
+// changes which elements are false positives.
+type PortableFilter struct {
+	lock      sync.RWMutex
+	numHashes int
+	seed      uint32
+	bits      []byte
+}
+
+// OptimalParameters returns the number of hashes and bytes of a filter that
+// contains [maxN] elements with a false positive probability of [p], if its
+// size is at most [maxBytes].
+func OptimalParameters(maxN uint64, p float64, maxBytes uint64) (int, uint64) {
+	maxN = math.Max(maxN, 1)
+	numBits := math.Min(streakKnife.OptimalM(maxN, p), maxBytes*8)
+	numBits = math.Max(numBits, 8)
+	numHashes := streakKnife.OptimalK(numBits, maxN)
+	numHashes = math.Min(math.Max(numHashes, MinHashes), MaxHashes)
+	return int(numHashes), (numBits + 7) / 8
+}
+
+// NewPortable returns an empty filter of [numBytes] bytes that uses
+// [numHashes] hash functions derived from [seed].
+func NewPortable(numHashes int, numBytes uint64, seed uint32) (*PortableFilter, error) {
+	if numHashes < MinHashes || numHashes > MaxHashes {
+		return nil, fmt.Errorf("%w: %d", errInvalidNumHashes, numHashes)
+	}
+	if numBytes == 0 {
+		return nil, errNoBits
+	}
+	return &PortableFilter{
+		numHashes: numHashes,
+		seed:      seed,
+		bits:      make([]byte, numBytes),
+	}, nil
+}
+
+// ParsePortable parses a filter that was serialized with Bytes.
+func ParsePortable(b []byte) (*PortableFilter, error) {
+	if len(b) <= headerLen {
+		return nil, errNoBits
+	}
+	numHashes := int(b[0])
+	if numHashes < MinHashes || numHashes > MaxHashes {
+		return nil, fmt.Errorf("%w: %d", errInvalidNumHashes, numHashes)
+	}
+	return &PortableFilter{
+		numHashes: numHashes,
+		seed:      binary.BigEndian.Uint32(b[1:headerLen]),
+		bits:      b[headerLen:],
+	}, nil
+}
+
+func (f *PortableFilter) Add(bl ...[]byte) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for _, b := range bl {
+		h1, h2 := murmur3.Sum128WithSeed(b, f.seed)
+		for i := 0; i < f.numHashes; i++ {
+			index := f.index(h1, h2, i)
+			f.bits[index/8] |= 1 << (index % 8)
+		}
+	}
+}
+
+func (f *PortableFilter) Check(b []byte) bool {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	h1, h2 := murmur3.Sum128WithSeed(b, f.seed)
+	for i := 0; i < f.numHashes; i++ {
+		index := f.index(h1, h2, i)
+		if f.bits[index/8]&(1<<(index%8)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Bytes returns the serialized filter.
+func (f *PortableFilter) Bytes() []byte {
+	f.lock.RLock()
+	defer f.lock.RUnlock()
+
+	b := make([]byte, headerLen+len(f.bits))
+	b[0] = byte(f.numHashes)
+	binary.BigEndian.PutUint32(b[1:headerLen], f.seed)
+	copy(b[headerLen:], f.bits)
+	return b
+}
+
+// index returns the bit set by the [i]'th hash function, using double
+// hashing.
+func (f *PortableFilter) index(h1, h2 uint64, i int) uint64 {
+	return (h1 + uint64(i)*h2) % (uint64(len(f.bits)) * 8)
+}
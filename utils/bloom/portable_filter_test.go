@@ -0,0 +1,117 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bloom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+)
+
+func TestPortableFilter(t *testing.T) {
+	require := require.New(t)
+
+	numHashes, numBytes := OptimalParameters(1000, .01, units.KiB)
+	require.LessOrEqual(numBytes, uint64(units.KiB))
+
+	f, err := NewPortable(numHashes, numBytes, 1)
+	require.NoError(err)
+
+	added := make([]ids.ID, 100)
+	for i := range added {
+		added[i] = ids.GenerateTestID()
+		f.Add(added[i][:])
+	}
+
+	parsed, err := ParsePortable(f.Bytes())
+	require.NoError(err)
+	for _, id := range added {
+		require.True(f.Check(id[:]))
+		require.True(parsed.Check(id[:]))
+	}
+
+	// The filter was sized for 1000 elements, so 100 random elements should
+	// almost never all be false positives.
+	numFalsePositives := 0
+	for i := 0; i < 100; i++ {
+		id := ids.GenerateTestID()
+		if parsed.Check(id[:]) {
+			numFalsePositives++
+		}
+	}
+	require.Less(numFalsePositives, 100)
+}
+
+func TestPortableFilterSeed(t *testing.T) {
+	require := require.New(t)
+
+	f0, err := NewPortable(1, 1, 0)
+	require.NoError(err)
+	f1, err := NewPortable(1, 1, 1)
+	require.NoError(err)
+
+	// A different seed sets different bits for the same element
+	for i := 0; i < 100; i++ {
+		id := ids.GenerateTestID()
+		f0.Add(id[:])
+		f1.Add(id[:])
+		if f0.Bytes()[headerLen] != f1.Bytes()[headerLen] {
+			return
+		}
+	}
+	require.FailNow("seed didn't change the filter")
+}
+
+func TestOptimalParameters(t *testing.T) {
+	require := require.New(t)
+
+	// An empty set still results in a valid filter
+	numHashes, numBytes := OptimalParameters(0, .01, units.KiB)
+	_, err := NewPortable(numHashes, numBytes, 0)
+	require.NoError(err)
+
+	// The size is capped
+	numHashes, numBytes = OptimalParameters(1_000_000, .01, units.KiB)
+	require.Equal(uint64(units.KiB), numBytes)
+	require.GreaterOrEqual(numHashes, MinHashes)
+	require.LessOrEqual(numHashes, MaxHashes)
+}
+
+func TestParsePortableErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		bytes []byte
+		err   error
+	}{
+		{
+			name:  "empty",
+			bytes: nil,
+			err:   errNoBits,
+		},
+		{
+			name:  "no bits",
+			bytes: []byte{1, 0, 0, 0, 0},
+			err:   errNoBits,
+		},
+		{
+			name:  "no hashes",
+			bytes: []byte{0, 0, 0, 0, 0, 0},
+			err:   errInvalidNumHashes,
+		},
+		{
+			name:  "too many hashes",
+			bytes: []byte{MaxHashes + 1, 0, 0, 0, 0, 0},
+			err:   errInvalidNumHashes,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParsePortable(test.bytes)
+			require.ErrorIs(t, err, test.err)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/utils/units"
+)
+
+var benchmarkSizes = []int{
+	units.KiB,
+	16 * units.KiB,
+	256 * units.KiB,
+	2 * units.MiB,
+}
+
+func newBenchmarkCompressors(b *testing.B) map[Type]Compressor {
+	gzipCompressor, err := NewGzipCompressor(2 * units.MiB)
+	require.NoError(b, err)
+
+	zstdCompressor, err := NewZstdCompressor(2 * units.MiB)
+	require.NoError(b, err)
+
+	return map[Type]Compressor{
+		TypeGzip: gzipCompressor,
+		TypeZstd: zstdCompressor,
+	}
+}
+
+// newBenchmarkData returns [size] bytes where half of the bytes are random and
+// the other half are zero. This roughly approximates the compressibility of
+// serialized blocks, which contain both hashes and padded integers.
+func newBenchmarkData(size int) []byte {
+	data := make([]byte, size)
+	for i := 0; i < size; i += 2 {
+		data[i] = byte(rand.Intn(256)) // #nosec G404
+	}
+	return data
+}
+
+func BenchmarkCompress(b *testing.B) {
+	for compressionType, compressor := range newBenchmarkCompressors(b) {
+		for _, size := range benchmarkSizes {
+			data := newBenchmarkData(size)
+			b.Run(fmt.Sprintf("%s_%d", compressionType, size), func(b *testing.B) {
+				b.SetBytes(int64(size))
+				for n := 0; n < b.N; n++ {
+					_, err := compressor.Compress(data)
+					require.NoError(b, err)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkDecompress(b *testing.B) {
+	for compressionType, compressor := range newBenchmarkCompressors(b) {
+		for _, size := range benchmarkSizes {
+			compressed, err := compressor.Compress(newBenchmarkData(size))
+			require.NoError(b, err)
+
+			b.Run(fmt.Sprintf("%s_%d", compressionType, size), func(b *testing.B) {
+				b.SetBytes(int64(size))
+				for n := 0; n < b.N; n++ {
+					_, err := compressor.Decompress(compressed)
+					require.NoError(b, err)
+				}
+			})
+		}
+	}
+}
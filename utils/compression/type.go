@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var errUnknownCompressionType = errors.New("unknown compression type")
+
+// Type is the compression algorithm used to encode a message
+type Type byte
+
+const (
+	TypeNone Type = iota + 1
+	TypeGzip
+	TypeZstd
+)
+
+// TypeFromString returns the compression type that is described by [s]
+func TypeFromString(s string) (Type, error) {
+	switch s {
+	case TypeNone.String():
+		return TypeNone, nil
+	case TypeGzip.String():
+		return TypeGzip, nil
+	case TypeZstd.String():
+		return TypeZstd, nil
+	default:
+		return TypeNone, fmt.Errorf("%w: %q", errUnknownCompressionType, s)
+	}
+}
+
+func (t Type) String() string {
+	switch t {
+	case TypeNone:
+		return "none"
+	case TypeGzip:
+		return "gzip"
+	case TypeZstd:
+		return "zstd"
+	default:
+		return "unknown"
+	}
+}
+
+func (t Type) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("%q", t)), nil
+}
+
+func (t *Type) UnmarshalJSON(b []byte) error {
+	str := strings.Trim(string(b), `"`)
+	parsed, err := TypeFromString(str)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeString(t *testing.T) {
+	require := require.New(t)
+
+	for _, compressionType := range []Type{TypeNone, TypeGzip, TypeZstd} {
+		parsedType, err := TypeFromString(compressionType.String())
+		require.NoError(err)
+		require.Equal(compressionType, parsedType)
+	}
+
+	_, err := TypeFromString("unknown")
+	require.ErrorIs(err, errUnknownCompressionType)
+}
+
+func TestTypeJSON(t *testing.T) {
+	require := require.New(t)
+
+	for _, compressionType := range []Type{TypeNone, TypeGzip, TypeZstd} {
+		b, err := json.Marshal(compressionType)
+		require.NoError(err)
+		require.Equal(`"`+compressionType.String()+`"`, string(b))
+
+		var parsedType Type
+		require.NoError(json.Unmarshal(b, &parsedType))
+		require.Equal(compressionType, parsedType)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/DataDog/zstd"
+)
+
+var (
+	_ Compressor = (*zstdCompressor)(nil)
+
+	ErrInvalidMaxSizeZstdCompressor = errors.New("invalid zstd compressor max size")
+	errMissingZstdMagicNumber       = errors.New("missing zstd magic number")
+
+	// zstdMagicNumber is the little endian encoded prefix of every zstd frame.
+	// The streaming decoder doesn't report an error if the input ends before a
+	// frame header was read, so the prefix is verified explicitly.
+	zstdMagicNumber = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+type zstdCompressor struct {
+	maxSize int64
+}
+
+// Compress [msg] and returns the compressed bytes.
+func (z *zstdCompressor) Compress(msg []byte) ([]byte, error) {
+	if int64(len(msg)) > z.maxSize {
+		return nil, fmt.Errorf("msg length (%d) > maximum msg length (%d)", len(msg), z.maxSize)
+	}
+	return zstd.Compress(nil, msg)
+}
+
+// Decompress decompresses [msg].
+func (z *zstdCompressor) Decompress(msg []byte) ([]byte, error) {
+	if !bytes.HasPrefix(msg, zstdMagicNumber) {
+		return nil, errMissingZstdMagicNumber
+	}
+
+	reader := zstd.NewReader(bytes.NewReader(msg))
+	defer reader.Close()
+
+	// We allow [io.LimitReader] to read up to [z.maxSize + 1] bytes, so that if
+	// the decompressed payload is greater than the maximum size, this function
+	// will return the appropriate error instead of an incomplete byte slice.
+	limitedReader := io.LimitReader(reader, z.maxSize+1)
+
+	decompressed, err := io.ReadAll(limitedReader)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decompressed)) > z.maxSize {
+		return nil, fmt.Errorf("msg length > maximum msg length (%d)", z.maxSize)
+	}
+	return decompressed, nil
+}
+
+// NewZstdCompressor returns a new zstd Compressor that compresses messages up
+// to [maxSize] bytes
+func NewZstdCompressor(maxSize int64) (Compressor, error) {
+	if maxSize == math.MaxInt64 {
+		// See [NewGzipCompressor] for why this is disallowed.
+		return nil, ErrInvalidMaxSizeZstdCompressor
+	}
+
+	return &zstdCompressor{
+		maxSize: maxSize,
+	}, nil
+}
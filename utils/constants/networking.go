@@ -7,6 +7,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/units"
 )
 
@@ -41,6 +42,11 @@ const (
 	DefaultInboundConnUpgradeThrottlerCooldown = 10 * time.Second
 	DefaultInboundThrottlerMaxConnsPerSec      = 256
 
+	// Peer Diversity
+	DefaultPeerDiversityMaxPeersPerIPv4Prefix = 16
+	DefaultPeerDiversityMaxPeersPerIPv6Prefix = 16
+	DefaultPeerDiversityMaxPeersPerASN        = 64
+
 	// Outbound Connection Throttling
 	DefaultOutboundConnectionThrottlingRps = 50
 	DefaultOutboundConnectionTimeout       = 30 * time.Second
@@ -55,11 +61,13 @@ const (
 	DefaultNetworkReadHandshakeTimeout  = 15 * time.Second
 
 	DefaultNetworkCompressionEnabled        = true
+	DefaultNetworkCompressionType           = compression.TypeGzip
 	DefaultNetworkMaxClockDifference        = time.Minute
 	DefaultNetworkAllowPrivateIPs           = true
 	DefaultNetworkRequireValidatorToConnect = false
 	DefaultNetworkPeerReadBufferSize        = 8 * units.KiB
 	DefaultNetworkPeerWriteBufferSize       = 8 * units.KiB
+	DefaultNetworkPeerTrafficMetricsEnabled = false
 
 	DefaultNetworkTCPProxyEnabled = false
 
@@ -102,6 +110,11 @@ const (
 	DefaultOutboundThrottlerAtLargeAllocSize    = 32 * units.MiB
 	DefaultOutboundThrottlerVdrAllocSize        = 32 * units.MiB
 	DefaultOutboundThrottlerNodeMaxAtLargeBytes = DefaultMaxMessageSize
+	// The outbound bandwidth isn't limited by default
+	DefaultOutboundThrottlerBandwidthRefillRate       = 0
+	DefaultOutboundThrottlerBandwidthMaxBurstSize     = DefaultMaxMessageSize
+	DefaultOutboundThrottlerNodeBandwidthRefillRate   = 0
+	DefaultOutboundThrottlerNodeBandwidthMaxBurstSize = DefaultMaxMessageSize
 
 	// Network Health
 	DefaultHealthCheckAveragerHalflife = 10 * time.Second
@@ -17,6 +17,8 @@ var _ Resolver = (*ifConfigResolver)(nil)
 // ifConfigResolver resolves our public IP using ifconfig's format.
 type ifConfigResolver struct {
 	url string
+	// If nil, http.DefaultClient is used.
+	client *http.Client
 }
 
 func (r *ifConfigResolver) Resolve(ctx context.Context) (net.IP, error) {
@@ -25,7 +27,11 @@ func (r *ifConfigResolver) Resolve(ctx context.Context) (net.IP, error) {
 		return nil, err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	client := r.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
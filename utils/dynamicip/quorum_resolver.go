@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dynamicip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+var (
+	_ Resolver = (*quorumResolver)(nil)
+
+	errNoQuorum = errors.New("resolvers didn't agree on a public IP")
+)
+
+// quorumResolver queries several resolvers concurrently and only returns an IP
+// that is reported by a majority of them. A resolver that fails counts against
+// every IP, so a single faulty resolver can't change our public IP.
+type quorumResolver struct {
+	resolvers []Resolver
+}
+
+// NewQuorumResolver returns a Resolver that returns the IP that a majority of
+// [resolvers] agree on.
+func NewQuorumResolver(resolvers ...Resolver) Resolver {
+	return &quorumResolver{
+		resolvers: resolvers,
+	}
+}
+
+func (r *quorumResolver) Resolve(ctx context.Context) (net.IP, error) {
+	type result struct {
+		ip  net.IP
+		err error
+	}
+	results := make(chan result, len(r.resolvers))
+	for _, resolver := range r.resolvers {
+		go func(resolver Resolver) {
+			ip, err := resolver.Resolve(ctx)
+			results <- result{
+				ip:  ip,
+				err: err,
+			}
+		}(resolver)
+	}
+
+	var (
+		votes = make(map[string]int)
+		errs  []error
+	)
+	for range r.resolvers {
+		result := <-results
+		if result.err != nil {
+			errs = append(errs, result.err)
+			continue
+		}
+
+		// IPv4 addresses may be returned in either their 4 or 16 byte form
+		key := string(result.ip.To16())
+		votes[key]++
+		if votes[key] > len(r.resolvers)/2 {
+			return result.ip, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %d of %d resolvers succeeded, errors: %v",
+		errNoQuorum,
+		len(r.resolvers)-len(errs),
+		len(r.resolvers),
+		errs,
+	)
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dynamicip
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("non-nil error")
+
+func newStaticResolver(ip net.IP, err error) Resolver {
+	return &mockResolver{
+		onResolve: func(context.Context) (net.IP, error) {
+			return ip, err
+		},
+	}
+}
+
+func TestQuorumResolver(t *testing.T) {
+	var (
+		ip      = net.IPv4(1, 2, 3, 4)
+		wrongIP = net.IPv4(5, 6, 7, 8)
+	)
+	tests := []struct {
+		name        string
+		resolvers   []Resolver
+		expectedIP  net.IP
+		expectedErr error
+	}{
+		{
+			name: "unanimous",
+			resolvers: []Resolver{
+				newStaticResolver(ip, nil),
+				newStaticResolver(ip, nil),
+				newStaticResolver(ip, nil),
+			},
+			expectedIP: ip,
+		},
+		{
+			name: "majority",
+			resolvers: []Resolver{
+				newStaticResolver(ip, nil),
+				newStaticResolver(wrongIP, nil),
+				newStaticResolver(ip.To4(), nil),
+			},
+			expectedIP: ip,
+		},
+		{
+			name: "majority despite failure",
+			resolvers: []Resolver{
+				newStaticResolver(ip, nil),
+				newStaticResolver(nil, errTest),
+				newStaticResolver(ip, nil),
+			},
+			expectedIP: ip,
+		},
+		{
+			name: "split",
+			resolvers: []Resolver{
+				newStaticResolver(ip, nil),
+				newStaticResolver(wrongIP, nil),
+			},
+			expectedErr: errNoQuorum,
+		},
+		{
+			name: "failures count against quorum",
+			resolvers: []Resolver{
+				newStaticResolver(wrongIP, nil),
+				newStaticResolver(nil, errTest),
+				newStaticResolver(nil, errTest),
+			},
+			expectedErr: errNoQuorum,
+		},
+		{
+			name:        "no resolvers",
+			expectedErr: errNoQuorum,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			resolver := NewQuorumResolver(test.resolvers...)
+			resolvedIP, err := resolver.Resolve(context.Background())
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				require.True(test.expectedIP.Equal(resolvedIP))
+			}
+		})
+	}
+}
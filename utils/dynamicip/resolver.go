@@ -5,9 +5,13 @@ package dynamicip
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"strings"
+
+	"golang.org/x/net/proxy"
 )
 
 const (
@@ -21,8 +25,17 @@ const (
 	IFConfigName   = "ifconfig"
 	IFConfigCoName = "ifconfigco"
 	IFConfigMeName = "ifconfigme"
+	STUNName       = "stun"
+
+	// stunPrefix is followed by the host:port of a STUN server to use
+	stunPrefix = STUNName + ":"
+	// resolverSeparator separates the names of resolvers that must agree on
+	// our public IP
+	resolverSeparator = ","
 )
 
+var errNotProxiable = errors.New("resolver can't be used through a proxy")
+
 // Resolver resolves our public IP
 type Resolver interface {
 	// Resolve and return our public IP.
@@ -32,16 +45,70 @@ type Resolver interface {
 // Returns a new Resolver that uses the given service
 // to resolve our public IP.
 // [resolverName] must be one of:
-// [OpenDNSName], [IFConfigName], [IFConfigCoName], [IFConfigMeName].
+// [OpenDNSName], [IFConfigName], [IFConfigCoName], [IFConfigMeName],
+// [STUNName] or "stun:<host:port>" to use a specific STUN server.
+// If [resolverName] is a comma separated list of the above, the returned
+// Resolver only resolves an IP that a majority of them agree on.
 // If [resolverService] isn't one of the above, returns an error
 func NewResolver(resolverName string) (Resolver, error) {
-	switch strings.ToLower(resolverName) {
+	return newResolver(resolverName, nil)
+}
+
+// NewProxiedResolver is like NewResolver, but the returned Resolver connects
+// to the services through [dialer]. Only [IFConfigName], [IFConfigCoName] and
+// [IFConfigMeName] can be used, because the other services are reached over
+// UDP.
+func NewProxiedResolver(resolverName string, dialer proxy.ContextDialer) (Resolver, error) {
+	return newResolver(resolverName, &http.Client{
+		Transport: &http.Transport{
+			DialContext: dialer.DialContext,
+		},
+	})
+}
+
+// newResolver returns the Resolver named [resolverName]. If [client] is
+// non-nil, it is used to make all requests and resolvers that don't use HTTP
+// are rejected.
+func newResolver(resolverName string, client *http.Client) (Resolver, error) {
+	resolverName = strings.ToLower(strings.TrimSpace(resolverName))
+	if strings.Contains(resolverName, resolverSeparator) {
+		names := strings.Split(resolverName, resolverSeparator)
+		resolvers := make([]Resolver, len(names))
+		for i, name := range names {
+			resolver, err := newResolver(name, client)
+			if err != nil {
+				return nil, err
+			}
+			resolvers[i] = resolver
+		}
+		return NewQuorumResolver(resolvers...), nil
+	}
+
+	if server := strings.TrimPrefix(resolverName, stunPrefix); server != resolverName {
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			return nil, fmt.Errorf("invalid STUN server %q: %w", server, err)
+		}
+		if client != nil {
+			return nil, fmt.Errorf("%w: %s", errNotProxiable, resolverName)
+		}
+		return &stunResolver{server: server}, nil
+	}
+
+	switch resolverName {
 	case OpenDNSName:
+		if client != nil {
+			return nil, fmt.Errorf("%w: %s", errNotProxiable, resolverName)
+		}
 		return newOpenDNSResolver(), nil
 	case IFConfigName, IFConfigCoName:
-		return &ifConfigResolver{url: ifConfigCoURL}, nil
+		return &ifConfigResolver{url: ifConfigCoURL, client: client}, nil
 	case IFConfigMeName:
-		return &ifConfigResolver{url: ifConfigMeURL}, nil
+		return &ifConfigResolver{url: ifConfigMeURL, client: client}, nil
+	case STUNName:
+		if client != nil {
+			return nil, fmt.Errorf("%w: %s", errNotProxiable, resolverName)
+		}
+		return &stunResolver{server: stunGoogleServer}, nil
 	default:
 		return nil, fmt.Errorf("got unknown resolver: %s", resolverName)
 	}
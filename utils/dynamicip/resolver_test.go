@@ -4,7 +4,12 @@
 package dynamicip
 
 import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -36,6 +41,26 @@ func TestNewResolver(t *testing.T) {
 			service:      strings.ToUpper(IFConfigMeName),
 			validService: true,
 		},
+		{
+			service:      STUNName,
+			validService: true,
+		},
+		{
+			service:      "stun:stun.example.com:3478",
+			validService: true,
+		},
+		{
+			service:      "stun:stun.example.com",
+			validService: false,
+		},
+		{
+			service:      "opendns,ifconfigco,stun",
+			validService: true,
+		},
+		{
+			service:      "opendns,not a valid resolution service name",
+			validService: false,
+		},
 		{
 			service:      "not a valid resolution service name",
 			validService: false,
@@ -53,3 +78,78 @@ func TestNewResolver(t *testing.T) {
 		})
 	}
 }
+
+// redirectDialer connects to [target] regardless of the requested address.
+type redirectDialer struct {
+	target string
+
+	lock      sync.Mutex
+	addresses []string
+}
+
+func (d *redirectDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.lock.Lock()
+	d.addresses = append(d.addresses, address)
+	d.lock.Unlock()
+
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, d.target)
+}
+
+func TestNewProxiedResolver(t *testing.T) {
+	tests := []struct {
+		service     string
+		expectedErr error
+	}{
+		{
+			service: IFConfigCoName,
+		},
+		{
+			service: "ifconfigco,ifconfigme",
+		},
+		{
+			service:     OpenDNSName,
+			expectedErr: errNotProxiable,
+		},
+		{
+			service:     STUNName,
+			expectedErr: errNotProxiable,
+		},
+		{
+			service:     "stun:stun.example.com:3478",
+			expectedErr: errNotProxiable,
+		},
+		{
+			service:     "ifconfigco,opendns",
+			expectedErr: errNotProxiable,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.service, func(t *testing.T) {
+			_, err := NewProxiedResolver(test.service, &redirectDialer{})
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestProxiedResolverResolve(t *testing.T) {
+	require := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("1.2.3.4\n"))
+	}))
+	defer server.Close()
+
+	dialer := &redirectDialer{
+		target: server.Listener.Addr().String(),
+	}
+	resolver, err := NewProxiedResolver(IFConfigMeName, dialer)
+	require.NoError(err)
+
+	ip, err := resolver.Resolve(context.Background())
+	require.NoError(err)
+	require.Equal(net.IPv4(1, 2, 3, 4).To16(), ip.To16())
+
+	// The service's address is passed to the dialer unresolved.
+	require.Equal([]string{"ifconfig.me:80"}, dialer.addresses)
+}
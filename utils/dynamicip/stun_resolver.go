@@ -0,0 +1,182 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dynamicip
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// See: https://www.rfc-editor.org/rfc/rfc5389
+const (
+	stunGoogleServer = "stun.l.google.com:19302"
+
+	stunHeaderLen          = 20
+	stunTransactionIDLen   = 12
+	stunMagicCookie        = 0x2112A442
+	stunBindingRequest     = 0x0001
+	stunBindingSuccess     = 0x0101
+	stunMappedAddress      = 0x0001
+	stunXORMappedAddress   = 0x0020
+	stunFamilyIPv4         = 0x01
+	stunFamilyIPv6         = 0x02
+	stunMaxMessageLen      = 1500
+	stunRetransmitInterval = 500 * time.Millisecond
+)
+
+var (
+	_ Resolver = (*stunResolver)(nil)
+
+	errSTUNNoAddress      = errors.New("STUN response contained no mapped address")
+	errSTUNInvalidAddress = errors.New("invalid STUN mapped address")
+)
+
+// stunResolver resolves our public IP by sending a STUN binding request to
+// [server], which responds with the address that it received the request from.
+type stunResolver struct {
+	server string
+}
+
+func (r *stunResolver) Resolve(ctx context.Context) (net.IP, error) {
+	d := net.Dialer{}
+	conn, err := d.DialContext(ctx, "udp", r.server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	request := make([]byte, stunHeaderLen)
+	binary.BigEndian.PutUint16(request[0:2], stunBindingRequest)
+	binary.BigEndian.PutUint32(request[4:8], stunMagicCookie)
+	transactionID := request[8:stunHeaderLen]
+	if _, err := rand.Read(transactionID); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, stunMaxMessageLen)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if _, err := conn.Write(request); err != nil {
+			return nil, err
+		}
+
+		// UDP may drop the request or the response, so the request is
+		// retransmitted until the context is done.
+		deadline := time.Now().Add(stunRetransmitInterval)
+		if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+			deadline = ctxDeadline
+		}
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			return nil, err
+		}
+
+		for {
+			n, err := conn.Read(buf)
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			response := buf[:n]
+			if len(response) < stunHeaderLen ||
+				binary.BigEndian.Uint16(response[0:2]) != stunBindingSuccess ||
+				binary.BigEndian.Uint32(response[4:8]) != stunMagicCookie ||
+				!bytes.Equal(response[8:stunHeaderLen], transactionID) {
+				// Ignore unexpected responses, such as responses to a
+				// previous request.
+				continue
+			}
+			return parseSTUNAddress(response)
+		}
+	}
+}
+
+// parseSTUNAddress returns the address in the binding success [response].
+func parseSTUNAddress(response []byte) (net.IP, error) {
+	length := int(binary.BigEndian.Uint16(response[2:4]))
+	if stunHeaderLen+length > len(response) {
+		return nil, fmt.Errorf("%w: length %d exceeds message size %d",
+			errSTUNInvalidAddress,
+			length,
+			len(response),
+		)
+	}
+
+	var mappedIP net.IP
+	attributes := response[stunHeaderLen : stunHeaderLen+length]
+	for len(attributes) >= 4 {
+		attrType := binary.BigEndian.Uint16(attributes[0:2])
+		attrLen := int(binary.BigEndian.Uint16(attributes[2:4]))
+		if 4+attrLen > len(attributes) {
+			return nil, fmt.Errorf("%w: attribute length %d exceeds message", errSTUNInvalidAddress, attrLen)
+		}
+		value := attributes[4 : 4+attrLen]
+
+		switch attrType {
+		case stunXORMappedAddress:
+			// The XOR-MAPPED-ADDRESS is preferred because NATs that rewrite
+			// addresses in payloads won't modify it.
+			return parseSTUNAddressValue(value, response[4:stunHeaderLen])
+		case stunMappedAddress:
+			ip, err := parseSTUNAddressValue(value, nil)
+			if err != nil {
+				return nil, err
+			}
+			mappedIP = ip
+		}
+
+		// Attributes are padded to a multiple of 4 bytes
+		padded := (attrLen + 3) &^ 3
+		if 4+padded > len(attributes) {
+			break
+		}
+		attributes = attributes[4+padded:]
+	}
+	if mappedIP == nil {
+		return nil, errSTUNNoAddress
+	}
+	return mappedIP, nil
+}
+
+// parseSTUNAddressValue parses a (XOR-)MAPPED-ADDRESS value. If [xorKey] is
+// non-nil, the address is XOR'd with it.
+func parseSTUNAddressValue(value []byte, xorKey []byte) (net.IP, error) {
+	if len(value) < 4 {
+		return nil, fmt.Errorf("%w: length %d", errSTUNInvalidAddress, len(value))
+	}
+
+	var ipLen int
+	switch family := value[1]; family {
+	case stunFamilyIPv4:
+		ipLen = net.IPv4len
+	case stunFamilyIPv6:
+		ipLen = net.IPv6len
+	default:
+		return nil, fmt.Errorf("%w: unknown family %d", errSTUNInvalidAddress, family)
+	}
+	if len(value) < 4+ipLen {
+		return nil, fmt.Errorf("%w: length %d", errSTUNInvalidAddress, len(value))
+	}
+
+	ip := make(net.IP, ipLen)
+	copy(ip, value[4:4+ipLen])
+	for i := range xorKey {
+		if i >= ipLen {
+			break
+		}
+		ip[i] ^= xorKey[i]
+	}
+	return ip, nil
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dynamicip
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// stunServer responds to binding requests with the address of the sender.
+type stunServer struct {
+	// useXOR responds with a XOR-MAPPED-ADDRESS rather than a MAPPED-ADDRESS
+	useXOR bool
+	// dropRequests is the number of requests to ignore
+	dropRequests int
+	// sendStale sends a response with the wrong transaction ID first
+	sendStale bool
+}
+
+func (s stunServer) start(t *testing.T, address string) string {
+	addr, err := net.ResolveUDPAddr("udp", address)
+	require.NoError(t, err)
+	conn, err := net.ListenUDP("udp", addr)
+	if err != nil {
+		t.Skipf("failed to listen on %s: %s", address, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		buf := make([]byte, stunMaxMessageLen)
+		for {
+			n, from, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			if n < stunHeaderLen {
+				continue
+			}
+			if s.dropRequests > 0 {
+				s.dropRequests--
+				continue
+			}
+
+			request := buf[:n]
+			if s.sendStale {
+				stale := s.response(request, from)
+				stale[8] ^= 0xff
+				_, _ = conn.WriteToUDP(stale, from)
+			}
+			_, _ = conn.WriteToUDP(s.response(request, from), from)
+		}
+	}()
+	t.Cleanup(func() {
+		_ = conn.Close()
+		<-done
+	})
+	return conn.LocalAddr().String()
+}
+
+func (s stunServer) response(request []byte, from *net.UDPAddr) []byte {
+	ip := from.IP
+	family := byte(stunFamilyIPv6)
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		family = stunFamilyIPv4
+	}
+
+	attrType := uint16(stunMappedAddress)
+	value := make([]byte, 4+len(ip))
+	value[1] = family
+	binary.BigEndian.PutUint16(value[2:4], uint16(from.Port))
+	copy(value[4:], ip)
+	if s.useXOR {
+		attrType = stunXORMappedAddress
+		binary.BigEndian.PutUint16(value[2:4], uint16(from.Port)^(stunMagicCookie>>16))
+		for i := range ip {
+			value[4+i] ^= request[4+i]
+		}
+	}
+
+	response := make([]byte, stunHeaderLen+4+len(value))
+	binary.BigEndian.PutUint16(response[0:2], stunBindingSuccess)
+	binary.BigEndian.PutUint16(response[2:4], uint16(4+len(value)))
+	copy(response[4:stunHeaderLen], request[4:stunHeaderLen])
+	binary.BigEndian.PutUint16(response[stunHeaderLen:stunHeaderLen+2], attrType)
+	binary.BigEndian.PutUint16(response[stunHeaderLen+2:stunHeaderLen+4], uint16(len(value)))
+	copy(response[stunHeaderLen+4:], value)
+	return response
+}
+
+func TestSTUNResolver(t *testing.T) {
+	tests := []struct {
+		name       string
+		address    string
+		server     stunServer
+		expectedIP net.IP
+	}{
+		{
+			name:       "xor mapped ipv4",
+			address:    "127.0.0.1:0",
+			server:     stunServer{useXOR: true},
+			expectedIP: net.IPv4(127, 0, 0, 1),
+		},
+		{
+			name:       "xor mapped ipv6",
+			address:    "[::1]:0",
+			server:     stunServer{useXOR: true},
+			expectedIP: net.IPv6loopback,
+		},
+		{
+			name:       "mapped ipv4",
+			address:    "127.0.0.1:0",
+			server:     stunServer{},
+			expectedIP: net.IPv4(127, 0, 0, 1),
+		},
+		{
+			name:    "retransmit and ignore stale response",
+			address: "127.0.0.1:0",
+			server: stunServer{
+				useXOR:       true,
+				dropRequests: 1,
+				sendStale:    true,
+			},
+			expectedIP: net.IPv4(127, 0, 0, 1),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			resolver := &stunResolver{server: test.server.start(t, test.address)}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			ip, err := resolver.Resolve(ctx)
+			require.NoError(err)
+			require.True(test.expectedIP.Equal(ip))
+		})
+	}
+}
+
+func TestSTUNResolverTimeout(t *testing.T) {
+	require := require.New(t)
+
+	server := stunServer{dropRequests: 1_000}
+	resolver := &stunResolver{server: server.start(t, "127.0.0.1:0")}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := resolver.Resolve(ctx)
+	require.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestParseSTUNAddress(t *testing.T) {
+	tests := []struct {
+		name        string
+		attributes  []byte
+		expectedErr error
+	}{
+		{
+			name:        "no attributes",
+			expectedErr: errSTUNNoAddress,
+		},
+		{
+			name:        "attribute exceeds message",
+			attributes:  []byte{0x00, 0x20, 0x00, 0x08, 0x00, 0x01},
+			expectedErr: errSTUNInvalidAddress,
+		},
+		{
+			name:        "unknown family",
+			attributes:  []byte{0x00, 0x01, 0x00, 0x08, 0x00, 0x03, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04},
+			expectedErr: errSTUNInvalidAddress,
+		},
+		{
+			name:        "truncated address",
+			attributes:  []byte{0x00, 0x01, 0x00, 0x04, 0x00, 0x02, 0x00, 0x00},
+			expectedErr: errSTUNInvalidAddress,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			response := make([]byte, stunHeaderLen, stunHeaderLen+len(test.attributes))
+			binary.BigEndian.PutUint16(response[2:4], uint16(len(test.attributes)))
+			response = append(response, test.attributes...)
+
+			_, err := parseSTUNAddress(response)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -78,6 +78,8 @@ type AdaptiveTimeoutConfig struct {
 	// Larger halflife --> less volatile timeout
 	// [timeoutHalfLife] must be positive
 	TimeoutHalflife time.Duration `json:"timeoutHalflife"`
+	// Clock that timeouts elapse according to
+	Clock mockable.Clock `json:"-"`
 }
 
 type AdaptiveTimeoutManager interface {
@@ -159,13 +161,14 @@ func NewAdaptiveTimeoutManager(
 			Name:      "pending_timeouts",
 			Help:      "Number of pending timeouts",
 		}),
+		clock:              config.Clock,
 		minimumTimeout:     config.MinimumTimeout,
 		maximumTimeout:     config.MaximumTimeout,
 		currentTimeout:     config.InitialTimeout,
 		timeoutCoefficient: config.TimeoutCoefficient,
 		timeoutMap:         make(map[ids.RequestID]*adaptiveTimeout),
 	}
-	tm.timer = NewTimer(tm.timeout)
+	tm.timer = NewTimerWithClock(tm.clock, tm.timeout)
 	tm.averager = math.NewAverager(float64(config.InitialTimeout), config.TimeoutHalflife, tm.clock.Time())
 
 	errs := &wrappers.Errs{}
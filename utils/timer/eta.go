@@ -17,3 +17,12 @@ func EstimateETA(startTime time.Time, progress, end uint64) time.Duration {
 	eta := estimatedTotalDuration - timeSpent
 	return eta.Round(time.Second)
 }
+
+// EstimateRate returns the average progress made per second since [startTime].
+func EstimateRate(startTime time.Time, progress uint64) float64 {
+	timeSpent := time.Since(startTime).Seconds()
+	if timeSpent <= 0 {
+		return 0
+	}
+	return float64(progress) / timeSpent
+}
@@ -14,6 +14,8 @@ var MaxTime = time.Unix(1<<63-62135596801, 0) // 0 is used because we drop the n
 type Clock struct {
 	faked bool
 	time  time.Time
+	// If nil, the wall clock is used.
+	source Source
 }
 
 // Set the time on the clock
@@ -22,12 +24,44 @@ func (c *Clock) Set(time time.Time) { c.faked = true; c.time = time }
 // Sync this clock with global time
 func (c *Clock) Sync() { c.faked = false }
 
+// SetSource drives this clock, and the timers created from it, by [source]
+// rather than by the wall clock. If [source] is nil, the wall clock is used.
+//
+// The time set by Set still takes precedence over [source].
+func (c *Clock) SetSource(source Source) { c.source = source }
+
+// Source returns the source that drives this clock, or nil if it is driven by
+// the wall clock.
+func (c *Clock) Source() Source { return c.source }
+
 // Time returns the time on this clock
 func (c *Clock) Time() time.Time {
-	if c.faked {
+	switch {
+	case c.faked:
 		return c.time
+	case c.source != nil:
+		return c.source.Now()
+	default:
+		return time.Now()
+	}
+}
+
+// NewTimer returns a timer that fires once [d] has elapsed on this clock's
+// source.
+func (c *Clock) NewTimer(d time.Duration) Timer {
+	if c.source != nil {
+		return c.source.NewTimer(d)
+	}
+	return wallTimer{timer: time.NewTimer(d)}
+}
+
+// NewTicker returns a ticker that fires every time [d] elapses on this clock's
+// source.
+func (c *Clock) NewTicker(d time.Duration) Ticker {
+	if c.source != nil {
+		return c.source.NewTicker(d)
 	}
-	return time.Now()
+	return wallTicker{ticker: time.NewTicker(d)}
 }
 
 // Time returns the unix time on this clock
@@ -22,7 +22,7 @@ func TestClockSet(t *testing.T) {
 }
 
 func TestClockSync(t *testing.T) {
-	clock := Clock{true, time.Unix(0, 0)}
+	clock := Clock{faked: true, time: time.Unix(0, 0)}
 	clock.Sync()
 	if clock.faked == true {
 		t.Error("Clock was synced, but .faked flag was set")
@@ -33,16 +33,61 @@ func TestClockSync(t *testing.T) {
 }
 
 func TestClockUnixTime(t *testing.T) {
-	clock := Clock{true, time.Unix(123, 123)}
+	clock := Clock{faked: true, time: time.Unix(123, 123)}
 	require.Zero(t, clock.UnixTime().Nanosecond())
 	require.Equal(t, 123, clock.Time().Nanosecond())
 }
 
 func TestClockUnix(t *testing.T) {
-	clock := Clock{true, time.Unix(-14159040, 0)}
+	clock := Clock{faked: true, time: time.Unix(-14159040, 0)}
 	actual := clock.Unix()
 	if actual != 0 {
 		// We are Unix of 1970s, Moon landings are irrelevant
 		t.Errorf("Expected time prior to Unix epoch to be clamped to 0, got %d", actual)
 	}
 }
+
+type testSource struct {
+	now       time.Time
+	durations []time.Duration
+}
+
+func (s *testSource) Now() time.Time {
+	return s.now
+}
+
+func (s *testSource) NewTimer(d time.Duration) Timer {
+	s.durations = append(s.durations, d)
+	return wallTimer{timer: time.NewTimer(d)}
+}
+
+func (s *testSource) NewTicker(d time.Duration) Ticker {
+	s.durations = append(s.durations, d)
+	return wallTicker{ticker: time.NewTicker(d)}
+}
+
+func TestClockSource(t *testing.T) {
+	require := require.New(t)
+
+	source := &testSource{now: time.Unix(123, 0)}
+	clock := Clock{}
+	clock.SetSource(source)
+	require.Equal(source, clock.Source())
+	require.Equal(source.now, clock.Time())
+
+	// Copies of the clock share its source
+	clockCopy := clock
+	source.now = time.Unix(456, 0)
+	require.Equal(source.now, clockCopy.Time())
+
+	clock.NewTimer(time.Hour).Stop()
+	clock.NewTicker(time.Minute).Stop()
+	require.Equal([]time.Duration{time.Hour, time.Minute}, source.durations)
+
+	// A faked time takes precedence over the source
+	clock.Set(time.Unix(789, 0))
+	require.Equal(time.Unix(789, 0), clock.Time())
+
+	clock.Sync()
+	require.Equal(source.now, clock.Time())
+}
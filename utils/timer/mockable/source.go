@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package mockable
+
+import "time"
+
+var (
+	_ Timer  = wallTimer{}
+	_ Ticker = wallTicker{}
+)
+
+// Source is a source of time, and of timers that fire according to that time.
+// It allows a Clock to be driven by something other than the wall clock, such
+// as a simulated clock that is shared by several nodes.
+type Source interface {
+	Now() time.Time
+	// NewTimer returns a timer that fires once [d] has elapsed.
+	NewTimer(d time.Duration) Timer
+	// NewTicker returns a ticker that fires every time [d] elapses. [d] must
+	// be positive.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer behaves like a time.Timer.
+type Timer interface {
+	// C returns the channel that receives the time when the timer fires.
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// Ticker behaves like a time.Ticker.
+type Ticker interface {
+	// C returns the channel that receives the time when the ticker fires.
+	C() <-chan time.Time
+	Stop()
+}
+
+type wallTimer struct {
+	timer *time.Timer
+}
+
+func (t wallTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t wallTimer) Stop() bool {
+	return t.timer.Stop()
+}
+
+func (t wallTimer) Reset(d time.Duration) bool {
+	return t.timer.Reset(d)
+}
+
+type wallTicker struct {
+	ticker *time.Ticker
+}
+
+func (t wallTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t wallTicker) Stop() {
+	t.ticker.Stop()
+}
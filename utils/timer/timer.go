@@ -6,6 +6,8 @@ package timer
 import (
 	"sync"
 	"time"
+
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 )
 
 // Timer wraps a timer object. This allows a user to specify a handler. Once
@@ -13,6 +15,7 @@ import (
 // will only return after calling Stop. SetTimeoutIn will result in calling the
 // handler in the specified amount of time.
 type Timer struct {
+	clock   mockable.Clock
 	handler func()
 	timeout chan struct{}
 
@@ -24,7 +27,14 @@ type Timer struct {
 
 // NewTimer creates a new timer object
 func NewTimer(handler func()) *Timer {
+	return NewTimerWithClock(mockable.Clock{}, handler)
+}
+
+// NewTimerWithClock creates a new timer object whose timeouts elapse according
+// to [clock]
+func NewTimerWithClock(clock mockable.Clock, handler func()) *Timer {
 	timer := &Timer{
+		clock:   clock,
 		handler: handler,
 		timeout: make(chan struct{}, 1),
 	}
@@ -69,12 +79,12 @@ func (t *Timer) Dispatch() {
 	defer t.lock.Unlock()
 	defer t.wg.Done()
 
-	timer := time.NewTimer(0)
+	timer := t.clock.NewTimer(0)
 	cleared := false
 	reset := false
 	for !t.finished { // t.finished needs to be thread safe
 		if !reset && !timer.Stop() && !cleared {
-			<-timer.C
+			<-timer.C()
 		}
 
 		if cleared && t.shouldExecute {
@@ -93,7 +103,7 @@ func (t *Timer) Dispatch() {
 				timer.Reset(t.duration)
 			}
 			reset = true
-		case <-timer.C:
+		case <-timer.C():
 			t.lock.Lock()
 			cleared = true
 		}
@@ -77,7 +77,12 @@ func (s *dagState) GetTx(txID ids.ID) (*txs.Tx, error) {
 		vm:   s.vm,
 		txID: txID,
 	}
-	if status := tx.Status(); !status.Fetched() {
+
+	s.vm.uniqueTxsLock.Lock()
+	defer s.vm.uniqueTxsLock.Unlock()
+
+	tx.refresh()
+	if !tx.status.Fetched() {
 		return nil, database.ErrNotFound
 	}
 	return tx.Tx, nil
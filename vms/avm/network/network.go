@@ -6,6 +6,9 @@ package network
 import (
 	"context"
 	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 
 	"go.uber.org/zap"
 
@@ -16,6 +19,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/vms/avm/blocks/executor"
 	"github.com/MetalBlockchain/metalgo/vms/avm/txs"
 	"github.com/MetalBlockchain/metalgo/vms/avm/txs/mempool"
+	"github.com/MetalBlockchain/metalgo/vms/components/gossip"
 	"github.com/MetalBlockchain/metalgo/vms/components/message"
 )
 
@@ -23,7 +27,10 @@ import (
 // in the cache, not entire transactions.
 const recentTxsCacheSize = 512
 
-var _ Network = (*network)(nil)
+var (
+	_ Network        = (*network)(nil)
+	_ gossip.Mempool = (*gossipMempool)(nil)
+)
 
 type Network interface {
 	common.AppHandler
@@ -33,6 +40,10 @@ type Network interface {
 	//
 	// Invariant: Assumes the context lock is held.
 	IssueTx(context.Context, *txs.Tx) error
+
+	// Gossip periodically pulls the txs that are missing from the mempool
+	// from peers until [ctx] is cancelled.
+	Gossip(ctx context.Context)
 }
 
 type network struct {
@@ -48,6 +59,8 @@ type network struct {
 	// gossip related attributes
 	recentTxsLock sync.Mutex
 	recentTxs     *cache.LRU[ids.ID, struct{}]
+	router        *gossip.Router
+	gossiper      *gossip.Gossiper
 }
 
 func New(
@@ -56,8 +69,10 @@ func New(
 	manager executor.Manager,
 	mempool mempool.Mempool,
 	appSender common.AppSender,
-) Network {
-	return &network{
+	peers *gossip.Peers,
+	registerer prometheus.Registerer,
+) (Network, error) {
+	n := &network{
 		AppHandler: common.NewNoOpAppHandler(ctx.Log),
 
 		ctx:       ctx,
@@ -70,6 +85,36 @@ func New(
 			Size: recentTxsCacheSize,
 		},
 	}
+
+	n.router = gossip.NewRouter(ctx.Log, appSender)
+	gossiper, err := gossip.New(
+		gossip.DefaultConfig,
+		ctx.Log,
+		&gossipMempool{network: n},
+		peers,
+		n.router,
+		appSender,
+		"gossip",
+		registerer,
+	)
+	n.gossiper = gossiper
+	return n, err
+}
+
+func (n *network) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, deadline time.Time, msgBytes []byte) error {
+	return n.gossiper.AppRequest(ctx, nodeID, requestID, deadline, msgBytes)
+}
+
+func (n *network) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, msgBytes []byte) error {
+	return n.router.AppResponse(ctx, nodeID, requestID, msgBytes)
+}
+
+func (n *network) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	return n.router.AppRequestFailed(ctx, nodeID, requestID)
+}
+
+func (n *network) Gossip(ctx context.Context) {
+	n.gossiper.Gossip(ctx)
 }
 
 func (n *network) AppGossip(ctx context.Context, nodeID ids.NodeID, msgBytes []byte) error {
@@ -200,3 +245,29 @@ func (n *network) gossipTx(ctx context.Context, txID ids.ID, msgBytes []byte) {
 		)
 	}
 }
+
+// gossipMempool exposes the mempool to pull gossip.
+type gossipMempool struct {
+	network *network
+}
+
+func (m *gossipMempool) Iterate(f func(txID ids.ID, txBytes []byte) bool) {
+	m.network.ctx.Lock.Lock()
+	defer m.network.ctx.Lock.Unlock()
+
+	m.network.mempool.Iterate(func(tx *txs.Tx) bool {
+		return f(tx.ID(), tx.Bytes())
+	})
+}
+
+func (m *gossipMempool) AddTx(_ context.Context, txBytes []byte) error {
+	tx, err := m.network.parser.ParseTx(txBytes)
+	if err != nil {
+		return err
+	}
+
+	m.network.ctx.Lock.Lock()
+	defer m.network.ctx.Lock.Unlock()
+
+	return m.network.issueTx(tx)
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/ids"
@@ -21,6 +23,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/vms/avm/txs"
 	"github.com/MetalBlockchain/metalgo/vms/avm/txs/mempool"
 	"github.com/MetalBlockchain/metalgo/vms/components/avax"
+	"github.com/MetalBlockchain/metalgo/vms/components/gossip"
 	"github.com/MetalBlockchain/metalgo/vms/components/message"
 	"github.com/MetalBlockchain/metalgo/vms/nftfx"
 	"github.com/MetalBlockchain/metalgo/vms/propertyfx"
@@ -149,7 +152,7 @@ func TestNetworkAppGossip(t *testing.T) {
 			})
 			require.NoError(err)
 
-			n := New(
+			n, err := New(
 				&snow.Context{
 					Log: logging.NoLog{},
 				},
@@ -157,7 +160,10 @@ func TestNetworkAppGossip(t *testing.T) {
 				executor.NewMockManager(ctrl), // Manager is unused in this test
 				tt.mempoolFunc(ctrl),
 				tt.appSenderFunc(ctrl),
+				&gossip.Peers{},
+				prometheus.NewRegistry(),
 			)
+			require.NoError(err)
 			err = n.AppGossip(context.Background(), ids.GenerateTestNodeID(), tt.msgBytesFunc())
 			require.NoError(err)
 		})
@@ -290,7 +296,7 @@ func TestNetworkIssueTx(t *testing.T) {
 			})
 			require.NoError(err)
 
-			n := New(
+			n, err := New(
 				&snow.Context{
 					Log: logging.NoLog{},
 				},
@@ -298,7 +304,10 @@ func TestNetworkIssueTx(t *testing.T) {
 				tt.managerFunc(ctrl),
 				tt.mempoolFunc(ctrl),
 				tt.appSenderFunc(ctrl),
+				&gossip.Peers{},
+				prometheus.NewRegistry(),
 			)
+			require.NoError(err)
 			err = n.IssueTx(context.Background(), &txs.Tx{})
 			require.ErrorIs(err, tt.expectedErr)
 		})
@@ -317,7 +326,7 @@ func TestNetworkGossipTx(t *testing.T) {
 
 	appSender := common.NewMockSender(ctrl)
 
-	nIntf := New(
+	nIntf, err := New(
 		&snow.Context{
 			Log: logging.NoLog{},
 		},
@@ -325,7 +334,10 @@ func TestNetworkGossipTx(t *testing.T) {
 		executor.NewMockManager(ctrl),
 		mempool.NewMockMempool(ctrl),
 		appSender,
+		&gossip.Peers{},
+		prometheus.NewRegistry(),
 	)
+	require.NoError(err)
 	n, ok := nIntf.(*network)
 	require.True(ok)
 
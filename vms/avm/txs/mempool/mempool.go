@@ -48,6 +48,10 @@ type Mempool interface {
 	Get(txID ids.ID) *txs.Tx
 	Remove(txs []*txs.Tx)
 
+	// Iterate calls [f] on the txs in the mempool, from oldest to newest,
+	// until [f] returns false.
+	Iterate(f func(tx *txs.Tx) bool)
+
 	// Peek returns the next first tx that was added to the mempool whose size
 	// is less than or equal to maxTxSize.
 	Peek(maxTxSize int) *txs.Tx
@@ -166,6 +170,15 @@ func (m *mempool) Get(txID ids.ID) *txs.Tx {
 	return unissuedTxs
 }
 
+func (m *mempool) Iterate(f func(tx *txs.Tx) bool) {
+	it := m.unissuedTxs.NewIterator()
+	for it.Next() {
+		if !f(it.Value()) {
+			return
+		}
+	}
+}
+
 func (m *mempool) Remove(txsToRemove []*txs.Tx) {
 	for _, tx := range txsToRemove {
 		txID := tx.ID()
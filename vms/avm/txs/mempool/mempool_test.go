@@ -116,6 +116,35 @@ func TestTxsInMempool(t *testing.T) {
 	}
 }
 
+func TestIterate(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mempool, err := New("mempool", registerer, nil)
+	require.NoError(err)
+
+	testTxs := createTestTxs(3)
+	for _, tx := range testTxs {
+		require.NoError(mempool.Add(tx))
+	}
+
+	// Txs are iterated from oldest to newest
+	var iterated []*txs.Tx
+	mempool.Iterate(func(tx *txs.Tx) bool {
+		iterated = append(iterated, tx)
+		return true
+	})
+	require.Equal(testTxs, iterated)
+
+	// Iteration stops once [f] returns false
+	iterated = nil
+	mempool.Iterate(func(tx *txs.Tx) bool {
+		iterated = append(iterated, tx)
+		return len(iterated) < 2
+	})
+	require.Equal(testTxs[:2], iterated)
+}
+
 func createTestTxs(count int) []*txs.Tx {
 	testTxs := make([]*txs.Tx, 0, count)
 	addr := keys[0].PublicKey().Address()
@@ -54,6 +54,9 @@ type TxCachedState struct {
 	status choices.Status
 }
 
+// refresh updates [tx] to point to the deduplicated state of the tx.
+//
+// Invariant: [tx.vm.uniqueTxsLock] must be held.
 func (tx *UniqueTx) refresh() {
 	tx.vm.metrics.IncTxRefreshes()
 
@@ -99,12 +102,16 @@ func (tx *UniqueTx) refresh() {
 // Evict is called when this UniqueTx will no longer be returned from a cache
 // lookup
 func (tx *UniqueTx) Evict() {
-	// Lock is already held here
+	// [tx.vm.uniqueTxsLock] is already held here, as txs are only evicted when
+	// another tx is deduplicated.
 	tx.unique = false
 	tx.deps = nil
 }
 
 func (tx *UniqueTx) setStatus(status choices.Status) {
+	tx.vm.uniqueTxsLock.Lock()
+	defer tx.vm.uniqueTxsLock.Unlock()
+
 	tx.refresh()
 	if tx.status != status {
 		tx.status = status
@@ -159,7 +166,7 @@ func (tx *UniqueTx) Accept(context.Context) error {
 		return fmt.Errorf("error committing accepted state changes while processing tx %s: %w", txID, err)
 	}
 
-	tx.deps = nil // Needed to prevent a memory leak
+	tx.clearDependencies()
 	return tx.vm.metrics.MarkTxAccepted(tx.Tx)
 }
 
@@ -182,25 +189,40 @@ func (tx *UniqueTx) Reject(context.Context) error {
 
 	tx.vm.walletService.decided(txID)
 
-	tx.deps = nil // Needed to prevent a memory leak
+	tx.clearDependencies()
 	return nil
 }
 
+// clearDependencies drops the references to the dependencies of a decided tx.
+// This is needed to prevent a memory leak.
+func (tx *UniqueTx) clearDependencies() {
+	tx.vm.uniqueTxsLock.Lock()
+	defer tx.vm.uniqueTxsLock.Unlock()
+
+	tx.deps = nil
+}
+
 // Status returns the current status of this transaction
 func (tx *UniqueTx) Status() choices.Status {
+	tx.vm.uniqueTxsLock.Lock()
+	defer tx.vm.uniqueTxsLock.Unlock()
+
 	tx.refresh()
 	return tx.status
 }
 
 // Dependencies returns the set of transactions this transaction builds on
 func (tx *UniqueTx) Dependencies() ([]snowstorm.Tx, error) {
+	tx.vm.uniqueTxsLock.Lock()
+	defer tx.vm.uniqueTxsLock.Unlock()
+
 	tx.refresh()
 	if tx.Tx == nil || len(tx.deps) != 0 {
 		return tx.deps, nil
 	}
 
 	txIDs := set.Set[ids.ID]{}
-	for _, in := range tx.InputUTXOs() {
+	for _, in := range tx.cachedInputUTXOs() {
 		if in.Symbolic() {
 			continue
 		}
@@ -230,12 +252,15 @@ func (tx *UniqueTx) Dependencies() ([]snowstorm.Tx, error) {
 
 // InputIDs returns the set of utxoIDs this transaction consumes
 func (tx *UniqueTx) InputIDs() []ids.ID {
+	tx.vm.uniqueTxsLock.Lock()
+	defer tx.vm.uniqueTxsLock.Unlock()
+
 	tx.refresh()
 	if tx.Tx == nil || len(tx.inputs) != 0 {
 		return tx.inputs
 	}
 
-	inputUTXOs := tx.InputUTXOs()
+	inputUTXOs := tx.cachedInputUTXOs()
 	tx.inputs = make([]ids.ID, len(inputUTXOs))
 	for i, utxo := range inputUTXOs {
 		tx.inputs[i] = utxo.InputID()
@@ -255,6 +280,16 @@ func (*UniqueTx) Whitelist(context.Context) (set.Set[ids.ID], error) {
 
 // InputUTXOs returns the utxos that will be consumed on tx acceptance
 func (tx *UniqueTx) InputUTXOs() []*avax.UTXOID {
+	tx.vm.uniqueTxsLock.Lock()
+	defer tx.vm.uniqueTxsLock.Unlock()
+
+	return tx.cachedInputUTXOs()
+}
+
+// cachedInputUTXOs returns the utxos that will be consumed on tx acceptance
+//
+// Invariant: [tx.vm.uniqueTxsLock] must be held.
+func (tx *UniqueTx) cachedInputUTXOs() []*avax.UTXOID {
 	tx.refresh()
 	if tx.Tx == nil || len(tx.inputUTXOs) != 0 {
 		return tx.inputUTXOs
@@ -265,6 +300,9 @@ func (tx *UniqueTx) InputUTXOs() []*avax.UTXOID {
 
 // UTXOs returns the utxos that will be added to the UTXO set on tx acceptance
 func (tx *UniqueTx) UTXOs() []*avax.UTXO {
+	tx.vm.uniqueTxsLock.Lock()
+	defer tx.vm.uniqueTxsLock.Unlock()
+
 	tx.refresh()
 	if tx.Tx == nil || len(tx.utxos) != 0 {
 		return tx.utxos
@@ -275,6 +313,9 @@ func (tx *UniqueTx) UTXOs() []*avax.UTXO {
 
 // Bytes returns the binary representation of this transaction
 func (tx *UniqueTx) Bytes() []byte {
+	tx.vm.uniqueTxsLock.Lock()
+	defer tx.vm.uniqueTxsLock.Unlock()
+
 	tx.refresh()
 	return tx.Tx.Bytes()
 }
@@ -298,12 +339,18 @@ func (tx *UniqueTx) Verify(context.Context) error {
 		return err
 	}
 
+	tx.vm.uniqueTxsLock.Lock()
+	defer tx.vm.uniqueTxsLock.Unlock()
+
 	tx.verifiedState = true
 	return nil
 }
 
 // SyntacticVerify verifies that this transaction is well formed
 func (tx *UniqueTx) SyntacticVerify() error {
+	tx.vm.uniqueTxsLock.Lock()
+	defer tx.vm.uniqueTxsLock.Unlock()
+
 	tx.refresh()
 
 	if tx.Tx == nil {
@@ -328,13 +375,20 @@ func (tx *UniqueTx) SemanticVerify() error {
 		return err
 	}
 
-	if tx.validity != nil || tx.verifiedState {
-		return tx.validity
+	tx.vm.uniqueTxsLock.Lock()
+	validity, verifiedState, innerTx := tx.validity, tx.verifiedState, tx.Tx
+	tx.vm.uniqueTxsLock.Unlock()
+
+	if validity != nil || verifiedState {
+		return validity
 	}
 
-	return tx.Unsigned.Visit(&executor.SemanticVerifier{
+	// The lock isn't held while the tx is verified against the state, so that
+	// txs can be verified concurrently. Verification may also read the state of
+	// other txs.
+	return innerTx.Unsigned.Visit(&executor.SemanticVerifier{
 		Backend: tx.vm.txBackend,
 		State:   tx.vm.dagState,
-		Tx:      tx.Tx,
+		Tx:      innerTx,
 	})
 }
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	stdjson "encoding/json"
@@ -46,6 +47,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/vms/avm/txs/mempool"
 	"github.com/MetalBlockchain/metalgo/vms/avm/utxo"
 	"github.com/MetalBlockchain/metalgo/vms/components/avax"
+	"github.com/MetalBlockchain/metalgo/vms/components/gossip"
 	"github.com/MetalBlockchain/metalgo/vms/components/index"
 	"github.com/MetalBlockchain/metalgo/vms/components/keystore"
 	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
@@ -70,6 +72,7 @@ var (
 	errBootstrapping             = errors.New("chain is currently bootstrapping")
 
 	_ vertex.LinearizableVMWithEngine = (*VM)(nil)
+	_ vertex.ConcurrentVerifyDAGVM    = (*VM)(nil)
 )
 
 type VM struct {
@@ -126,22 +129,35 @@ type VM struct {
 
 	addressTxsIndexer index.AddressTxsIndexer
 
-	uniqueTxs cache.Deduplicator[ids.ID, *UniqueTx]
+	// uniqueTxsLock protects the state shared by the deduplicated txs, as txs
+	// may be verified concurrently while bootstrapping.
+	uniqueTxsLock sync.Mutex
+	uniqueTxs     cache.Deduplicator[ids.ID, *UniqueTx]
 
 	txBackend *txexecutor.Backend
 	dagState  *dagState
 
+	// Peers that can be polled for mempool txs
+	gossipPeers gossip.Peers
+
 	// These values are only initialized after the chain has been linearized.
 	blockbuilder.Builder
 	chainManager blockexecutor.Manager
 	network      network.Network
+
+	// Cancelled on shutdown
+	onShutdownCtx       context.Context
+	onShutdownCtxCancel context.CancelFunc
+	awaitShutdown       sync.WaitGroup
 }
 
-func (*VM) Connected(context.Context, ids.NodeID, *version.Application) error {
+func (vm *VM) Connected(_ context.Context, nodeID ids.NodeID, _ *version.Application) error {
+	vm.gossipPeers.Connected(nodeID)
 	return nil
 }
 
-func (*VM) Disconnected(context.Context, ids.NodeID) error {
+func (vm *VM) Disconnected(_ context.Context, nodeID ids.NodeID) error {
+	vm.gossipPeers.Disconnected(nodeID)
 	return nil
 }
 
@@ -167,6 +183,8 @@ func (vm *VM) Initialize(
 	fxs []*common.Fx,
 	appSender common.AppSender,
 ) error {
+	vm.clock.SetSource(ctx.Clock.Source())
+
 	noopMessageHandler := common.NewNoOpAppHandler(ctx.Log)
 	vm.Atomic = network.NewAtomic(noopMessageHandler)
 
@@ -200,6 +218,7 @@ func (vm *VM) Initialize(
 	vm.ctx = ctx
 	vm.toEngine = toEngine
 	vm.appSender = appSender
+	vm.onShutdownCtx, vm.onShutdownCtxCancel = context.WithCancel(context.Background())
 	vm.baseDB = db
 	vm.db = versiondb.New(db)
 	vm.assetToFxCache = &cache.LRU[ids.ID, set.Bits64]{Size: assetToFxCacheSize}
@@ -249,7 +268,7 @@ func (vm *VM) Initialize(
 		return err
 	}
 
-	vm.timer = timer.NewTimer(func() {
+	vm.timer = timer.NewTimerWithClock(vm.clock, func() {
 		ctx.Lock.Lock()
 		defer ctx.Lock.Unlock()
 
@@ -348,10 +367,13 @@ func (vm *VM) Shutdown(context.Context) error {
 		return nil
 	}
 
-	// There is a potential deadlock if the timer is about to execute a timeout.
-	// So, the lock must be released before stopping the timer.
+	// There is a potential deadlock if the timer is about to execute a timeout,
+	// or if pull gossip is waiting on the context lock. So, the lock must be
+	// released before stopping them.
 	vm.ctx.Lock.Unlock()
 	vm.timer.Stop()
+	vm.onShutdownCtxCancel()
+	vm.awaitShutdown.Wait()
 	vm.ctx.Lock.Lock()
 
 	errs := wrappers.Errs{}
@@ -470,18 +492,29 @@ func (vm *VM) Linearize(_ context.Context, stopVertexID ids.ID, toEngine chan<-
 		mempool,
 	)
 
-	vm.network = network.New(
+	vm.network, err = network.New(
 		vm.ctx,
 		vm.parser,
 		vm.chainManager,
 		mempool,
 		vm.appSender,
+		&vm.gossipPeers,
+		vm.registerer,
 	)
+	if err != nil {
+		return fmt.Errorf("failed to create network: %w", err)
+	}
 
 	// Note: It's important only to switch the networking stack after the full
 	// chainVM has been initialized. Traffic will immediately start being
 	// handled asynchronously.
 	vm.Atomic.Set(vm.network)
+
+	vm.awaitShutdown.Add(1)
+	go func() {
+		defer vm.awaitShutdown.Done()
+		vm.network.Gossip(vm.onShutdownCtx)
+	}()
 	return nil
 }
 
@@ -497,6 +530,13 @@ func (vm *VM) ParseTx(_ context.Context, b []byte) (snowstorm.Tx, error) {
 	return vm.parseTx(b)
 }
 
+// ConcurrentTxVerifyEnabled returns true, as the state that txs share is
+// protected by [uniqueTxsLock] and txs only read from [state] when they are
+// verified.
+func (*VM) ConcurrentTxVerifyEnabled(context.Context) (bool, error) {
+	return true, nil
+}
+
 func (vm *VM) GetTx(_ context.Context, txID ids.ID) (snowstorm.Tx, error) {
 	tx := &UniqueTx{
 		vm:   vm,
@@ -793,6 +833,8 @@ func (vm *VM) onAccept(tx *txs.Tx) error {
 }
 
 // UniqueTx de-duplicates the transaction.
+//
+// Invariant: [uniqueTxsLock] must be held.
 func (vm *VM) DeduplicateTx(tx *UniqueTx) *UniqueTx {
 	return vm.uniqueTxs.Deduplicate(tx)
 }
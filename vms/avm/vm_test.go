@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"sync"
 	"testing"
 
 	stdjson "encoding/json"
@@ -17,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/api/keystore"
+	"github.com/MetalBlockchain/metalgo/cache"
 	"github.com/MetalBlockchain/metalgo/chains/atomic"
 	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/database/manager"
@@ -25,6 +27,8 @@ import (
 	"github.com/MetalBlockchain/metalgo/database/versiondb"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/choices"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowstorm"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/cb58"
@@ -1315,6 +1319,144 @@ func TestTxVerifyAfterVerifyAncestorTx(t *testing.T) {
 	}
 }
 
+// Txs whose dependencies are accepted should be able to be verified
+// concurrently, as they are while bootstrapping
+func TestConcurrentTxVerify(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+		ctx.Lock.Unlock()
+	}()
+
+	enabled, err := vm.ConcurrentTxVerifyEnabled(context.Background())
+	require.NoError(err)
+	require.True(enabled)
+
+	avaxTx := GetAVAXTxFromGenesisTest(genesisBytes, t)
+	key := keys[0]
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{key.PublicKey().Address()},
+	}
+
+	const numTxs = 16
+	splitOuts := make([]*avax.TransferableOutput, numTxs)
+	for i := range splitOuts {
+		splitOuts[i] = &avax.TransferableOutput{
+			Asset: avax.Asset{ID: avaxTx.ID()},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          2*vm.TxFee + uint64(i),
+				OutputOwners: owners,
+			},
+		}
+	}
+	avax.SortTransferableOutputs(splitOuts, vm.parser.Codec())
+
+	splitTx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    constants.UnitTestID,
+		BlockchainID: chainID,
+		Ins: []*avax.TransferableInput{{
+			UTXOID: avax.UTXOID{
+				TxID:        avaxTx.ID(),
+				OutputIndex: 2,
+			},
+			Asset: avax.Asset{ID: avaxTx.ID()},
+			In: &secp256k1fx.TransferInput{
+				Amt: startBalance,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{
+						0,
+					},
+				},
+			},
+		}},
+		Outs: splitOuts,
+	}}}
+	require.NoError(splitTx.SignSECP256K1Fx(vm.parser.Codec(), [][]*secp256k1.PrivateKey{{key}}))
+
+	parsedSplitTx, err := vm.ParseTx(context.Background(), splitTx.Bytes())
+	require.NoError(err)
+	require.NoError(parsedSplitTx.Verify(context.Background()))
+	require.NoError(parsedSplitTx.Accept(context.Background()))
+
+	// Evict txs from the deduplicator while they are being verified.
+	vm.uniqueTxs = &cache.EvictableLRU[ids.ID, *UniqueTx]{
+		Size: 2,
+	}
+
+	// The last tx spends a UTXO that doesn't exist.
+	parsedTxs := make([]snowstorm.Tx, numTxs+1)
+	for i := range parsedTxs {
+		amt := 2 * vm.TxFee
+		if i < numTxs {
+			amt = splitOuts[i].Out.Amount()
+		}
+		tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: chainID,
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{
+					TxID:        splitTx.ID(),
+					OutputIndex: uint32(i),
+				},
+				Asset: avax.Asset{ID: avaxTx.ID()},
+				In: &secp256k1fx.TransferInput{
+					Amt: amt,
+					Input: secp256k1fx.Input{
+						SigIndices: []uint32{
+							0,
+						},
+					},
+				},
+			}},
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: avaxTx.ID()},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          amt - vm.TxFee,
+					OutputOwners: owners,
+				},
+			}},
+		}}}
+		require.NoError(tx.SignSECP256K1Fx(vm.parser.Codec(), [][]*secp256k1.PrivateKey{{key}}))
+
+		parsedTxs[i], err = vm.ParseTx(context.Background(), tx.Bytes())
+		require.NoError(err)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(parsedTxs))
+	)
+	wg.Add(len(parsedTxs))
+	for i, tx := range parsedTxs {
+		go func(i int, tx snowstorm.Tx) {
+			defer wg.Done()
+
+			deps, err := tx.Dependencies()
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			for _, dep := range deps {
+				if dep.Status() != choices.Accepted {
+					errs[i] = errMissing
+					return
+				}
+			}
+			errs[i] = tx.Verify(context.Background())
+		}(i, tx)
+	}
+	wg.Wait()
+
+	for i, err := range errs[:numTxs] {
+		require.NoError(err, i)
+	}
+	require.ErrorIs(errs[numTxs], database.ErrNotFound)
+}
+
 func TestImportTxSerialization(t *testing.T) {
 	_, vm, _, _ := setupIssueTx(t)
 	expected := []byte{
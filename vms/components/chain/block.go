@@ -42,7 +42,9 @@ func (bw *BlockWrapper) Verify(ctx context.Context) error {
 
 	blkID := bw.ID()
 	bw.state.unverifiedBlocks.Evict(blkID)
+	bw.state.verifiedLock.Lock()
 	bw.state.verifiedBlocks[blkID] = bw
+	bw.state.verifiedLock.Unlock()
 	return nil
 }
 
@@ -84,7 +86,9 @@ func (bw *BlockWrapper) VerifyWithContext(ctx context.Context, blockCtx *block.C
 
 	blkID := bw.ID()
 	bw.state.unverifiedBlocks.Evict(blkID)
+	bw.state.verifiedLock.Lock()
 	bw.state.verifiedBlocks[blkID] = bw
+	bw.state.verifiedLock.Unlock()
 	return nil
 }
 
@@ -92,7 +96,9 @@ func (bw *BlockWrapper) VerifyWithContext(ctx context.Context, blockCtx *block.C
 // block, and updates the last accepted block.
 func (bw *BlockWrapper) Accept(ctx context.Context) error {
 	blkID := bw.ID()
+	bw.state.verifiedLock.Lock()
 	delete(bw.state.verifiedBlocks, blkID)
+	bw.state.verifiedLock.Unlock()
 	bw.state.decidedBlocks.Put(blkID, bw)
 	bw.state.lastAcceptedBlock = bw
 
@@ -103,7 +109,9 @@ func (bw *BlockWrapper) Accept(ctx context.Context) error {
 // decided block.
 func (bw *BlockWrapper) Reject(ctx context.Context) error {
 	blkID := bw.ID()
+	bw.state.verifiedLock.Lock()
 	delete(bw.state.verifiedBlocks, blkID)
+	bw.state.verifiedLock.Unlock()
 	bw.state.decidedBlocks.Put(blkID, bw)
 	return bw.Block.Reject(ctx)
 }
@@ -6,6 +6,7 @@ package chain
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -39,6 +40,9 @@ type State struct {
 	// getStatus returns the status of the block
 	getStatus func(context.Context, snowman.Block) (choices.Status, error)
 
+	// verifiedLock protects [verifiedBlocks], as blocks may be verified
+	// concurrently.
+	verifiedLock sync.RWMutex
 	// verifiedBlocks is a map of blocks that have been verified and are
 	// therefore currently in consensus.
 	verifiedBlocks map[ids.ID]*BlockWrapper
@@ -200,8 +204,11 @@ func NewMeteredState(
 // This also flushes [lastAcceptedBlock] from missingBlocks and unverifiedBlocks to
 // ensure that their contents stay valid.
 func (s *State) SetLastAcceptedBlock(lastAcceptedBlock snowman.Block) error {
-	if len(s.verifiedBlocks) != 0 {
-		return fmt.Errorf("cannot set chain state last accepted block with non-zero number of verified blocks in processing: %d", len(s.verifiedBlocks))
+	s.verifiedLock.RLock()
+	numVerified := len(s.verifiedBlocks)
+	s.verifiedLock.RUnlock()
+	if numVerified != 0 {
+		return fmt.Errorf("cannot set chain state last accepted block with non-zero number of verified blocks in processing: %d", numVerified)
 	}
 
 	// [lastAcceptedBlock] is no longer missing or unverified, so we evict it from the corresponding
@@ -257,7 +264,10 @@ func (s *State) GetBlock(ctx context.Context, blkID ids.ID) (snowman.Block, erro
 // getCachedBlock checks the caches for [blkID] by priority. Returning
 // true if [blkID] is found in one of the caches.
 func (s *State) getCachedBlock(blkID ids.ID) (snowman.Block, bool) {
-	if blk, ok := s.verifiedBlocks[blkID]; ok {
+	s.verifiedLock.RLock()
+	blk, ok := s.verifiedBlocks[blkID]
+	s.verifiedLock.RUnlock()
+	if ok {
 		return blk, true
 	}
 
@@ -487,6 +497,9 @@ func (s *State) LastAcceptedBlockInternal() snowman.Block {
 
 // IsProcessing returns whether [blkID] is processing in consensus
 func (s *State) IsProcessing(blkID ids.ID) bool {
+	s.verifiedLock.RLock()
+	defer s.verifiedLock.RUnlock()
+
 	_, ok := s.verifiedBlocks[blkID]
 	return ok
 }
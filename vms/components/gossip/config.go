@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"errors"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/utils/units"
+)
+
+var (
+	DefaultConfig = Config{
+		Frequency:                10 * time.Second,
+		PollSize:                 2,
+		FalsePositiveProbability: .01,
+		MaxFilterBytes:           64 * units.KiB,
+		MaxResponseBytes:         256 * units.KiB,
+		RequestRate:              1,
+		RequestBurst:             5,
+	}
+
+	errInvalidFrequency                = errors.New("frequency must be positive")
+	errInvalidPollSize                 = errors.New("poll size must be positive")
+	errInvalidFalsePositiveProbability = errors.New("false positive probability must be in (0, 1)")
+	errInvalidMaxFilterBytes           = errors.New("max filter bytes must be positive")
+	errInvalidMaxResponseBytes         = errors.New("max response bytes must be positive")
+	errInvalidRequestRate              = errors.New("request rate must be positive")
+	errInvalidRequestBurst             = errors.New("request burst must be positive")
+)
+
+type Config struct {
+	// Frequency is how often peers are polled for the txs that are missing
+	// from our mempool.
+	Frequency time.Duration `json:"frequency"`
+	// PollSize is the number of peers that are polled at once.
+	PollSize int `json:"pollSize"`
+	// FalsePositiveProbability of the filter sent to peers. A tx that is a
+	// false positive isn't pulled in that round, but the filter is salted
+	// differently every round.
+	FalsePositiveProbability float64 `json:"falsePositiveProbability"`
+	// MaxFilterBytes is the maximum size of the filter sent to peers. If the
+	// mempool is too large for this size, the false positive probability is
+	// higher than configured.
+	MaxFilterBytes uint64 `json:"maxFilterBytes"`
+	// MaxResponseBytes is the maximum total size of the txs sent in response
+	// to a request.
+	MaxResponseBytes int `json:"maxResponseBytes"`
+	// RequestRate is the number of requests per second that are answered for
+	// each peer, on average.
+	RequestRate float64 `json:"requestRate"`
+	// RequestBurst is the number of requests that are answered for a peer
+	// before [RequestRate] applies.
+	RequestBurst int `json:"requestBurst"`
+}
+
+func (c *Config) Verify() error {
+	switch {
+	case c.Frequency <= 0:
+		return errInvalidFrequency
+	case c.PollSize <= 0:
+		return errInvalidPollSize
+	case c.FalsePositiveProbability <= 0 || c.FalsePositiveProbability >= 1:
+		return errInvalidFalsePositiveProbability
+	case c.MaxFilterBytes == 0:
+		return errInvalidMaxFilterBytes
+	case c.MaxResponseBytes <= 0:
+		return errInvalidMaxResponseBytes
+	case c.RequestRate <= 0:
+		return errInvalidRequestRate
+	case c.RequestBurst <= 0:
+		return errInvalidRequestBurst
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"golang.org/x/time/rate"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/utils/bloom"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/vms/components/message"
+)
+
+var _ ResponseHandler = (*Gossiper)(nil)
+
+// Mempool is the set of txs that is reconciled with peers.
+//
+// The Gossiper never holds a lock while calling the Mempool, so the
+// implementation is expected to grab any lock it requires, such as the
+// context lock.
+type Mempool interface {
+	// Iterate calls [f] on the ID and bytes of the txs in the mempool until
+	// [f] returns false.
+	Iterate(f func(txID ids.ID, txBytes []byte) bool)
+
+	// AddTx parses, verifies and adds a tx that was pulled from a peer.
+	AddTx(ctx context.Context, txBytes []byte) error
+}
+
+// Gossiper periodically sends a bloom filter of the mempool to peers, which
+// reply with the txs that are missing from the filter. This allows nodes that
+// missed the push gossip of a tx, such as nodes that were offline, to learn
+// about it.
+//
+// The requests are sent through a Requester, which owns the request IDs of the
+// VM and delivers the responses back to the Gossiper.
+type Gossiper struct {
+	config    Config
+	log       logging.Logger
+	mempool   Mempool
+	peers     *Peers
+	requester Requester
+	appSender common.AppSender
+	metrics   *metrics
+
+	lock sync.Mutex
+	// peer -> limiter of the requests that are answered
+	limiters map[ids.NodeID]*rate.Limiter
+}
+
+func New(
+	config Config,
+	log logging.Logger,
+	mempool Mempool,
+	peers *Peers,
+	requester Requester,
+	appSender common.AppSender,
+	namespace string,
+	registerer prometheus.Registerer,
+) (*Gossiper, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	metrics, err := newMetrics(namespace, registerer)
+	if err != nil {
+		return nil, err
+	}
+	return &Gossiper{
+		config:    config,
+		log:       log,
+		mempool:   mempool,
+		peers:     peers,
+		requester: requester,
+		appSender: appSender,
+		metrics:   metrics,
+		limiters:  make(map[ids.NodeID]*rate.Limiter),
+	}, nil
+}
+
+// Gossip polls peers every [Config.Frequency] until [ctx] is cancelled.
+func (g *Gossiper) Gossip(ctx context.Context) {
+	ticker := time.NewTicker(g.config.Frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := g.Pull(ctx); err != nil {
+				g.log.Warn("failed to pull gossip",
+					zap.Error(err),
+				)
+			}
+			g.pruneLimiters()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Pull sends a request to [Config.PollSize] peers for the txs that are
+// missing from the mempool.
+func (g *Gossiper) Pull(ctx context.Context) error {
+	nodeIDs := g.peers.Sample(g.config.PollSize)
+	if len(nodeIDs) == 0 {
+		return nil
+	}
+
+	var txIDs []ids.ID
+	g.mempool.Iterate(func(txID ids.ID, _ []byte) bool {
+		txIDs = append(txIDs, txID)
+		return true
+	})
+
+	numHashes, numBytes := bloom.OptimalParameters(
+		uint64(len(txIDs)),
+		g.config.FalsePositiveProbability,
+		g.config.MaxFilterBytes,
+	)
+	filter, err := bloom.NewPortable(numHashes, numBytes, rand.Uint32()) // #nosec G404
+	if err != nil {
+		return err
+	}
+	for _, txID := range txIDs {
+		filter.Add(txID[:])
+	}
+
+	msgBytes, err := message.Build(&message.PullGossipRequest{
+		Filter: filter.Bytes(),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, nodeID := range nodeIDs {
+		if err := g.requester.SendAppRequest(ctx, nodeID, msgBytes, g); err != nil {
+			return err
+		}
+		g.metrics.requestsSent.Inc()
+	}
+	return nil
+}
+
+// AppRequest answers a pull gossip request from [nodeID].
+func (g *Gossiper) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, _ time.Time, msgBytes []byte) error {
+	g.metrics.requestsReceived.Inc()
+	if !g.allow(nodeID) {
+		g.log.Debug("dropping pull gossip request",
+			zap.String("reason", "rate limited"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		g.metrics.requestsThrottled.Inc()
+		return nil
+	}
+
+	msgIntf, err := message.Parse(msgBytes)
+	if err != nil {
+		g.dropInvalid(nodeID, requestID, "failed to parse message", err)
+		return nil
+	}
+	msg, ok := msgIntf.(*message.PullGossipRequest)
+	if !ok {
+		g.dropInvalid(nodeID, requestID, "unexpected message", nil)
+		return nil
+	}
+	filter, err := bloom.ParsePortable(msg.Filter)
+	if err != nil {
+		g.dropInvalid(nodeID, requestID, "failed to parse filter", err)
+		return nil
+	}
+
+	var (
+		txs      [][]byte
+		numBytes int
+	)
+	g.mempool.Iterate(func(txID ids.ID, txBytes []byte) bool {
+		if filter.Check(txID[:]) {
+			return true
+		}
+		if numBytes+len(txBytes) > g.config.MaxResponseBytes {
+			return false
+		}
+		txs = append(txs, txBytes)
+		numBytes += len(txBytes)
+		return true
+	})
+
+	responseBytes, err := message.Build(&message.PullGossipResponse{
+		Txs: txs,
+	})
+	if err != nil {
+		g.log.Warn("failed to build pull gossip response",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Error(err),
+		)
+		return nil
+	}
+	if err := g.appSender.SendAppResponse(ctx, nodeID, requestID, responseBytes); err != nil {
+		return err
+	}
+	g.metrics.txsSent.Add(float64(len(txs)))
+	g.metrics.txBytesSent.Add(float64(numBytes))
+	return nil
+}
+
+// AppResponse adds the txs that [nodeID] sent in response to a request to
+// the mempool.
+//
+// Only responses to the requests of the Gossiper are expected to be delivered
+// by the Requester.
+func (g *Gossiper) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, msgBytes []byte) error {
+	msgIntf, err := message.Parse(msgBytes)
+	if err != nil {
+		g.dropInvalid(nodeID, requestID, "failed to parse message", err)
+		return nil
+	}
+	msg, ok := msgIntf.(*message.PullGossipResponse)
+	if !ok {
+		g.dropInvalid(nodeID, requestID, "unexpected message", nil)
+		return nil
+	}
+
+	for _, txBytes := range msg.Txs {
+		g.metrics.txsReceived.Inc()
+		g.metrics.txBytesReceived.Add(float64(len(txBytes)))
+
+		if err := g.mempool.AddTx(ctx, txBytes); err != nil {
+			g.log.Debug("failed to add pulled tx",
+				zap.Stringer("nodeID", nodeID),
+				zap.Error(err),
+			)
+			continue
+		}
+		g.metrics.txsAdded.Inc()
+	}
+	return nil
+}
+
+func (g *Gossiper) AppRequestFailed(context.Context, ids.NodeID, uint32) error {
+	g.metrics.requestsFailed.Inc()
+	return nil
+}
+
+// allow returns true if a request from [nodeID] should be answered.
+func (g *Gossiper) allow(nodeID ids.NodeID) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	limiter, ok := g.limiters[nodeID]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(g.config.RequestRate), g.config.RequestBurst)
+		g.limiters[nodeID] = limiter
+	}
+	return limiter.Allow()
+}
+
+// pruneLimiters removes the limiters of disconnected peers.
+func (g *Gossiper) pruneLimiters() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	for nodeID := range g.limiters {
+		if !g.peers.Contains(nodeID) {
+			delete(g.limiters, nodeID)
+		}
+	}
+}
+
+func (g *Gossiper) dropInvalid(nodeID ids.NodeID, requestID uint32, reason string, err error) {
+	g.log.Debug("dropping pull gossip message",
+		zap.String("reason", reason),
+		zap.Stringer("nodeID", nodeID),
+		zap.Uint32("requestID", requestID),
+		zap.Error(err),
+	)
+	g.metrics.invalidMessages.Inc()
+}
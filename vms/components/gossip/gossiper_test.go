@@ -0,0 +1,270 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/utils/hashing"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/vms/components/message"
+)
+
+var (
+	errInvalidTx = errors.New("invalid tx")
+
+	// testConfig makes false positives of the filter, which would cause a tx
+	// to not be pulled, practically impossible.
+	testConfig = func() Config {
+		config := DefaultConfig
+		config.FalsePositiveProbability = 1e-9
+		return config
+	}()
+)
+
+// testMempool identifies a tx by the hash of its bytes
+type testMempool struct {
+	lock sync.Mutex
+	txs  map[ids.ID][]byte
+}
+
+func newTestMempool(txs ...[]byte) *testMempool {
+	m := &testMempool{
+		txs: make(map[ids.ID][]byte),
+	}
+	for _, tx := range txs {
+		m.txs[hashing.ComputeHash256Array(tx)] = tx
+	}
+	return m
+}
+
+func (m *testMempool) Iterate(f func(txID ids.ID, txBytes []byte) bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for txID, tx := range m.txs {
+		if !f(txID, tx) {
+			return
+		}
+	}
+}
+
+func (m *testMempool) AddTx(_ context.Context, tx []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if len(tx) == 0 {
+		return errInvalidTx
+	}
+	m.txs[hashing.ComputeHash256Array(tx)] = tx
+	return nil
+}
+
+type testNode struct {
+	nodeID   ids.NodeID
+	mempool  *testMempool
+	peers    *Peers
+	sender   *common.SenderTest
+	router   *Router
+	gossiper *Gossiper
+}
+
+func newTestNode(t *testing.T, config Config, txs ...[]byte) *testNode {
+	n := &testNode{
+		nodeID:  ids.GenerateTestNodeID(),
+		mempool: newTestMempool(txs...),
+		peers:   &Peers{},
+		sender:  &common.SenderTest{T: t},
+	}
+	n.router = NewRouter(logging.NoLog{}, n.sender)
+	gossiper, err := New(config, logging.NoLog{}, n.mempool, n.peers, n.router, n.sender, "", prometheus.NewRegistry())
+	require.NoError(t, err)
+	n.gossiper = gossiper
+	return n
+}
+
+// connect delivers the messages sent by [a] and [b] to each other.
+func connect(a, b *testNode) {
+	link := func(from, to *testNode) {
+		from.peers.Connected(to.nodeID)
+		from.sender.SendAppRequestF = func(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, msg []byte) error {
+			for nodeID := range nodeIDs {
+				if nodeID == to.nodeID {
+					if err := to.gossiper.AppRequest(ctx, from.nodeID, requestID, time.Time{}, msg); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}
+		from.sender.SendAppResponseF = func(ctx context.Context, nodeID ids.NodeID, requestID uint32, msg []byte) error {
+			if nodeID != to.nodeID {
+				return nil
+			}
+			return to.router.AppResponse(ctx, from.nodeID, requestID, msg)
+		}
+	}
+	link(a, b)
+	link(b, a)
+}
+
+func TestPull(t *testing.T) {
+	require := require.New(t)
+
+	shared := []byte("shared")
+	missing := []byte("missing")
+	a := newTestNode(t, testConfig, shared)
+	b := newTestNode(t, testConfig, shared, missing, nil)
+	connect(a, b)
+
+	require.NoError(a.gossiper.Pull(context.Background()))
+
+	// [a] learned the tx it was missing, but not the invalid one
+	require.Len(a.mempool.txs, 2)
+	require.Contains(a.mempool.txs, ids.ID(hashing.ComputeHash256Array(missing)))
+	require.Empty(a.router.pending)
+}
+
+func TestPullNoPeers(t *testing.T) {
+	a := newTestNode(t, DefaultConfig)
+
+	// No request is sent, which would fail the test sender
+	require.NoError(t, a.gossiper.Pull(context.Background()))
+}
+
+func TestAppRequestMaxResponseBytes(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig
+	config.MaxResponseBytes = 5
+	a := newTestNode(t, testConfig)
+	b := newTestNode(t, config, []byte("tx0"), []byte("tx1"))
+	connect(a, b)
+
+	require.NoError(a.gossiper.Pull(context.Background()))
+	require.Len(a.mempool.txs, 1)
+}
+
+func TestAppRequestThrottled(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig
+	config.RequestRate = 1e-9
+	config.RequestBurst = 1
+	a := newTestNode(t, testConfig)
+	b := newTestNode(t, config, []byte("tx0"))
+	connect(a, b)
+
+	responses := 0
+	sendAppResponseF := b.sender.SendAppResponseF
+	b.sender.SendAppResponseF = func(ctx context.Context, nodeID ids.NodeID, requestID uint32, msg []byte) error {
+		responses++
+		return sendAppResponseF(ctx, nodeID, requestID, msg)
+	}
+
+	require.NoError(a.gossiper.Pull(context.Background()))
+	require.NoError(a.gossiper.Pull(context.Background()))
+	require.Equal(1, responses)
+
+	// The unanswered request eventually fails
+	require.Len(a.router.pending, 1)
+	for requestID := range a.router.pending {
+		require.NoError(a.router.AppRequestFailed(context.Background(), b.nodeID, requestID))
+	}
+	require.Empty(a.router.pending)
+
+	// Limiters of disconnected peers are removed
+	b.peers.Disconnected(a.nodeID)
+	b.gossiper.pruneLimiters()
+	require.Empty(b.gossiper.limiters)
+}
+
+func TestInvalidMessages(t *testing.T) {
+	require := require.New(t)
+
+	a := newTestNode(t, DefaultConfig)
+	nodeID := ids.GenerateTestNodeID()
+
+	txMsg, err := message.Build(&message.Tx{Tx: []byte("tx")})
+	require.NoError(err)
+	invalidFilterMsg, err := message.Build(&message.PullGossipRequest{})
+	require.NoError(err)
+
+	// Invalid requests aren't answered, which would fail the test sender
+	require.NoError(a.gossiper.AppRequest(context.Background(), nodeID, 0, time.Time{}, []byte{1}))
+	require.NoError(a.gossiper.AppRequest(context.Background(), nodeID, 1, time.Time{}, txMsg))
+	require.NoError(a.gossiper.AppRequest(context.Background(), nodeID, 2, time.Time{}, invalidFilterMsg))
+
+	// Unrequested responses are dropped
+	responseMsg, err := message.Build(&message.PullGossipResponse{Txs: [][]byte{[]byte("tx")}})
+	require.NoError(err)
+	require.NoError(a.router.AppResponse(context.Background(), nodeID, 0, responseMsg))
+	require.Empty(a.mempool.txs)
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		err    error
+	}{
+		{
+			name:   "valid",
+			modify: func(*Config) {},
+		},
+		{
+			name:   "invalid frequency",
+			modify: func(c *Config) { c.Frequency = 0 },
+			err:    errInvalidFrequency,
+		},
+		{
+			name:   "invalid poll size",
+			modify: func(c *Config) { c.PollSize = 0 },
+			err:    errInvalidPollSize,
+		},
+		{
+			name:   "invalid false positive probability",
+			modify: func(c *Config) { c.FalsePositiveProbability = 1 },
+			err:    errInvalidFalsePositiveProbability,
+		},
+		{
+			name:   "invalid max filter bytes",
+			modify: func(c *Config) { c.MaxFilterBytes = 0 },
+			err:    errInvalidMaxFilterBytes,
+		},
+		{
+			name:   "invalid max response bytes",
+			modify: func(c *Config) { c.MaxResponseBytes = 0 },
+			err:    errInvalidMaxResponseBytes,
+		},
+		{
+			name:   "invalid request rate",
+			modify: func(c *Config) { c.RequestRate = 0 },
+			err:    errInvalidRequestRate,
+		},
+		{
+			name:   "invalid request burst",
+			modify: func(c *Config) { c.RequestBurst = 0 },
+			err:    errInvalidRequestBurst,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := DefaultConfig
+			test.modify(&config)
+			require.ErrorIs(t, config.Verify(), test.err)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+type metrics struct {
+	requestsSent      prometheus.Counter
+	requestsFailed    prometheus.Counter
+	requestsReceived  prometheus.Counter
+	requestsThrottled prometheus.Counter
+	invalidMessages   prometheus.Counter
+	txsSent           prometheus.Counter
+	txBytesSent       prometheus.Counter
+	txsReceived       prometheus.Counter
+	txBytesReceived   prometheus.Counter
+	txsAdded          prometheus.Counter
+}
+
+func newCounter(namespace, name, help string) prometheus.Counter {
+	return prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      name,
+		Help:      help,
+	})
+}
+
+func newMetrics(namespace string, registerer prometheus.Registerer) (*metrics, error) {
+	m := &metrics{
+		requestsSent:      newCounter(namespace, "pull_requests_sent", "Number of pull gossip requests sent"),
+		requestsFailed:    newCounter(namespace, "pull_requests_failed", "Number of pull gossip requests sent that failed"),
+		requestsReceived:  newCounter(namespace, "pull_requests_received", "Number of pull gossip requests received"),
+		requestsThrottled: newCounter(namespace, "pull_requests_throttled", "Number of pull gossip requests received that were dropped due to rate limiting"),
+		invalidMessages:   newCounter(namespace, "pull_invalid_messages", "Number of invalid or unexpected pull gossip messages received"),
+		txsSent:           newCounter(namespace, "pull_txs_sent", "Number of txs sent in pull gossip responses"),
+		txBytesSent:       newCounter(namespace, "pull_tx_bytes_sent", "Size, in bytes, of the txs sent in pull gossip responses"),
+		txsReceived:       newCounter(namespace, "pull_txs_received", "Number of txs received in pull gossip responses"),
+		txBytesReceived:   newCounter(namespace, "pull_tx_bytes_received", "Size, in bytes, of the txs received in pull gossip responses"),
+		txsAdded:          newCounter(namespace, "pull_txs_added", "Number of txs received in pull gossip responses that were added to the mempool"),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.requestsSent),
+		registerer.Register(m.requestsFailed),
+		registerer.Register(m.requestsReceived),
+		registerer.Register(m.requestsThrottled),
+		registerer.Register(m.invalidMessages),
+		registerer.Register(m.txsSent),
+		registerer.Register(m.txBytesSent),
+		registerer.Register(m.txsReceived),
+		registerer.Register(m.txBytesReceived),
+		registerer.Register(m.txsAdded),
+	)
+	return m, errs.Err
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"sync"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/math"
+	"github.com/MetalBlockchain/metalgo/utils/sampler"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+// Peers tracks the connected peers that can be polled. The VM is expected to
+// forward its Connected and Disconnected calls.
+type Peers struct {
+	lock  sync.RWMutex
+	peers set.Set[ids.NodeID]
+}
+
+func (p *Peers) Connected(nodeID ids.NodeID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.peers.Add(nodeID)
+}
+
+func (p *Peers) Disconnected(nodeID ids.NodeID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.peers.Remove(nodeID)
+}
+
+func (p *Peers) Contains(nodeID ids.NodeID) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.peers.Contains(nodeID)
+}
+
+// Sample returns up to [n] connected peers, chosen uniformly at random.
+func (p *Peers) Sample(n int) []ids.NodeID {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	peers := p.peers.List()
+	n = math.Min(n, len(peers))
+
+	s := sampler.NewUniform()
+	if err := s.Initialize(uint64(len(peers))); err != nil {
+		return nil
+	}
+	indices, err := s.Sample(n)
+	if err != nil {
+		return nil
+	}
+
+	sampled := make([]ids.NodeID, n)
+	for i, index := range indices {
+		sampled[i] = peers[index]
+	}
+	return sampled
+}
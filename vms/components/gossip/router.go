@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+var _ Requester = (*Router)(nil)
+
+// ResponseHandler handles the outcome of an AppRequest.
+type ResponseHandler interface {
+	AppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, msgBytes []byte) error
+	AppRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error
+}
+
+// Requester sends AppRequests on behalf of a ResponseHandler.
+type Requester interface {
+	// SendAppRequest sends [msgBytes] to [nodeID] and delivers the
+	// AppResponse, or the AppRequestFailed, of the request to [handler].
+	SendAppRequest(
+		ctx context.Context,
+		nodeID ids.NodeID,
+		msgBytes []byte,
+		handler ResponseHandler,
+	) error
+}
+
+type pendingRequest struct {
+	nodeID  ids.NodeID
+	handler ResponseHandler
+}
+
+// Router assigns the IDs of the AppRequests that a VM sends and routes the
+// responses to the handler that sent each request.
+//
+// The VM must send all of its AppRequests through the Router, and forward all
+// of its AppResponses and AppRequestFailed messages to it, so that the IDs of
+// different handlers never collide.
+type Router struct {
+	log       logging.Logger
+	appSender common.AppSender
+
+	lock          sync.Mutex
+	nextRequestID uint32
+	// requestID -> the request that is waiting for a response
+	pending map[uint32]pendingRequest
+}
+
+func NewRouter(log logging.Logger, appSender common.AppSender) *Router {
+	return &Router{
+		log:       log,
+		appSender: appSender,
+		pending:   make(map[uint32]pendingRequest),
+	}
+}
+
+func (r *Router) SendAppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	msgBytes []byte,
+	handler ResponseHandler,
+) error {
+	r.lock.Lock()
+	requestID := r.nextRequestID
+	r.nextRequestID++
+	r.pending[requestID] = pendingRequest{
+		nodeID:  nodeID,
+		handler: handler,
+	}
+	r.lock.Unlock()
+
+	err := r.appSender.SendAppRequest(ctx, set.Set[ids.NodeID]{nodeID: struct{}{}}, requestID, msgBytes)
+	if err != nil {
+		r.lock.Lock()
+		delete(r.pending, requestID)
+		r.lock.Unlock()
+	}
+	return err
+}
+
+// AppResponse delivers the response to the handler that sent the request.
+// Unrequested responses are dropped.
+func (r *Router) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, msgBytes []byte) error {
+	handler, ok := r.completeRequest(nodeID, requestID)
+	if !ok {
+		r.log.Debug("dropping unexpected AppResponse",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+	return handler.AppResponse(ctx, nodeID, requestID, msgBytes)
+}
+
+// AppRequestFailed notifies the handler that sent the request of the failure.
+func (r *Router) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	handler, ok := r.completeRequest(nodeID, requestID)
+	if !ok {
+		r.log.Debug("dropping unexpected AppRequestFailed",
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+	return handler.AppRequestFailed(ctx, nodeID, requestID)
+}
+
+// completeRequest returns the handler of [requestID] if it was sent to
+// [nodeID] and is no longer pending.
+func (r *Router) completeRequest(nodeID ids.NodeID, requestID uint32) (ResponseHandler, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	request, ok := r.pending[requestID]
+	if !ok || request.nodeID != nodeID {
+		return nil, false
+	}
+	delete(r.pending, requestID)
+	return request.handler, true
+}
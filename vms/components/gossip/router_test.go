@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+var errSendFailed = errors.New("send failed")
+
+type testResponseHandler struct {
+	responses map[uint32][]byte
+	failed    set.Set[uint32]
+}
+
+func (h *testResponseHandler) AppResponse(_ context.Context, _ ids.NodeID, requestID uint32, msgBytes []byte) error {
+	h.responses[requestID] = msgBytes
+	return nil
+}
+
+func (h *testResponseHandler) AppRequestFailed(_ context.Context, _ ids.NodeID, requestID uint32) error {
+	h.failed.Add(requestID)
+	return nil
+}
+
+func newTestResponseHandler() *testResponseHandler {
+	return &testResponseHandler{
+		responses: make(map[uint32][]byte),
+	}
+}
+
+func TestRouter(t *testing.T) {
+	require := require.New(t)
+
+	sender := &common.SenderTest{T: t}
+	var requestIDs []uint32
+	sender.SendAppRequestF = func(_ context.Context, _ set.Set[ids.NodeID], requestID uint32, _ []byte) error {
+		requestIDs = append(requestIDs, requestID)
+		return nil
+	}
+	router := NewRouter(logging.NoLog{}, sender)
+
+	nodeID := ids.GenerateTestNodeID()
+	a := newTestResponseHandler()
+	b := newTestResponseHandler()
+	require.NoError(router.SendAppRequest(context.Background(), nodeID, []byte("a"), a))
+	require.NoError(router.SendAppRequest(context.Background(), nodeID, []byte("b"), b))
+	require.Len(requestIDs, 2)
+	require.NotEqual(requestIDs[0], requestIDs[1])
+
+	// Responses from other peers are dropped
+	require.NoError(router.AppResponse(context.Background(), ids.GenerateTestNodeID(), requestIDs[0], []byte("a")))
+	require.Empty(a.responses)
+
+	// Each handler only receives the outcome of its own request
+	require.NoError(router.AppResponse(context.Background(), nodeID, requestIDs[0], []byte("a")))
+	require.NoError(router.AppRequestFailed(context.Background(), nodeID, requestIDs[1]))
+	require.Equal(map[uint32][]byte{requestIDs[0]: []byte("a")}, a.responses)
+	require.Empty(a.failed)
+	require.Empty(b.responses)
+	require.True(b.failed.Contains(requestIDs[1]))
+
+	// Requests complete only once
+	require.NoError(router.AppRequestFailed(context.Background(), nodeID, requestIDs[0]))
+	require.Empty(a.failed)
+	require.Empty(router.pending)
+}
+
+func TestRouterSendFailed(t *testing.T) {
+	require := require.New(t)
+
+	sender := &common.SenderTest{T: t}
+	sender.SendAppRequestF = func(context.Context, set.Set[ids.NodeID], uint32, []byte) error {
+		return errSendFailed
+	}
+	router := NewRouter(logging.NoLog{}, sender)
+
+	err := router.SendAppRequest(context.Background(), ids.GenerateTestNodeID(), nil, newTestResponseHandler())
+	require.ErrorIs(err, errSendFailed)
+	require.Empty(router.pending)
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+var (
+	_ Message = (*PullGossipRequest)(nil)
+	_ Message = (*PullGossipResponse)(nil)
+)
+
+// PullGossipRequest asks a peer for the txs in its mempool that aren't in
+// [Filter].
+type PullGossipRequest struct {
+	message
+
+	// Filter is a serialized bloom.PortableFilter of the IDs of the txs that
+	// the requester already knows.
+	Filter []byte `serialize:"true"`
+}
+
+func (msg *PullGossipRequest) Handle(handler Handler, nodeID ids.NodeID, requestID uint32) error {
+	return handler.HandlePullGossipRequest(nodeID, requestID, msg)
+}
+
+// PullGossipResponse contains the txs that were missing from a
+// PullGossipRequest's filter.
+type PullGossipResponse struct {
+	message
+
+	Txs [][]byte `serialize:"true"`
+}
+
+func (msg *PullGossipResponse) Handle(handler Handler, nodeID ids.NodeID, requestID uint32) error {
+	return handler.HandlePullGossipResponse(nodeID, requestID, msg)
+}
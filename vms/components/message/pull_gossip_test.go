@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+)
+
+func TestPullGossipRequest(t *testing.T) {
+	require := require.New(t)
+
+	filter := utils.RandomBytes(units.KiB)
+	builtMsg := PullGossipRequest{
+		Filter: filter,
+	}
+	builtMsgBytes, err := Build(&builtMsg)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, builtMsg.Bytes())
+
+	parsedMsgIntf, err := Parse(builtMsgBytes)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, parsedMsgIntf.Bytes())
+
+	parsedMsg, ok := parsedMsgIntf.(*PullGossipRequest)
+	require.True(ok)
+
+	require.Equal(filter, parsedMsg.Filter)
+}
+
+func TestPullGossipResponse(t *testing.T) {
+	require := require.New(t)
+
+	txs := [][]byte{
+		utils.RandomBytes(units.KiB),
+		utils.RandomBytes(units.KiB),
+	}
+	builtMsg := PullGossipResponse{
+		Txs: txs,
+	}
+	builtMsgBytes, err := Build(&builtMsg)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, builtMsg.Bytes())
+
+	parsedMsgIntf, err := Parse(builtMsgBytes)
+	require.NoError(err)
+	require.Equal(builtMsgBytes, parsedMsgIntf.Bytes())
+
+	parsedMsg, ok := parsedMsgIntf.(*PullGossipResponse)
+	require.True(ok)
+
+	require.Equal(txs, parsedMsg.Txs)
+}
@@ -24,6 +24,7 @@ var (
 	_ block.BatchedChainVM               = (*blockVM)(nil)
 	_ block.HeightIndexedChainVM         = (*blockVM)(nil)
 	_ block.StateSyncableVM              = (*blockVM)(nil)
+	_ block.ConcurrentVerifyChainVM      = (*blockVM)(nil)
 )
 
 type blockVM struct {
@@ -32,6 +33,7 @@ type blockVM struct {
 	batchedVM    block.BatchedChainVM
 	hVM          block.HeightIndexedChainVM
 	ssVM         block.StateSyncableVM
+	cvVM         block.ConcurrentVerifyChainVM
 
 	blockMetrics
 	clock mockable.Clock
@@ -42,12 +44,14 @@ func NewBlockVM(vm block.ChainVM) block.ChainVM {
 	batchedVM, _ := vm.(block.BatchedChainVM)
 	hVM, _ := vm.(block.HeightIndexedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	cvVM, _ := vm.(block.ConcurrentVerifyChainVM)
 	return &blockVM{
 		ChainVM:      vm,
 		buildBlockVM: buildBlockVM,
 		batchedVM:    batchedVM,
 		hVM:          hVM,
 		ssVM:         ssVM,
+		cvVM:         cvVM,
 	}
 }
 
@@ -0,0 +1,20 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metervm
+
+import "context"
+
+func (vm *blockVM) ConcurrentVerifyEnabled(ctx context.Context) (bool, error) {
+	if vm.cvVM == nil {
+		return false, nil
+	}
+	return vm.cvVM.ConcurrentVerifyEnabled(ctx)
+}
+
+func (vm *vertexVM) ConcurrentTxVerifyEnabled(ctx context.Context) (bool, error) {
+	if vm.cvVM == nil {
+		return false, nil
+	}
+	return vm.cvVM.ConcurrentTxVerifyEnabled(ctx)
+}
@@ -20,17 +20,21 @@ import (
 
 var (
 	_ vertex.LinearizableVMWithEngine = (*vertexVM)(nil)
+	_ vertex.ConcurrentVerifyDAGVM    = (*vertexVM)(nil)
 	_ snowstorm.Tx                    = (*meterTx)(nil)
 )
 
 func NewVertexVM(vm vertex.LinearizableVMWithEngine) vertex.LinearizableVMWithEngine {
+	cvVM, _ := vm.(vertex.ConcurrentVerifyDAGVM)
 	return &vertexVM{
 		LinearizableVMWithEngine: vm,
+		cvVM:                     cvVM,
 	}
 }
 
 type vertexVM struct {
 	vertex.LinearizableVMWithEngine
+	cvVM vertex.ConcurrentVerifyDAGVM
 	vertexMetrics
 	clock mockable.Clock
 }
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"go.uber.org/zap"
 
 	"github.com/MetalBlockchain/metalgo/ids"
@@ -17,6 +19,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/timer"
 	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 	"github.com/MetalBlockchain/metalgo/utils/units"
+	"github.com/MetalBlockchain/metalgo/vms/components/gossip"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/blocks"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/state"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/txs"
@@ -89,7 +92,9 @@ func New(
 	blkManager blockexecutor.Manager,
 	toEngine chan<- common.Message,
 	appSender common.AppSender,
-) Builder {
+	peers *gossip.Peers,
+	registerer prometheus.Registerer,
+) (Builder, error) {
 	builder := &builder{
 		Mempool:           mempool,
 		txBuilder:         txBuilder,
@@ -98,16 +103,21 @@ func New(
 		toEngine:          toEngine,
 	}
 
-	builder.timer = timer.NewTimer(builder.setNextBuildBlockTime)
-
-	builder.Network = NewNetwork(
+	network, err := NewNetwork(
 		txExecutorBackend.Ctx,
 		builder,
 		appSender,
+		peers,
+		registerer,
 	)
+	if err != nil {
+		return nil, err
+	}
+	builder.Network = network
 
+	builder.timer = timer.NewTimerWithClock(*txExecutorBackend.Clk, builder.setNextBuildBlockTime)
 	go txExecutorBackend.Ctx.Log.RecoverAndPanic(builder.timer.Dispatch)
-	return builder
+	return builder, nil
 }
 
 func (b *builder) SetPreference(blockID ids.ID) {
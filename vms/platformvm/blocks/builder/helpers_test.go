@@ -38,6 +38,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/wrappers"
 	"github.com/MetalBlockchain/metalgo/version"
 	"github.com/MetalBlockchain/metalgo/vms/components/avax"
+	"github.com/MetalBlockchain/metalgo/vms/components/gossip"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/api"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/config"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/fx"
@@ -181,14 +182,19 @@ func newEnvironment(t *testing.T) *environment {
 		window,
 	)
 
-	res.Builder = New(
+	res.Builder, err = New(
 		res.mempool,
 		res.txBuilder,
 		&res.backend,
 		res.blkManager,
 		nil, // toEngine,
 		res.sender,
+		&gossip.Peers{},
+		registerer,
 	)
+	if err != nil {
+		panic(fmt.Errorf("failed to create builder: %w", err))
+	}
 
 	res.Builder.SetPreference(genesisID)
 	addSubnet(res)
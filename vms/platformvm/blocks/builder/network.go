@@ -10,12 +10,15 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"go.uber.org/zap"
 
 	"github.com/MetalBlockchain/metalgo/cache"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/vms/components/gossip"
 	"github.com/MetalBlockchain/metalgo/vms/components/message"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/txs"
 )
@@ -26,13 +29,20 @@ const (
 	recentCacheSize = 512
 )
 
-var _ Network = (*network)(nil)
+var (
+	_ Network        = (*network)(nil)
+	_ gossip.Mempool = (*gossipMempool)(nil)
+)
 
 type Network interface {
 	common.AppHandler
 
 	// GossipTx gossips the transaction to some of the connected peers
 	GossipTx(tx *txs.Tx) error
+
+	// Gossip periodically pulls the txs that are missing from the mempool
+	// from peers until [ctx] is cancelled.
+	Gossip(ctx context.Context)
 }
 
 type network struct {
@@ -42,19 +52,37 @@ type network struct {
 	// gossip related attributes
 	appSender common.AppSender
 	recentTxs *cache.LRU[ids.ID, struct{}]
+	router    *gossip.Router
+	gossiper  *gossip.Gossiper
 }
 
 func NewNetwork(
 	ctx *snow.Context,
 	blkBuilder *builder,
 	appSender common.AppSender,
-) Network {
-	return &network{
+	peers *gossip.Peers,
+	registerer prometheus.Registerer,
+) (Network, error) {
+	n := &network{
 		ctx:        ctx,
 		blkBuilder: blkBuilder,
 		appSender:  appSender,
 		recentTxs:  &cache.LRU[ids.ID, struct{}]{Size: recentCacheSize},
 	}
+
+	n.router = gossip.NewRouter(ctx.Log, appSender)
+	gossiper, err := gossip.New(
+		gossip.DefaultConfig,
+		ctx.Log,
+		&gossipMempool{network: n},
+		peers,
+		n.router,
+		appSender,
+		"gossip",
+		registerer,
+	)
+	n.gossiper = gossiper
+	return n, err
 }
 
 func (*network) CrossChainAppRequestFailed(context.Context, ids.ID, uint32) error {
@@ -75,22 +103,19 @@ func (*network) CrossChainAppResponse(context.Context, ids.ID, uint32, []byte) e
 	return nil
 }
 
-func (*network) AppRequestFailed(context.Context, ids.NodeID, uint32) error {
-	// This VM currently only supports gossiping of txs, so there are no
-	// requests.
-	return nil
+func (n *network) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32) error {
+	// The router delivers the response to the sender of the request.
+	return n.router.AppRequestFailed(ctx, nodeID, requestID)
 }
 
-func (*network) AppRequest(context.Context, ids.NodeID, uint32, time.Time, []byte) error {
-	// This VM currently only supports gossiping of txs, so there are no
-	// requests.
-	return nil
+func (n *network) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, deadline time.Time, msgBytes []byte) error {
+	// The only requests are pull gossip requests.
+	return n.gossiper.AppRequest(ctx, nodeID, requestID, deadline, msgBytes)
 }
 
-func (*network) AppResponse(context.Context, ids.NodeID, uint32, []byte) error {
-	// This VM currently only supports gossiping of txs, so there are no
-	// requests.
-	return nil
+func (n *network) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, msgBytes []byte) error {
+	// The router delivers the response to the sender of the request.
+	return n.router.AppResponse(ctx, nodeID, requestID, msgBytes)
 }
 
 func (n *network) AppGossip(_ context.Context, nodeID ids.NodeID, msgBytes []byte) error {
@@ -166,3 +191,36 @@ func (n *network) GossipTx(tx *txs.Tx) error {
 	}
 	return n.appSender.SendAppGossip(context.TODO(), msgBytes)
 }
+
+func (n *network) Gossip(ctx context.Context) {
+	n.gossiper.Gossip(ctx)
+}
+
+// gossipMempool exposes the mempool to pull gossip.
+type gossipMempool struct {
+	network *network
+}
+
+func (m *gossipMempool) Iterate(f func(txID ids.ID, txBytes []byte) bool) {
+	m.network.ctx.Lock.Lock()
+	defer m.network.ctx.Lock.Unlock()
+
+	m.network.blkBuilder.Iterate(func(tx *txs.Tx) bool {
+		return f(tx.ID(), tx.Bytes())
+	})
+}
+
+func (m *gossipMempool) AddTx(_ context.Context, txBytes []byte) error {
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return err
+	}
+
+	m.network.ctx.Lock.Lock()
+	defer m.network.ctx.Lock.Unlock()
+
+	if reason := m.network.blkBuilder.GetDropReason(tx.ID()); reason != nil {
+		return reason
+	}
+	return m.network.blkBuilder.AddUnverifiedTx(tx)
+}
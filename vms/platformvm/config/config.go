@@ -10,6 +10,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow/uptime"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
+	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/reward"
@@ -105,7 +106,8 @@ type Config struct {
 	// isn't used.
 	// If a subnet is tracked but not in this map, we use the value for the
 	// Primary Network.
-	MinPercentConnectedStakeHealthy map[ids.ID]float64
+	// The map is replaced when the consensus parameters of a subnet change.
+	MinPercentConnectedStakeHealthy *utils.Atomic[map[ids.ID]float64]
 
 	// UseCurrentHeight forces [GetMinimumHeight] to return the current height
 	// of the P-Chain instead of the oldest block in the [recentlyAccepted]
@@ -44,7 +44,8 @@ func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
 		return nil, fmt.Errorf("couldn't get current local validator: %w", err)
 	}
 
-	primaryMinPercentConnected, ok := vm.MinPercentConnectedStakeHealthy[constants.PrimaryNetworkID]
+	minPercentConnectedStakeHealthy := vm.MinPercentConnectedStakeHealthy.Get()
+	primaryMinPercentConnected, ok := minPercentConnectedStakeHealthy[constants.PrimaryNetworkID]
 	if !ok {
 		// This should never happen according to the comment for
 		// [MinPercentConnectedStakeHealthy] but we include it here to avoid the
@@ -71,7 +72,7 @@ func (vm *VM) HealthCheck(context.Context) (interface{}, error) {
 		if err != nil {
 			return nil, fmt.Errorf("couldn't get percent connected for %q: %w", subnetID, err)
 		}
-		minPercentConnected, ok := vm.MinPercentConnectedStakeHealthy[subnetID]
+		minPercentConnected, ok := minPercentConnectedStakeHealthy[subnetID]
 		if !ok {
 			minPercentConnected = primaryMinPercentConnected
 		}
@@ -89,9 +89,9 @@ func TestHealthCheckSubnet(t *testing.T) {
 				expectedMinStake = defaultMinConnectedStake
 			} else {
 				expectedMinStake = test.minStake
-				vm.MinPercentConnectedStakeHealthy = map[ids.ID]float64{
+				vm.MinPercentConnectedStakeHealthy.Set(map[ids.ID]float64{
 					subnetID: expectedMinStake,
-				}
+				})
 			}
 			for index, vdr := range subnetVdrs.List() {
 				err := vm.ConnectedSubnet(context.Background(), vdr.NodeID, subnetID)
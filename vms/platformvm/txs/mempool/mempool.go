@@ -55,6 +55,10 @@ type Mempool interface {
 	Get(txID ids.ID) *txs.Tx
 	Remove(txs []*txs.Tx)
 
+	// Iterate calls [f] on the decision txs and then the staker txs in the
+	// mempool until [f] returns false.
+	Iterate(f func(tx *txs.Tx) bool)
+
 	// Following Banff activation, all mempool transactions,
 	// (both decision and staker) are included into Standard blocks.
 	// HasTxs allow to check for availability of any mempool transaction.
@@ -208,6 +212,19 @@ func (m *mempool) Get(txID ids.ID) *txs.Tx {
 	return m.unissuedStakerTxs.Get(txID)
 }
 
+func (m *mempool) Iterate(f func(tx *txs.Tx) bool) {
+	for _, tx := range m.unissuedDecisionTxs.List() {
+		if !f(tx) {
+			return
+		}
+	}
+	for _, tx := range m.unissuedStakerTxs.List() {
+		if !f(tx) {
+			return
+		}
+	}
+}
+
 func (m *mempool) Remove(txsToRemove []*txs.Tx) {
 	remover := &remover{
 		m: m,
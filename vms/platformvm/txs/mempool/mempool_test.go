@@ -172,6 +172,39 @@ func TestProposalTxsInMempool(t *testing.T) {
 	}
 }
 
+func TestIterate(t *testing.T) {
+	require := require.New(t)
+
+	registerer := prometheus.NewRegistry()
+	mpool, err := NewMempool("mempool", registerer, &noopBlkTimer{})
+	require.NoError(err)
+
+	decisionTxs, err := createTestDecisionTxs(2)
+	require.NoError(err)
+	proposalTxs, err := createTestProposalTxs(2)
+	require.NoError(err)
+
+	allTxs := append(decisionTxs, proposalTxs...)
+	for _, tx := range allTxs {
+		require.NoError(mpool.Add(tx))
+	}
+
+	var iterated []*txs.Tx
+	mpool.Iterate(func(tx *txs.Tx) bool {
+		iterated = append(iterated, tx)
+		return true
+	})
+	require.ElementsMatch(allTxs, iterated)
+
+	// Iteration stops once [f] returns false
+	numIterated := 0
+	mpool.Iterate(func(*txs.Tx) bool {
+		numIterated++
+		return numIterated < 3
+	})
+	require.Equal(3, numIterated)
+}
+
 func createTestDecisionTxs(count int) ([]*txs.Tx, error) {
 	decisionTxs := make([]*txs.Tx, 0, count)
 	for i := uint32(0); i < uint32(count); i++ {
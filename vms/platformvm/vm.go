@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/gorilla/rpc/v2"
@@ -37,6 +38,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/wrappers"
 	"github.com/MetalBlockchain/metalgo/version"
 	"github.com/MetalBlockchain/metalgo/vms/components/avax"
+	"github.com/MetalBlockchain/metalgo/vms/components/gossip"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/api"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/blocks"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/config"
@@ -105,6 +107,14 @@ type VM struct {
 
 	txBuilder txbuilder.Builder
 	manager   blockexecutor.Manager
+
+	// Peers that can be polled for mempool txs
+	gossipPeers gossip.Peers
+
+	// Cancelled on shutdown
+	onShutdownCtx       context.Context
+	onShutdownCtxCancel context.CancelFunc
+	awaitShutdown       sync.WaitGroup
 }
 
 // Initialize this blockchain.
@@ -135,7 +145,9 @@ func (vm *VM) Initialize(
 	}
 
 	vm.ctx = chainCtx
+	vm.clock.SetSource(chainCtx.Clock.Source())
 	vm.dbManager = dbManager
+	vm.onShutdownCtx, vm.onShutdownCtxCancel = context.WithCancel(context.Background())
 
 	vm.codecRegistry = linearcodec.NewDefault()
 	vm.fx = &secp256k1fx.Fx{}
@@ -169,7 +181,7 @@ func (vm *VM) Initialize(
 
 	vm.atomicUtxosManager = avax.NewAtomicUTXOManager(chainCtx.SharedMemory, txs.Codec)
 	utxoHandler := utxo.NewHandler(vm.ctx, &vm.clock, vm.fx)
-	vm.uptimeManager = uptime.NewManager(vm.state)
+	vm.uptimeManager = uptime.NewManagerWithClock(vm.state, chainCtx.Clock)
 	vm.UptimeLockedCalculator.SetCalculator(&vm.bootstrapped, &chainCtx.Lock, vm.uptimeManager)
 
 	vm.txBuilder = txbuilder.New(
@@ -207,14 +219,19 @@ func (vm *VM) Initialize(
 		txExecutorBackend,
 		vm.recentlyAccepted,
 	)
-	vm.Builder = blockbuilder.New(
+	vm.Builder, err = blockbuilder.New(
 		mempool,
 		vm.txBuilder,
 		txExecutorBackend,
 		vm.manager,
 		toEngine,
 		appSender,
+		&vm.gossipPeers,
+		registerer,
 	)
+	if err != nil {
+		return fmt.Errorf("failed to create block builder: %w", err)
+	}
 
 	// Create all of the chains that the database says exist
 	if err := vm.initBlockchains(); err != nil {
@@ -314,6 +331,13 @@ func (vm *VM) onNormalOperationsStarted() error {
 
 	// Start the block builder
 	vm.Builder.ResetBlockTimer()
+
+	// Start pulling the txs that were missed while bootstrapping
+	vm.awaitShutdown.Add(1)
+	go func() {
+		defer vm.awaitShutdown.Done()
+		vm.Builder.Gossip(vm.onShutdownCtx)
+	}()
 	return nil
 }
 
@@ -336,6 +360,13 @@ func (vm *VM) Shutdown(context.Context) error {
 
 	vm.Builder.Shutdown()
 
+	// Pull gossip may be waiting on the context lock, so the lock must be
+	// released before waiting for it to stop.
+	vm.ctx.Lock.Unlock()
+	vm.onShutdownCtxCancel()
+	vm.awaitShutdown.Wait()
+	vm.ctx.Lock.Lock()
+
 	if vm.bootstrapped.Get() {
 		primaryVdrIDs, exists := vm.getValidatorIDs(constants.PrimaryNetworkID)
 		if !exists {
@@ -461,6 +492,7 @@ func (*VM) CreateStaticHandlers(context.Context) (map[string]*common.HTTPHandler
 }
 
 func (vm *VM) Connected(_ context.Context, nodeID ids.NodeID, _ *version.Application) error {
+	vm.gossipPeers.Connected(nodeID)
 	return vm.uptimeManager.Connect(nodeID, constants.PrimaryNetworkID)
 }
 
@@ -469,6 +501,7 @@ func (vm *VM) ConnectedSubnet(_ context.Context, nodeID ids.NodeID, subnetID ids
 }
 
 func (vm *VM) Disconnected(_ context.Context, nodeID ids.NodeID) error {
+	vm.gossipPeers.Disconnected(nodeID)
 	if err := vm.uptimeManager.Disconnect(nodeID); err != nil {
 		return err
 	}
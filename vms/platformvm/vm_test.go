@@ -24,6 +24,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/database/prefixdb"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/choices"
@@ -40,6 +41,8 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/uptime"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/subnets"
+	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
 	"github.com/MetalBlockchain/metalgo/utils/crypto/secp256k1"
@@ -337,6 +340,8 @@ func defaultVM() (*VM, database.Database, *mutableSharedMemory) {
 		ApricotPhase3Time:      defaultValidateEndTime,
 		ApricotPhase5Time:      defaultValidateEndTime,
 		BanffTime:              banffForkTime,
+
+		MinPercentConnectedStakeHealthy: &utils.Atomic[map[ids.ID]float64]{},
 	}}
 
 	baseDBManager := manager.NewMemDB(version.Semantic1_0_0)
@@ -1721,7 +1726,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 	chainRouter := &router.ChainRouter{}
 
 	metrics := prometheus.NewRegistry()
-	mc, err := message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
+	mc, err := message.NewCreator(metrics, "dummyNamespace", compression.TypeGzip, 10*time.Second)
 	require.NoError(err)
 
 	err = chainRouter.Initialize(
@@ -1828,6 +1833,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 		msgChan,
 		time.Hour,
 		cpuTracker,
+		throttling.NewNoSubnetSystemThrottler(),
 		vm,
 		subnets.New(ctx.NodeID, subnets.Config{}),
 	)
@@ -1841,7 +1847,8 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 		Validators:    beacons,
 		Params: snowball.Parameters{
 			K:                     1,
-			Alpha:                 1,
+			AlphaPreference:       1,
+			AlphaConfidence:       1,
 			BetaVirtuous:          20,
 			BetaRogue:             20,
 			ConcurrentRepolls:     1,
@@ -0,0 +1,16 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import "context"
+
+// ConcurrentVerifyEnabled returns true if the inner VM allows its blocks to be
+// verified concurrently. The verification performed by the proposervm itself
+// is safe to be called concurrently.
+func (vm *VM) ConcurrentVerifyEnabled(ctx context.Context) (bool, error) {
+	if vm.cvVM == nil {
+		return false, nil
+	}
+	return vm.cvVM.ConcurrentVerifyEnabled(ctx)
+}
@@ -0,0 +1,268 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/choices"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+
+	statelessblock "github.com/MetalBlockchain/metalgo/vms/proposervm/block"
+)
+
+type concurrentVerifyVM struct {
+	enabled bool
+}
+
+func (vm *concurrentVerifyVM) ConcurrentVerifyEnabled(context.Context) (bool, error) {
+	return vm.enabled, nil
+}
+
+type countingBlock struct {
+	*snowman.TestBlock
+	numVerifies *int32
+}
+
+func (b *countingBlock) Verify(ctx context.Context) error {
+	atomic.AddInt32(b.numVerifies, 1)
+	return b.TestBlock.Verify(ctx)
+}
+
+func TestConcurrentVerifyEnabled(t *testing.T) {
+	require := require.New(t)
+
+	_, _, proVM, _, _ := initTestProposerVM(t, time.Time{}, 0)
+	enabled, err := proVM.ConcurrentVerifyEnabled(context.Background())
+	require.NoError(err)
+	require.False(enabled)
+
+	proVM.cvVM = &concurrentVerifyVM{enabled: true}
+	enabled, err = proVM.ConcurrentVerifyEnabled(context.Background())
+	require.NoError(err)
+	require.True(enabled)
+}
+
+func TestConcurrentVerifySameInnerBlock(t *testing.T) {
+	require := require.New(t)
+
+	_, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+
+	pChainHeight, err := proVM.ctx.ValidatorState.GetCurrentHeight(context.Background())
+	require.NoError(err)
+
+	// Two post fork blocks, verified concurrently, wrap the same inner block.
+	var (
+		numVerifies int32
+		coreBlkID   = ids.GenerateTestID()
+		proBlks     = make([]*postForkBlock, 2)
+	)
+	for i := range proBlks {
+		coreBlk := &countingBlock{
+			TestBlock: &snowman.TestBlock{
+				TestDecidable: choices.TestDecidable{
+					IDV:     coreBlkID,
+					StatusV: choices.Processing,
+				},
+				BytesV:     []byte{1},
+				ParentV:    coreGenBlk.ID(),
+				HeightV:    coreGenBlk.Height() + 1,
+				TimestampV: genesisTimestamp,
+			},
+			numVerifies: &numVerifies,
+		}
+
+		slb, err := statelessblock.BuildUnsigned(
+			proVM.preferred,
+			coreBlk.Timestamp().Add(time.Duration(i)*time.Second),
+			pChainHeight,
+			coreBlk.Bytes(),
+		)
+		require.NoError(err)
+		proBlks[i] = &postForkBlock{
+			SignedBlock: slb,
+			postForkCommonComponents: postForkCommonComponents{
+				vm:       proVM,
+				innerBlk: coreBlk,
+				status:   choices.Processing,
+			},
+		}
+	}
+	require.NotEqual(proBlks[0].ID(), proBlks[1].ID())
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(proBlks))
+	)
+	wg.Add(len(proBlks))
+	for i, proBlk := range proBlks {
+		i, proBlk := i, proBlk
+		go func() {
+			defer wg.Done()
+			errs[i] = proBlk.Verify(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	require.Equal([]error{nil, nil}, errs)
+	require.Equal(int32(1), numVerifies)
+	require.Equal(proBlks[0].getInnerBlk(), proBlks[1].getInnerBlk())
+	require.Empty(proVM.verifyingInnerBlks)
+	for _, proBlk := range proBlks {
+		blk, err := proVM.getPostForkBlock(context.Background(), proBlk.ID())
+		require.NoError(err)
+		require.Equal(proBlk, blk)
+	}
+}
+
+func TestConcurrentVerifyMixedForkSiblings(t *testing.T) {
+	require := require.New(t)
+
+	activationTime := genesisTimestamp.Add(10 * time.Second)
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, activationTime, 0)
+	proVM.Set(activationTime)
+
+	pChainHeight, err := proVM.ctx.ValidatorState.GetCurrentHeight(context.Background())
+	require.NoError(err)
+
+	// The last pre fork blocks can have post fork children, while
+	// [preForkCoreBlk] can only have pre fork children.
+	const numForkBlks = 8
+	coreBlks := map[ids.ID]snowman.Block{
+		coreGenBlk.ID(): coreGenBlk,
+	}
+	forkCoreBlks := make([]*snowman.TestBlock, numForkBlks)
+	for i := range forkCoreBlks {
+		forkCoreBlks[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			BytesV:     []byte{1, byte(i)},
+			ParentV:    coreGenBlk.ID(),
+			HeightV:    coreGenBlk.Height() + 1,
+			TimestampV: activationTime,
+		}
+		coreBlks[forkCoreBlks[i].ID()] = forkCoreBlks[i]
+	}
+	preForkCoreBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{2},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: activationTime.Add(-time.Second),
+	}
+	coreBlks[preForkCoreBlk.ID()] = preForkCoreBlk
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		blk, ok := coreBlks[blkID]
+		if !ok {
+			return nil, errUnknownBlock
+		}
+		return blk, nil
+	}
+	for _, coreBlk := range append(forkCoreBlks, preForkCoreBlk) {
+		blk := &preForkBlock{
+			Block: coreBlk,
+			vm:    proVM,
+		}
+		require.NoError(blk.Verify(context.Background()))
+	}
+
+	// The children of the pre fork siblings are verified concurrently.
+	// Verifying a post fork child reads the inner block tree while the inner
+	// blocks of the other post fork children are being added to it.
+	blks := make([]snowman.Block, 0, 2*numForkBlks)
+	for i, forkCoreBlk := range forkCoreBlks {
+		postForkCoreBlk := &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			BytesV:     []byte{3, byte(i)},
+			ParentV:    forkCoreBlk.ID(),
+			HeightV:    forkCoreBlk.Height() + 1,
+			TimestampV: activationTime,
+		}
+		slb, err := statelessblock.BuildUnsigned(
+			forkCoreBlk.ID(),
+			activationTime,
+			pChainHeight,
+			postForkCoreBlk.Bytes(),
+		)
+		require.NoError(err)
+		blks = append(blks, &postForkBlock{
+			SignedBlock: slb,
+			postForkCommonComponents: postForkCommonComponents{
+				vm:       proVM,
+				innerBlk: postForkCoreBlk,
+				status:   choices.Processing,
+			},
+		})
+
+		blks = append(blks, &preForkBlock{
+			Block: &snowman.TestBlock{
+				TestDecidable: choices.TestDecidable{
+					IDV:     ids.GenerateTestID(),
+					StatusV: choices.Processing,
+				},
+				BytesV:     []byte{4, byte(i)},
+				ParentV:    preForkCoreBlk.ID(),
+				HeightV:    preForkCoreBlk.Height() + 1,
+				TimestampV: preForkCoreBlk.Timestamp(),
+			},
+			vm: proVM,
+		})
+	}
+
+	// Staggering the parent lookups makes the inner block tree be read by
+	// some verifications after it was modified by others.
+	coreVM.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		for i, forkCoreBlk := range forkCoreBlks {
+			if forkCoreBlk.ID() == blkID {
+				time.Sleep(time.Duration(i) * time.Millisecond)
+			}
+		}
+		blk, ok := coreBlks[blkID]
+		if !ok {
+			return nil, errUnknownBlock
+		}
+		return blk, nil
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(blks))
+	)
+	wg.Add(len(blks))
+	for i, blk := range blks {
+		i, blk := i, blk
+		go func() {
+			defer wg.Done()
+			errs[i] = blk.Verify(context.Background())
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(err)
+	}
+	for _, blk := range blks {
+		postForkBlk, ok := blk.(*postForkBlock)
+		if !ok {
+			continue
+		}
+		_, contains := proVM.Tree.Get(postForkBlk.innerBlk)
+		require.True(contains)
+	}
+}
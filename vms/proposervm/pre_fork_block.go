@@ -247,7 +247,15 @@ func (b *preForkBlock) verifyIsPreForkBlock() error {
 			// error.
 			return err
 		}
-	} else if _, contains := b.vm.Tree.Get(b.Block); contains {
+		return nil
+	}
+
+	// The inner block tree may be modified by blocks that are being verified
+	// concurrently.
+	b.vm.verifiedLock.Lock()
+	_, contains := b.vm.Tree.Get(b.Block)
+	b.vm.verifiedLock.Unlock()
+	if contains {
 		// If this block is a preForkBlock, then it's inner block shouldn't have
 		// been registered into the inner block tree. If this block was
 		// registered into the inner block tree, then it wasn't a preForkBlock.
@@ -5,6 +5,7 @@ package proposer
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/MetalBlockchain/metalgo/ids"
@@ -51,6 +52,10 @@ type windower struct {
 	state       validators.State
 	subnetID    ids.ID
 	chainSource uint64
+
+	// samplerLock allows the proposers to be calculated concurrently, such as
+	// when blocks are verified concurrently.
+	samplerLock sync.Mutex
 	sampler     sampler.WeightedWithoutReplacement
 }
 
@@ -97,6 +102,9 @@ func (w *windower) Proposers(ctx context.Context, chainHeight, pChainHeight uint
 		validatorWeights[i] = v.weight
 	}
 
+	w.samplerLock.Lock()
+	defer w.samplerLock.Unlock()
+
 	if err := w.sampler.Initialize(validatorWeights); err != nil {
 		return nil, err
 	}
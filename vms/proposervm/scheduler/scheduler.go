@@ -10,6 +10,7 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 )
 
 type Scheduler interface {
@@ -23,7 +24,8 @@ type Scheduler interface {
 // when the engine should call BuildBlock. Namely, when this node is allowed to
 // propose a block under the congestion control mechanism.
 type scheduler struct {
-	log logging.Logger
+	log   logging.Logger
+	clock mockable.Clock
 	// The VM sends a message on this channel when it wants to tell the engine
 	// that the engine should call the VM's BuildBlock method
 	fromVM <-chan common.Message
@@ -36,10 +38,11 @@ type scheduler struct {
 	newBuildBlockTime chan time.Time
 }
 
-func New(log logging.Logger, toEngine chan<- common.Message) (Scheduler, chan<- common.Message) {
+func New(log logging.Logger, clock mockable.Clock, toEngine chan<- common.Message) (Scheduler, chan<- common.Message) {
 	vmToEngine := make(chan common.Message, cap(toEngine))
 	return &scheduler{
 		log:               log,
+		clock:             clock,
 		fromVM:            vmToEngine,
 		toEngine:          toEngine,
 		newBuildBlockTime: make(chan time.Time),
@@ -47,15 +50,15 @@ func New(log logging.Logger, toEngine chan<- common.Message) (Scheduler, chan<-
 }
 
 func (s *scheduler) Dispatch(buildBlockTime time.Time) {
-	timer := time.NewTimer(time.Until(buildBlockTime))
+	timer := s.clock.NewTimer(buildBlockTime.Sub(s.clock.Time()))
 waitloop:
 	for {
 		select {
-		case <-timer.C: // It's time to tell the engine to try to build a block
+		case <-timer.C(): // It's time to tell the engine to try to build a block
 		case buildBlockTime, ok := <-s.newBuildBlockTime:
-			// Stop the timer and clear [timer.C] if needed
+			// Stop the timer and clear [timer.C()] if needed
 			if !timer.Stop() {
-				<-timer.C
+				<-timer.C()
 			}
 
 			if !ok {
@@ -65,7 +68,7 @@ waitloop:
 
 			// The time at which we should notify the engine that it should try
 			// to build a block has changed
-			timer.Reset(time.Until(buildBlockTime))
+			timer.Reset(buildBlockTime.Sub(s.clock.Time()))
 			continue waitloop
 		}
 
@@ -91,9 +94,9 @@ waitloop:
 					// s.Close() was called
 					return
 				}
-				// We know [timer.C] was drained in the first select statement
+				// We know [timer.C()] was drained in the first select statement
 				// so its safe to call [timer.Reset]
-				timer.Reset(time.Until(buildBlockTime))
+				timer.Reset(buildBlockTime.Sub(s.clock.Time()))
 				continue waitloop
 			}
 		}
@@ -9,13 +9,14 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 )
 
 func TestDelayFromNew(t *testing.T) {
 	toEngine := make(chan common.Message, 10)
 	startTime := time.Now().Add(50 * time.Millisecond)
 
-	s, fromVM := New(logging.NoLog{}, toEngine)
+	s, fromVM := New(logging.NoLog{}, mockable.Clock{}, toEngine)
 	defer s.Close()
 	go s.Dispatch(startTime)
 
@@ -32,7 +33,7 @@ func TestDelayFromSetTime(t *testing.T) {
 	now := time.Now()
 	startTime := now.Add(50 * time.Millisecond)
 
-	s, fromVM := New(logging.NoLog{}, toEngine)
+	s, fromVM := New(logging.NoLog{}, mockable.Clock{}, toEngine)
 	defer s.Close()
 	go s.Dispatch(now)
 
@@ -51,7 +52,7 @@ func TestReceipt(*testing.T) {
 	now := time.Now()
 	startTime := now.Add(50 * time.Millisecond)
 
-	s, fromVM := New(logging.NoLog{}, toEngine)
+	s, fromVM := New(logging.NoLog{}, mockable.Clock{}, toEngine)
 	defer s.Close()
 	go s.Dispatch(now)
 
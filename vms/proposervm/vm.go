@@ -8,6 +8,7 @@ import (
 	"crypto"
 	"crypto/x509"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -49,10 +50,11 @@ const (
 )
 
 var (
-	_ block.ChainVM              = (*VM)(nil)
-	_ block.BatchedChainVM       = (*VM)(nil)
-	_ block.HeightIndexedChainVM = (*VM)(nil)
-	_ block.StateSyncableVM      = (*VM)(nil)
+	_ block.ChainVM                 = (*VM)(nil)
+	_ block.BatchedChainVM          = (*VM)(nil)
+	_ block.HeightIndexedChainVM    = (*VM)(nil)
+	_ block.StateSyncableVM         = (*VM)(nil)
+	_ block.ConcurrentVerifyChainVM = (*VM)(nil)
 
 	dbPrefix = []byte("proposervm")
 )
@@ -63,6 +65,7 @@ type VM struct {
 	batchedVM      block.BatchedChainVM
 	hVM            block.HeightIndexedChainVM
 	ssVM           block.StateSyncableVM
+	cvVM           block.ConcurrentVerifyChainVM
 
 	activationTime      time.Time
 	minimumPChainHeight uint64
@@ -84,10 +87,16 @@ type VM struct {
 	db          *versiondb.Database
 	toScheduler chan<- common.Message
 
+	// verifiedLock protects [verifiedBlocks], [Tree] and [verifyingInnerBlks]
+	// while blocks are verified concurrently.
+	verifiedLock sync.Mutex
 	// Block ID --> Block
 	// Each element is a block that passed verification but
 	// hasn't yet been accepted/rejected
 	verifiedBlocks map[ids.ID]PostForkBlock
+	// Inner block ID --> channel that is closed once the verification of the
+	// inner block finishes
+	verifyingInnerBlks map[ids.ID]chan struct{}
 	// Stateless block ID --> inner block.
 	// Only contains post-fork blocks near the tip so that the cache doesn't get
 	// filled with random blocks every time this node parses blocks while
@@ -121,12 +130,14 @@ func New(
 	batchedVM, _ := vm.(block.BatchedChainVM)
 	hVM, _ := vm.(block.HeightIndexedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	cvVM, _ := vm.(block.ConcurrentVerifyChainVM)
 	return &VM{
 		ChainVM:        vm,
 		blockBuilderVM: blockBuilderVM,
 		batchedVM:      batchedVM,
 		hVM:            hVM,
 		ssVM:           ssVM,
+		cvVM:           cvVM,
 
 		activationTime:      activationTime,
 		minimumPChainHeight: minimumPChainHeight,
@@ -165,6 +176,7 @@ func (vm *VM) Initialize(
 	chainCtx.Metrics = optionalGatherer
 
 	vm.ctx = chainCtx
+	vm.Clock.SetSource(chainCtx.Clock.Source())
 	rawDB := dbManager.Current().Database
 	prefixDB := prefixdb.New(dbPrefix, rawDB)
 	vm.db = versiondb.New(prefixDB)
@@ -186,15 +198,16 @@ func (vm *VM) Initialize(
 	indexerState := state.New(indexerDB)
 	vm.hIndexer = indexer.NewHeightIndexer(vm, vm.ctx.Log, indexerState)
 
-	scheduler, vmToEngine := scheduler.New(vm.ctx.Log, toEngine)
+	scheduler, vmToEngine := scheduler.New(vm.ctx.Log, chainCtx.Clock, toEngine)
 	vm.Scheduler = scheduler
 	vm.toScheduler = vmToEngine
 
 	go chainCtx.Log.RecoverAndPanic(func() {
-		scheduler.Dispatch(time.Now())
+		scheduler.Dispatch(chainCtx.Clock.Time())
 	})
 
 	vm.verifiedBlocks = make(map[ids.ID]PostForkBlock)
+	vm.verifyingInnerBlks = make(map[ids.ID]chan struct{})
 	detachedCtx := utils.Detach(ctx)
 	context, cancel := context.WithCancel(detachedCtx)
 	vm.context = context
@@ -657,7 +670,9 @@ func (vm *VM) getBlock(ctx context.Context, id ids.ID) (Block, error) {
 }
 
 func (vm *VM) getPostForkBlock(ctx context.Context, blkID ids.ID) (PostForkBlock, error) {
+	vm.verifiedLock.Lock()
 	block, exists := vm.verifiedBlocks[blkID]
+	vm.verifiedLock.Unlock()
 	if exists {
 		return block, nil
 	}
@@ -716,7 +731,15 @@ func (vm *VM) storePostForkBlock(blk PostForkBlock) error {
 func (vm *VM) verifyAndRecordInnerBlk(ctx context.Context, blockCtx *block.Context, postFork PostForkBlock) error {
 	innerBlk := postFork.getInnerBlk()
 	postForkID := postFork.ID()
+
+	// If the same inner block is wrapped by multiple post fork blocks that are
+	// verified concurrently, the inner block must still only be verified once.
+	unlock := vm.lockInnerBlk(innerBlk.ID())
+	defer unlock()
+
+	vm.verifiedLock.Lock()
 	originalInnerBlock, previouslyVerified := vm.Tree.Get(innerBlk)
+	vm.verifiedLock.Unlock()
 	if previouslyVerified {
 		innerBlk = originalInnerBlock
 		// We must update all of the mappings from postFork -> innerBlock to
@@ -759,6 +782,9 @@ func (vm *VM) verifyAndRecordInnerBlk(ctx context.Context, blockCtx *block.Conte
 
 	// Since verification passed, we should ensure the inner block tree is
 	// populated.
+	vm.verifiedLock.Lock()
+	defer vm.verifiedLock.Unlock()
+
 	if !previouslyVerified {
 		vm.Tree.Add(innerBlk)
 	}
@@ -766,6 +792,29 @@ func (vm *VM) verifyAndRecordInnerBlk(ctx context.Context, blockCtx *block.Conte
 	return nil
 }
 
+// lockInnerBlk waits until no other verification of the inner block
+// [innerBlkID] is in progress and marks it as being verified. The returned
+// function must be called once the verification finishes.
+func (vm *VM) lockInnerBlk(innerBlkID ids.ID) func() {
+	for {
+		vm.verifiedLock.Lock()
+		verifying, ok := vm.verifyingInnerBlks[innerBlkID]
+		if !ok {
+			done := make(chan struct{})
+			vm.verifyingInnerBlks[innerBlkID] = done
+			vm.verifiedLock.Unlock()
+			return func() {
+				vm.verifiedLock.Lock()
+				delete(vm.verifyingInnerBlks, innerBlkID)
+				vm.verifiedLock.Unlock()
+				close(done)
+			}
+		}
+		vm.verifiedLock.Unlock()
+		<-verifying
+	}
+}
+
 // notifyInnerBlockReady tells the scheduler that the inner VM is ready to build
 // a new block
 func (vm *VM) notifyInnerBlockReady() {
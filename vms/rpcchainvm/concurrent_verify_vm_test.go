@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database/manager"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/snow/choices"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block/mocks"
+	"github.com/MetalBlockchain/metalgo/version"
+)
+
+const concurrentVerifyTimeout = 10 * time.Second
+
+var (
+	_ block.ChainVM                 = ConcurrentVerifyVMMock{}
+	_ block.ConcurrentVerifyChainVM = ConcurrentVerifyVMMock{}
+
+	_ snowman.Block = (*concurrentVerifyBlock)(nil)
+
+	errNotVerifiedConcurrently = errors.New("blocks weren't verified concurrently")
+)
+
+type ConcurrentVerifyVMMock struct {
+	*mocks.MockChainVM
+	*mocks.MockConcurrentVerifyChainVM
+}
+
+// concurrentVerifyBlock only passes verification if all the blocks sharing
+// [verifying] are being verified at the same time.
+type concurrentVerifyBlock struct {
+	*snowman.TestBlock
+
+	verifying *sync.WaitGroup
+}
+
+func (b *concurrentVerifyBlock) Verify(context.Context) error {
+	b.verifying.Done()
+
+	verified := make(chan struct{})
+	go func() {
+		b.verifying.Wait()
+		close(verified)
+	}()
+
+	select {
+	case <-verified:
+		return nil
+	case <-time.After(concurrentVerifyTimeout):
+		return errNotVerifiedConcurrently
+	}
+}
+
+func newConcurrentVerifyTestBlocks() []*snowman.TestBlock {
+	blks := make([]*snowman.TestBlock, 2)
+	for i := range blks {
+		blks[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.ID{'c', 'v', byte(i)},
+				StatusV: choices.Processing,
+			},
+			ParentV:    preSummaryBlk.ID(),
+			HeightV:    preSummaryHeight + 1,
+			TimestampV: time.Unix(int64(i), 0),
+			BytesV:     []byte{'c', 'v', byte(i)},
+		}
+	}
+	return blks
+}
+
+func concurrentVerifyTestPlugin(t *testing.T, loadExpectations bool) (block.ChainVM, *gomock.Controller) {
+	// test key is "concurrentVerifyTestKey"
+
+	// create mock
+	ctrl := gomock.NewController(t)
+	cvVM := ConcurrentVerifyVMMock{
+		MockChainVM:                 mocks.NewMockChainVM(ctrl),
+		MockConcurrentVerifyChainVM: mocks.NewMockConcurrentVerifyChainVM(ctrl),
+	}
+
+	if loadExpectations {
+		blks := newConcurrentVerifyTestBlocks()
+		verifying := &sync.WaitGroup{}
+		verifying.Add(len(blks))
+
+		gomock.InOrder(
+			// Initialize
+			cvVM.MockChainVM.EXPECT().Initialize(
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(),
+			).Return(nil).Times(1),
+			cvVM.MockChainVM.EXPECT().LastAccepted(gomock.Any()).Return(preSummaryBlk.ID(), nil).Times(1),
+			cvVM.MockChainVM.EXPECT().GetBlock(gomock.Any(), gomock.Any()).Return(preSummaryBlk, nil).Times(1),
+
+			// ConcurrentVerifyEnabled
+			cvVM.MockConcurrentVerifyChainVM.EXPECT().ConcurrentVerifyEnabled(gomock.Any()).Return(true, nil).Times(1),
+		)
+
+		// ParseBlock is called once when the block is parsed and once when it
+		// is verified.
+		for _, blk := range blks {
+			cvVM.MockChainVM.EXPECT().ParseBlock(gomock.Any(), blk.Bytes()).Return(&concurrentVerifyBlock{
+				TestBlock: blk,
+				verifying: verifying,
+			}, nil).Times(2)
+		}
+	}
+
+	return cvVM, ctrl
+}
+
+func TestConcurrentVerify(t *testing.T) {
+	require := require.New(t)
+	testKey := concurrentVerifyTestKey
+
+	// Create and start the plugin
+	vm, stopper := buildClientHelper(require, testKey)
+	defer stopper.Stop(context.Background())
+
+	ctx := snow.DefaultContextTest()
+	dbManager := manager.NewMemDB(version.Semantic1_0_0)
+
+	err := vm.Initialize(context.Background(), ctx, dbManager, nil, nil, nil, nil, nil, nil)
+	require.NoError(err)
+
+	enabled, err := vm.ConcurrentVerifyEnabled(context.Background())
+	require.NoError(err)
+	require.True(enabled)
+
+	testBlks := newConcurrentVerifyTestBlocks()
+	blks := make([]snowman.Block, len(testBlks))
+	for i, testBlk := range testBlks {
+		blks[i], err = vm.ParseBlock(context.Background(), testBlk.Bytes())
+		require.NoError(err)
+	}
+
+	// The plugin only reports the blocks as valid if it verifies them at the
+	// same time.
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(blks))
+	)
+	wg.Add(len(blks))
+	for i, blk := range blks {
+		go func(i int, blk snowman.Block) {
+			defer wg.Done()
+			errs[i] = blk.Verify(context.Background())
+		}(i, blk)
+	}
+	wg.Wait()
+
+	for i, blk := range blks {
+		require.NoError(errs[i])
+		require.True(vm.State.IsProcessing(blk.ID()))
+		require.Equal(testBlks[i].Timestamp().Unix(), blk.Timestamp().Unix())
+	}
+}
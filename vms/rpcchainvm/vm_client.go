@@ -19,7 +19,9 @@ import (
 	"go.uber.org/zap"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
@@ -78,6 +80,7 @@ var (
 	_ block.BatchedChainVM               = (*VMClient)(nil)
 	_ block.HeightIndexedChainVM         = (*VMClient)(nil)
 	_ block.StateSyncableVM              = (*VMClient)(nil)
+	_ block.ConcurrentVerifyChainVM      = (*VMClient)(nil)
 	_ prometheus.Gatherer                = (*VMClient)(nil)
 
 	_ snowman.Block           = (*blockClient)(nil)
@@ -816,6 +819,19 @@ func (vm *VMClient) GetStateSummary(ctx context.Context, summaryHeight uint64) (
 	}, err
 }
 
+func (vm *VMClient) ConcurrentVerifyEnabled(ctx context.Context) (bool, error) {
+	resp, err := vm.client.ConcurrentVerifyEnabled(ctx, &emptypb.Empty{})
+	if status.Code(err) == codes.Unimplemented {
+		// Plugins that predate this RPC only support verifying blocks
+		// sequentially.
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return resp.Enabled, nil
+}
+
 func (vm *VMClient) newBlockFromBuildBlock(resp *vmpb.BuildBlockResponse) (*blockClient, error) {
 	id, err := ids.ToID(resp.Id)
 	if err != nil {
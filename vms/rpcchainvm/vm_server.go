@@ -72,6 +72,8 @@ type VMServer struct {
 	hVM block.HeightIndexedChainVM
 	// If nil, the underlying VM doesn't implement the interface.
 	ssVM block.StateSyncableVM
+	// If nil, the underlying VM doesn't implement the interface.
+	cvVM block.ConcurrentVerifyChainVM
 
 	processMetrics prometheus.Gatherer
 	dbManager      manager.Manager
@@ -88,11 +90,13 @@ func NewServer(vm block.ChainVM) *VMServer {
 	bVM, _ := vm.(block.BuildBlockWithContextChainVM)
 	hVM, _ := vm.(block.HeightIndexedChainVM)
 	ssVM, _ := vm.(block.StateSyncableVM)
+	cvVM, _ := vm.(block.ConcurrentVerifyChainVM)
 	return &VMServer{
 		vm:   vm,
 		bVM:  bVM,
 		hVM:  hVM,
 		ssVM: ssVM,
+		cvVM: cvVM,
 	}
 }
 
@@ -837,6 +841,28 @@ func (vm *VMServer) GetStateSummary(
 	}, nil
 }
 
+func (vm *VMServer) ConcurrentVerifyEnabled(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*vmpb.ConcurrentVerifyEnabledResponse, error) {
+	var (
+		enabled bool
+		err     error
+	)
+	if vm.cvVM != nil {
+		enabled, err = vm.cvVM.ConcurrentVerifyEnabled(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmpb.ConcurrentVerifyEnabledResponse{
+		Enabled: enabled,
+	}, nil
+}
+
+// BlockVerify may be called concurrently on different blocks if the VM enabled
+// concurrent verification, as each RPC is handled in its own goroutine.
 func (vm *VMServer) BlockVerify(ctx context.Context, req *vmpb.BlockVerifyRequest) (*vmpb.BlockVerifyResponse, error) {
 	blk, err := vm.vm.ParseBlock(ctx, req.Bytes)
 	if err != nil {
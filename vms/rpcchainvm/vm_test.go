@@ -39,6 +39,7 @@ const (
 	lastAcceptedBlockPostStateSummaryAcceptTestKey = "lastAcceptedBlockPostStateSummaryAcceptTest"
 	contextTestKey                                 = "contextTest"
 	batchedParseBlockCachingTestKey                = "batchedParseBlockCachingTest"
+	concurrentVerifyTestKey                        = "concurrentVerifyTest"
 )
 
 var TestServerPluginMap = map[string]func(*testing.T, bool) (block.ChainVM, *gomock.Controller){
@@ -51,6 +52,7 @@ var TestServerPluginMap = map[string]func(*testing.T, bool) (block.ChainVM, *gom
 	lastAcceptedBlockPostStateSummaryAcceptTestKey: lastAcceptedBlockPostStateSummaryAcceptTestPlugin,
 	contextTestKey:                                 contextEnabledTestPlugin,
 	batchedParseBlockCachingTestKey:                batchedParseBlockCachingTestPlugin,
+	concurrentVerifyTestKey:                        concurrentVerifyTestPlugin,
 }
 
 // helperProcess helps with creating the subnet binary for testing.
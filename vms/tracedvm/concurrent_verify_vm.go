@@ -0,0 +1,28 @@
+// Copyright (C) 2019-2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracedvm
+
+import "context"
+
+func (vm *blockVM) ConcurrentVerifyEnabled(ctx context.Context) (bool, error) {
+	if vm.cvVM == nil {
+		return false, nil
+	}
+
+	ctx, span := vm.tracer.Start(ctx, vm.concurrentVerifyEnabledTag)
+	defer span.End()
+
+	return vm.cvVM.ConcurrentVerifyEnabled(ctx)
+}
+
+func (vm *vertexVM) ConcurrentTxVerifyEnabled(ctx context.Context) (bool, error) {
+	if vm.cvVM == nil {
+		return false, nil
+	}
+
+	ctx, span := vm.tracer.Start(ctx, "vertexVM.ConcurrentTxVerifyEnabled")
+	defer span.End()
+
+	return vm.cvVM.ConcurrentTxVerifyEnabled(ctx)
+}
@@ -19,16 +19,22 @@ import (
 	"github.com/MetalBlockchain/metalgo/trace"
 )
 
-var _ vertex.LinearizableVMWithEngine = (*vertexVM)(nil)
+var (
+	_ vertex.LinearizableVMWithEngine = (*vertexVM)(nil)
+	_ vertex.ConcurrentVerifyDAGVM    = (*vertexVM)(nil)
+)
 
 type vertexVM struct {
 	vertex.LinearizableVMWithEngine
+	cvVM   vertex.ConcurrentVerifyDAGVM
 	tracer trace.Tracer
 }
 
 func NewVertexVM(vm vertex.LinearizableVMWithEngine, tracer trace.Tracer) vertex.LinearizableVMWithEngine {
+	cvVM, _ := vm.(vertex.ConcurrentVerifyDAGVM)
 	return &vertexVM{
 		LinearizableVMWithEngine: vm,
+		cvVM:                     cvVM,
 		tracer:                   tracer,
 	}
 }